@@ -162,9 +162,7 @@ func (s *KeeperTestHelper) AllocateRewardsToValidator(valAddr sdk.ValAddress, re
 
 // SetupGammPoolsWithBondDenomMultiplier uses given multipliers to set initial pool supply of bond denom.
 func (s *KeeperTestHelper) SetupGammPoolsWithBondDenomMultiplier(multipliers []sdk.Dec) []gammtypes.PoolI {
-	s.App.GAMMKeeper.SetParams(s.Ctx, gammtypes.Params{
-		PoolCreationFee: sdk.Coins{},
-	})
+	s.App.GAMMKeeper.SetParams(s.Ctx, gammtypes.NewParams(sdk.Coins{}, gammtypes.DefaultMaxPoolAssets, gammtypes.DefaultCircuitBreakerThreshold, gammtypes.DefaultProtocolFeeShare))
 
 	bondDenom := s.App.StakingKeeper.BondDenom(s.Ctx)
 	// TODO: use sdk crypto instead of tendermint to generate address
@@ -50,6 +50,17 @@ func AbsDifferenceWithSign(a, b sdk.Dec) (sdk.Dec, bool) {
 // TODO: In the future, lets add some optimized routines for common exponents, e.g. for common wIn / wOut ratios
 // Many simple exponents like 2:1 pools.
 func Pow(base sdk.Dec, exp sdk.Dec) sdk.Dec {
+	// A maxIterations of 0 leaves the series unbounded, so the error is always nil.
+	output, _ := PowBounded(base, exp, powPrecision, 0)
+	return output
+}
+
+// PowBounded computes base^(exp), approximating the fractional component of exp
+// up to precision. At most maxIterations terms of the series are computed,
+// and an error is returned if the approximation has not reached precision by then.
+// A maxIterations of 0 leaves the number of terms unbounded.
+// Like Pow, it panics if base is not in (0, 2).
+func PowBounded(base sdk.Dec, exp sdk.Dec, precision sdk.Dec, maxIterations int64) (sdk.Dec, error) {
 	// Exponentiation of a negative base with an arbitrary real exponent is not closed within the reals.
 	// You can see this by recalling that `i = (-1)^(.5)`. We have to go to complex numbers to define this.
 	// (And would have to implement complex logarithms)
@@ -72,19 +83,33 @@ func Pow(base sdk.Dec, exp sdk.Dec) sdk.Dec {
 	integerPow := base.Power(uint64(integer.TruncateInt64()))
 
 	if fractional.IsZero() {
-		return integerPow
+		return integerPow, nil
 	}
 
-	fractionalPow := PowApprox(base, fractional, powPrecision)
+	fractionalPow, err := PowApproxBounded(base, fractional, precision, maxIterations)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
 
-	return integerPow.Mul(fractionalPow)
+	return integerPow.Mul(fractionalPow), nil
 }
 
 // Contract: 0 < base <= 2
 // 0 <= exp < 1.
 func PowApprox(base sdk.Dec, exp sdk.Dec, precision sdk.Dec) sdk.Dec {
+	// A maxIterations of 0 leaves the series unbounded, so the error is always nil.
+	output, _ := PowApproxBounded(base, exp, precision, 0)
+	return output
+}
+
+// PowApproxBounded is PowApprox, computing at most maxIterations terms of the series.
+// It returns an error if the last computed term is still not below precision.
+// A maxIterations of 0 leaves the number of terms unbounded.
+// Contract: 0 < base <= 2
+// 0 <= exp < 1.
+func PowApproxBounded(base sdk.Dec, exp sdk.Dec, precision sdk.Dec, maxIterations int64) (sdk.Dec, error) {
 	if exp.IsZero() {
-		return sdk.OneDec()
+		return sdk.OneDec(), nil
 	}
 
 	// Common case optimization
@@ -94,7 +119,7 @@ func PowApprox(base sdk.Dec, exp sdk.Dec, precision sdk.Dec) sdk.Dec {
 		if err != nil {
 			panic(err)
 		}
-		return output
+		return output, nil
 	}
 	// TODO: Make an approx-equal function, and then check if exp * 3 = 1, and do a check accordingly
 
@@ -136,6 +161,9 @@ func PowApprox(base sdk.Dec, exp sdk.Dec, precision sdk.Dec) sdk.Dec {
 	bigK := sdk.NewDec(0)
 	// TODO: Document this computation via taylor expansion
 	for i := int64(1); term.GTE(precision); i++ {
+		if maxIterations > 0 && i > maxIterations {
+			return sdk.Dec{}, fmt.Errorf("pow approximation with exponent %s did not reach precision %s within %d iterations", exp, precision, maxIterations)
+		}
 		// At each iteration, we need two values, i and i-1.
 		// To avoid expensive big.Int allocation, we reuse bigK variable.
 		// On this line, bigK == i-1.
@@ -164,5 +192,5 @@ func PowApprox(base sdk.Dec, exp sdk.Dec, precision sdk.Dec) sdk.Dec {
 			sum.AddMut(term)
 		}
 	}
-	return sum
+	return sum, nil
 }
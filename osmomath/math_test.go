@@ -70,3 +70,51 @@ func TestPow(t *testing.T) {
 		"expected value & actual value's difference should less than precision",
 	)
 }
+
+func TestPowBounded(t *testing.T) {
+	tests := []struct {
+		name          string
+		base          sdk.Dec
+		exp           sdk.Dec
+		maxIterations int64
+		expectErr     bool
+	}{
+		{
+			name:          "converges within bound",
+			base:          sdk.MustNewDecFromStr("0.8"),
+			exp:           sdk.MustNewDecFromStr("1.32"),
+			maxIterations: 100,
+		},
+		{
+			name:          "unbounded",
+			base:          sdk.MustNewDecFromStr("0.01"),
+			exp:           sdk.MustNewDecFromStr("0.3"),
+			maxIterations: 0,
+		},
+		{
+			name:          "integer exponent needs no iterations",
+			base:          sdk.MustNewDecFromStr("0.01"),
+			exp:           sdk.MustNewDecFromStr("3"),
+			maxIterations: 1,
+		},
+		{
+			name:          "does not converge within bound",
+			base:          sdk.MustNewDecFromStr("0.01"),
+			exp:           sdk.MustNewDecFromStr("0.3"),
+			maxIterations: 100,
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := PowBounded(tc.base, tc.exp, powPrecision, tc.maxIterations)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, Pow(tc.base, tc.exp), s)
+		})
+	}
+}
@@ -60,20 +60,17 @@ func (qp QueryPlugin) GetSpotPrice(ctx sdk.Context, spotPrice *bindings.SpotPric
 	denomOut := spotPrice.Swap.DenomOut
 	withSwapFee := spotPrice.WithSwapFee
 
-	price, err := qp.gammKeeper.CalculateSpotPrice(ctx, poolId, denomIn, denomOut)
+	var price sdk.Dec
+	var err error
+	if withSwapFee {
+		price, err = qp.gammKeeper.CalculateSpotPriceWithSwapFee(ctx, poolId, denomIn, denomOut)
+	} else {
+		price, err = qp.gammKeeper.CalculateSpotPrice(ctx, poolId, denomIn, denomOut)
+	}
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "gamm get spot price")
 	}
 
-	if withSwapFee {
-		poolData, err := qp.gammKeeper.GetPoolAndPoke(ctx, poolId)
-		if err != nil {
-			return nil, sdkerrors.Wrap(err, "gamm get pool")
-		}
-
-		price = price.Mul(sdk.OneDec().Sub(poolData.GetSwapFee(ctx)))
-	}
-
 	return &price, nil
 }
 
@@ -34,6 +34,7 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdPoolParams(),
 		GetCmdTotalShares(),
 		GetCmdSpotPrice(),
+		GetCmdSwapableDenoms(),
 		GetCmdQueryTotalLiquidity(),
 		GetCmdEstimateSwapExactAmountIn(),
 		GetCmdEstimateSwapExactAmountOut(),
@@ -398,6 +399,41 @@ func GetCmdSpotPrice() *cobra.Command {
 	return cmd
 }
 
+// GetCmdSwapableDenoms returns the denoms that a denom of a pool can be swapped for.
+func GetCmdSwapableDenoms() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "swapable-denoms <pool-ID> <token-in-denom>",
+		Short: "Query the denoms that a denom of a pool can be swapped for",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+
+			poolID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.SwapableDenoms(cmd.Context(), &types.QuerySwapableDenomsRequest{
+				PoolId:       uint64(poolID),
+				TokenInDenom: args[1],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdEstimateSwapExactAmountIn returns estimation of output coin when amount of x token input.
 func GetCmdEstimateSwapExactAmountIn() *cobra.Command {
 	cmd := &cobra.Command{
@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetAssetScalingFactors sets the decimals of the assets of poolId, by which CalculateNormalizedSpotPrice
+// prices them. Factors without any factor remove the pool's scaling factors.
+// It is meant to be set by governance, e.g. in an upgrade handler, and has no message.
+func (k Keeper) SetAssetScalingFactors(ctx sdk.Context, poolId uint64, factors types.AssetScalingFactors) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if len(factors.Factors) == 0 {
+		store.Delete(types.GetKeyAssetScalingFactors(poolId))
+		return nil
+	}
+
+	if err := factors.Validate(pool.GetTotalPoolLiquidity(ctx)); err != nil {
+		return err
+	}
+
+	store.Set(types.GetKeyAssetScalingFactors(poolId), k.cdc.MustMarshal(&factors))
+	return nil
+}
+
+// GetAssetScalingFactors returns the asset scaling factors of poolId, and false if the pool has none.
+func (k Keeper) GetAssetScalingFactors(ctx sdk.Context, poolId uint64) (types.AssetScalingFactors, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetKeyAssetScalingFactors(poolId))
+	if bz == nil {
+		return types.AssetScalingFactors{}, false
+	}
+
+	var factors types.AssetScalingFactors
+	k.cdc.MustUnmarshal(bz, &factors)
+	return factors, true
+}
+
+// getAllAssetScalingFactors returns the asset scaling factors of all pools with some.
+func (k Keeper) getAllAssetScalingFactors(ctx sdk.Context) []types.PoolAssetScalingFactors {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixAssetScalingFactors)
+	defer iter.Close()
+
+	allFactors := []types.PoolAssetScalingFactors{}
+	for ; iter.Valid(); iter.Next() {
+		factors := types.PoolAssetScalingFactors{PoolId: sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixAssetScalingFactors):])}
+		k.cdc.MustUnmarshal(iter.Value(), &factors.Factors)
+		allFactors = append(allFactors, factors)
+	}
+	return allFactors
+}
+
+// CalculateNormalizedSpotPrice is CalculateSpotPrice in whole tokens rather than base units, scaling
+// the balances of the base and quote assets by the pool's asset scaling factors, see SetAssetScalingFactors.
+// E.g. for a pool of 2,000,000 usdc of 6 decimals and 1,000 weth of 18 decimals with equal weights,
+// the spot price of weth in usdc is 2000, where CalculateSpotPrice returns 2 * 10^-9 usdc base units per wei.
+// Swaps and the other spot prices of the module are in base units, and are unaffected by the scaling factors.
+// Only balancer pools are supported.
+func (k Keeper) CalculateNormalizedSpotPrice(
+	ctx sdk.Context,
+	poolID uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+) (sdk.Dec, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolID)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolID)
+	}
+
+	factors, _ := k.GetAssetScalingFactors(ctx, poolID)
+	return balancerPool.NormalizedSpotPrice(ctx, baseAssetDenom, quoteAssetDenom,
+		factors.ScalingFactor(baseAssetDenom), factors.ScalingFactor(quoteAssetDenom))
+}
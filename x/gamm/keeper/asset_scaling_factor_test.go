@@ -0,0 +1,129 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+var mixedDecimalsScalingFactors = types.AssetScalingFactors{Factors: []types.AssetScalingFactor{
+	{Denom: "uusdc", Decimals: 6},
+	{Denom: "wei", Decimals: 18},
+}}
+
+// prepareMixedDecimalsPool creates a pool of 2,000,000 usdc of 6 decimals and 1,000 weth of 18 decimals,
+// with equal weights, so that one weth is worth 2000 usdc.
+func (suite *KeeperTestSuite) prepareMixedDecimalsPool() uint64 {
+	usdc := sdk.NewCoin("uusdc", sdk.NewInt(2_000_000_000_000))
+	weth := sdk.NewCoin("wei", sdk.NewIntWithDecimal(1000, 18))
+	return suite.prepareCustomBalancerPool(
+		sdk.NewCoins(usdc, weth, sdk.NewInt64Coin("uosmo", 100_000_000_000)),
+		[]balancer.PoolAsset{
+			{Token: usdc, Weight: sdk.NewInt(100)},
+			{Token: weth, Weight: sdk.NewInt(100)},
+		},
+		balancer.PoolParams{SwapFee: sdk.NewDecWithPrec(1, 3), ExitFee: sdk.ZeroDec()},
+	)
+}
+
+func (suite *KeeperTestSuite) TestSetAssetScalingFactors() {
+	tests := []struct {
+		name        string
+		factors     types.AssetScalingFactors
+		expectedErr error
+	}{
+		{name: "valid factors", factors: mixedDecimalsScalingFactors},
+		{name: "no factors", factors: types.AssetScalingFactors{}},
+		{
+			name: "denom not in pool",
+			factors: types.AssetScalingFactors{Factors: []types.AssetScalingFactor{
+				{Denom: "uatom", Decimals: 6},
+			}},
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+		{
+			name: "too many decimals",
+			factors: types.AssetScalingFactors{Factors: []types.AssetScalingFactor{
+				{Denom: "wei", Decimals: sdk.Precision + 1},
+			}},
+			expectedErr: types.ErrPrecisionTooLarge,
+		},
+		{
+			name: "duplicate denom",
+			factors: types.AssetScalingFactors{Factors: []types.AssetScalingFactor{
+				{Denom: "wei", Decimals: 18},
+				{Denom: "wei", Decimals: 6},
+			}},
+			expectedErr: types.ErrDuplicateScalingFactor,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.prepareMixedDecimalsPool()
+			keeper := suite.App.GAMMKeeper
+
+			err := keeper.SetAssetScalingFactors(suite.Ctx, poolId, test.factors)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			factors, found := keeper.GetAssetScalingFactors(suite.Ctx, poolId)
+			suite.Require().Equal(len(test.factors.Factors) > 0, found)
+			if found {
+				suite.Require().Equal(test.factors, factors)
+			}
+		})
+	}
+}
+
+// TestCalculateNormalizedSpotPrice tests that the spot prices of a pool of 6 and 18 decimal assets
+// are the prices of their whole tokens, while swaps keep trading base units.
+func (suite *KeeperTestSuite) TestCalculateNormalizedSpotPrice() {
+	suite.SetupTest()
+	poolId := suite.prepareMixedDecimalsPool()
+	keeper := suite.App.GAMMKeeper
+	// the pool creator deposited all of its wei.
+	trader := suite.TestAccs[1]
+	tokenIn := sdk.NewCoin("wei", sdk.NewIntWithDecimal(1, 18))
+
+	// without scaling factors, the spot price is of base units, 2 * 10^-9 uusdc per wei.
+	rawSpotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecWithPrec(2, 9), rawSpotPrice)
+	spotPrice, err := keeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+	suite.Require().Equal(rawSpotPrice, spotPrice)
+	cacheCtx, _ := suite.Ctx.CacheContext()
+	rawTokenOutAmount, err := keeper.SwapExactAmountIn(cacheCtx, trader, poolId, tokenIn, "uusdc", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	err = keeper.SetAssetScalingFactors(suite.Ctx, poolId, mixedDecimalsScalingFactors)
+	suite.Require().NoError(err)
+
+	// one weth costs 2000 usdc, and one usdc 0.0005 weth.
+	spotPrice, err = keeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDec(2000), spotPrice)
+	spotPrice, err = keeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "wei", "uusdc")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecWithPrec(5, 4), spotPrice)
+
+	// the other spot prices and swaps are in base units, as before.
+	spotPrice, err = keeper.CalculateSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+	suite.Require().Equal(rawSpotPrice, spotPrice)
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "uusdc", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(rawTokenOutAmount, tokenOutAmount)
+
+	// the swap of one weth got about 2000 usdc, less the swap fee and slippage.
+	tokenOutUsdc := tokenOutAmount.ToDec().QuoInt64(1_000_000)
+	suite.Require().True(tokenOutUsdc.GT(sdk.NewDec(1995)) && tokenOutUsdc.LT(sdk.NewDec(2000)), "got %s usdc", tokenOutUsdc)
+	_, err = keeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "uusdc", "uatom")
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+}
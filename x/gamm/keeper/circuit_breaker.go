@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetCircuitBreakerEnabled enables, or disables, the circuit breaker of the pool with poolId.
+// A pool with its circuit breaker enabled is paused by any single swap that moves its spot price
+// by more than the CircuitBreakerThreshold param, see GetCircuitBreakerThreshold.
+// It is called by governance through a SetCircuitBreakerEnabledProposal.
+func (k Keeper) SetCircuitBreakerEnabled(ctx sdk.Context, poolId uint64, enabled bool) error {
+	if _, err := k.GetPoolAndPoke(ctx, poolId); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if !enabled {
+		store.Delete(types.GetKeyCircuitBreakerPool(poolId))
+		return nil
+	}
+	store.Set(types.GetKeyCircuitBreakerPool(poolId), []byte{1})
+	return nil
+}
+
+// IsCircuitBreakerEnabled returns whether the circuit breaker of the pool with poolId is enabled.
+func (k Keeper) IsCircuitBreakerEnabled(ctx sdk.Context, poolId uint64) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetKeyCircuitBreakerPool(poolId))
+}
+
+// SetPoolPaused pauses, or resumes, swaps through the pool with poolId.
+// Swaps through a paused pool fail with ErrPoolLocked, as for an inactive pool, while joins and exits are unaffected.
+// Pools are paused by their circuit breaker, and paused or resumed by governance through a SetPoolPausedProposal.
+func (k Keeper) SetPoolPaused(ctx sdk.Context, poolId uint64, paused bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !paused {
+		store.Delete(types.GetKeyPausedPool(poolId))
+		return
+	}
+	store.Set(types.GetKeyPausedPool(poolId), []byte{1})
+}
+
+// IsPoolPaused returns whether swaps through the pool with poolId are paused, see SetPoolPaused.
+func (k Keeper) IsPoolPaused(ctx sdk.Context, poolId uint64) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetKeyPausedPool(poolId))
+}
+
+// getPoolIdsWithKeyPrefix returns the IDs of the pools marked in the store under keyPrefix,
+// e.g. types.KeyPrefixPausedPools.
+func (k Keeper) getPoolIdsWithKeyPrefix(ctx sdk.Context, keyPrefix []byte) []uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, keyPrefix)
+	defer iter.Close()
+
+	poolIds := []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		poolIds = append(poolIds, sdk.BigEndianToUint64(iter.Key()[len(keyPrefix):]))
+	}
+	return poolIds
+}
+
+// checkCircuitBreaker pauses the pool of hop, if its circuit breaker is enabled and the swap
+// moved its spot price by more than the threshold, relative to the spot price before the swap.
+// The swap that trips the breaker is still written, as its sender accepted its price by its limit amount,
+// only the swaps after it are rejected.
+func (k Keeper) checkCircuitBreaker(ctx sdk.Context, hop swapHop) {
+	poolId := hop.pool.GetId()
+	if !k.IsCircuitBreakerEnabled(ctx, poolId) || k.IsPoolPaused(ctx, poolId) {
+		return
+	}
+
+	threshold := k.GetCircuitBreakerThreshold(ctx)
+	priceMove := hop.spotPriceAfter.Sub(hop.spotPriceBefore).Abs().Quo(hop.spotPriceBefore)
+	if priceMove.LTE(threshold) {
+		return
+	}
+
+	k.SetPoolPaused(ctx, poolId, true)
+	ctx.EventManager().EmitEvent(types.CreatePoolPausedEvent(ctx, poolId, hop.spotPriceBefore, hop.spotPriceAfter, threshold))
+}
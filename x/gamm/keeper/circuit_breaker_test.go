@@ -0,0 +1,110 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// TestCircuitBreaker tests that a swap moving the spot price by more than the threshold pauses the pool,
+// and that the swaps after it are rejected until the pool is resumed.
+func (suite *KeeperTestSuite) TestCircuitBreaker() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	trader := suite.TestAccs[0]
+	suite.FundAcc(trader, sdk.NewCoins(sdk.NewInt64Coin("foo", 10000000)))
+	smallTokenIn := sdk.NewInt64Coin("foo", 10000)
+	largeTokenIn := sdk.NewInt64Coin("foo", 5000000)
+
+	suite.Require().Equal(types.DefaultCircuitBreakerThreshold, keeper.GetCircuitBreakerThreshold(suite.Ctx))
+	suite.Require().Error(keeper.SetCircuitBreakerEnabled(suite.Ctx, poolId+1, true))
+	suite.Require().NoError(keeper.SetCircuitBreakerEnabled(suite.Ctx, poolId, true))
+	suite.Require().True(keeper.IsCircuitBreakerEnabled(suite.Ctx, poolId))
+
+	// swaps moving the price by less than the threshold don't trip the breaker.
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, smallTokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().False(keeper.IsPoolPaused(suite.Ctx, poolId))
+
+	// the swap that trips the breaker goes through, and pauses the pool.
+	spotPriceBefore, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, largeTokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	spotPriceAfter, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().True(spotPriceAfter.Sub(spotPriceBefore).Quo(spotPriceBefore).GT(types.DefaultCircuitBreakerThreshold))
+	suite.Require().True(keeper.IsPoolPaused(suite.Ctx, poolId))
+	pausedEvents := 0
+	for _, event := range suite.Ctx.EventManager().Events() {
+		if event.Type == types.TypeEvtPoolPaused {
+			pausedEvents++
+		}
+	}
+	suite.Require().Equal(1, pausedEvents)
+
+	// all swaps through the paused pool are rejected, while joins aren't.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, smallTokenIn, "bar", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "bar", sdk.NewInt(1000000), sdk.NewInt64Coin("foo", 10000))
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+	_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, []types.SwapAmountInRoute{
+		{PoolId: poolId, TokenOutDenom: "bar"},
+	}, smallTokenIn, sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+	_, err = keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, smallTokenIn, "bar")
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+	suite.Require().NoError(keeper.JoinPoolNoSwap(suite.Ctx, trader, poolId, types.OneShare, nil))
+
+	// once resumed, the pool can be swapped through again.
+	keeper.SetPoolPaused(suite.Ctx, poolId, false)
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, smallTokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().False(keeper.IsPoolPaused(suite.Ctx, poolId))
+}
+
+// TestCircuitBreakerThreshold tests that the breaker only trips for pools that enabled it,
+// and for price moves larger than the governance set threshold.
+func (suite *KeeperTestSuite) TestCircuitBreakerThreshold() {
+	tests := []struct {
+		name           string
+		enabled        bool
+		threshold      sdk.Dec
+		multihop       bool
+		expectedPaused bool
+	}{
+		{name: "disabled", threshold: types.DefaultCircuitBreakerThreshold},
+		{name: "enabled", enabled: true, threshold: types.DefaultCircuitBreakerThreshold, expectedPaused: true},
+		{name: "enabled, multihop", enabled: true, threshold: types.DefaultCircuitBreakerThreshold, multihop: true, expectedPaused: true},
+		{name: "enabled, raised threshold", enabled: true, threshold: sdk.NewDec(10)},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPool()
+			trader := suite.TestAccs[0]
+			suite.FundAcc(trader, sdk.NewCoins(sdk.NewInt64Coin("foo", 10000000)))
+			tokenIn := sdk.NewInt64Coin("foo", 5000000)
+			suite.Require().NoError(keeper.SetCircuitBreakerEnabled(suite.Ctx, poolId, test.enabled))
+			suite.Require().NoError(keeper.SetCircuitBreakerThreshold(suite.Ctx, test.threshold))
+			suite.Require().Equal(test.threshold, keeper.GetCircuitBreakerThreshold(suite.Ctx))
+
+			var err error
+			if test.multihop {
+				_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, []types.SwapAmountInRoute{
+					{PoolId: poolId, TokenOutDenom: "bar"},
+				}, tokenIn, sdk.OneInt())
+			} else {
+				_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedPaused, keeper.IsPoolPaused(suite.Ctx, poolId))
+		})
+	}
+
+	suite.Require().Error(suite.App.GAMMKeeper.SetCircuitBreakerThreshold(suite.Ctx, sdk.ZeroDec()))
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetDirectionalSwapFees sets the swap fees of poolId by swap direction. Fees without any fee remove
+// the pool's directional swap fees, so that swaps in all directions pay the pool's swap fee again.
+// It is called by governance through a SetDirectionalSwapFeesProposal.
+func (k Keeper) SetDirectionalSwapFees(ctx sdk.Context, poolId uint64, fees types.DirectionalSwapFees) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if len(fees.Fees) == 0 {
+		store.Delete(types.GetKeyDirectionalSwapFees(poolId))
+		return nil
+	}
+
+	if err := fees.Validate(pool.GetTotalPoolLiquidity(ctx)); err != nil {
+		return err
+	}
+
+	store.Set(types.GetKeyDirectionalSwapFees(poolId), k.cdc.MustMarshal(&fees))
+	return nil
+}
+
+// GetDirectionalSwapFees returns the directional swap fees of poolId, and false if the pool has none.
+func (k Keeper) GetDirectionalSwapFees(ctx sdk.Context, poolId uint64) (types.DirectionalSwapFees, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetKeyDirectionalSwapFees(poolId))
+	if bz == nil {
+		return types.DirectionalSwapFees{}, false
+	}
+
+	var fees types.DirectionalSwapFees
+	k.cdc.MustUnmarshal(bz, &fees)
+	return fees, true
+}
+
+// getAllDirectionalSwapFees returns the directional swap fees of all pools with some.
+func (k Keeper) getAllDirectionalSwapFees(ctx sdk.Context) []types.PoolDirectionalSwapFees {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixDirectionalSwapFees)
+	defer iter.Close()
+
+	allFees := []types.PoolDirectionalSwapFees{}
+	for ; iter.Valid(); iter.Next() {
+		fees := types.PoolDirectionalSwapFees{PoolId: sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixDirectionalSwapFees):])}
+		k.cdc.MustUnmarshal(iter.Value(), &fees.Fees)
+		allFees = append(allFees, fees)
+	}
+	return allFees
+}
+
+// directionalSwapFee returns the pool's swap fee for swapping tokenInDenom for tokenOutDenom,
+// which is the pool's swap fee if the pool has no directional swap fees.
+func (k Keeper) directionalSwapFee(ctx sdk.Context, pool types.PoolI, tokenInDenom, tokenOutDenom string) sdk.Dec {
+	fees, found := k.GetDirectionalSwapFees(ctx, pool.GetId())
+	if !found {
+		return pool.GetSwapFee(ctx)
+	}
+	return fees.SwapFeeForDirection(pool.GetSwapFee(ctx), tokenInDenom, tokenOutDenom)
+}
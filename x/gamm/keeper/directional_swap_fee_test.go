@@ -0,0 +1,184 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+var (
+	sellFooSwapFee = sdk.NewDecWithPrec(5, 2)
+	buyFooSwapFee  = sdk.NewDecWithPrec(5, 4)
+	fooBazSwapFee  = sdk.NewDecWithPrec(3, 2)
+
+	defaultDirectionalSwapFees = types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+		{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sellFooSwapFee},
+		{TokenInDenom: "bar", TokenOutDenom: "foo", SwapFee: buyFooSwapFee},
+		{TokenInDenom: "foo", TokenOutDenom: "baz", SwapFee: fooBazSwapFee},
+	}}
+)
+
+func (suite *KeeperTestSuite) prepareDirectionalSwapFeePool() uint64 {
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.ZeroDec(),
+	})
+	suite.Require().NoError(suite.App.GAMMKeeper.SetDirectionalSwapFees(suite.Ctx, poolId, defaultDirectionalSwapFees))
+	return poolId
+}
+
+func (suite *KeeperTestSuite) TestSetDirectionalSwapFees() {
+	tests := []struct {
+		name        string
+		fees        types.DirectionalSwapFees
+		expectedErr error
+	}{
+		{
+			name: "valid fees",
+			fees: defaultDirectionalSwapFees,
+		},
+		{
+			name: "no fees",
+			fees: types.DirectionalSwapFees{},
+		},
+		{
+			name: "denom not in pool",
+			fees: types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+				{TokenInDenom: "foo", TokenOutDenom: "uatom", SwapFee: sellFooSwapFee},
+			}},
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+		{
+			name: "same denom in and out",
+			fees: types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+				{TokenInDenom: "foo", TokenOutDenom: "foo", SwapFee: sellFooSwapFee},
+			}},
+			expectedErr: types.ErrSameDenom,
+		},
+		{
+			name: "negative swap fee",
+			fees: types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+				{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sdk.NewDecWithPrec(-1, 2)},
+			}},
+			expectedErr: types.ErrNegativeSwapFee,
+		},
+		{
+			name: "swap fee of 1",
+			fees: types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+				{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sdk.OneDec()},
+			}},
+			expectedErr: types.ErrTooMuchSwapFee,
+		},
+		{
+			name: "duplicate direction",
+			fees: types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+				{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sellFooSwapFee},
+				{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: buyFooSwapFee},
+			}},
+			expectedErr: types.ErrDuplicateSwapDirection,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+
+			err := keeper.SetDirectionalSwapFees(suite.Ctx, poolId, test.fees)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			fees, found := keeper.GetDirectionalSwapFees(suite.Ctx, poolId)
+			suite.Require().Equal(len(test.fees.Fees) > 0, found)
+			if found {
+				suite.Require().Equal(test.fees, fees)
+			}
+		})
+	}
+}
+
+// TestDirectionalSwapFees tests that buying and selling the same pair are charged their own swap fees,
+// in both the exact amount in and the exact amount out swaps.
+func (suite *KeeperTestSuite) TestDirectionalSwapFees() {
+	poolSwapFee := sdk.NewDecWithPrec(1, 2)
+	tests := []struct {
+		name            string
+		tokenInDenom    string
+		tokenOutDenom   string
+		expectedSwapFee sdk.Dec
+	}{
+		{name: "sell foo", tokenInDenom: "foo", tokenOutDenom: "bar", expectedSwapFee: sellFooSwapFee},
+		{name: "buy foo", tokenInDenom: "bar", tokenOutDenom: "foo", expectedSwapFee: buyFooSwapFee},
+		{name: "one direction set", tokenInDenom: "foo", tokenOutDenom: "baz", expectedSwapFee: fooBazSwapFee},
+		{name: "opposite of one direction set", tokenInDenom: "baz", tokenOutDenom: "foo", expectedSwapFee: fooBazSwapFee},
+		{name: "no direction set", tokenInDenom: "bar", tokenOutDenom: "baz", expectedSwapFee: poolSwapFee},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.prepareDirectionalSwapFeePool()
+			keeper := suite.App.GAMMKeeper
+			trader := suite.TestAccs[0]
+
+			swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, test.tokenInDenom, test.tokenOutDenom)
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedSwapFee, swapFee)
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			tokenIn := sdk.NewInt64Coin(test.tokenInDenom, 100000)
+			expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, test.tokenOutDenom, test.expectedSwapFee)
+			suite.Require().NoError(err)
+			tokenOut := sdk.NewInt64Coin(test.tokenOutDenom, 100000)
+			expectedTokenIn, err := pool.CalcInAmtGivenOut(suite.Ctx, sdk.Coins{tokenOut}, test.tokenInDenom, test.expectedSwapFee)
+			suite.Require().NoError(err)
+
+			estimatedAmount, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, test.tokenOutDenom)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenOut.Amount, estimatedAmount)
+
+			cacheCtx, _ := suite.Ctx.CacheContext()
+			tokenOutAmount, err := keeper.SwapExactAmountIn(cacheCtx, trader, poolId, tokenIn, test.tokenOutDenom, sdk.OneInt())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+
+			cacheCtx, _ = suite.Ctx.CacheContext()
+			tokenInAmount, err := keeper.SwapExactAmountOut(cacheCtx, trader, poolId, test.tokenInDenom, sdk.NewInt(1000000), tokenOut)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenIn.Amount, tokenInAmount)
+
+			cacheCtx, _ = suite.Ctx.CacheContext()
+			tokenOutAmount, err = keeper.MultihopSwapExactAmountIn(cacheCtx, trader, []types.SwapAmountInRoute{
+				{PoolId: poolId, TokenOutDenom: test.tokenOutDenom},
+			}, tokenIn, sdk.OneInt())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+		})
+	}
+}
+
+// TestDirectionalSwapFeesWithTiers tests that a trader's tier lowers the fee of a direction,
+// but never raises it.
+func (suite *KeeperTestSuite) TestDirectionalSwapFeesWithTiers() {
+	suite.SetupTest()
+	poolId := suite.prepareDirectionalSwapFeePool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	suite.Require().NoError(keeper.SetSwapFeeTiers(suite.Ctx, poolId, defaultSwapFeeTiers))
+
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("foo", 300000), "baz", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(defaultSwapFeeTiers.Tiers[1].SwapFee, swapFee)
+	swapFee, err = keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "bar", "foo")
+	suite.Require().NoError(err)
+	suite.Require().Equal(buyFooSwapFee, swapFee)
+}
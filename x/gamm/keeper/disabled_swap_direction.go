@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetDisabledSwapDirections sets the swap directions poolId rejects swaps in. Disabled without any direction
+// enables all directions of the pool again.
+// It is meant to be set by governance, e.g. in an upgrade handler, and has no message.
+func (k Keeper) SetDisabledSwapDirections(ctx sdk.Context, poolId uint64, disabled types.DisabledSwapDirections) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if len(disabled.Directions) == 0 {
+		store.Delete(types.GetKeyDisabledSwapDirections(poolId))
+		return nil
+	}
+
+	if err := disabled.Validate(pool.GetTotalPoolLiquidity(ctx)); err != nil {
+		return err
+	}
+
+	store.Set(types.GetKeyDisabledSwapDirections(poolId), k.cdc.MustMarshal(&disabled))
+	return nil
+}
+
+// GetDisabledSwapDirections returns the swap directions disabled on poolId, and false if the pool has none.
+func (k Keeper) GetDisabledSwapDirections(ctx sdk.Context, poolId uint64) (types.DisabledSwapDirections, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetKeyDisabledSwapDirections(poolId))
+	if bz == nil {
+		return types.DisabledSwapDirections{}, false
+	}
+
+	var disabled types.DisabledSwapDirections
+	k.cdc.MustUnmarshal(bz, &disabled)
+	return disabled, true
+}
+
+// getAllDisabledSwapDirections returns the swap directions disabled on all pools with some.
+func (k Keeper) getAllDisabledSwapDirections(ctx sdk.Context) []types.PoolDisabledSwapDirections {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixDisabledSwapDirections)
+	defer iter.Close()
+
+	allDisabled := []types.PoolDisabledSwapDirections{}
+	for ; iter.Valid(); iter.Next() {
+		disabled := types.PoolDisabledSwapDirections{PoolId: sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixDisabledSwapDirections):])}
+		k.cdc.MustUnmarshal(iter.Value(), &disabled.Directions)
+		allDisabled = append(allDisabled, disabled)
+	}
+	return allDisabled
+}
+
+// checkSwapDirectionEnabled returns ErrSwapDirectionDisabled if swapping tokenInDenom for tokenOutDenom
+// is disabled on poolId, see SetDisabledSwapDirections.
+func (k Keeper) checkSwapDirectionEnabled(ctx sdk.Context, poolId uint64, tokenInDenom, tokenOutDenom string) error {
+	disabled, found := k.GetDisabledSwapDirections(ctx, poolId)
+	if found && disabled.IsDisabled(tokenInDenom, tokenOutDenom) {
+		return sdkerrors.Wrapf(types.ErrSwapDirectionDisabled, "swapping %s for %s on pool %d", tokenInDenom, tokenOutDenom, poolId)
+	}
+	return nil
+}
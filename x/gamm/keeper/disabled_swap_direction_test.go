@@ -0,0 +1,110 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// buyBarOnly disables selling bar back for foo, while foo can still be swapped for bar.
+var buyBarOnly = types.DisabledSwapDirections{Directions: []types.SwapDirection{
+	{TokenInDenom: "bar", TokenOutDenom: "foo"},
+}}
+
+func (suite *KeeperTestSuite) TestSetDisabledSwapDirections() {
+	tests := []struct {
+		name        string
+		disabled    types.DisabledSwapDirections
+		expectedErr error
+	}{
+		{name: "valid directions", disabled: buyBarOnly},
+		{name: "no directions", disabled: types.DisabledSwapDirections{}},
+		{
+			name: "denom not in pool",
+			disabled: types.DisabledSwapDirections{Directions: []types.SwapDirection{
+				{TokenInDenom: "bar", TokenOutDenom: "uatom"},
+			}},
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+		{
+			name: "same denom in and out",
+			disabled: types.DisabledSwapDirections{Directions: []types.SwapDirection{
+				{TokenInDenom: "bar", TokenOutDenom: "bar"},
+			}},
+			expectedErr: types.ErrSameDenom,
+		},
+		{
+			name: "duplicate direction",
+			disabled: types.DisabledSwapDirections{Directions: []types.SwapDirection{
+				{TokenInDenom: "bar", TokenOutDenom: "foo"},
+				{TokenInDenom: "bar", TokenOutDenom: "foo"},
+			}},
+			expectedErr: types.ErrDuplicateSwapDirection,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+
+			err := keeper.SetDisabledSwapDirections(suite.Ctx, poolId, test.disabled)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			disabled, found := keeper.GetDisabledSwapDirections(suite.Ctx, poolId)
+			suite.Require().Equal(len(test.disabled.Directions) > 0, found)
+			if found {
+				suite.Require().Equal(test.disabled, disabled)
+			}
+		})
+	}
+}
+
+// TestDisabledSwapDirections tests that swaps in a disabled direction are rejected, however they're routed,
+// while swaps in the opposite direction succeed.
+func (suite *KeeperTestSuite) TestDisabledSwapDirections() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	err := keeper.SetDisabledSwapDirections(suite.Ctx, poolId, buyBarOnly)
+	suite.Require().NoError(err)
+
+	// foo can be swapped for bar.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("foo", 1000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "foo", sdk.NewInt(10000), sdk.NewInt64Coin("bar", 1000))
+	suite.Require().NoError(err)
+
+	// bar can't be swapped back for foo, and the rejected swaps leave the pool unchanged.
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	liquidity := pool.GetTotalPoolLiquidity(suite.Ctx)
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("bar", 1000), "foo", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrSwapDirectionDisabled)
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "bar", sdk.NewInt(10000), sdk.NewInt64Coin("foo", 1000))
+	suite.Require().ErrorIs(err, types.ErrSwapDirectionDisabled)
+	_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, []types.SwapAmountInRoute{
+		{PoolId: poolId, TokenOutDenom: "bar"},
+		{PoolId: poolId, TokenOutDenom: "foo"},
+	}, sdk.NewInt64Coin("baz", 1000), sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrSwapDirectionDisabled)
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(liquidity, pool.GetTotalPoolLiquidity(suite.Ctx))
+
+	// the pool's other directions are enabled.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("bar", 1000), "baz", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	// enabling all directions again allows swapping bar for foo.
+	err = keeper.SetDisabledSwapDirections(suite.Ctx, poolId, types.DisabledSwapDirections{})
+	suite.Require().NoError(err)
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("bar", 1000), "foo", sdk.OneInt())
+	suite.Require().NoError(err)
+}
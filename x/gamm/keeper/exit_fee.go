@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetExitFeeRecipient sets the address the exit fees of poolId are sent to on ExitPool.
+// Without a recipient, which is the default, the exit fees stay in the pool, to the benefit of the remaining LPs.
+// The recipient only applies to proportional exits, i.e. ExitPool and the exits built on it. Single asset exits
+// of pools implementing types.PoolAmountOutExtension, ExitSwapShareAmountIn and ExitSwapExactAmountOut,
+// always leave their exit fee in the pool, as it is part of their exit math rather than withheld coins.
+// An empty recipient resets the pool to the default.
+// It is called by governance through a SetExitFeeRecipientProposal.
+func (k Keeper) SetExitFeeRecipient(ctx sdk.Context, poolId uint64, recipient sdk.AccAddress) error {
+	if _, err := k.GetPoolAndPoke(ctx, poolId); err != nil {
+		return err
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if recipient.Empty() {
+		store.Delete(types.GetKeyExitFeeRecipient(poolId))
+		return nil
+	}
+	store.Set(types.GetKeyExitFeeRecipient(poolId), recipient)
+	return nil
+}
+
+// GetExitFeeRecipient returns the address the exit fees of poolId are sent to,
+// and false if the exit fees stay in the pool.
+func (k Keeper) GetExitFeeRecipient(ctx sdk.Context, poolId uint64) (sdk.AccAddress, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetKeyExitFeeRecipient(poolId))
+	if bz == nil {
+		return nil, false
+	}
+	return sdk.AccAddress(bz), true
+}
+
+// getAllExitFeeRecipients returns the exit fee recipients of all pools with one.
+func (k Keeper) getAllExitFeeRecipients(ctx sdk.Context) []types.PoolExitFeeRecipient {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixExitFeeRecipients)
+	defer iter.Close()
+
+	recipients := []types.PoolExitFeeRecipient{}
+	for ; iter.Valid(); iter.Next() {
+		recipients = append(recipients, types.PoolExitFeeRecipient{
+			PoolId:    sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixExitFeeRecipients):]),
+			Recipient: sdk.AccAddress(iter.Value()).String(),
+		})
+	}
+	return recipients
+}
+
+// exitPoolToExitFeeRecipient exits the pool like pool.ExitPool, but rather than withholding the exit fee
+// in the pool, the pool gives out the coins of an exit without fee. It returns the coins for the exiter,
+// and the exit fee withheld from them, which the caller has to send to the exit fee recipient.
+func exitPoolToExitFeeRecipient(ctx sdk.Context, pool types.PoolI, shareInAmount sdk.Int, exitFee sdk.Dec) (exitCoins sdk.Coins, withheldCoins sdk.Coins, err error) {
+	exitCoins, err = pool.CalcExitPoolShares(ctx, shareInAmount, exitFee)
+	if err != nil {
+		return sdk.Coins{}, sdk.Coins{}, err
+	}
+	allCoins, err := pool.ExitPool(ctx, shareInAmount, sdk.ZeroDec())
+	if err != nil {
+		return sdk.Coins{}, sdk.Coins{}, err
+	}
+	withheldCoins, hasNeg := allCoins.SafeSub(exitCoins)
+	if hasNeg {
+		return sdk.Coins{}, sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "exit of %s with exit fee is larger than the exit without exit fee %s", exitCoins, allCoins)
+	}
+	return exitCoins, withheldCoins, nil
+}
@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// All swaps in tests check that they don't decrease the pool invariant.
+func init() {
+	checkSwapInvariant = true
+}
+
+func (k Keeper) UpdatePoolForSwap(ctx sdk.Context, pool types.PoolI, sender sdk.AccAddress, tokenIn sdk.Coin, tokenOut sdk.Coin, spotPriceBefore sdk.Dec, swapFee sdk.Dec) error {
+	return k.updatePoolForSwap(ctx, pool, sender, tokenIn, tokenOut, spotPriceBefore, swapFee)
+}
+
+// ReplaceHooks replaces the keeper's hooks with gh, returning the hooks it replaced.
+func (k *Keeper) ReplaceHooks(gh types.GammHooks) types.GammHooks {
+	hooks := k.hooks
+	k.hooks = gh
+	return hooks
+}
+
+// ReplaceBankKeeper replaces the keeper's bank keeper with bk, returning the bank keeper it replaced.
+func (k *Keeper) ReplaceBankKeeper(bk types.BankKeeper) types.BankKeeper {
+	bankKeeper := k.bankKeeper
+	k.bankKeeper = bk
+	return bankKeeper
+}
+
+// UpdatePoolDenomIndex moves poolId from the index of the denoms of oldLiquidity to the one of the denoms of newLiquidity.
+func (k Keeper) UpdatePoolDenomIndex(ctx sdk.Context, poolId uint64, oldLiquidity, newLiquidity sdk.Coins) {
+	k.updatePoolDenomIndex(ctx, poolId, oldLiquidity, newLiquidity)
+}
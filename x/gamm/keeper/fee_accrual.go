@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// GetPoolFeesPerShare returns the cumulative swap fees accrued to the LPs of poolId per types.OneShare
+// of the pool's shares, for every denom swapped in. The fees accrue at the pool's total shares at the time
+// of each swap, and exclude the protocol's share of the swap fees, see GetProtocolFeeShare.
+//
+// The fees per share only ever increase, so an LP can take them as a checkpoint when joining,
+// and estimate the fees accrued to its shares since with GetAccruedFees.
+func (k Keeper) GetPoolFeesPerShare(ctx sdk.Context, poolId uint64) sdk.DecCoins {
+	return k.GetPoolAccumulators(ctx, poolId).FeesPerShare
+}
+
+// GetAccruedFees estimates the swap fees accrued to shareAmount of the shares of poolId since checkpoint,
+// a value of GetPoolFeesPerShare, assuming the shares were held throughout.
+// The fees aren't paid out separately: they're part of the pool's reserves, and are received on exiting the pool.
+func (k Keeper) GetAccruedFees(ctx sdk.Context, poolId uint64, shareAmount sdk.Int, checkpoint sdk.DecCoins) (sdk.DecCoins, error) {
+	feesPerShare := k.GetPoolFeesPerShare(ctx, poolId)
+	accruedPerShare, isNegative := feesPerShare.SafeSub(checkpoint)
+	if isNegative {
+		return sdk.DecCoins{}, sdkerrors.Wrapf(types.ErrInvalidFeeCheckpoint, "checkpoint %s, fees per share of pool %d %s", checkpoint, poolId, feesPerShare)
+	}
+	return accruedPerShare.MulDec(shareAmount.ToDec()).QuoDec(types.OneShare.ToDec()), nil
+}
+
+// hopFeePerShare returns the LP fee of hop divided by the pool's total shares,
+// and false if the hop paid no LP fee.
+func hopFeePerShare(hop swapHop) (sdk.DecCoin, bool) {
+	totalShares := hop.pool.GetTotalShares()
+	if hop.lpFee.IsNil() || !hop.lpFee.IsPositive() || !totalShares.IsPositive() {
+		return sdk.DecCoin{}, false
+	}
+	// per OneShare rather than per base unit of shares, as the fees per base unit are too small for a Dec.
+	return sdk.NewDecCoinFromDec(hop.tokenIn.Denom, hop.lpFee.MulInt(types.OneShare).QuoInt(totalShares)), true
+}
@@ -0,0 +1,58 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// TestPoolFeesPerShare tests that swaps accrue the LPs' share of their swap fees per share of the pool,
+// and that the fees accrued to a share balance are its part of the fees swapped in since a checkpoint.
+func (suite *KeeperTestSuite) TestPoolFeesPerShare() {
+	suite.SetupTest()
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.ZeroDec(),
+	})
+	err := keeper.SetProtocolFeeShare(suite.Ctx, sdk.NewDecWithPrec(2, 1))
+	suite.Require().NoError(err)
+	trader := suite.TestAccs[0]
+	// the pool creator, which also trades, holds all of the pool's 100 shares.
+	totalShares := types.OneShare.MulRaw(100)
+
+	checkpoint := keeper.GetPoolFeesPerShare(suite.Ctx, poolId)
+	suite.Require().Empty(checkpoint)
+
+	// the swap fee is 100000 * 0.01 = 1000 foo, of which 800 foo stay in the pool, 8 foo per share.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("foo", 100000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	feesPerShare := keeper.GetPoolFeesPerShare(suite.Ctx, poolId)
+	suite.Require().Equal(sdk.NewDecCoins(sdk.NewInt64DecCoin("foo", 8)), feesPerShare)
+
+	accruedFees, err := keeper.GetAccruedFees(suite.Ctx, poolId, totalShares, checkpoint)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecCoins(sdk.NewInt64DecCoin("foo", 800)), accruedFees)
+	accruedFees, err = keeper.GetAccruedFees(suite.Ctx, poolId, totalShares.QuoRaw(4), checkpoint)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecCoins(sdk.NewInt64DecCoin("foo", 200)), accruedFees)
+
+	// swaps of an exact amount out accrue the fee paid on the token in, and fees accrue per denom from a later checkpoint.
+	checkpoint = feesPerShare
+	tokenInAmount, err := keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "bar", sdk.NewInt(1000000), sdk.NewInt64Coin("baz", 100000))
+	suite.Require().NoError(err)
+	expectedLpFee := tokenInAmount.ToDec().Mul(sdk.NewDecWithPrec(1, 2))
+	expectedLpFee = expectedLpFee.Sub(expectedLpFee.Mul(sdk.NewDecWithPrec(2, 1)).TruncateDec())
+	accruedFees, err = keeper.GetAccruedFees(suite.Ctx, poolId, totalShares, checkpoint)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecCoins(sdk.NewDecCoinFromDec("bar", expectedLpFee)), accruedFees)
+
+	// a checkpoint ahead of the pool's fees per share is invalid.
+	_, err = keeper.GetAccruedFees(suite.Ctx, poolId, totalShares, sdk.NewDecCoins(sdk.NewInt64DecCoin("foo", 9)))
+	suite.Require().ErrorIs(err, types.ErrInvalidFeeCheckpoint)
+
+	// other pools accrue no fees.
+	suite.Require().Empty(keeper.GetPoolFeesPerShare(suite.Ctx, poolId+1))
+}
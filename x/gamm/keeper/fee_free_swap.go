@@ -0,0 +1,120 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetFeeFreeSwapModule authorizes, or deauthorizes, the account of moduleName to swap
+// through SwapExactAmountInNoFee, without being charged the swap fee, and through
+// SwapExactAmountInWithFee, with a reduced swap fee.
+// It is meant for protocol operations, e.g. rebalancing or liquidations, and has no message.
+// moduleName must have a module account, otherwise ErrNoModuleAccount is returned.
+func (k Keeper) SetFeeFreeSwapModule(ctx sdk.Context, moduleName string, authorized bool) error {
+	if k.accountKeeper.GetModuleAddress(moduleName) == nil {
+		return sdkerrors.Wrapf(types.ErrNoModuleAccount, "module %s", moduleName)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if !authorized {
+		store.Delete(types.GetKeyFeeFreeSwapModule(moduleName))
+		return nil
+	}
+	store.Set(types.GetKeyFeeFreeSwapModule(moduleName), []byte{1})
+	return nil
+}
+
+// IsFeeFreeSwapModule returns whether the account of moduleName is authorized to swap without a swap fee.
+func (k Keeper) IsFeeFreeSwapModule(ctx sdk.Context, moduleName string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetKeyFeeFreeSwapModule(moduleName))
+}
+
+// getAllFeeFreeSwapModules returns the names of all modules authorized to swap without a swap fee.
+func (k Keeper) getAllFeeFreeSwapModules(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixFeeFreeSwapModules)
+	defer iter.Close()
+
+	moduleNames := []string{}
+	for ; iter.Valid(); iter.Next() {
+		moduleNames = append(moduleNames, string(iter.Key()[len(types.KeyPrefixFeeFreeSwapModules):]))
+	}
+	return moduleNames
+}
+
+// SwapExactAmountInNoFee is SwapExactAmountIn without a swap fee, so that all of tokenIn is
+// swapped against the pool. The sender must be the account of a module authorized by SetFeeFreeSwapModule,
+// otherwise ErrUnauthorizedFeeFreeSwap is returned.
+// The swap updates the pool's reserves like any other swap, and its swap event has a fee_free attribute.
+func (k Keeper) SwapExactAmountInNoFee(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+) (sdk.Int, error) {
+	if !k.isFeeFreeSwapSender(ctx, sender) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrUnauthorizedFeeFreeSwap, "sender %s", sender)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	tokenOut, spotPriceBefore, tokenOutRemainder, err := applySwapExactAmountIn(ctx, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, sdk.ZeroDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	hop, err := k.newSwapHop(ctx, pool, tokenIn, tokenOut, spotPriceBefore, sdk.ZeroDec())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	hop.feeFree = true
+	hop.tokenOutRemainder = tokenOutRemainder
+	if err := k.updatePoolForSwapHop(ctx, sender, hop); err != nil {
+		return sdk.Int{}, err
+	}
+
+	return tokenOut.Amount, nil
+}
+
+// SwapExactAmountInWithFee is SwapExactAmountIn charging swapFee instead of the pool's swap fee,
+// e.g. for rebalancing protocol-owned liquidity. The override is only honored for the account of a module
+// authorized by SetFeeFreeSwapModule, and only if it is below the fee the sender would otherwise pay,
+// see GetPoolSwapFee. Otherwise the swap is charged that fee, as by SwapExactAmountIn.
+func (k Keeper) SwapExactAmountInWithFee(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	swapFee sdk.Dec,
+) (sdk.Int, error) {
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Int{}, sdkerrors.Wrapf(err, "swap fee override %s", swapFee)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	poolSwapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	if !k.isFeeFreeSwapSender(ctx, sender) {
+		swapFee = poolSwapFee
+	}
+	return k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, sdk.MinDec(swapFee, poolSwapFee))
+}
+
+// isFeeFreeSwapSender returns whether sender is the account of a module authorized by SetFeeFreeSwapModule.
+func (k Keeper) isFeeFreeSwapSender(ctx sdk.Context, sender sdk.AccAddress) bool {
+	moduleAccount, ok := k.accountKeeper.GetAccount(ctx, sender).(authtypes.ModuleAccountI)
+	return ok && k.IsFeeFreeSwapModule(ctx, moduleAccount.GetName())
+}
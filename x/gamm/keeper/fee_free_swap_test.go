@@ -0,0 +1,174 @@
+package keeper_test
+
+import (
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+	superfluidtypes "github.com/osmosis-labs/osmosis/v7/x/superfluid/types"
+	txfeestypes "github.com/osmosis-labs/osmosis/v7/x/txfees/types"
+)
+
+func (suite *KeeperTestSuite) TestSwapExactAmountInNoFee() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	swapFee := sdk.NewDecWithPrec(1, 2)
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()})
+	moduleAddr := suite.App.AccountKeeper.GetModuleAccount(suite.Ctx, superfluidtypes.ModuleName).GetAddress()
+	otherModuleAddr := suite.App.AccountKeeper.GetModuleAccount(suite.Ctx, txfeestypes.ModuleName).GetAddress()
+	tokenIn := sdk.NewInt64Coin("foo", 100000)
+	for _, moduleName := range []string{superfluidtypes.ModuleName, txfeestypes.ModuleName} {
+		err := simapp.FundModuleAccount(suite.App.BankKeeper, suite.Ctx, moduleName, sdk.NewCoins(tokenIn))
+		suite.Require().NoError(err)
+	}
+
+	// only the accounts of authorized modules can swap without a fee.
+	suite.Require().False(keeper.IsFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName))
+	suite.Require().NoError(keeper.SetFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName, true))
+	suite.Require().True(keeper.IsFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName))
+	// only modules with a module account can be authorized.
+	err := keeper.SetFeeFreeSwapModule(suite.Ctx, "notamodule", true)
+	suite.Require().ErrorIs(err, types.ErrNoModuleAccount)
+	suite.Require().False(keeper.IsFeeFreeSwapModule(suite.Ctx, "notamodule"))
+	for _, sender := range []sdk.AccAddress{otherModuleAddr, suite.TestAccs[0]} {
+		_, err := keeper.SwapExactAmountInNoFee(suite.Ctx, sender, poolId, tokenIn, "bar", sdk.OneInt())
+		suite.Require().ErrorIs(err, types.ErrUnauthorizedFeeFreeSwap)
+	}
+
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	// the fee is exactly 1000foo, so the fee-charged swap gets what a fee-free swap of 99000foo gets.
+	feeCharged := sdk.NewInt64Coin("foo", 1000)
+	suite.Require().Equal(feeCharged.Amount.ToDec(), tokenIn.Amount.ToDec().Mul(swapFee))
+	expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", sdk.ZeroDec())
+	suite.Require().NoError(err)
+	feeChargedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", swapFee)
+	suite.Require().NoError(err)
+	tokenOutOfSwapAfterFee, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn.Sub(feeCharged)}, "bar", sdk.ZeroDec())
+	suite.Require().NoError(err)
+	suite.Require().Equal(tokenOutOfSwapAfterFee, feeChargedTokenOut)
+	suite.Require().True(expectedTokenOut.Amount.GT(feeChargedTokenOut.Amount))
+
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	tokenOutAmount, err := keeper.SwapExactAmountInNoFee(suite.Ctx, moduleAddr, poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+	suite.Require().Equal(sdk.NewCoins(expectedTokenOut), suite.App.BankKeeper.GetAllBalances(suite.Ctx, moduleAddr))
+
+	// the swap updated the pool's reserves.
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(5000000).Add(tokenIn.Amount), pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo"))
+	suite.Require().Equal(sdk.NewInt(5000000).Sub(expectedTokenOut.Amount), pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("bar"))
+
+	// the swap event is flagged as fee-free, unlike the event of a swap with a fee.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	var swapEvents []sdk.Event
+	for _, event := range suite.Ctx.EventManager().Events() {
+		if event.Type == types.TypeEvtTokenSwapped {
+			swapEvents = append(swapEvents, event)
+		}
+	}
+	suite.Require().Len(swapEvents, 2)
+	feeFreeAttributes := []string{}
+	for _, event := range swapEvents {
+		attributes := map[string]string{}
+		for _, attribute := range event.Attributes {
+			attributes[string(attribute.Key)] = string(attribute.Value)
+		}
+		feeFreeAttributes = append(feeFreeAttributes, attributes[types.AttributeKeyFeeFree])
+	}
+	suite.Require().Equal([]string{"true", ""}, feeFreeAttributes)
+
+	// deauthorized modules can't swap without a fee anymore.
+	suite.Require().NoError(keeper.SetFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName, false))
+	suite.Require().False(keeper.IsFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName))
+	_, err = keeper.SwapExactAmountInNoFee(suite.Ctx, moduleAddr, poolId, sdk.NewInt64Coin("bar", 1000), "foo", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrUnauthorizedFeeFreeSwap)
+}
+
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithFee() {
+	swapFee := sdk.NewDecWithPrec(1, 2)
+	reducedSwapFee := sdk.NewDecWithPrec(2, 3)
+	tests := []struct {
+		name            string
+		moduleName      string
+		swapFee         sdk.Dec
+		expectedSwapFee sdk.Dec
+		expectedErr     error
+	}{
+		{
+			name:            "authorized module, reduced fee",
+			moduleName:      superfluidtypes.ModuleName,
+			swapFee:         reducedSwapFee,
+			expectedSwapFee: reducedSwapFee,
+		},
+		{
+			name:            "authorized module, no fee",
+			moduleName:      superfluidtypes.ModuleName,
+			swapFee:         sdk.ZeroDec(),
+			expectedSwapFee: sdk.ZeroDec(),
+		},
+		{
+			name:            "authorized module, fee above the pool's fee",
+			moduleName:      superfluidtypes.ModuleName,
+			swapFee:         sdk.NewDecWithPrec(5, 2),
+			expectedSwapFee: swapFee,
+		},
+		{
+			name:            "unauthorized module",
+			moduleName:      txfeestypes.ModuleName,
+			swapFee:         reducedSwapFee,
+			expectedSwapFee: swapFee,
+		},
+		{
+			name:        "negative fee",
+			moduleName:  superfluidtypes.ModuleName,
+			swapFee:     sdk.NewDecWithPrec(-1, 2),
+			expectedErr: types.ErrNegativeSwapFee,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()})
+			suite.Require().NoError(keeper.SetFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName, true))
+			tokenIn := sdk.NewInt64Coin("foo", 100000)
+			err := simapp.FundModuleAccount(suite.App.BankKeeper, suite.Ctx, test.moduleName, sdk.NewCoins(tokenIn))
+			suite.Require().NoError(err)
+			sender := suite.App.AccountKeeper.GetModuleAccount(suite.Ctx, test.moduleName).GetAddress()
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			tokenOutAmount, err := keeper.SwapExactAmountInWithFee(suite.Ctx, sender, poolId, tokenIn, "bar", sdk.OneInt(), test.swapFee)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", test.expectedSwapFee)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+		})
+	}
+
+	suite.Run("account that is not a module", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()})
+		tokenIn := sdk.NewInt64Coin("foo", 100000)
+
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", swapFee)
+		suite.Require().NoError(err)
+		tokenOutAmount, err := keeper.SwapExactAmountInWithFee(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt(), sdk.ZeroDec())
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+	})
+}
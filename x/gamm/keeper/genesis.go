@@ -26,12 +26,67 @@ func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState, unpack
 		}
 
 		poolAssets := pool.GetTotalPoolLiquidity(ctx)
+		k.updatePoolDenomIndex(ctx, pool.GetId(), sdk.Coins{}, poolAssets)
 		for _, asset := range poolAssets {
 			liquidity = liquidity.Add(asset)
 		}
 	}
 
 	k.SetTotalLiquidity(ctx, liquidity)
+
+	for _, record := range genState.TwapRecords {
+		k.setTwapRecord(ctx, record)
+	}
+	for _, recipient := range genState.ExitFeeRecipients {
+		recipientAddr, err := sdk.AccAddressFromBech32(recipient.Recipient)
+		if err != nil {
+			panic(err)
+		}
+		if err := k.SetExitFeeRecipient(ctx, recipient.PoolId, recipientAddr); err != nil {
+			panic(err)
+		}
+	}
+	for _, bucket := range genState.SwapVolumes {
+		if err := k.setSwapVolumeBucket(ctx, bucket); err != nil {
+			panic(err)
+		}
+	}
+	for _, record := range genState.PoolAccumulators {
+		k.setPoolAccumulators(ctx, record.PoolId, record.Accumulators)
+	}
+	for _, minReserve := range genState.MinPoolReserves {
+		if err := k.SetMinPoolReserve(ctx, minReserve.Denom, minReserve.MinReserve); err != nil {
+			panic(err)
+		}
+	}
+	for _, fees := range genState.DirectionalSwapFees {
+		if err := k.SetDirectionalSwapFees(ctx, fees.PoolId, fees.Fees); err != nil {
+			panic(err)
+		}
+	}
+	for _, moduleName := range genState.FeeFreeSwapModules {
+		if err := k.SetFeeFreeSwapModule(ctx, moduleName, true); err != nil {
+			panic(err)
+		}
+	}
+	for _, poolId := range genState.CircuitBreakerPoolIds {
+		if err := k.SetCircuitBreakerEnabled(ctx, poolId, true); err != nil {
+			panic(err)
+		}
+	}
+	for _, poolId := range genState.PausedPoolIds {
+		k.SetPoolPaused(ctx, poolId, true)
+	}
+	for _, factors := range genState.AssetScalingFactors {
+		if err := k.SetAssetScalingFactors(ctx, factors.PoolId, factors.Factors); err != nil {
+			panic(err)
+		}
+	}
+	for _, disabled := range genState.DisabledSwapDirections {
+		if err := k.SetDisabledSwapDirections(ctx, disabled.PoolId, disabled.Directions); err != nil {
+			panic(err)
+		}
+	}
 }
 
 // ExportGenesis returns the capability module's exported genesis.
@@ -49,8 +104,19 @@ func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
 		poolAnys = append(poolAnys, any)
 	}
 	return &types.GenesisState{
-		NextPoolNumber: k.GetNextPoolNumberAndIncrement(ctx),
-		Pools:          poolAnys,
-		Params:         k.GetParams(ctx),
+		NextPoolNumber:         k.GetNextPoolNumberAndIncrement(ctx),
+		Pools:                  poolAnys,
+		Params:                 k.GetParams(ctx),
+		TwapRecords:            k.GetAllTwapRecords(ctx),
+		ExitFeeRecipients:      k.getAllExitFeeRecipients(ctx),
+		SwapVolumes:            k.getAllSwapVolumeBuckets(ctx),
+		PoolAccumulators:       k.getAllPoolAccumulators(ctx),
+		MinPoolReserves:        k.getAllMinPoolReserves(ctx),
+		DirectionalSwapFees:    k.getAllDirectionalSwapFees(ctx),
+		FeeFreeSwapModules:     k.getAllFeeFreeSwapModules(ctx),
+		CircuitBreakerPoolIds:  k.getPoolIdsWithKeyPrefix(ctx, types.KeyPrefixCircuitBreakerPools),
+		PausedPoolIds:          k.getPoolIdsWithKeyPrefix(ctx, types.KeyPrefixPausedPools),
+		AssetScalingFactors:    k.getAllAssetScalingFactors(ctx),
+		DisabledSwapDirections: k.getAllDisabledSwapDirections(ctx),
 	}
 }
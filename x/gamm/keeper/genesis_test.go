@@ -0,0 +1,173 @@
+package keeper_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+	superfluidtypes "github.com/osmosis-labs/osmosis/v7/x/superfluid/types"
+)
+
+// exportAndImportGenesis exports the gamm genesis, and imports it into a new app at the same block height and time.
+func (suite *KeeperTestSuite) exportAndImportGenesis() *types.GenesisState {
+	genesis := suite.App.GAMMKeeper.ExportGenesis(suite.Ctx)
+	suite.Require().NoError(genesis.Validate())
+
+	ctx := suite.Ctx
+	suite.SetupTest()
+	suite.Ctx = suite.Ctx.WithBlockHeight(ctx.BlockHeight()).WithBlockTime(ctx.BlockTime())
+	suite.App.GAMMKeeper.InitGenesis(suite.Ctx, *genesis, suite.App.AppCodec())
+	return genesis
+}
+
+func (suite *KeeperTestSuite) TestExitFeeRecipientsGenesis() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	recipient := suite.TestAccs[2]
+	suite.Require().NoError(suite.App.GAMMKeeper.SetExitFeeRecipient(suite.Ctx, poolId, recipient))
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]types.PoolExitFeeRecipient{{PoolId: poolId, Recipient: recipient.String()}}, genesis.ExitFeeRecipients)
+	importedRecipient, found := suite.App.GAMMKeeper.GetExitFeeRecipient(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(recipient, importedRecipient)
+	_, found = suite.App.GAMMKeeper.GetExitFeeRecipient(suite.Ctx, otherPoolId)
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestSwapVolumesGenesis() {
+	suite.SetupTest()
+	poolId := suite.prepareTieredSwapFeePool()
+	trader := suite.TestAccs[0]
+	t0 := suite.Ctx.BlockTime()
+	for _, t := range []time.Time{t0, t0.Add(12 * time.Hour)} {
+		suite.Ctx = suite.Ctx.WithBlockTime(t)
+		_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("foo", 60000), "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+	}
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Len(genesis.SwapVolumes, 2)
+	suite.Require().Equal(sdk.NewInt(120000), suite.App.GAMMKeeper.GetSwapVolume(suite.Ctx, poolId, trader))
+	swapFee, err := suite.App.GAMMKeeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(defaultSwapFeeTiers.Tiers[0].SwapFee, swapFee)
+}
+
+func (suite *KeeperTestSuite) TestPoolAccumulatorsGenesis() {
+	suite.SetupTest()
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+	poolParams := balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.ZeroDec(),
+	}
+	poolId := suite.PrepareBalancerPoolWithPoolParams(poolParams)
+	otherPoolId := suite.PrepareBalancerPoolWithPoolParams(poolParams)
+	for i := 0; i < 3; i++ {
+		_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 12345), "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+	}
+	accumulators := suite.App.GAMMKeeper.GetPoolAccumulators(suite.Ctx, poolId)
+	suite.Require().False(accumulators.RoundingDust.IsZero())
+	suite.Require().False(accumulators.FeesPerShare.IsZero())
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]types.PoolAccumulatorsRecord{{PoolId: poolId, Accumulators: accumulators}}, genesis.PoolAccumulators)
+	suite.Require().Equal(accumulators, suite.App.GAMMKeeper.GetPoolAccumulators(suite.Ctx, poolId))
+	suite.Require().Equal(types.PoolAccumulators{}, suite.App.GAMMKeeper.GetPoolAccumulators(suite.Ctx, otherPoolId))
+}
+
+func (suite *KeeperTestSuite) TestMinPoolReservesGenesis() {
+	suite.SetupTest()
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+	suite.Require().NoError(suite.App.GAMMKeeper.SetMinPoolReserve(suite.Ctx, "foo", sdk.NewInt(5000)))
+	suite.Require().NoError(suite.App.GAMMKeeper.SetMinPoolReserve(suite.Ctx, "bar", sdk.ZeroInt()))
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Len(genesis.MinPoolReserves, 2)
+	suite.Require().Equal(sdk.NewInt(5000).String(), suite.App.GAMMKeeper.GetMinPoolReserve(suite.Ctx, "foo").String())
+	suite.Require().True(suite.App.GAMMKeeper.GetMinPoolReserve(suite.Ctx, "bar").IsZero())
+	suite.Require().Equal(types.DefaultMinPoolReserve, suite.App.GAMMKeeper.GetMinPoolReserve(suite.Ctx, "baz"))
+}
+
+func (suite *KeeperTestSuite) TestDirectionalSwapFeesGenesis() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(suite.App.GAMMKeeper.SetDirectionalSwapFees(suite.Ctx, poolId, defaultDirectionalSwapFees))
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]types.PoolDirectionalSwapFees{{PoolId: poolId, Fees: defaultDirectionalSwapFees}}, genesis.DirectionalSwapFees)
+	fees, found := suite.App.GAMMKeeper.GetDirectionalSwapFees(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(defaultDirectionalSwapFees, fees)
+	_, found = suite.App.GAMMKeeper.GetDirectionalSwapFees(suite.Ctx, otherPoolId)
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestFeeFreeSwapModulesGenesis() {
+	suite.SetupTest()
+	suite.Require().NoError(suite.App.GAMMKeeper.SetFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName, true))
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]string{superfluidtypes.ModuleName}, genesis.FeeFreeSwapModules)
+	suite.Require().True(suite.App.GAMMKeeper.IsFeeFreeSwapModule(suite.Ctx, superfluidtypes.ModuleName))
+	suite.Require().False(suite.App.GAMMKeeper.IsFeeFreeSwapModule(suite.Ctx, types.ModuleName))
+}
+
+func (suite *KeeperTestSuite) TestCircuitBreakerPoolsGenesis() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	pausedPoolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(suite.App.GAMMKeeper.SetCircuitBreakerEnabled(suite.Ctx, poolId, true))
+	suite.Require().NoError(suite.App.GAMMKeeper.SetCircuitBreakerEnabled(suite.Ctx, pausedPoolId, true))
+	suite.App.GAMMKeeper.SetPoolPaused(suite.Ctx, pausedPoolId, true)
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]uint64{poolId, pausedPoolId}, genesis.CircuitBreakerPoolIds)
+	suite.Require().Equal([]uint64{pausedPoolId}, genesis.PausedPoolIds)
+	suite.Require().True(suite.App.GAMMKeeper.IsCircuitBreakerEnabled(suite.Ctx, poolId))
+	suite.Require().False(suite.App.GAMMKeeper.IsPoolPaused(suite.Ctx, poolId))
+	suite.Require().True(suite.App.GAMMKeeper.IsPoolPaused(suite.Ctx, pausedPoolId))
+	suite.Require().False(suite.App.GAMMKeeper.IsCircuitBreakerEnabled(suite.Ctx, otherPoolId))
+	_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], pausedPoolId, sdk.NewInt64Coin("foo", 1000), "bar", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+}
+
+func (suite *KeeperTestSuite) TestAssetScalingFactorsGenesis() {
+	suite.SetupTest()
+	poolId := suite.prepareMixedDecimalsPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(suite.App.GAMMKeeper.SetAssetScalingFactors(suite.Ctx, poolId, mixedDecimalsScalingFactors))
+	spotPrice, err := suite.App.GAMMKeeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]types.PoolAssetScalingFactors{{PoolId: poolId, Factors: mixedDecimalsScalingFactors}}, genesis.AssetScalingFactors)
+	importedSpotPrice, err := suite.App.GAMMKeeper.CalculateNormalizedSpotPrice(suite.Ctx, poolId, "uusdc", "wei")
+	suite.Require().NoError(err)
+	suite.Require().Equal(spotPrice, importedSpotPrice)
+	_, found := suite.App.GAMMKeeper.GetAssetScalingFactors(suite.Ctx, otherPoolId)
+	suite.Require().False(found)
+}
+
+func (suite *KeeperTestSuite) TestDisabledSwapDirectionsGenesis() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(suite.App.GAMMKeeper.SetDisabledSwapDirections(suite.Ctx, poolId, buyBarOnly))
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Equal([]types.PoolDisabledSwapDirections{{PoolId: poolId, Directions: buyBarOnly}}, genesis.DisabledSwapDirections)
+	_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 1000), "foo", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrSwapDirectionDisabled)
+	disabled, found := suite.App.GAMMKeeper.GetDisabledSwapDirections(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(buyBarOnly, disabled)
+	_, found = suite.App.GAMMKeeper.GetDisabledSwapDirections(suite.Ctx, otherPoolId)
+	suite.Require().False(found)
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (k Keeper) HandleUpdatePoolSwapFeeProposal(ctx sdk.Context, p *types.UpdatePoolSwapFeeProposal) error {
+	return k.SetPoolSwapFee(ctx, p.PoolId, p.SwapFee)
+}
+
+func (k Keeper) HandleSetExitFeeRecipientProposal(ctx sdk.Context, p *types.SetExitFeeRecipientProposal) error {
+	var recipient sdk.AccAddress
+	if p.Recipient != "" {
+		var err error
+		recipient, err = sdk.AccAddressFromBech32(p.Recipient)
+		if err != nil {
+			return err
+		}
+	}
+	return k.SetExitFeeRecipient(ctx, p.PoolId, recipient)
+}
+
+func (k Keeper) HandleSetSwapFeeTiersProposal(ctx sdk.Context, p *types.SetSwapFeeTiersProposal) error {
+	return k.SetSwapFeeTiers(ctx, p.PoolId, p.SwapFeeTiers)
+}
+
+func (k Keeper) HandleSetMinPoolReserveProposal(ctx sdk.Context, p *types.SetMinPoolReserveProposal) error {
+	return k.SetMinPoolReserve(ctx, p.Denom, p.MinReserve)
+}
+
+func (k Keeper) HandleSetDirectionalSwapFeesProposal(ctx sdk.Context, p *types.SetDirectionalSwapFeesProposal) error {
+	return k.SetDirectionalSwapFees(ctx, p.PoolId, p.DirectionalSwapFees)
+}
+
+func (k Keeper) HandleSetCircuitBreakerEnabledProposal(ctx sdk.Context, p *types.SetCircuitBreakerEnabledProposal) error {
+	return k.SetCircuitBreakerEnabled(ctx, p.PoolId, p.Enabled)
+}
+
+func (k Keeper) HandleSetPoolPausedProposal(ctx sdk.Context, p *types.SetPoolPausedProposal) error {
+	if _, err := k.GetPoolAndPoke(ctx, p.PoolId); err != nil {
+		return err
+	}
+	k.SetPoolPaused(ctx, p.PoolId, p.Paused)
+	return nil
+}
+
+func (k Keeper) HandleMigrateBalancerPoolToStableswapProposal(ctx sdk.Context, p *types.MigrateBalancerPoolToStableswapProposal) error {
+	return k.MigrateBalancerPoolToStableswap(ctx, p.PoolId, p.AmplificationParameter, p.ScalingFactors, p.ScalingFactorGovernor)
+}
@@ -0,0 +1,214 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/stableswap"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// executeProposal submits content as a governance proposal, and executes it as gov does once it passed.
+func (suite *KeeperTestSuite) executeProposal(content govtypes.Content) error {
+	proposal, err := suite.App.GovKeeper.SubmitProposal(suite.Ctx, content)
+	if err != nil {
+		return err
+	}
+	handler := suite.App.GovKeeper.Router().GetRoute(proposal.ProposalRoute())
+	return handler(suite.Ctx, proposal.GetContent())
+}
+
+func (suite *KeeperTestSuite) TestUpdatePoolSwapFeeProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: sdk.NewDecWithPrec(1, 2), ExitFee: sdk.ZeroDec()})
+	newSwapFee := sdk.NewDecWithPrec(3, 3)
+
+	err := suite.executeProposal(types.NewUpdatePoolSwapFeeProposal("title", "description", poolId, newSwapFee))
+	suite.Require().NoError(err)
+
+	// later swaps are charged the new fee.
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(newSwapFee, pool.GetSwapFee(suite.Ctx))
+	tokenIn := sdk.NewInt64Coin("foo", 100000)
+	expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", newSwapFee)
+	suite.Require().NoError(err)
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+
+	// proposals with invalid fees or for unknown pools can't be submitted.
+	proposal := types.NewUpdatePoolSwapFeeProposal("title", "description", poolId, sdk.OneDec())
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrTooMuchSwapFee)
+	err = suite.executeProposal(proposal)
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+	err = suite.executeProposal(types.NewUpdatePoolSwapFeeProposal("title", "description", poolId+1, newSwapFee))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestSetExitFeeRecipientProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	recipient := suite.TestAccs[1]
+
+	err := suite.executeProposal(types.NewSetExitFeeRecipientProposal("title", "description", poolId, recipient.String()))
+	suite.Require().NoError(err)
+	actualRecipient, found := keeper.GetExitFeeRecipient(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(recipient, actualRecipient)
+
+	// an empty recipient leaves the exit fees in the pool again.
+	err = suite.executeProposal(types.NewSetExitFeeRecipientProposal("title", "description", poolId, ""))
+	suite.Require().NoError(err)
+	_, found = keeper.GetExitFeeRecipient(suite.Ctx, poolId)
+	suite.Require().False(found)
+
+	proposal := types.NewSetExitFeeRecipientProposal("title", "description", poolId, "invalid")
+	suite.Require().Error(proposal.ValidateBasic())
+	err = suite.executeProposal(types.NewSetExitFeeRecipientProposal("title", "description", poolId+1, recipient.String()))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestSetSwapFeeTiersProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: sdk.NewDecWithPrec(1, 2), ExitFee: sdk.ZeroDec()})
+
+	err := suite.executeProposal(types.NewSetSwapFeeTiersProposal("title", "description", poolId, defaultSwapFeeTiers))
+	suite.Require().NoError(err)
+	tiers, found := keeper.GetSwapFeeTiers(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(defaultSwapFeeTiers, tiers)
+
+	// tiers without any tier remove the pool's tiers.
+	err = suite.executeProposal(types.NewSetSwapFeeTiersProposal("title", "description", poolId, types.SwapFeeTiers{VolumeDenom: "foo"}))
+	suite.Require().NoError(err)
+	_, found = keeper.GetSwapFeeTiers(suite.Ctx, poolId)
+	suite.Require().False(found)
+
+	// increasing swap fees are rejected on submission, swap fees above the pool's once the proposal is executed.
+	proposal := types.NewSetSwapFeeTiersProposal("title", "description", poolId, types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+		{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(1, 3)},
+		{MinVolume: sdk.NewInt(300000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+	}})
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrInvalidSwapFeeTiers)
+	err = suite.executeProposal(types.NewSetSwapFeeTiersProposal("title", "description", poolId, types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+		{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(2, 2)},
+	}}))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestSetMinPoolReserveProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	err := suite.executeProposal(types.NewSetMinPoolReserveProposal("title", "description", "foo", sdk.NewInt(5000)))
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(5000), keeper.GetMinPoolReserve(suite.Ctx, "foo"))
+
+	proposal := types.NewSetMinPoolReserveProposal("title", "description", "foo", sdk.NewInt(-1))
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrNegativeMinPoolReserve)
+	proposal = types.NewSetMinPoolReserveProposal("title", "description", "!", sdk.NewInt(1))
+	suite.Require().Error(proposal.ValidateBasic())
+}
+
+func (suite *KeeperTestSuite) TestSetDirectionalSwapFeesProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	fees := types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+		{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sdk.NewDecWithPrec(3, 2)},
+	}}
+
+	err := suite.executeProposal(types.NewSetDirectionalSwapFeesProposal("title", "description", poolId, fees))
+	suite.Require().NoError(err)
+	actualFees, found := keeper.GetDirectionalSwapFees(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(fees, actualFees)
+	swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, suite.TestAccs[0], "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewDecWithPrec(3, 2), swapFee)
+
+	// fees without any fee remove the pool's directional swap fees.
+	err = suite.executeProposal(types.NewSetDirectionalSwapFeesProposal("title", "description", poolId, types.DirectionalSwapFees{}))
+	suite.Require().NoError(err)
+	_, found = keeper.GetDirectionalSwapFees(suite.Ctx, poolId)
+	suite.Require().False(found)
+
+	// fees between the same denom are rejected on submission, fees of denoms not in the pool once the proposal is executed.
+	proposal := types.NewSetDirectionalSwapFeesProposal("title", "description", poolId, types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+		{TokenInDenom: "foo", TokenOutDenom: "foo", SwapFee: sdk.NewDecWithPrec(3, 2)},
+	}})
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrSameDenom)
+	err = suite.executeProposal(types.NewSetDirectionalSwapFeesProposal("title", "description", poolId, types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+		{TokenInDenom: "foo", TokenOutDenom: "uatom", SwapFee: sdk.NewDecWithPrec(3, 2)},
+	}}))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestSetCircuitBreakerEnabledProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+
+	err := suite.executeProposal(types.NewSetCircuitBreakerEnabledProposal("title", "description", poolId, true))
+	suite.Require().NoError(err)
+	suite.Require().True(keeper.IsCircuitBreakerEnabled(suite.Ctx, poolId))
+
+	err = suite.executeProposal(types.NewSetCircuitBreakerEnabledProposal("title", "description", poolId, false))
+	suite.Require().NoError(err)
+	suite.Require().False(keeper.IsCircuitBreakerEnabled(suite.Ctx, poolId))
+
+	err = suite.executeProposal(types.NewSetCircuitBreakerEnabledProposal("title", "description", poolId+1, true))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestSetPoolPausedProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	tokenIn := sdk.NewInt64Coin("foo", 100000)
+
+	err := suite.executeProposal(types.NewSetPoolPausedProposal("title", "description", poolId, true))
+	suite.Require().NoError(err)
+	suite.Require().True(keeper.IsPoolPaused(suite.Ctx, poolId))
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolLocked)
+
+	// resuming the pool lets swaps through it again.
+	err = suite.executeProposal(types.NewSetPoolPausedProposal("title", "description", poolId, false))
+	suite.Require().NoError(err)
+	suite.Require().False(keeper.IsPoolPaused(suite.Ctx, poolId))
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	err = suite.executeProposal(types.NewSetPoolPausedProposal("title", "description", poolId+1, true))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
+
+func (suite *KeeperTestSuite) TestMigrateBalancerPoolToStableswapProposal() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+
+	err := suite.executeProposal(types.NewMigrateBalancerPoolToStableswapProposal("title", "description", poolId, 100, []uint64{1, 1}, ""))
+	suite.Require().NoError(err)
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	stableswapPool, ok := pool.(*stableswap.Pool)
+	suite.Require().True(ok)
+	suite.Require().Equal(uint64(100), stableswapPool.PoolParams.AmplificationParameter)
+	suite.Require().Equal([]uint64{1, 1}, stableswapPool.GetScalingFactors())
+
+	// proposals without an amplification parameter or with a zero scaling factor can't be submitted,
+	// ones for pools that aren't balancer pools fail once executed.
+	proposal := types.NewMigrateBalancerPoolToStableswapProposal("title", "description", poolId, 0, []uint64{1, 1}, "")
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrInvalidAmplificationParameter)
+	proposal = types.NewMigrateBalancerPoolToStableswapProposal("title", "description", poolId, 100, []uint64{0, 1}, "")
+	suite.Require().ErrorIs(proposal.ValidateBasic(), types.ErrInvalidStableswapScalingFactors)
+	err = suite.executeProposal(types.NewMigrateBalancerPoolToStableswapProposal("title", "description", poolId, 100, []uint64{1, 1}, ""))
+	suite.Require().ErrorIs(err, govtypes.ErrInvalidProposalContent)
+}
@@ -160,13 +160,13 @@ func (q Querier) TotalPoolLiquidity(ctx context.Context, req *types.QueryTotalPo
 
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 
-	pool, err := q.Keeper.GetPoolAndPoke(sdkCtx, req.PoolId)
+	liquidity, err := q.Keeper.GetPoolLiquidity(sdkCtx, req.PoolId)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &types.QueryTotalPoolLiquidityResponse{
-		Liquidity: pool.GetTotalPoolLiquidity(sdkCtx),
+		Liquidity: liquidity,
 	}, nil
 }
 
@@ -219,6 +219,27 @@ func (q Querier) SpotPrice(ctx context.Context, req *types.QuerySpotPriceRequest
 	}, nil
 }
 
+func (q Querier) SwapableDenoms(ctx context.Context, req *types.QuerySwapableDenomsRequest) (*types.QuerySwapableDenomsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.TokenInDenom == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid token in denom")
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	denoms, err := q.Keeper.GetSwapableDenoms(sdkCtx, req.PoolId, req.TokenInDenom)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QuerySwapableDenomsResponse{
+		Denoms: denoms,
+	}, nil
+}
+
 func (q Querier) TotalLiquidity(ctx context.Context, _ *types.QueryTotalLiquidityRequest) (*types.QueryTotalLiquidityResponse, error) {
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 
@@ -283,3 +283,23 @@ func (suite *KeeperTestSuite) TestQueryBalancerPoolSpotPrice() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestQuerySwapableDenoms() {
+	queryClient := suite.queryClient
+	poolID := suite.PrepareBalancerPool()
+
+	res, err := queryClient.SwapableDenoms(gocontext.Background(), &types.QuerySwapableDenomsRequest{
+		PoolId:       poolID,
+		TokenInDenom: "bar",
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{"baz", "foo"}, res.Denoms)
+
+	_, err = queryClient.SwapableDenoms(gocontext.Background(), &types.QuerySwapableDenomsRequest{PoolId: poolID})
+	suite.Require().Error(err)
+	_, err = queryClient.SwapableDenoms(gocontext.Background(), &types.QuerySwapableDenomsRequest{
+		PoolId:       poolID,
+		TokenInDenom: "uatom",
+	})
+	suite.Require().Error(err)
+}
@@ -4,24 +4,38 @@ package keeper
 
 import (
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
-const poolBalanceInvariantName = "pool-account-balance-equals-expected"
+const (
+	poolBalanceInvariantName    = "pool-account-balance-equals-expected"
+	totalLiquidityInvariantName = "total-liquidity-equals-pool-liquidity"
+	poolReserveInvariantName    = "pool-reserves-equal-account-balances"
+)
 
 // RegisterInvariants registers all governance invariants.
 func RegisterInvariants(ir sdk.InvariantRegistry, keeper Keeper, bk types.BankKeeper) {
 	ir.RegisterRoute(types.ModuleName, poolBalanceInvariantName, PoolAccountInvariant(keeper, bk))
+	ir.RegisterRoute(types.ModuleName, totalLiquidityInvariantName, TotalLiquidityInvariant(keeper))
+	ir.RegisterRoute(types.ModuleName, poolReserveInvariantName, PoolReserveInvariant(keeper, bk))
 }
 
 // AllInvariants runs all invariants of the gamm module
 func AllInvariants(keeper Keeper, bk types.BankKeeper) sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
 		msg, broke := PoolAccountInvariant(keeper, bk)(ctx)
-		return msg, broke
+		if broke {
+			return msg, broke
+		}
+		msg, broke = TotalLiquidityInvariant(keeper)(ctx)
+		if broke {
+			return msg, broke
+		}
+		return PoolReserveInvariant(keeper, bk)(ctx)
 	}
 }
 
@@ -49,3 +63,61 @@ func PoolAccountInvariant(keeper Keeper, bk types.BankKeeper) sdk.Invariant {
 			"\tgamm all pool asset coins and account coins match\n"), false
 	}
 }
+
+// TotalLiquidityInvariant checks that the recorded total liquidity equals
+// the sum of the liquidity of all pools
+func TotalLiquidityInvariant(keeper Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		pools, err := keeper.GetPoolsAndPoke(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, totalLiquidityInvariantName,
+				"\tgamm pool retrieval failed"), true
+		}
+
+		poolsLiquidity := sdk.Coins{}
+		for _, pool := range pools {
+			poolsLiquidity = poolsLiquidity.Add(pool.GetTotalPoolLiquidity(ctx)...)
+		}
+
+		totalLiquidity := keeper.GetTotalLiquidity(ctx)
+		if !totalLiquidity.IsAllGTE(poolsLiquidity) || !poolsLiquidity.IsAllGTE(totalLiquidity) {
+			return sdk.FormatInvariant(types.ModuleName, totalLiquidityInvariantName,
+				fmt.Sprintf("\tgamm recorded total liquidity: %s\n\t sum of pool liquidity: %s\n",
+					totalLiquidity, poolsLiquidity)), true
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, totalLiquidityInvariantName,
+			"\tgamm recorded total liquidity and sum of pool liquidity match\n"), false
+	}
+}
+
+// PoolReserveInvariant checks that every pool's recorded reserve of each of its assets
+// equals the balance of that asset in the pool account.
+// Swaps update the recorded reserves and move the coins separately, so this catches
+// the two drifting apart. Balances of denoms that aren't pool assets are not checked.
+func PoolReserveInvariant(keeper Keeper, bk types.BankKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		pools, err := keeper.GetPoolsAndPoke(ctx)
+		if err != nil {
+			return sdk.FormatInvariant(types.ModuleName, poolReserveInvariantName,
+				"\tgamm pool retrieval failed"), true
+		}
+
+		var msg strings.Builder
+		for _, pool := range pools {
+			for _, reserve := range pool.GetTotalPoolLiquidity(ctx) {
+				balance := bk.GetBalance(ctx, pool.GetAddress(), reserve.Denom)
+				if !balance.Amount.Equal(reserve.Amount) {
+					msg.WriteString(fmt.Sprintf("\tgamm pool id %d denom %s\n\t recorded reserve: %s\n\t account balance: %s\n\t delta: %s\n",
+						pool.GetId(), reserve.Denom, reserve.Amount, balance.Amount, balance.Amount.Sub(reserve.Amount)))
+				}
+			}
+		}
+		if msg.Len() > 0 {
+			return sdk.FormatInvariant(types.ModuleName, poolReserveInvariantName, msg.String()), true
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, poolReserveInvariantName,
+			"\tgamm all pool reserves and account balances match\n"), false
+	}
+}
@@ -0,0 +1,72 @@
+package keeper_test
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+)
+
+func (suite *KeeperTestSuite) TestPoolReserveInvariant() {
+	tests := []struct {
+		name          string
+		desync        func(poolId uint64)
+		expectedDelta string
+	}{
+		{
+			name: "recorded reserve above account balance",
+			desync: func(poolId uint64) {
+				pool, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				balancerPool := pool.(*balancer.Pool)
+				suite.Require().NoError(balancerPool.UpdatePoolAssetBalance(sdk.NewInt64Coin("foo", 5000100)))
+				suite.Require().NoError(suite.App.GAMMKeeper.SetPool(suite.Ctx, balancerPool))
+			},
+			expectedDelta: "denom foo\n\t recorded reserve: 5000100\n\t account balance: 5000000\n\t delta: -100\n",
+		},
+		{
+			name: "account balance above recorded reserve",
+			desync: func(poolId uint64) {
+				pool, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				err = simapp.FundAccount(suite.App.BankKeeper, suite.Ctx, pool.GetAddress(), sdk.NewCoins(sdk.NewInt64Coin("bar", 42)))
+				suite.Require().NoError(err)
+			},
+			expectedDelta: "denom bar\n\t recorded reserve: 5000000\n\t account balance: 5000042\n\t delta: 42\n",
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			otherPoolId := suite.PrepareBalancerPool()
+			invariant := keeper.PoolReserveInvariant(*suite.App.GAMMKeeper, suite.App.BankKeeper)
+
+			_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], otherPoolId, sdk.NewInt64Coin("foo", 10000), "bar", sdk.OneInt())
+			suite.Require().NoError(err)
+			msg, broken := invariant(suite.Ctx)
+			suite.Require().False(broken, msg)
+
+			// denoms that aren't pool assets don't break the invariant.
+			pool, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			err = simapp.FundAccount(suite.App.BankKeeper, suite.Ctx, pool.GetAddress(), sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1)))
+			suite.Require().NoError(err)
+			msg, broken = invariant(suite.Ctx)
+			suite.Require().False(broken, msg)
+
+			test.desync(poolId)
+			msg, broken = invariant(suite.Ctx)
+			suite.Require().True(broken)
+			suite.Require().Contains(msg, fmt.Sprintf("gamm pool id %d %s", poolId, test.expectedDelta))
+			suite.Require().NotContains(msg, fmt.Sprintf("gamm pool id %d ", otherPoolId))
+
+			msg, broken = keeper.AllInvariants(*suite.App.GAMMKeeper, suite.App.BankKeeper)(suite.Ctx)
+			suite.Require().True(broken, msg)
+		})
+	}
+}
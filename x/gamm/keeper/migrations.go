@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper Keeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(keeper Keeper) Migrator {
+	return Migrator{keeper: keeper}
+}
+
+// Migrate1to2 migrates from version 1 to 2, indexing the pools created before the index of
+// the pools by denom, see GetPoolsWithDenom.
+func (m Migrator) Migrate1to2(ctx sdk.Context) error {
+	m.keeper.IteratePools(ctx, func(pool types.PoolI) bool {
+		m.keeper.updatePoolDenomIndex(ctx, pool.GetId(), sdk.Coins{}, pool.GetTotalPoolLiquidity(ctx))
+		return false
+	})
+	return nil
+}
+
+// Migrate2to3 migrates from version 2 to 3, setting the params added to Params in version 3,
+// MaxPoolAssets, CircuitBreakerThreshold and ProtocolFeeShare, to their defaults unless already set.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	defaults := types.DefaultParams()
+	if !m.keeper.paramSpace.Has(ctx, types.KeyMaxPoolAssets) {
+		m.keeper.paramSpace.Set(ctx, types.KeyMaxPoolAssets, defaults.MaxPoolAssets)
+	}
+	if !m.keeper.paramSpace.Has(ctx, types.KeyCircuitBreakerThreshold) {
+		m.keeper.paramSpace.Set(ctx, types.KeyCircuitBreakerThreshold, defaults.CircuitBreakerThreshold)
+	}
+	if !m.keeper.paramSpace.Has(ctx, types.KeyProtocolFeeShare) {
+		m.keeper.paramSpace.Set(ctx, types.KeyProtocolFeeShare, defaults.ProtocolFeeShare)
+	}
+	return nil
+}
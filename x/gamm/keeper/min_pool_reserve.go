@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetMinPoolReserve sets the minimum reserve of denom in every pool, below which swaps can't take the reserve.
+// Without a minimum set, the minimum is types.DefaultMinPoolReserve from the v11 upgrade on, and zero before it.
+// A zero minimum allows draining the reserve.
+// It is called by governance through a SetMinPoolReserveProposal.
+func (k Keeper) SetMinPoolReserve(ctx sdk.Context, denom string, minReserve sdk.Int) error {
+	if err := types.ValidateMinPoolReserve(denom, minReserve); err != nil {
+		return err
+	}
+
+	bz, err := minReserve.Marshal()
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetKeyMinPoolReserve(denom), bz)
+	return nil
+}
+
+// GetMinPoolReserve returns the minimum reserve of denom in every pool.
+func (k Keeper) GetMinPoolReserve(ctx sdk.Context, denom string) sdk.Int {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetKeyMinPoolReserve(denom))
+	if bz == nil {
+		// swaps in blocks before the v11 upgrade could drain the reserves of existing pools.
+		if ctx.BlockHeight() < types.V11UpgradeHeight {
+			return sdk.ZeroInt()
+		}
+		return types.DefaultMinPoolReserve
+	}
+
+	var minReserve sdk.Int
+	if err := minReserve.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return minReserve
+}
+
+// getAllMinPoolReserves returns the minimum reserves of all denoms with one set.
+func (k Keeper) getAllMinPoolReserves(ctx sdk.Context) []types.MinPoolReserve {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixMinPoolReserves)
+	defer iter.Close()
+
+	minReserves := []types.MinPoolReserve{}
+	for ; iter.Valid(); iter.Next() {
+		var minReserve sdk.Int
+		if err := minReserve.Unmarshal(iter.Value()); err != nil {
+			panic(err)
+		}
+		minReserves = append(minReserves, types.MinPoolReserve{
+			Denom:      string(iter.Key()[len(types.KeyPrefixMinPoolReserves):]),
+			MinReserve: minReserve,
+		})
+	}
+	return minReserves
+}
+
+// checkMinPoolReserve returns ErrPoolReserveBelowMinimum if the pool's reserve of denom is below its minimum.
+func (k Keeper) checkMinPoolReserve(ctx sdk.Context, pool types.PoolI, denom string) error {
+	reserve := pool.GetTotalPoolLiquidity(ctx).AmountOf(denom)
+	minReserve := k.GetMinPoolReserve(ctx, denom)
+	if reserve.LT(minReserve) {
+		return sdkerrors.Wrapf(types.ErrPoolReserveBelowMinimum,
+			"reserve of %s in pool %d would be %s, which is below the minimum of %s", denom, pool.GetId(), reserve, minReserve)
+	}
+	return nil
+}
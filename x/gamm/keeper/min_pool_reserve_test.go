@@ -0,0 +1,84 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestSetMinPoolReserve() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	// existing pools had no minimum reserve before the v11 upgrade.
+	suite.Require().True(keeper.GetMinPoolReserve(suite.Ctx.WithBlockHeight(types.V11UpgradeHeight-1), "foo").IsZero())
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+	suite.Require().Equal(types.DefaultMinPoolReserve, keeper.GetMinPoolReserve(suite.Ctx, "foo"))
+
+	err := keeper.SetMinPoolReserve(suite.Ctx, "foo", sdk.NewInt(5000))
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(5000).String(), keeper.GetMinPoolReserve(suite.Ctx, "foo").String())
+	suite.Require().Equal(types.DefaultMinPoolReserve, keeper.GetMinPoolReserve(suite.Ctx, "bar"))
+
+	err = keeper.SetMinPoolReserve(suite.Ctx, "foo", sdk.ZeroInt())
+	suite.Require().NoError(err)
+	suite.Require().True(keeper.GetMinPoolReserve(suite.Ctx, "foo").IsZero())
+
+	err = keeper.SetMinPoolReserve(suite.Ctx, "foo", sdk.NewInt(-1))
+	suite.Require().ErrorIs(err, types.ErrNegativeMinPoolReserve)
+	err = keeper.SetMinPoolReserve(suite.Ctx, "!", sdk.NewInt(1))
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestSwapRejectedBelowMinPoolReserve() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+	otherPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("bar", 1000000), sdk.NewInt64Coin("baz", 1000000))
+	trader := suite.TestAccs[1]
+	suite.FundAcc(trader, sdk.NewCoins(
+		sdk.NewCoin("foo", sdk.NewInt(2000000000000)),
+		sdk.NewCoin("bar", sdk.NewInt(1000000000000)),
+	))
+
+	// swapping in nearly the whole pool would leave a bar reserve of ~1, below the default minimum,
+	// which only applies from the v11 upgrade on.
+	tokenIn := sdk.NewInt64Coin("foo", 1000000000000)
+	preUpgradeCtx, _ := suite.Ctx.WithBlockHeight(types.V11UpgradeHeight - 1).CacheContext()
+	_, err := keeper.SwapExactAmountIn(preUpgradeCtx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolReserveBelowMinimum)
+	_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, []types.SwapAmountInRoute{
+		{PoolId: otherPoolId, TokenOutDenom: "baz"},
+	}, sdk.NewInt64Coin("bar", 1000000000000), sdk.OneInt())
+	suite.Require().ErrorIs(err, types.ErrPoolReserveBelowMinimum)
+	_, _, _, _, err = keeper.SimulateSwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar")
+	suite.Require().ErrorIs(err, types.ErrPoolReserveBelowMinimum)
+
+	// the rejected swaps didn't change the pool.
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("bar", 1000000), sdk.NewInt64Coin("foo", 1000000)), pool.GetTotalPoolLiquidity(suite.Ctx))
+
+	// a swap can take the reserve down to exactly the minimum, but not below it.
+	err = keeper.SetMinPoolReserve(suite.Ctx, "bar", sdk.NewInt(900000))
+	suite.Require().NoError(err)
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("bar", 100001))
+	suite.Require().ErrorIs(err, types.ErrPoolReserveBelowMinimum)
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("bar", 100000))
+	suite.Require().NoError(err)
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(900000).String(), pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("bar").String())
+
+	// without a minimum, the swap in of nearly the whole pool goes through.
+	err = keeper.SetMinPoolReserve(suite.Ctx, "bar", sdk.ZeroInt())
+	suite.Require().NoError(err)
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().True(pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("bar").LT(types.DefaultMinPoolReserve))
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
@@ -9,6 +10,8 @@ import (
 // MultihopSwapExactAmountIn defines the input denom and input amount for the first pool,
 // the output of the first pool is chained as the input for the next routed pool
 // transaction succeeds when final amount out is greater than tokenOutMinAmount defined.
+// The swaps are atomic, if any hop fails none of the hops are applied.
+// Every pool on the route is written once after all hops, see updatePoolsForMultihopSwap.
 func (k Keeper) MultihopSwapExactAmountIn(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -16,25 +19,53 @@ func (k Keeper) MultihopSwapExactAmountIn(
 	tokenIn sdk.Coin,
 	tokenOutMinAmount sdk.Int,
 ) (tokenOutAmount sdk.Int, err error) {
+	// every hop is applied on a cache context, that only gets written once all hops succeeded.
+	cacheCtx, write := ctx.CacheContext()
+	routePools := make(map[uint64]types.PoolI, len(routes))
+	hops := make([]swapHop, 0, len(routes))
 	for i, route := range routes {
 		_outMinAmount := sdk.NewInt(1)
 		if len(routes)-1 == i {
 			_outMinAmount = tokenOutMinAmount
 		}
 
-		tokenOutAmount, err = k.SwapExactAmountIn(ctx, sender, route.PoolId, tokenIn, route.TokenOutDenom, _outMinAmount)
+		pool, err := k.getRoutePoolForSwap(cacheCtx, routePools, route.PoolId)
 		if err != nil {
 			return sdk.Int{}, err
 		}
-		tokenIn = sdk.NewCoin(route.TokenOutDenom, tokenOutAmount)
+
+		swapFee := k.swapFeeForSender(cacheCtx, pool, sender, tokenIn.Denom, route.TokenOutDenom)
+		tokenOut, spotPriceBefore, tokenOutRemainder, err := applySwapExactAmountIn(cacheCtx, pool, tokenIn, route.TokenOutDenom, _outMinAmount, swapFee)
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		hop, err := k.newSwapHop(cacheCtx, pool, tokenIn, tokenOut, spotPriceBefore, swapFee)
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		hop.tokenOutRemainder = tokenOutRemainder
+		hops = append(hops, hop)
+
+		tokenIn = tokenOut
+		tokenOutAmount = tokenOut.Amount
 	}
-	return
+
+	if err := k.updatePoolsForMultihopSwap(cacheCtx, sender, hops); err != nil {
+		return sdk.Int{}, err
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return tokenOutAmount, nil
 }
 
 // MultihopSwapExactAmountOut defines the output denom and output amount for the last pool.
 // Calculation starts by providing the tokenOutAmount of the final pool to calculate the required tokenInAmount
 // the calculated tokenInAmount is used as defined tokenOutAmount of the previous pool, calculating in reverse order of the swap
 // Transaction succeeds if the calculated tokenInAmount of the first pool is less than the defined tokenInMaxAmount defined.
+// Every hop swaps for exactly the amount the next hop needs, so the final hop delivers exactly tokenOut.
+// The swaps are atomic, if any hop fails none of the hops are applied.
+// Every pool on the route is written once after all hops, see updatePoolsForMultihopSwap.
 func (k Keeper) MultihopSwapExactAmountOut(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -42,34 +73,148 @@ func (k Keeper) MultihopSwapExactAmountOut(
 	tokenInMaxAmount sdk.Int,
 	tokenOut sdk.Coin,
 ) (tokenInAmount sdk.Int, err error) {
-	insExpected, err := k.createMultihopExpectedSwapOuts(ctx, routes, tokenOut)
+	insExpected, err := k.createMultihopExpectedSwapOuts(ctx, sender, routes, tokenOut)
 	if err != nil {
 		return sdk.Int{}, err
 	}
 
 	insExpected[0] = tokenInMaxAmount
 
+	// every hop is applied on a cache context, that only gets written once all hops succeeded.
+	cacheCtx, write := ctx.CacheContext()
+	routePools := make(map[uint64]types.PoolI, len(routes))
+	hops := make([]swapHop, 0, len(routes))
 	for i, route := range routes {
 		_tokenOut := tokenOut
 		if i != len(routes)-1 {
 			_tokenOut = sdk.NewCoin(routes[i+1].TokenInDenom, insExpected[i+1])
 		}
 
-		_tokenInAmount, err := k.SwapExactAmountOut(ctx, sender, route.PoolId, route.TokenInDenom, insExpected[i], _tokenOut)
+		pool, err := k.getRoutePoolForSwap(cacheCtx, routePools, route.PoolId)
 		if err != nil {
 			return sdk.Int{}, err
 		}
 
+		swapFee := k.swapFeeForSender(cacheCtx, pool, sender, route.TokenInDenom, _tokenOut.Denom)
+		_tokenIn, spotPriceBefore, err := applySwapExactAmountOut(cacheCtx, pool, route.TokenInDenom, insExpected[i], _tokenOut, swapFee)
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		hop, err := k.newSwapHop(cacheCtx, pool, _tokenIn, _tokenOut, spotPriceBefore, swapFee)
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		hops = append(hops, hop)
+
 		if i == 0 {
-			tokenInAmount = _tokenInAmount
+			tokenInAmount = _tokenIn.Amount
 		}
 	}
 
+	if err := k.updatePoolsForMultihopSwap(cacheCtx, sender, hops); err != nil {
+		return sdk.Int{}, err
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
 	return tokenInAmount, nil
 }
 
-// TODO: Document this function.
-func (k Keeper) createMultihopExpectedSwapOuts(ctx sdk.Context, routes []types.SwapAmountOutRoute, tokenOut sdk.Coin) ([]sdk.Int, error) {
+// getRoutePoolForSwap returns the pool with poolId for a hop of a multihop swap.
+// A route can go through the same pool more than once, so each pool is only loaded once
+// into routePools, and later hops through it swap against the pool as left by earlier hops.
+func (k Keeper) getRoutePoolForSwap(ctx sdk.Context, routePools map[uint64]types.PoolI, poolId uint64) (types.PoolI, error) {
+	if pool, ok := routePools[poolId]; ok {
+		return pool, nil
+	}
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+	routePools[poolId] = pool
+	return pool, nil
+}
+
+// updatePoolsForMultihopSwap applies the hops of a multihop swap to state, after all hops have been
+// applied to the pool structs. Every pool is written once, however many hops go through it.
+// The intermediate tokens go directly from each hop's pool to the next hop's pool, rather than through
+// the sender, so the sender only sends the first hop's tokens in, and receives the last hop's tokens out.
+// Every hop's pool must receive all of its tokens in, see sendTokenInToPool.
+// If a hop swaps out more than the next hop swaps in, the remainder is sent to the sender.
+// The total liquidity only changes by the route's tokens in and out, as the intermediate tokens stay in pools.
+// The AfterSwap hook is called for every hop once all hops are written, and if it fails the caller must
+// not write ctx.
+func (k Keeper) updatePoolsForMultihopSwap(ctx sdk.Context, sender sdk.AccAddress, hops []swapHop) error {
+	writtenPools := make(map[uint64]bool, len(hops))
+	for _, hop := range hops {
+		if writtenPools[hop.pool.GetId()] {
+			continue
+		}
+		if err := k.SetPool(ctx, hop.pool); err != nil {
+			return err
+		}
+		writtenPools[hop.pool.GetId()] = true
+	}
+
+	firstHop, lastHop := hops[0], hops[len(hops)-1]
+	if err := k.sendTokenInToPool(ctx, sender, firstHop.pool, firstHop.tokenIn); err != nil {
+		return err
+	}
+	for i := 0; i < len(hops)-1; i++ {
+		hop, nextHop := hops[i], hops[i+1]
+		if hop.tokenOut.Denom != nextHop.tokenIn.Denom || hop.tokenOut.Amount.LT(nextHop.tokenIn.Amount) {
+			return sdkerrors.Wrapf(types.ErrInvalidMathApprox, "hop %d swaps in %s, more than the %s swapped out by the previous hop", i+1, nextHop.tokenIn, hop.tokenOut)
+		}
+		remainder := hop.tokenOut.Sub(nextHop.tokenIn)
+		if hop.pool.GetId() != nextHop.pool.GetId() {
+			if err := k.sendTokenInToPool(ctx, hop.pool.GetAddress(), nextHop.pool, nextHop.tokenIn); err != nil {
+				return err
+			}
+		}
+		if remainder.IsPositive() {
+			if err := k.bankKeeper.SendCoins(ctx, hop.pool.GetAddress(), sender, sdk.Coins{remainder}); err != nil {
+				return err
+			}
+			k.RecordTotalLiquidityDecrease(ctx, sdk.Coins{remainder})
+		}
+	}
+	if err := k.bankKeeper.SendCoins(ctx, lastHop.pool.GetAddress(), sender, sdk.Coins{lastHop.tokenOut}); err != nil {
+		return err
+	}
+	for _, hop := range hops {
+		if err := k.sendProtocolFee(ctx, hop); err != nil {
+			return err
+		}
+	}
+
+	for _, hop := range hops {
+		k.recordSwapVolume(ctx, sender, hop)
+		k.recordPoolAccumulators(ctx, hop)
+		k.checkCircuitBreaker(ctx, hop)
+	}
+	k.RecordTotalLiquidityIncrease(ctx, sdk.Coins{firstHop.tokenIn})
+	k.RecordTotalLiquidityDecrease(ctx, sdk.Coins{lastHop.tokenOut})
+
+	for _, hop := range hops {
+		if err := k.emitSwapHop(ctx, sender, hop); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createMultihopExpectedSwapOuts returns the amount of tokens that have to go into every hop of routes,
+// for the final hop to return tokenOut. insExpected[i] is the amount of routes[i].TokenInDenom
+// needed by routes[i], which is then also the amount routes[i-1] has to swap out.
+// The amounts are derived in reverse, starting from the final hop.
+//
+// Each hop's CalcInAmtGivenOut rounds the required input up, after dividing by (1 - swapFee).
+// So rounding accumulates against the sender rather than the pools:
+// every hop is asked for exactly the (rounded up) input of the next hop,
+// and the final hop delivers no less than tokenOut.
+// The amounts are computed against the pool state before any hop executes,
+// so a route that goes through the same pool twice fails on the max amount in rather than under-delivering.
+func (k Keeper) createMultihopExpectedSwapOuts(ctx sdk.Context, sender sdk.AccAddress, routes []types.SwapAmountOutRoute, tokenOut sdk.Coin) ([]sdk.Int, error) {
 	insExpected := make([]sdk.Int, len(routes))
 	for i := len(routes) - 1; i >= 0; i-- {
 		route := routes[i]
@@ -79,7 +224,7 @@ func (k Keeper) createMultihopExpectedSwapOuts(ctx sdk.Context, routes []types.S
 			return nil, err
 		}
 
-		tokenIn, err := pool.CalcInAmtGivenOut(ctx, sdk.NewCoins(tokenOut), route.TokenInDenom, pool.GetSwapFee(ctx))
+		tokenIn, err := pool.CalcInAmtGivenOut(ctx, sdk.NewCoins(tokenOut), route.TokenInDenom, k.swapFeeForSender(ctx, pool, sender, route.TokenInDenom, tokenOut.Denom))
 		if err != nil {
 			return nil, err
 		}
@@ -90,3 +235,49 @@ func (k Keeper) createMultihopExpectedSwapOuts(ctx sdk.Context, routes []types.S
 
 	return insExpected, nil
 }
+
+// MultiSwapExactAmountIn splits tokenIn across the pools in splits, swapping each split's fraction
+// of tokenIn for tokenOutDenom through its pool, to reduce the price impact of a large trade.
+// The fractions are rounded down, and the last split swaps whatever remains of tokenIn, so that
+// exactly tokenIn is swapped.
+// The transaction succeeds when the total amount out over all splits is at least tokenOutMinAmount.
+// The swaps are atomic, if any swap fails or the total falls short, none of the swaps are applied.
+func (k Keeper) MultiSwapExactAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	splits []types.SwapAmountInSplit,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+) (tokenOutAmount sdk.Int, err error) {
+	if err := types.ValidateSwapAmountInSplits(splits); err != nil {
+		return sdk.Int{}, err
+	}
+
+	// every split is applied on a cache context, that only gets written once all splits succeeded.
+	cacheCtx, write := ctx.CacheContext()
+	tokenOutAmount = sdk.ZeroInt()
+	remainingAmountIn := tokenIn.Amount
+	for i, split := range splits {
+		splitAmountIn := remainingAmountIn
+		if i != len(splits)-1 {
+			splitAmountIn = split.Fraction.MulInt(tokenIn.Amount).TruncateInt()
+		}
+		remainingAmountIn = remainingAmountIn.Sub(splitAmountIn)
+
+		splitTokenOutAmount, err := k.SwapExactAmountIn(cacheCtx, sender, split.PoolId, sdk.NewCoin(tokenIn.Denom, splitAmountIn), tokenOutDenom, sdk.ZeroInt())
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		tokenOutAmount = tokenOutAmount.Add(splitTokenOutAmount)
+	}
+
+	if tokenOutAmount.LT(tokenOutMinAmount) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMinAmount,
+			"split swap returned %s%s, less than the min amount of %s", tokenOutAmount, tokenOutDenom, tokenOutMinAmount)
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return tokenOutAmount, nil
+}
@@ -0,0 +1,110 @@
+package keeper_test
+
+import (
+	"testing"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/osmosis-labs/osmosis/v7/app"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	gammtypes "github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+
+	"github.com/cosmos/cosmos-sdk/simapp"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// writeCountingGasMeter is an infinite gas meter, that also counts the store writes it is charged for.
+type writeCountingGasMeter struct {
+	sdk.GasMeter
+	writes uint64
+}
+
+func (m *writeCountingGasMeter) ConsumeGas(amount sdk.Gas, descriptor string) {
+	if descriptor == storetypes.GasWriteCostFlatDesc {
+		m.writes++
+	}
+	m.GasMeter.ConsumeGas(amount, descriptor)
+}
+
+// setupThreeHopRoute creates the pools foo/bar, bar/baz and baz/uosmo,
+// and returns a funded sender with the route from foo to uosmo through them.
+func setupThreeHopRoute(b *testing.B, osmosis *app.OsmosisApp, ctx sdk.Context) (sdk.AccAddress, []gammtypes.SwapAmountInRoute) {
+	sender := sdk.AccAddress([]byte("multihop_bench_addr_"))
+	err := simapp.FundAccount(osmosis.BankKeeper, ctx, sender, sdk.NewCoins(
+		sdk.NewCoin("uosmo", sdk.NewInt(10000000000000)),
+		sdk.NewCoin("foo", sdk.NewInt(10000000000000)),
+		sdk.NewCoin("bar", sdk.NewInt(10000000000000)),
+		sdk.NewCoin("baz", sdk.NewInt(10000000000000)),
+	))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	poolParams := balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 3),
+		ExitFee: sdk.ZeroDec(),
+	}
+	denomPairs := [][2]string{{"foo", "bar"}, {"bar", "baz"}, {"baz", "uosmo"}}
+	routes := make([]gammtypes.SwapAmountInRoute, 0, len(denomPairs))
+	for _, pair := range denomPairs {
+		poolAssets := []balancer.PoolAsset{
+			{Token: sdk.NewCoin(pair[0], sdk.NewInt(1000000000000)), Weight: sdk.NewInt(100)},
+			{Token: sdk.NewCoin(pair[1], sdk.NewInt(1000000000000)), Weight: sdk.NewInt(100)},
+		}
+		poolId, err := osmosis.GAMMKeeper.CreatePool(ctx, balancer.NewMsgCreateBalancerPool(sender, poolParams, poolAssets, ""))
+		if err != nil {
+			b.Fatal(err)
+		}
+		routes = append(routes, gammtypes.SwapAmountInRoute{PoolId: poolId, TokenOutDenom: pair[1]})
+	}
+	return sender, routes
+}
+
+// benchmarkThreeHopSwap runs swap for a 3-hop route on a fresh cache of the same state every iteration,
+// and reports the gas and the number of store writes of each swap.
+func benchmarkThreeHopSwap(b *testing.B, swap func(ctx sdk.Context, osmosis *app.OsmosisApp, sender sdk.AccAddress, routes []gammtypes.SwapAmountInRoute) error) {
+	osmosis := app.Setup(false)
+	ctx := osmosis.BaseApp.NewContext(false, tmproto.Header{Height: 1, ChainID: "osmosis-1"})
+	sender, routes := setupThreeHopRoute(b, osmosis, ctx)
+
+	var gas, writes uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gasMeter := &writeCountingGasMeter{GasMeter: sdk.NewInfiniteGasMeter()}
+		cacheCtx, _ := ctx.CacheContext()
+		cacheCtx = cacheCtx.WithGasMeter(gasMeter)
+		if err := swap(cacheCtx, osmosis, sender, routes); err != nil {
+			b.Fatal(err)
+		}
+		gas += gasMeter.GasConsumed()
+		writes += gasMeter.writes
+	}
+	b.ReportMetric(float64(gas)/float64(b.N), "gas/op")
+	b.ReportMetric(float64(writes)/float64(b.N), "writes/op")
+}
+
+// BenchmarkThreeHopSequentialSwaps swaps along the route with one SwapExactAmountIn per hop,
+// which writes every pool, and moves the intermediate tokens through the sender.
+func BenchmarkThreeHopSequentialSwaps(b *testing.B) {
+	benchmarkThreeHopSwap(b, func(ctx sdk.Context, osmosis *app.OsmosisApp, sender sdk.AccAddress, routes []gammtypes.SwapAmountInRoute) error {
+		tokenIn := sdk.NewCoin("foo", sdk.NewInt(1000000))
+		for _, route := range routes {
+			tokenOutAmount, err := osmosis.GAMMKeeper.SwapExactAmountIn(ctx, sender, route.PoolId, tokenIn, route.TokenOutDenom, sdk.OneInt())
+			if err != nil {
+				return err
+			}
+			tokenIn = sdk.NewCoin(route.TokenOutDenom, tokenOutAmount)
+		}
+		return nil
+	})
+}
+
+// BenchmarkThreeHopMultihopSwap swaps along the route with MultihopSwapExactAmountIn,
+// which writes every pool once, and moves the intermediate tokens directly between the pools.
+func BenchmarkThreeHopMultihopSwap(b *testing.B) {
+	benchmarkThreeHopSwap(b, func(ctx sdk.Context, osmosis *app.OsmosisApp, sender sdk.AccAddress, routes []gammtypes.SwapAmountInRoute) error {
+		_, err := osmosis.GAMMKeeper.MultihopSwapExactAmountIn(ctx, sender, routes, sdk.NewCoin("foo", sdk.NewInt(1000000)), sdk.OneInt())
+		return err
+	})
+}
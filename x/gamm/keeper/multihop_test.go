@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
@@ -196,3 +197,287 @@ func (suite *KeeperTestSuite) TestBalancerPoolSimpleMultihopSwapExactAmountOut()
 		}
 	}
 }
+
+func (suite *KeeperTestSuite) TestMultihopSwapExactAmountInIsAtomic() {
+	suite.SetupTest()
+
+	// Prepare 2 pools
+	suite.PrepareBalancerPool()
+	suite.PrepareBalancerPool()
+
+	keeper := suite.App.GAMMKeeper
+	routes := []types.SwapAmountInRoute{
+		{
+			PoolId:        1,
+			TokenOutDenom: "bar",
+		},
+		{
+			PoolId:        2,
+			TokenOutDenom: "baz",
+		},
+	}
+
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
+	firstPoolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+	suite.Require().NoError(err)
+
+	// the first hop succeeds, while the second fails on the min amount out.
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], routes, sdk.NewCoin("foo", sdk.NewInt(100000)), sdk.NewInt(100000000))
+	suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+
+	firstPoolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(firstPoolBefore.GetTotalPoolLiquidity(suite.Ctx), firstPoolAfter.GetTotalPoolLiquidity(suite.Ctx))
+	suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0]))
+	suite.Require().Empty(suite.Ctx.EventManager().Events(), "no events of the failed hops should be emitted")
+}
+
+// TestMultihopSwapExactAmountInNetsTransfers tests that a route of hops, that goes through the same pool twice,
+// gives the same amount out as swapping hop by hop, and leaves every pool's bank balance equal to its liquidity.
+func (suite *KeeperTestSuite) TestMultihopSwapExactAmountInNetsTransfers() {
+	suite.SetupTest()
+
+	// Prepare 2 pools
+	suite.PrepareBalancerPool()
+	suite.PrepareBalancerPool()
+
+	keeper := suite.App.GAMMKeeper
+	sender := suite.TestAccs[0]
+	routes := []types.SwapAmountInRoute{
+		{
+			PoolId:        1,
+			TokenOutDenom: "bar",
+		},
+		{
+			PoolId:        2,
+			TokenOutDenom: "baz",
+		},
+		{
+			PoolId:        1,
+			TokenOutDenom: "foo",
+		},
+	}
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(100000))
+
+	// swap hop by hop on a cache context, to get the expected amount out.
+	cacheCtx, _ := suite.Ctx.CacheContext()
+	hopTokenIn := tokenIn
+	for _, route := range routes {
+		hopTokenOutAmount, err := keeper.SwapExactAmountIn(cacheCtx, sender, route.PoolId, hopTokenIn, route.TokenOutDenom, sdk.OneInt())
+		suite.Require().NoError(err)
+		hopTokenIn = sdk.NewCoin(route.TokenOutDenom, hopTokenOutAmount)
+	}
+
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+	tokenOutAmount, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, routes, tokenIn, sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(hopTokenIn.Amount, tokenOutAmount)
+
+	balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+	suite.Require().Equal(balancesBefore.AmountOf("foo").Sub(tokenIn.Amount).Add(tokenOutAmount), balancesAfter.AmountOf("foo"))
+	suite.Require().Equal(balancesBefore.AmountOf("bar"), balancesAfter.AmountOf("bar"))
+	suite.Require().Equal(balancesBefore.AmountOf("baz"), balancesAfter.AmountOf("baz"))
+
+	for _, poolId := range []uint64{1, 2} {
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx), suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()))
+	}
+}
+
+// TestMultihopSwapExactAmountOutDeliversTokenOut tests that, even with swap fees
+// and rounding at every hop, the sender receives at least the requested tokenOut,
+// and that no intermediary tokens are left with the sender.
+func (suite *KeeperTestSuite) TestMultihopSwapExactAmountOutDeliversTokenOut() {
+	routes := []types.SwapAmountOutRoute{
+		{
+			PoolId:       1,
+			TokenInDenom: "foo",
+		},
+		{
+			PoolId:       2,
+			TokenInDenom: "bar",
+		},
+	}
+
+	tests := []struct {
+		name             string
+		tokenOut         sdk.Coin
+		tokenInMaxAmount sdk.Int
+		expectPass       bool
+	}{
+		{
+			name:             "odd amount out",
+			tokenOut:         sdk.NewCoin("baz", sdk.NewInt(99_999)),
+			tokenInMaxAmount: sdk.NewInt(90000000),
+			expectPass:       true,
+		},
+		{
+			name:             "one token out",
+			tokenOut:         sdk.NewCoin("baz", sdk.NewInt(1)),
+			tokenInMaxAmount: sdk.NewInt(90000000),
+			expectPass:       true,
+		},
+		{
+			name:             "max amount in too low",
+			tokenOut:         sdk.NewCoin("baz", sdk.NewInt(99_999)),
+			tokenInMaxAmount: sdk.NewInt(1),
+			expectPass:       false,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+
+			// Prepare 2 pools with a swap fee, so that rounding happens on every hop.
+			poolParams := balancer.PoolParams{
+				SwapFee: sdk.NewDecWithPrec(3, 3),
+				ExitFee: sdk.ZeroDec(),
+			}
+			suite.PrepareBalancerPoolWithPoolParams(poolParams)
+			suite.PrepareBalancerPoolWithPoolParams(poolParams)
+
+			sender := suite.TestAccs[0]
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			tokenInAmount, err := suite.App.GAMMKeeper.MultihopSwapExactAmountOut(suite.Ctx, sender, routes, test.tokenInMaxAmount, test.tokenOut)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			if !test.expectPass {
+				suite.Require().Error(err)
+				suite.Require().Equal(balancesBefore, balancesAfter)
+				return
+			}
+			suite.Require().NoError(err)
+
+			suite.Require().True(balancesAfter.AmountOf("baz").Sub(balancesBefore.AmountOf("baz")).GTE(test.tokenOut.Amount))
+			suite.Require().Equal(balancesBefore.AmountOf("bar"), balancesAfter.AmountOf("bar"))
+			suite.Require().Equal(balancesBefore.AmountOf("foo").Sub(tokenInAmount), balancesAfter.AmountOf("foo"))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestMultiSwapExactAmountIn() {
+	poolParams := balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(3, 3),
+		ExitFee: sdk.ZeroDec(),
+	}
+	prepareThinAndDeepPools := func() (thinPoolId, deepPoolId uint64) {
+		thinPoolId = suite.prepareCustomBalancerPool(defaultAcctFunds, []balancer.PoolAsset{
+			{Weight: sdk.NewInt(100), Token: sdk.NewCoin("foo", sdk.NewInt(100000))},
+			{Weight: sdk.NewInt(100), Token: sdk.NewCoin("bar", sdk.NewInt(100000))},
+		}, poolParams)
+		deepPoolId = suite.prepareCustomBalancerPool(defaultAcctFunds, []balancer.PoolAsset{
+			{Weight: sdk.NewInt(100), Token: sdk.NewCoin("foo", sdk.NewInt(900000))},
+			{Weight: sdk.NewInt(100), Token: sdk.NewCoin("bar", sdk.NewInt(900000))},
+		}, poolParams)
+		return thinPoolId, deepPoolId
+	}
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(50000))
+
+	suite.Run("split gives more than the thinnest pool", func() {
+		suite.SetupTest()
+		thinPoolId, deepPoolId := prepareThinAndDeepPools()
+		sender := suite.TestAccs[0]
+		keeper := suite.App.GAMMKeeper
+
+		thinOnlyCtx, _ := suite.Ctx.CacheContext()
+		thinOnlyAmount, err := keeper.SwapExactAmountIn(thinOnlyCtx, sender, thinPoolId, tokenIn, "bar", sdk.ZeroInt())
+		suite.Require().NoError(err)
+
+		balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+		splits := []types.SwapAmountInSplit{
+			{PoolId: thinPoolId, Fraction: sdk.NewDecWithPrec(1, 1)},
+			{PoolId: deepPoolId, Fraction: sdk.NewDecWithPrec(9, 1)},
+		}
+		tokenOutAmount, err := keeper.MultiSwapExactAmountIn(suite.Ctx, sender, splits, tokenIn, "bar", sdk.ZeroInt())
+		suite.Require().NoError(err)
+		suite.Require().True(tokenOutAmount.GT(thinOnlyAmount), "split gave %s, thin pool only gave %s", tokenOutAmount, thinOnlyAmount)
+
+		balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+		suite.Require().Equal(balancesBefore.AmountOf("foo").Sub(tokenIn.Amount), balancesAfter.AmountOf("foo"))
+		suite.Require().Equal(balancesBefore.AmountOf("bar").Add(tokenOutAmount), balancesAfter.AmountOf("bar"))
+
+		// each pool got its fraction of tokenIn
+		thinPool, err := keeper.GetPoolAndPoke(suite.Ctx, thinPoolId)
+		suite.Require().NoError(err)
+		suite.Require().Equal(sdk.NewInt(105000), thinPool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo"))
+		deepPool, err := keeper.GetPoolAndPoke(suite.Ctx, deepPoolId)
+		suite.Require().NoError(err)
+		suite.Require().Equal(sdk.NewInt(945000), deepPool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo"))
+	})
+
+	suite.Run("last split swaps the rounding remainder", func() {
+		suite.SetupTest()
+		thinPoolId, deepPoolId := prepareThinAndDeepPools()
+		keeper := suite.App.GAMMKeeper
+
+		oneThird := sdk.OneDec().QuoInt64(3)
+		splits := []types.SwapAmountInSplit{
+			{PoolId: thinPoolId, Fraction: oneThird},
+			{PoolId: deepPoolId, Fraction: sdk.OneDec().Sub(oneThird)},
+		}
+		_, err := keeper.MultiSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], splits, tokenIn, "bar", sdk.ZeroInt())
+		suite.Require().NoError(err)
+
+		thinPool, err := keeper.GetPoolAndPoke(suite.Ctx, thinPoolId)
+		suite.Require().NoError(err)
+		deepPool, err := keeper.GetPoolAndPoke(suite.Ctx, deepPoolId)
+		suite.Require().NoError(err)
+		thinAmountIn := thinPool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo").Sub(sdk.NewInt(100000))
+		deepAmountIn := deepPool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo").Sub(sdk.NewInt(900000))
+		suite.Require().Equal(sdk.NewInt(16666), thinAmountIn)
+		suite.Require().Equal(tokenIn.Amount, thinAmountIn.Add(deepAmountIn))
+	})
+
+	suite.Run("total below min amount reverts all splits", func() {
+		suite.SetupTest()
+		thinPoolId, deepPoolId := prepareThinAndDeepPools()
+		sender := suite.TestAccs[0]
+		keeper := suite.App.GAMMKeeper
+
+		balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+		splits := []types.SwapAmountInSplit{
+			{PoolId: thinPoolId, Fraction: sdk.NewDecWithPrec(5, 1)},
+			{PoolId: deepPoolId, Fraction: sdk.NewDecWithPrec(5, 1)},
+		}
+		_, err := keeper.MultiSwapExactAmountIn(suite.Ctx, sender, splits, tokenIn, "bar", tokenIn.Amount)
+		suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+		suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+
+		thinPool, err := keeper.GetPoolAndPoke(suite.Ctx, thinPoolId)
+		suite.Require().NoError(err)
+		suite.Require().Equal(sdk.NewInt(100000), thinPool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("foo"))
+	})
+
+	invalidSplits := map[string][]types.SwapAmountInSplit{
+		"no splits": {},
+		"fractions sum to less than one": {
+			{PoolId: 1, Fraction: sdk.NewDecWithPrec(5, 1)},
+			{PoolId: 2, Fraction: sdk.NewDecWithPrec(4, 1)},
+		},
+		"fractions sum to more than one": {
+			{PoolId: 1, Fraction: sdk.NewDecWithPrec(5, 1)},
+			{PoolId: 2, Fraction: sdk.NewDecWithPrec(6, 1)},
+		},
+		"zero fraction": {
+			{PoolId: 1, Fraction: sdk.OneDec()},
+			{PoolId: 2, Fraction: sdk.ZeroDec()},
+		},
+		"duplicate pool": {
+			{PoolId: 1, Fraction: sdk.NewDecWithPrec(5, 1)},
+			{PoolId: 1, Fraction: sdk.NewDecWithPrec(5, 1)},
+		},
+	}
+	for name, splits := range invalidSplits {
+		splits := splits
+		suite.Run(name, func() {
+			suite.SetupTest()
+			prepareThinAndDeepPools()
+
+			_, err := suite.App.GAMMKeeper.MultiSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], splits, tokenIn, "bar", sdk.ZeroInt())
+			suite.Require().ErrorIs(err, types.ErrInvalidSwapSplits)
+		})
+	}
+}
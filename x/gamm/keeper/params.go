@@ -16,3 +16,57 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramSpace.SetParamSet(ctx, &params)
 }
+
+// GetPoolCreationFee returns the PoolCreationFee param, the fee of creating a pool sent to the community pool.
+func (k Keeper) GetPoolCreationFee(ctx sdk.Context) (poolCreationFee sdk.Coins) {
+	k.paramSpace.Get(ctx, types.KeyPoolCreationFee, &poolCreationFee)
+	return poolCreationFee
+}
+
+// GetMaxPoolAssets returns the MaxPoolAssets param, the maximum number of assets of a new pool.
+func (k Keeper) GetMaxPoolAssets(ctx sdk.Context) (maxPoolAssets uint64) {
+	k.paramSpace.Get(ctx, types.KeyMaxPoolAssets, &maxPoolAssets)
+	return maxPoolAssets
+}
+
+// SetMaxPoolAssets sets the MaxPoolAssets param. The limit only applies to the creation of pools,
+// existing pools with more assets are not affected.
+func (k Keeper) SetMaxPoolAssets(ctx sdk.Context, maxPoolAssets uint64) error {
+	if err := types.ValidateMaxPoolAssets(maxPoolAssets); err != nil {
+		return err
+	}
+	k.paramSpace.Set(ctx, types.KeyMaxPoolAssets, maxPoolAssets)
+	return nil
+}
+
+// GetCircuitBreakerThreshold returns the CircuitBreakerThreshold param, the relative spot price move
+// of a single swap that pauses a pool with its circuit breaker enabled.
+func (k Keeper) GetCircuitBreakerThreshold(ctx sdk.Context) (threshold sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyCircuitBreakerThreshold, &threshold)
+	return threshold
+}
+
+// SetCircuitBreakerThreshold sets the CircuitBreakerThreshold param.
+func (k Keeper) SetCircuitBreakerThreshold(ctx sdk.Context, threshold sdk.Dec) error {
+	if err := types.ValidateCircuitBreakerThreshold(threshold); err != nil {
+		return err
+	}
+	k.paramSpace.Set(ctx, types.KeyCircuitBreakerThreshold, threshold)
+	return nil
+}
+
+// GetProtocolFeeShare returns the ProtocolFeeShare param, the fraction of every swap fee sent to
+// the protocol fee module account.
+func (k Keeper) GetProtocolFeeShare(ctx sdk.Context) (share sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeyProtocolFeeShare, &share)
+	return share
+}
+
+// SetProtocolFeeShare sets the ProtocolFeeShare param.
+func (k Keeper) SetProtocolFeeShare(ctx sdk.Context, share sdk.Dec) error {
+	if err := types.ValidateProtocolFeeShare(share); err != nil {
+		return err
+	}
+	k.paramSpace.Set(ctx, types.KeyProtocolFeeShare, share)
+	return nil
+}
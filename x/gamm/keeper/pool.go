@@ -53,9 +53,68 @@ func (k Keeper) getPoolForSwap(ctx sdk.Context, poolId uint64) (types.PoolI, err
 	if !pool.IsActive(ctx) {
 		return &balancer.Pool{}, sdkerrors.Wrapf(types.ErrPoolLocked, "swap on inactive pool")
 	}
+	if k.IsPoolPaused(ctx, poolId) {
+		return &balancer.Pool{}, sdkerrors.Wrapf(types.ErrPoolLocked, "swap on pool %d paused by its circuit breaker", poolId)
+	}
 	return pool, nil
 }
 
+// GetPoolAssetsAndWeights returns the assets of the balancer pool with poolId,
+// along with their weights and normalized weights.
+func (k Keeper) GetPoolAssetsAndWeights(ctx sdk.Context, poolId uint64) ([]balancer.NormalizedPoolAsset, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return nil, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool with id %d does not have weighted assets", poolId)
+	}
+
+	return balancerPool.GetAllNormalizedPoolAssets(), nil
+}
+
+// GetPoolInvariantK returns the constant function value k of the balancer pool with poolId,
+// prod(balance_i ^ normalizedWeight_i), for monitoring that its swaps don't decrease it.
+func (k Keeper) GetPoolInvariantK(ctx sdk.Context, poolId uint64) (sdk.Dec, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool with id %d is not a weighted constant function pool", poolId)
+	}
+
+	return balancerPool.InvariantK()
+}
+
+// GetSwapableDenoms returns the denoms that tokenInDenom can be swapped for in the pool with poolId,
+// which are all of the pool's other denoms, in sorted order.
+// Returns ErrDenomNotFoundInPool if tokenInDenom is not in the pool.
+func (k Keeper) GetSwapableDenoms(ctx sdk.Context, poolId uint64, tokenInDenom string) ([]string, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+
+	liquidity := pool.GetTotalPoolLiquidity(ctx)
+	if !liquidity.AmountOf(tokenInDenom).IsPositive() {
+		return nil, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "denom %s does not exist in pool, the denoms of pool %d are: %s",
+			tokenInDenom, poolId, liquidity)
+	}
+
+	denoms := make([]string, 0, len(liquidity)-1)
+	for _, coin := range liquidity {
+		if coin.Denom != tokenInDenom {
+			denoms = append(denoms, coin.Denom)
+		}
+	}
+	return denoms, nil
+}
+
 func (k Keeper) iterator(ctx sdk.Context, prefix []byte) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
 	return sdk.KVStorePrefixIterator(store, prefix)
@@ -80,6 +139,27 @@ func (k Keeper) GetPoolsAndPoke(ctx sdk.Context) (res []types.PoolI, err error)
 	return res, nil
 }
 
+// IteratePools calls cb on every pool in ascending pool id order, poking each pool first as GetPoolsAndPoke does,
+// until cb returns true. Unlike GetPoolsAndPoke, it holds only one pool in memory at a time,
+// e.g. for analytics or genesis export over many pools.
+func (k Keeper) IteratePools(ctx sdk.Context, cb func(types.PoolI) (stop bool)) {
+	// pool keys end in the big endian pool id, so the store iterates them in ascending id order.
+	iter := k.iterator(ctx, types.KeyPrefixPools)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		pool, err := k.UnmarshalPool(iter.Value())
+		if err != nil {
+			panic(err)
+		}
+
+		pool.PokePool(ctx.BlockTime())
+		if cb(pool) {
+			break
+		}
+	}
+}
+
 func (k Keeper) SetPool(ctx sdk.Context, pool types.PoolI) error {
 	bz, err := k.MarshalPool(pool)
 	if err != nil {
@@ -90,17 +170,26 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.PoolI) error {
 	poolKey := types.GetKeyPrefixPools(pool.GetId())
 	store.Set(poolKey, bz)
 
+	// every change of a pool's reserves goes through SetPool
+	k.markPoolChanged(ctx, pool.GetId())
+
 	return nil
 }
 
 func (k Keeper) DeletePool(ctx sdk.Context, poolId uint64) error {
 	store := ctx.KVStore(k.storeKey)
 	poolKey := types.GetKeyPrefixPools(poolId)
-	if !store.Has(poolKey) {
+	bz := store.Get(poolKey)
+	if bz == nil {
 		return fmt.Errorf("pool with ID %d does not exist", poolId)
 	}
+	pool, err := k.UnmarshalPool(bz)
+	if err != nil {
+		return err
+	}
 
 	store.Delete(poolKey)
+	k.updatePoolDenomIndex(ctx, poolId, pool.GetTotalPoolLiquidity(ctx), sdk.Coins{})
 	return nil
 }
 
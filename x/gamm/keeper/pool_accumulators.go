@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// GetPoolAccumulators returns the cumulative amounts of the swaps through poolId, see GetPoolVolume,
+// GetPoolRoundingDust and GetPoolFeesPerShare. They are kept in a single record per pool,
+// so that a swap hop through the pool reads and writes them once.
+// A pool without any swaps, or that does not exist, has empty accumulators.
+func (k Keeper) GetPoolAccumulators(ctx sdk.Context, poolId uint64) types.PoolAccumulators {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetKeyPoolAccumulators(poolId))
+	if bz == nil {
+		return types.PoolAccumulators{}
+	}
+
+	var accumulators types.PoolAccumulators
+	k.cdc.MustUnmarshal(bz, &accumulators)
+	return accumulators
+}
+
+func (k Keeper) setPoolAccumulators(ctx sdk.Context, poolId uint64, accumulators types.PoolAccumulators) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetKeyPoolAccumulators(poolId), k.cdc.MustMarshal(&accumulators))
+}
+
+// getAllPoolAccumulators returns the accumulators of all pools with swaps.
+func (k Keeper) getAllPoolAccumulators(ctx sdk.Context) []types.PoolAccumulatorsRecord {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixPoolAccumulators)
+	defer iter.Close()
+
+	records := []types.PoolAccumulatorsRecord{}
+	for ; iter.Valid(); iter.Next() {
+		record := types.PoolAccumulatorsRecord{PoolId: sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixPoolAccumulators):])}
+		k.cdc.MustUnmarshal(iter.Value(), &record.Accumulators)
+		records = append(records, record)
+	}
+	return records
+}
+
+// GetPoolVolume returns the cumulative amounts of every denom swapped into and out of poolId,
+// over all of the pool's swaps, including the hops of multihop swaps through the pool.
+// A pool without any swaps, or that does not exist, has no volume.
+func (k Keeper) GetPoolVolume(ctx sdk.Context, poolId uint64) (volumeIn sdk.Coins, volumeOut sdk.Coins) {
+	accumulators := k.GetPoolAccumulators(ctx, poolId)
+	return accumulators.VolumeIn, accumulators.VolumeOut
+}
+
+// recordPoolAccumulators adds the tokens in and out of hop to the volume of its pool,
+// along with the hop's rounding dust and LP fee per share.
+func (k Keeper) recordPoolAccumulators(ctx sdk.Context, hop swapHop) {
+	poolId := hop.pool.GetId()
+	accumulators := k.GetPoolAccumulators(ctx, poolId)
+	accumulators.VolumeIn = accumulators.VolumeIn.Add(hop.tokenIn)
+	accumulators.VolumeOut = accumulators.VolumeOut.Add(hop.tokenOut)
+	if dust, ok := hopRoundingDust(hop); ok {
+		accumulators.RoundingDust = accumulators.RoundingDust.Add(dust)
+	}
+	if feePerShare, ok := hopFeePerShare(hop); ok {
+		accumulators.FeesPerShare = accumulators.FeesPerShare.Add(feePerShare)
+	}
+	k.setPoolAccumulators(ctx, poolId, accumulators)
+}
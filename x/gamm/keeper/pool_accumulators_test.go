@@ -0,0 +1,63 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestPoolVolume() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+
+	volumeIn, volumeOut := keeper.GetPoolVolume(suite.Ctx, poolId)
+	suite.Require().True(volumeIn.Empty())
+	suite.Require().True(volumeOut.Empty())
+
+	expectedVolumeIn, expectedVolumeOut := sdk.Coins{}, sdk.Coins{}
+	for i := int64(1); i <= 5; i++ {
+		tokenIn := sdk.NewInt64Coin("foo", i*10000)
+		tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+		expectedVolumeIn = expectedVolumeIn.Add(tokenIn)
+		expectedVolumeOut = expectedVolumeOut.Add(sdk.NewCoin("bar", tokenOutAmount))
+	}
+	tokenOut := sdk.NewInt64Coin("foo", 20000)
+	tokenInAmount, err := keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "baz", sdk.NewInt(1000000), tokenOut)
+	suite.Require().NoError(err)
+	expectedVolumeIn = expectedVolumeIn.Add(sdk.NewCoin("baz", tokenInAmount))
+	expectedVolumeOut = expectedVolumeOut.Add(tokenOut)
+
+	volumeIn, volumeOut = keeper.GetPoolVolume(suite.Ctx, poolId)
+	suite.Require().Equal(expectedVolumeIn, volumeIn)
+	suite.Require().Equal(expectedVolumeOut, volumeOut)
+
+	// a multihop swap adds to the volume of every pool it swaps through.
+	routes := []types.SwapAmountInRoute{
+		{PoolId: poolId, TokenOutDenom: "bar"},
+		{PoolId: otherPoolId, TokenOutDenom: "baz"},
+	}
+	tokenIn := sdk.NewInt64Coin("foo", 30000)
+	bazOutAmount, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, routes, tokenIn, sdk.OneInt())
+	suite.Require().NoError(err)
+
+	volumeIn, volumeOut = keeper.GetPoolVolume(suite.Ctx, poolId)
+	suite.Require().Equal(expectedVolumeIn.Add(tokenIn), volumeIn)
+	barOut := volumeOut.Sub(expectedVolumeOut)
+	suite.Require().Len(barOut, 1)
+	suite.Require().Equal("bar", barOut[0].Denom)
+
+	otherVolumeIn, otherVolumeOut := keeper.GetPoolVolume(suite.Ctx, otherPoolId)
+	suite.Require().Equal(sdk.Coins{barOut[0]}, otherVolumeIn)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewCoin("baz", bazOutAmount)), otherVolumeOut)
+
+	// the volume is kept in the pool's accumulators, with the rounding dust of the swaps.
+	accumulators := keeper.GetPoolAccumulators(suite.Ctx, poolId)
+	suite.Require().Equal(volumeIn, accumulators.VolumeIn)
+	suite.Require().Equal(volumeOut, accumulators.VolumeOut)
+	suite.Require().False(accumulators.RoundingDust.Empty())
+	suite.Require().Equal(keeper.GetPoolRoundingDust(suite.Ctx, poolId), accumulators.RoundingDust)
+}
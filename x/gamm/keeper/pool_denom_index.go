@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// GetPoolsWithDenom returns the ids of the pools with denom as an asset, in ascending order.
+// The pools are looked up in an index, so that routers don't have to iterate over all pools.
+func (k Keeper) GetPoolsWithDenom(ctx sdk.Context, denom string) []uint64 {
+	prefix := types.GetKeyPrefixPoolsByDenom(denom)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iter.Close()
+
+	poolIds := []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		poolIds = append(poolIds, sdk.BigEndianToUint64(iter.Key()[len(prefix):]))
+	}
+	return poolIds
+}
+
+// updatePoolDenomIndex updates the index of the pools by denom for a change of the assets of poolId
+// from the denoms of oldLiquidity to the ones of newLiquidity.
+//
+// The denoms of a pool only change when it's created or deleted, so swaps and LP'ing don't pay
+// for maintaining the index. Any future change of the denoms of an existing pool must call it too.
+func (k Keeper) updatePoolDenomIndex(ctx sdk.Context, poolId uint64, oldLiquidity, newLiquidity sdk.Coins) {
+	store := ctx.KVStore(k.storeKey)
+	for _, coin := range oldLiquidity {
+		if !newLiquidity.AmountOf(coin.Denom).IsPositive() {
+			store.Delete(types.GetKeyPoolByDenom(coin.Denom, poolId))
+		}
+	}
+	for _, coin := range newLiquidity {
+		if !oldLiquidity.AmountOf(coin.Denom).IsPositive() {
+			store.Set(types.GetKeyPoolByDenom(coin.Denom, poolId), []byte{1})
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+)
+
+func (suite *KeeperTestSuite) TestGetPoolsWithDenom() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	// the pools have the assets bar, baz and foo; foo and bar; and bar and baz.
+	barBazFooPoolId := suite.PrepareBalancerPool()
+	fooBarPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+	barBazPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("bar", 1000000), sdk.NewInt64Coin("baz", 1000000))
+
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId, barBazPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "bar"))
+	suite.Require().Equal([]uint64{barBazFooPoolId, barBazPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "baz"))
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "foo"))
+	suite.Require().Empty(keeper.GetPoolsWithDenom(suite.Ctx, "qux"))
+	// a denom doesn't match the denoms it is a prefix of.
+	suite.Require().Empty(keeper.GetPoolsWithDenom(suite.Ctx, "ba"))
+
+	// swaps don't change the index.
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], fooBarPoolId, sdk.NewInt64Coin("foo", 100000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "foo"))
+
+	// a change of a pool's assets moves it from the index of its old denom to the one of its new denom.
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, barBazFooPoolId)
+	suite.Require().NoError(err)
+	oldLiquidity := pool.GetTotalPoolLiquidity(suite.Ctx)
+	balancerPool := pool.(*balancer.Pool)
+	suite.Require().Equal("foo", balancerPool.PoolAssets[2].Token.Denom)
+	balancerPool.PoolAssets[2].Token.Denom = "qux"
+	suite.Require().NoError(keeper.SetPool(suite.Ctx, balancerPool))
+	keeper.UpdatePoolDenomIndex(suite.Ctx, barBazFooPoolId, oldLiquidity, balancerPool.GetTotalPoolLiquidity(suite.Ctx))
+
+	suite.Require().Equal([]uint64{fooBarPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "foo"))
+	suite.Require().Equal([]uint64{barBazFooPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "qux"))
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId, barBazPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "bar"))
+
+	// deleted pools are removed from the index.
+	suite.Require().NoError(keeper.DeletePool(suite.Ctx, barBazFooPoolId))
+	suite.Require().Equal([]uint64{fooBarPoolId, barBazPoolId}, keeper.GetPoolsWithDenom(suite.Ctx, "bar"))
+	suite.Require().Empty(keeper.GetPoolsWithDenom(suite.Ctx, "qux"))
+}
+
+// TestMigrate1to2 tests that the migration to version 2 indexes the pools created before the index.
+func (suite *KeeperTestSuite) TestMigrate1to2() {
+	suite.SetupTest()
+	gammKeeper := suite.App.GAMMKeeper
+	barBazFooPoolId := suite.PrepareBalancerPool()
+	fooBarPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+
+	// the pools were created without being indexed.
+	for _, poolId := range []uint64{barBazFooPoolId, fooBarPoolId} {
+		pool, err := gammKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		gammKeeper.UpdatePoolDenomIndex(suite.Ctx, poolId, pool.GetTotalPoolLiquidity(suite.Ctx), sdk.Coins{})
+	}
+	suite.Require().Empty(gammKeeper.GetPoolsWithDenom(suite.Ctx, "foo"))
+
+	err := keeper.NewMigrator(*gammKeeper).Migrate1to2(suite.Ctx)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId}, gammKeeper.GetPoolsWithDenom(suite.Ctx, "foo"))
+	suite.Require().Equal([]uint64{barBazFooPoolId, fooBarPoolId}, gammKeeper.GetPoolsWithDenom(suite.Ctx, "bar"))
+	suite.Require().Equal([]uint64{barBazFooPoolId}, gammKeeper.GetPoolsWithDenom(suite.Ctx, "baz"))
+}
@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/stableswap"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// MigrateBalancerPoolToStableswap converts the balancer pool with poolId into a stableswap pool solved on
+// Curve's CFMM with amplificationParameter, which must be in [1, stableswap.MaxAmplificationParameter],
+// with the given scaling factors, one per asset in the pool's liquidity order, and scaling factor governor.
+// The pool keeps its id, address, reserves, LP shares, swap and exit fees and future governor,
+// so that LPs and routes through the pool are unaffected, while later swaps are solved on the stableswap curve.
+// Only two asset pools can be migrated.
+//
+// The asset scaling factors of the pool, see SetAssetScalingFactors, only apply to balancer pools
+// and are removed, the stableswap pool scales its reserves by scalingFactors instead.
+// Pools with directional swap fees aren't migrated, as the fees were set for the balancer curve,
+// so governance has to remove them or set them anew for the stableswap pool.
+// Neither are pools with swap fee tiers, which only balancer pools have.
+// It is called by governance through a MigrateBalancerPoolToStableswapProposal.
+func (k Keeper) MigrateBalancerPoolToStableswap(ctx sdk.Context, poolId uint64, amplificationParameter uint64, scalingFactors []uint64, scalingFactorGovernor string) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolId)
+	}
+
+	if _, found := k.GetDirectionalSwapFees(ctx, poolId); found {
+		return sdkerrors.Wrapf(types.ErrPoolHasDirectionalSwapFees, "pool %d can't be migrated", poolId)
+	}
+	if balancerPool.PoolParams.SwapFeeTiers != nil {
+		return sdkerrors.Wrapf(types.ErrPoolHasSwapFeeTiers, "pool %d can't be migrated", poolId)
+	}
+	if amplificationParameter == 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidAmplificationParameter, "amplification parameter must be at least 1")
+	}
+
+	liquidity := balancerPool.GetTotalPoolLiquidity(ctx)
+	if liquidity.Len() != 2 {
+		return sdkerrors.Wrapf(types.ErrTooManyPoolAssets, "pool %d has %d assets, a stableswap pool has 2", poolId, liquidity.Len())
+	}
+	if len(scalingFactors) != liquidity.Len() {
+		return sdkerrors.Wrapf(types.ErrInvalidStableswapScalingFactors, "%d scaling factors for %d assets", len(scalingFactors), liquidity.Len())
+	}
+	for i, scalingFactor := range scalingFactors {
+		// scaling factors divide the reserves as an int64.
+		if scalingFactor == 0 || scalingFactor > math.MaxInt64 {
+			return sdkerrors.Wrapf(types.ErrInvalidStableswapScalingFactors, "scaling factor %d of %s is not in [1, 2^63)", scalingFactor, liquidity[i].Denom)
+		}
+	}
+
+	stableswapPool := stableswap.Pool{
+		Address: balancerPool.Address,
+		Id:      balancerPool.Id,
+		PoolParams: stableswap.PoolParams{
+			SwapFee:                balancerPool.PoolParams.SwapFee,
+			ExitFee:                balancerPool.PoolParams.ExitFee,
+			AmplificationParameter: amplificationParameter,
+		},
+		FuturePoolGovernor:    balancerPool.FuturePoolGovernor,
+		TotalShares:           balancerPool.TotalShares,
+		PoolLiquidity:         liquidity,
+		ScalingFactor:         scalingFactors,
+		ScalingFactorGovernor: scalingFactorGovernor,
+	}
+	if err := stableswapPool.PoolParams.Validate(); err != nil {
+		return err
+	}
+	if err := validateStableswapReserves(ctx, stableswapPool); err != nil {
+		return err
+	}
+
+	if err := k.SetPool(ctx, &stableswapPool); err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetKeyAssetScalingFactors(poolId))
+
+	ctx.EventManager().EmitEvent(types.CreatePoolMigratedEvent(ctx, poolId, amplificationParameter, scalingFactors))
+	return nil
+}
+
+// validateStableswapReserves checks that the stableswap curve solves swaps against the pool's scaled reserves.
+// Reserves larger than stableswap.MaxScaledReserve would overflow the curve's math, so large reserves need
+// large enough scaling factors. Within that range, a swap of 1% of each reserve must converge.
+func validateStableswapReserves(ctx sdk.Context, pool stableswap.Pool) error {
+	liquidity := pool.GetTotalPoolLiquidity(ctx)
+	for i, reserve := range liquidity {
+		scaledReserve := reserve.Amount.ToDec().QuoInt64(int64(pool.GetScalingFactorByLiquidityIndex(i)))
+		if scaledReserve.GT(stableswap.MaxScaledReserve) {
+			return sdkerrors.Wrapf(types.ErrInvalidStableswapScalingFactors,
+				"reserve %s scaled by %d is larger than %s", reserve, pool.GetScalingFactorByLiquidityIndex(i), stableswap.MaxScaledReserve)
+		}
+	}
+
+	for i, reserveIn := range liquidity {
+		reserveOut := liquidity[1-i]
+		tokenIn := sdk.NewCoin(reserveIn.Denom, reserveIn.Amount.QuoRaw(100))
+		tokenOut, err := pool.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, reserveOut.Denom, sdk.ZeroDec())
+		if err != nil {
+			return sdkerrors.Wrapf(types.ErrInvalidStableswapScalingFactors,
+				"reserves %s scaled by %v are out of the range of the stableswap curve: %s", liquidity, pool.GetScalingFactors(), err)
+		}
+		if tokenOut.Amount.GTE(reserveOut.Amount) {
+			return sdkerrors.Wrapf(types.ErrInvalidStableswapScalingFactors,
+				"reserves %s scaled by %v are out of the range of the stableswap curve", liquidity, pool.GetScalingFactors())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/stableswap"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestMigrateBalancerPoolToStableswap() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+	tokenIn := sdk.NewInt64Coin("foo", 10000)
+
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	totalShares := pool.GetTotalShares()
+	liquidity := pool.GetTotalPoolLiquidity(suite.Ctx)
+	balances := suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress())
+	balancerQuote, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, "bar")
+	suite.Require().NoError(err)
+	err = keeper.SetAssetScalingFactors(suite.Ctx, poolId, types.AssetScalingFactors{Factors: []types.AssetScalingFactor{
+		{Denom: "foo", Decimals: 6},
+		{Denom: "bar", Decimals: 6},
+	}})
+	suite.Require().NoError(err)
+
+	err = keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, "")
+	suite.Require().NoError(err)
+
+	// the migration keeps the pool's shares and reserves.
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	stableswapPool, ok := pool.(*stableswap.Pool)
+	suite.Require().True(ok)
+	suite.Require().Equal(totalShares.String(), stableswapPool.GetTotalShares().String())
+	suite.Require().Equal(liquidity, stableswapPool.GetTotalPoolLiquidity(suite.Ctx))
+	suite.Require().Equal(balances, suite.App.BankKeeper.GetAllBalances(suite.Ctx, stableswapPool.GetAddress()))
+	suite.Require().Equal([]uint64{1, 1}, stableswapPool.GetScalingFactors())
+	suite.Require().Equal(uint64(100), stableswapPool.PoolParams.AmplificationParameter)
+	// the asset scaling factors of the balancer pool are removed.
+	_, found := keeper.GetAssetScalingFactors(suite.Ctx, poolId)
+	suite.Require().False(found)
+
+	// quotes and swaps are solved on the stableswap curve.
+	expectedTokenOut, err := stableswapPool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", stableswapPool.GetSwapFee(suite.Ctx))
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(balancerQuote.String(), expectedTokenOut.Amount.String())
+	quote, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount.String(), quote.String())
+	suite.FundAcc(suite.TestAccs[0], sdk.Coins{tokenIn})
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount.String(), tokenOutAmount.String())
+
+	// a migrated pool can't be migrated again.
+	err = keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, "")
+	suite.Require().ErrorIs(err, types.ErrNotBalancerPool)
+}
+
+func (suite *KeeperTestSuite) TestMigrateBalancerPoolToStableswapInvalid() {
+	tests := []struct {
+		name                   string
+		threeAssets            bool
+		reserve                sdk.Int
+		amplificationParameter uint64
+		scalingFactors         []uint64
+		expectedErr            error
+	}{
+		{
+			name:                   "three assets",
+			amplificationParameter: 100,
+			threeAssets:            true,
+			scalingFactors:         []uint64{1, 1, 1},
+			expectedErr:            types.ErrTooManyPoolAssets,
+		},
+		{
+			name:                   "reserves out of the range of the stableswap curve",
+			amplificationParameter: 100,
+			reserve:                sdk.NewIntWithDecimal(1, 25),
+			scalingFactors:         []uint64{1, 1},
+			expectedErr:            types.ErrInvalidStableswapScalingFactors,
+		},
+		{
+			name:                   "no amplification parameter",
+			amplificationParameter: 0,
+			scalingFactors:         []uint64{1, 1},
+			expectedErr:            types.ErrInvalidAmplificationParameter,
+		},
+		{
+			name:                   "amplification parameter too large",
+			amplificationParameter: stableswap.MaxAmplificationParameter + 1,
+			scalingFactors:         []uint64{1, 1},
+			expectedErr:            types.ErrInvalidAmplificationParameter,
+		},
+		{
+			name:                   "too few scaling factors",
+			amplificationParameter: 100,
+			scalingFactors:         []uint64{1},
+			expectedErr:            types.ErrInvalidStableswapScalingFactors,
+		},
+		{
+			name:                   "zero scaling factor",
+			amplificationParameter: 100,
+			scalingFactors:         []uint64{1, 0},
+			expectedErr:            types.ErrInvalidStableswapScalingFactors,
+		},
+		{
+			name:                   "scaling factor overflowing int64",
+			amplificationParameter: 100,
+			scalingFactors:         []uint64{1 << 63, 1},
+			expectedErr:            types.ErrInvalidStableswapScalingFactors,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			reserve := sdk.NewInt(1000000)
+			if !test.reserve.IsNil() {
+				reserve = test.reserve
+			}
+			poolId := suite.PrepareUni2PoolWithAssets(sdk.NewCoin("foo", reserve), sdk.NewCoin("bar", reserve))
+			if test.threeAssets {
+				poolId = suite.PrepareBalancerPool()
+			}
+
+			err := keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, test.amplificationParameter, test.scalingFactors, "")
+			suite.Require().ErrorIs(err, test.expectedErr)
+
+			// the pool is still a balancer pool.
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			_, ok := pool.(*stableswap.Pool)
+			suite.Require().False(ok)
+		})
+	}
+
+	suite.Run("directional swap fees", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+		err := keeper.SetDirectionalSwapFees(suite.Ctx, poolId, types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+			{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: sellFooSwapFee},
+		}})
+		suite.Require().NoError(err)
+
+		err = keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, "")
+		suite.Require().ErrorIs(err, types.ErrPoolHasDirectionalSwapFees)
+
+		// once governance removes the fees, the pool can be migrated.
+		suite.Require().NoError(keeper.SetDirectionalSwapFees(suite.Ctx, poolId, types.DirectionalSwapFees{}))
+		err = keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, "")
+		suite.Require().NoError(err)
+	})
+
+	suite.Run("swap fee tiers", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+		err := keeper.SetSwapFeeTiers(suite.Ctx, poolId, types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+			{MinVolume: sdk.NewInt(100000), SwapFee: sdk.ZeroDec()},
+		}})
+		suite.Require().NoError(err)
+
+		err = keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, "")
+		suite.Require().ErrorIs(err, types.ErrPoolHasSwapFeeTiers)
+	})
+
+	suite.Run("pool not found", func() {
+		suite.SetupTest()
+		err := suite.App.GAMMKeeper.MigrateBalancerPoolToStableswap(suite.Ctx, 10, 100, []uint64{1, 1}, "")
+		suite.Require().Error(err)
+	})
+}
@@ -6,8 +6,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
@@ -29,7 +29,119 @@ func (k Keeper) CalculateSpotPrice(
 	return pool.SpotPrice(ctx, baseAssetDenom, quoteAssetDenom)
 }
 
-func validateCreatePoolMsg(ctx sdk.Context, msg types.CreatePoolMsg) error {
+// GetSpotPriceInBothDirections returns the spot price of the quote asset in terms of the base asset,
+// as CalculateSpotPrice does, and the inverse spot price of the base asset in terms of the quote asset,
+// from a single fetch of the pool.
+// Both prices are computed by the pool, rather than inverting one of them, so that each is rounded
+// like CalculateSpotPrice rounds it. They are reciprocals within types.SpotPriceReciprocalTolerance.
+func (k Keeper) GetSpotPriceInBothDirections(
+	ctx sdk.Context,
+	poolID uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+) (spotPrice sdk.Dec, inverseSpotPrice sdk.Dec, err error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolID)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+
+	spotPrice, err = pool.SpotPrice(ctx, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+	inverseSpotPrice, err = pool.SpotPrice(ctx, quoteAssetDenom, baseAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, sdk.Dec{}, err
+	}
+	return spotPrice, inverseSpotPrice, nil
+}
+
+// CalculateSpotPriceWithSwapFee is CalculateSpotPrice including the pool's swap fee for swapping
+// baseAssetDenom in for quoteAssetDenom, i.e. how much baseAssetDenom a marginal swap pays per quoteAssetDenom.
+// As only tokenIn * (1 - swapFee) trades against the pool, this is spot_price / (1 - swapFee),
+// the inverse of the marginal rate tokenOut / tokenIn. Swap fee tiers of the sender are not applied.
+func (k Keeper) CalculateSpotPriceWithSwapFee(
+	ctx sdk.Context,
+	poolID uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+) (sdk.Dec, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolID)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	spotPrice, err := pool.SpotPrice(ctx, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	swapFee := k.directionalSwapFee(ctx, pool, baseAssetDenom, quoteAssetDenom)
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Dec{}, err
+	}
+	return spotPrice.Quo(sdk.OneDec().Sub(swapFee)), nil
+}
+
+// CalculateSpotPriceWithPrecision is CalculateSpotPrice rounded to the given number of decimal places,
+// for displaying the spot price. Halfway cases are rounded to the nearest even decimal (banker's rounding).
+// Swaps never use the rounded price, they use the full precision of CalculateSpotPrice.
+func (k Keeper) CalculateSpotPriceWithPrecision(
+	ctx sdk.Context,
+	poolID uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	decimals uint64,
+) (sdk.Dec, error) {
+	if decimals > sdk.Precision {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrPrecisionTooLarge, "spot prices have at most %d decimals, got %d", sdk.Precision, decimals)
+	}
+
+	spotPrice, err := k.CalculateSpotPrice(ctx, poolID, baseAssetDenom, quoteAssetDenom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	// sdk.Dec.RoundInt rounds halfway cases to even.
+	scale := sdk.NewDec(10).Power(decimals)
+	return spotPrice.Mul(scale).RoundInt().ToDec().Quo(scale), nil
+}
+
+// GetPoolTVL returns the total value locked in the pool in terms of referenceDenom,
+// valuing every asset at its spot price in referenceDenom within the pool, and referenceDenom itself 1:1.
+// E.g. for a pool of 2 atom and 9 osmo where 1 atom costs 1.5 osmo, the value in osmo is 12.
+// Returns an error if referenceDenom is not in the pool, as there is no price path within the pool,
+// or the pool has no spot price of one of its assets in referenceDenom.
+func (k Keeper) GetPoolTVL(ctx sdk.Context, poolId uint64, referenceDenom string) (sdk.Dec, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	liquidity := pool.GetTotalPoolLiquidity(ctx)
+	if !liquidity.AmountOf(referenceDenom).IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "reference denom %s is not in pool %d", referenceDenom, poolId)
+	}
+
+	value := sdk.ZeroDec()
+	for _, coin := range liquidity {
+		if coin.Denom == referenceDenom {
+			value = value.Add(coin.Amount.ToDec())
+			continue
+		}
+		// the spot price of referenceDenom in terms of coin's denom, is the price of coin's denom in referenceDenom.
+		price, err := pool.SpotPrice(ctx, referenceDenom, coin.Denom)
+		if err != nil {
+			return sdk.Dec{}, sdkerrors.Wrapf(err, "pool %d has no spot price of %s in %s", poolId, coin.Denom, referenceDenom)
+		}
+		if !price.IsPositive() {
+			return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "pool %d has a non-positive spot price of %s in %s", poolId, coin.Denom, referenceDenom)
+		}
+		value = value.Add(coin.Amount.ToDec().Mul(price))
+	}
+	return value, nil
+}
+
+func validateCreatePoolMsg(ctx sdk.Context, msg types.CreatePoolMsg, maxPoolAssets uint64) error {
 	err := msg.Validate(ctx)
 	if err != nil {
 		return err
@@ -40,10 +152,10 @@ func validateCreatePoolMsg(ctx sdk.Context, msg types.CreatePoolMsg) error {
 	if numAssets < types.MinPoolAssets {
 		return types.ErrTooFewPoolAssets
 	}
-	if numAssets > types.MaxPoolAssets {
+	if uint64(numAssets) > maxPoolAssets {
 		return sdkerrors.Wrapf(
 			types.ErrTooManyPoolAssets,
-			"pool has too many PoolAssets (%d)", numAssets,
+			"pool has too many PoolAssets (%d), the maximum is %d", numAssets, maxPoolAssets,
 		)
 	}
 	return nil
@@ -86,12 +198,16 @@ func (k Keeper) validateCreatedPool(
 // pool. It will create a dedicated module account for the pool and sends the
 // initial liquidity to the created module account.
 //
-// After the initial liquidity is sent to the pool's account, shares are minted
-// and sent to the pool creator. The shares are created using a denomination in
+// The message is validated first, e.g. that the pool has at least two assets and at most
+// the MaxPoolAssets param, with positive balances and valid weights.
+//
+// After the initial liquidity is sent to the pool's account, InitPoolSharesSupply shares are minted
+// and sent to the pool creator, except for MinimumLiquidityShares, which are
+// locked at MinimumLiquidityAddress. The shares are created using a denomination in
 // the form of gamm/pool/{poolID}. In addition, the x/bank metadata is updated
 // to reflect the newly created GAMM share denomination.
 func (k Keeper) CreatePool(ctx sdk.Context, msg types.CreatePoolMsg) (uint64, error) {
-	err := validateCreatePoolMsg(ctx, msg)
+	err := validateCreatePoolMsg(ctx, msg, k.GetMaxPoolAssets(ctx))
 	if err != nil {
 		return 0, err
 	}
@@ -100,8 +216,7 @@ func (k Keeper) CreatePool(ctx sdk.Context, msg types.CreatePoolMsg) (uint64, er
 	initialPoolLiquidity := msg.InitialLiquidity()
 
 	// send pool creation fee to community pool
-	params := k.GetParams(ctx)
-	if err := k.distrKeeper.FundCommunityPool(ctx, params.PoolCreationFee, sender); err != nil {
+	if err := k.distrKeeper.FundCommunityPool(ctx, k.GetPoolCreationFee(ctx), sender); err != nil {
 		return 0, err
 	}
 
@@ -131,38 +246,20 @@ func (k Keeper) CreatePool(ctx sdk.Context, msg types.CreatePoolMsg) (uint64, er
 		return 0, err
 	}
 
-	// Mint the initial pool shares share token to the sender
-	err = k.MintPoolShareToAccount(ctx, pool, sender, pool.GetTotalShares())
+	// Mint the initial pool shares share token to the sender,
+	// except for the minimum liquidity shares, which are locked forever.
+	err = k.mintInitialPoolShares(ctx, pool, sender)
 	if err != nil {
 		return 0, err
 	}
 
 	// Finally, add the share token's meta data to the bank keeper.
-	poolShareBaseDenom := types.GetPoolShareDenom(pool.GetId())
-	poolShareDisplayDenom := fmt.Sprintf("GAMM-%d", pool.GetId())
-	k.bankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
-		Description: fmt.Sprintf("The share token of the gamm pool %d", pool.GetId()),
-		DenomUnits: []*banktypes.DenomUnit{
-			{
-				Denom:    poolShareBaseDenom,
-				Exponent: 0,
-				Aliases: []string{
-					"attopoolshare",
-				},
-			},
-			{
-				Denom:    poolShareDisplayDenom,
-				Exponent: types.OneShareExponent,
-				Aliases:  nil,
-			},
-		},
-		Base:    poolShareBaseDenom,
-		Display: poolShareDisplayDenom,
-	})
+	k.bankKeeper.SetDenomMetaData(ctx, types.NewPoolShareDenomMetadata(pool.GetId(), pool.GetTotalPoolLiquidity(ctx)))
 
 	if err := k.SetPool(ctx, pool); err != nil {
 		return 0, err
 	}
+	k.updatePoolDenomIndex(ctx, pool.GetId(), sdk.Coins{}, initialPoolLiquidity)
 
 	k.hooks.AfterPoolCreated(ctx, sender, pool.GetId())
 	k.RecordTotalLiquidityIncrease(ctx, initialPoolLiquidity)
@@ -172,6 +269,8 @@ func (k Keeper) CreatePool(ctx sdk.Context, msg types.CreatePoolMsg) (uint64, er
 
 // JoinPoolNoSwap aims to LP exactly enough to pool #{poolId} to get shareOutAmount number of LP shares.
 // If the required tokens is greater than tokenInMaxs, returns an error & the message reverts.
+// This guards the LP against the reserves shifting before the join: every pool asset needs a maximum
+// in tokenInMaxs, unless tokenInMaxs is empty, which doesn't limit the tokens in.
 // Leftover tokens that weren't LP'd (due to being at inexact ratios) remain in the sender account.
 //
 // JoinPoolNoSwap determines the maximum amount that can be LP'd without any swap,
@@ -206,7 +305,7 @@ func (k Keeper) JoinPoolNoSwap(
 		}
 	}
 
-	sharesOut, err := pool.JoinPool(ctx, neededLpLiquidity, pool.GetSwapFee(ctx))
+	sharesOut, tokensJoined, err := pool.JoinPool(ctx, neededLpLiquidity, pool.GetSwapFee(ctx))
 	if err != nil {
 		return err
 	}
@@ -216,7 +315,7 @@ func (k Keeper) JoinPoolNoSwap(
 			shareOutAmount, sharesOut))
 	}
 
-	err = k.applyJoinPoolStateChange(ctx, pool, sender, sharesOut, neededLpLiquidity)
+	err = k.applyJoinPoolStateChange(ctx, pool, sender, sharesOut, tokensJoined)
 	return err
 }
 
@@ -249,6 +348,46 @@ func getMaximalNoSwapLPAmount(ctx sdk.Context, pool types.PoolI, shareOutAmount
 	return neededLpLiquidity, nil
 }
 
+// CalcNumSharesOutFromExactCoins returns the most shares of poolId that JoinPoolNoSwap can join for with tokensIn
+// as its tokenInMaxs, and the remainder of tokensIn the join leaves with the sender, without changing any state.
+// As in MaximalExactRatioJoin, the shares are those of the coin of tokensIn with the smallest ratio to its pool
+// liquidity, rounded down, e.g. for a pool of 10 foo and 10 bar with 100 shares, tokensIn of 1 foo and 2 bar
+// join for 10 shares with a remainder of 1 bar. tokensIn must have coins of every denom of the pool.
+func (k Keeper) CalcNumSharesOutFromExactCoins(
+	ctx sdk.Context,
+	poolId uint64,
+	tokensIn sdk.Coins,
+) (sharesOut sdk.Int, remainder sdk.Coins, err error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, sdk.Coins{}, err
+	}
+
+	poolLiquidity := pool.GetTotalPoolLiquidity(ctx)
+	if !tokensIn.DenomsSubsetOf(poolLiquidity) {
+		return sdk.Int{}, sdk.Coins{}, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "tokens in %s, pool liquidity %s", tokensIn, poolLiquidity)
+	}
+	minShareRatio := sdk.MaxSortableDec
+	for _, coin := range poolLiquidity {
+		shareRatio := tokensIn.AmountOf(coin.Denom).ToDec().QuoInt(coin.Amount)
+		if shareRatio.LT(minShareRatio) {
+			minShareRatio = shareRatio
+		}
+	}
+	sharesOut = minShareRatio.MulInt(pool.GetTotalShares()).TruncateInt()
+
+	neededLpLiquidity, err := getMaximalNoSwapLPAmount(ctx, pool, sharesOut)
+	if err != nil {
+		return sdk.Int{}, sdk.Coins{}, err
+	}
+	// the shares are rounded down, so they never need more than tokensIn.
+	if !tokensIn.IsAllGTE(neededLpLiquidity) {
+		return sdk.Int{}, sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox,
+			"joining %s shares needs %s, more than tokens in %s", sharesOut, neededLpLiquidity, tokensIn)
+	}
+	return sharesOut, tokensIn.Sub(neededLpLiquidity), nil
+}
+
 // JoinSwapExactAmountIn is an LP transaction, that will LP all of the provided
 // tokensIn coins. The underlying pool is responsible for swapping any non-even
 // LP proportions to the correct ratios. An error is returned if the amount of
@@ -266,7 +405,7 @@ func (k Keeper) JoinSwapExactAmountIn(
 		return sdk.Int{}, err
 	}
 
-	sharesOut, err := pool.JoinPool(ctx, tokensIn, pool.GetSwapFee(ctx))
+	sharesOut, tokensJoined, err := pool.JoinPool(ctx, tokensIn, pool.GetSwapFee(ctx))
 	switch {
 	case err != nil:
 		return sdk.ZeroInt(), err
@@ -282,13 +421,72 @@ func (k Keeper) JoinSwapExactAmountIn(
 		return sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrInvalidMathApprox, "share amount is zero or negative")
 	}
 
-	if err := k.applyJoinPoolStateChange(ctx, pool, sender, sharesOut, tokensIn); err != nil {
+	// only the joined tokens are taken from the sender, the rest stays in their account.
+	if err := k.applyJoinPoolStateChange(ctx, pool, sender, sharesOut, tokensJoined); err != nil {
 		return sdk.ZeroInt(), err
 	}
+	if len(tokensJoined) == 1 {
+		if err := k.emitSingleAssetJoinSwap(ctx, sender, pool, tokensJoined[0]); err != nil {
+			return sdk.ZeroInt(), err
+		}
+	}
 
 	return sharesOut, nil
 }
 
+// CalcJoinPoolShares returns the shares JoinSwapExactAmountIn would mint for tokensIn in poolId,
+// along with the tokens it would take from tokensIn, without changing any state.
+func (k Keeper) CalcJoinPoolShares(
+	ctx sdk.Context,
+	poolId uint64,
+	tokensIn sdk.Coins,
+) (sharesOut sdk.Int, tokensJoined sdk.Coins, err error) {
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, sdk.Coins{}, err
+	}
+
+	sharesOut, tokensJoined, err = pool.CalcJoinPoolShares(ctx, tokensIn, pool.GetSwapFee(ctx))
+	if err != nil {
+		return sdk.Int{}, sdk.Coins{}, err
+	}
+	if sharesOut.LTE(sdk.ZeroInt()) {
+		return sdk.Int{}, sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "share amount is zero or negative")
+	}
+	return sharesOut, tokensJoined, nil
+}
+
+// JoinSwapExternAmountIn is a single asset join, that LPs all of tokenIn. The pool swaps the part of tokenIn
+// that is not in proportion to the pool's liquidity, charging its swap fee on that part.
+// As with JoinSwapExactAmountIn, an error wrapping ErrLimitMinAmount is returned if fewer than
+// shareOutMinAmount shares would be minted, which protects the join from slippage.
+func (k Keeper) JoinSwapExternAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	shareOutMinAmount sdk.Int,
+) (sharesOut sdk.Int, err error) {
+	if !tokenIn.IsValid() || !tokenIn.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotPositiveRequireAmount, "token in %s must be positive", tokenIn)
+	}
+	return k.JoinSwapExactAmountIn(ctx, sender, poolId, sdk.Coins{tokenIn}, shareOutMinAmount)
+}
+
+// EstimateJoinSwapExternAmountIn returns the shares JoinSwapExternAmountIn would mint for tokenIn in poolId,
+// against the current state of the pool. No state is written, and no tokens are transferred.
+func (k Keeper) EstimateJoinSwapExternAmountIn(
+	ctx sdk.Context,
+	poolId uint64,
+	tokenIn sdk.Coin,
+) (sharesOut sdk.Int, err error) {
+	if !tokenIn.IsValid() || !tokenIn.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotPositiveRequireAmount, "token in %s must be positive", tokenIn)
+	}
+	sharesOut, _, err = k.CalcJoinPoolShares(ctx, poolId, sdk.Coins{tokenIn})
+	return sharesOut, err
+}
+
 func (k Keeper) JoinSwapShareAmountOut(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -323,9 +521,121 @@ func (k Keeper) JoinSwapShareAmountOut(
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
+	if err := k.emitSingleAssetJoinSwap(ctx, sender, pool, tokenIn[0]); err != nil {
+		return sdk.ZeroInt(), err
+	}
 	return tokenInAmount, nil
 }
 
+// emitSingleAssetJoinSwap emits the part of tokenIn that a single asset join of tokenIn into pool
+// implicitly swaps, so that the swap inside the join can be audited like any other swap.
+// Only balancer pools swap a fixed part of the token in, other pools emit no event.
+func (k Keeper) emitSingleAssetJoinSwap(ctx sdk.Context, sender sdk.AccAddress, pool types.PoolI, tokenIn sdk.Coin) error {
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return nil
+	}
+	impliedSwap, err := balancerPool.SingleAssetJoinImpliedSwap(tokenIn)
+	if err != nil {
+		return err
+	}
+	ctx.EventManager().EmitEvent(types.CreateSingleAssetJoinSwapEvent(ctx, sender, pool.GetId(), tokenIn, impliedSwap))
+	return nil
+}
+
+// CalcExitPoolCoins returns the coins ExitPool would give out for exitingShares of poolId,
+// after the pool's exit fee, without changing any state.
+func (k Keeper) CalcExitPoolCoins(ctx sdk.Context, poolId uint64, exitingShares sdk.Int) (sdk.Coins, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Coins{}, err
+	}
+
+	totalSharesAmount := pool.GetTotalShares()
+	if exitingShares.GTE(totalSharesAmount) || exitingShares.LTE(sdk.ZeroInt()) {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "share ratio is zero or negative")
+	}
+	// the exiter gets the same coins whether or not the pool has an exit fee recipient.
+	return pool.CalcExitPoolShares(ctx, exitingShares, pool.GetExitFee(ctx))
+}
+
+// CalcSharesForExactCoinsOut returns the fewest shares of poolId for which ExitPool gives out at least coinsOut,
+// after the pool's exit fee, without changing any state. It is the inverse of CalcExitPoolCoins, e.g. for a pool
+// of 10 foo and 20 bar with 100 shares and no exit fee, coinsOut of 1 foo and 1 bar need 10 shares, which exit
+// 1 foo and 2 bar. Exits are proportional, so it returns ErrDenomNotFoundInPool if coinsOut has a denom that isn't
+// in the pool, and ErrTooManyTokensOut if getting coinsOut out would take all of the pool's shares.
+func (k Keeper) CalcSharesForExactCoinsOut(ctx sdk.Context, poolId uint64, coinsOut sdk.Coins) (sdk.Int, error) {
+	if !coinsOut.IsValid() || coinsOut.Empty() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotPositiveRequireAmount, "coins out %s", coinsOut)
+	}
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	poolLiquidity := pool.GetTotalPoolLiquidity(ctx)
+	if !coinsOut.DenomsSubsetOf(poolLiquidity) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "coins out %s, pool liquidity %s", coinsOut, poolLiquidity)
+	}
+
+	// an exit of shares gives out floor(shares * (1 - exit fee) / total shares * liquidity) of each denom,
+	// so getting coin out needs at least coin * total shares / ((1 - exit fee) * liquidity) shares.
+	totalShares := pool.GetTotalShares()
+	exitFee := pool.GetExitFee(ctx)
+	oneSubExitFee := sdk.OneDec().Sub(exitFee)
+	sharesIn := sdk.OneInt()
+	for _, coin := range coinsOut {
+		neededShares := coin.Amount.ToDec().MulInt(totalShares).Quo(oneSubExitFee.MulInt(poolLiquidity.AmountOf(coin.Denom))).Ceil().TruncateInt()
+		sharesIn = sdk.MaxInt(sharesIn, neededShares)
+	}
+
+	// the bound is rounded to the Dec precision, and the exit rounds its share ratio down, so sharesIn is
+	// checked against the exit itself, and moved to the fewest shares that cover coinsOut if it's off.
+	covers := func(shares sdk.Int) bool {
+		if shares.GTE(totalShares) {
+			return false
+		}
+		exitCoins, err := pool.CalcExitPoolShares(ctx, shares, exitFee)
+		return err == nil && exitCoins.IsAllGTE(coinsOut)
+	}
+	if sharesIn.GT(sdk.OneInt()) && covers(sharesIn.SubRaw(1)) {
+		return sharesIn.SubRaw(1), nil
+	}
+	if covers(sharesIn) {
+		return sharesIn, nil
+	}
+	short, step := sharesIn, sdk.OneInt()
+	for {
+		sharesIn = short.Add(step)
+		if sharesIn.GTE(totalShares) {
+			sharesIn = totalShares.SubRaw(1)
+		}
+		if covers(sharesIn) {
+			break
+		}
+		if sharesIn.Equal(totalShares.SubRaw(1)) {
+			return sdk.Int{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut,
+				"getting %s out of pool %d with liquidity %s needs all of its %s shares", coinsOut, poolId, poolLiquidity, totalShares)
+		}
+		short, step = sharesIn, step.MulRaw(2)
+	}
+	// short doesn't cover coinsOut and sharesIn does, so the fewest shares that do are in (short, sharesIn].
+	for sharesIn.Sub(short).GT(sdk.OneInt()) {
+		mid := short.Add(sharesIn).QuoRaw(2)
+		if covers(mid) {
+			sharesIn = mid
+		} else {
+			short = mid
+		}
+	}
+	return sharesIn, nil
+}
+
+// ExitPool exits shareInAmount of sender's shares of the pool proportionally into the pool's assets,
+// withholding the pool's exit fee. The exit fee stays in the pool, unless the pool has an exit fee
+// recipient, see SetExitFeeRecipient, in which case the withheld coins are sent to the recipient.
+// tokenOutMins guards the exit against slippage: if any coin the sender would get is less than
+// its minimum in tokenOutMins, or tokenOutMins has a denom the exit doesn't return, the exit fails
+// with ErrLimitMinAmount and nothing changes.
 func (k Keeper) ExitPool(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -343,7 +653,13 @@ func (k Keeper) ExitPool(
 		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "share ratio is zero or negative")
 	}
 	exitFee := pool.GetExitFee(ctx)
-	exitCoins, err = pool.ExitPool(ctx, shareInAmount, exitFee)
+	exitFeeRecipient, hasExitFeeRecipient := k.GetExitFeeRecipient(ctx, poolId)
+	withheldCoins := sdk.Coins{}
+	if hasExitFeeRecipient {
+		exitCoins, withheldCoins, err = exitPoolToExitFeeRecipient(ctx, pool, shareInAmount, exitFee)
+	} else {
+		exitCoins, err = pool.ExitPool(ctx, shareInAmount, exitFee)
+	}
 	if err != nil {
 		return sdk.Coins{}, err
 	}
@@ -353,6 +669,14 @@ func (k Keeper) ExitPool(
 			exitCoins, tokenOutMins)
 	}
 
+	// the withheld coins are sent first, so that the AfterExitPool hook observes the exit fully applied.
+	if !withheldCoins.Empty() {
+		if err := k.bankKeeper.SendCoins(ctx, pool.GetAddress(), exitFeeRecipient, withheldCoins); err != nil {
+			return sdk.Coins{}, err
+		}
+		k.RecordTotalLiquidityDecrease(ctx, withheldCoins)
+	}
+
 	err = k.applyExitPoolStateChange(ctx, pool, sender, shareInAmount, exitCoins)
 	if err != nil {
 		return sdk.Coins{}, err
@@ -361,9 +685,12 @@ func (k Keeper) ExitPool(
 	return exitCoins, nil
 }
 
-// ExitSwapShareAmountIn is an Exit Pool transaction, that will exit all of the provided LP shares,
-// and then swap it all against the pool into tokenOutDenom.
-// If the amount of tokens gotten out after the swap is less than tokenOutMinAmount, return an error.
+// ExitSwapShareAmountIn is an Exit Pool transaction, that will exit all of the provided LP shares
+// into a single asset, tokenOutDenom.
+// Pools implementing types.PoolAmountOutExtension compute the single asset exit directly, returning
+// ErrTooManyTokensOut if the shares would withdraw all of tokenOutDenom from the pool.
+// Other pools exit proportionally, and then swap the other assets against the pool into tokenOutDenom.
+// If the amount of tokens gotten out is less than tokenOutMinAmount, return an error.
 func (k Keeper) ExitSwapShareAmountIn(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -371,6 +698,45 @@ func (k Keeper) ExitSwapShareAmountIn(
 	tokenOutDenom string,
 	shareInAmount sdk.Int,
 	tokenOutMinAmount sdk.Int,
+) (tokenOutAmount sdk.Int, err error) {
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	extendedPool, ok := pool.(types.PoolAmountOutExtension)
+	if !ok {
+		return k.exitPoolAndSwapShareAmountIn(ctx, sender, poolId, tokenOutDenom, shareInAmount, tokenOutMinAmount)
+	}
+
+	tokenOutAmount, err = extendedPool.ExitSwapShareAmountIn(ctx, tokenOutDenom, shareInAmount)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if tokenOutAmount.LT(tokenOutMinAmount) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMinAmount,
+			"Provided LP shares yield %s tokens out, wanted a minimum of %s for it to work",
+			tokenOutAmount, tokenOutMinAmount)
+	}
+
+	exitCoins := sdk.NewCoins(sdk.NewCoin(tokenOutDenom, tokenOutAmount))
+	if err := k.applyExitPoolStateChange(ctx, pool, sender, shareInAmount, exitCoins); err != nil {
+		return sdk.Int{}, err
+	}
+
+	return tokenOutAmount, nil
+}
+
+// exitPoolAndSwapShareAmountIn exits the provided LP shares proportionally,
+// and then swaps all the other exited assets against the pool into tokenOutDenom.
+func (k Keeper) exitPoolAndSwapShareAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenOutDenom string,
+	shareInAmount sdk.Int,
+	tokenOutMinAmount sdk.Int,
 ) (tokenOutAmount sdk.Int, err error) {
 	exitCoins, err := k.ExitPool(ctx, sender, poolId, shareInAmount, sdk.Coins{})
 	if err != nil {
@@ -396,6 +762,81 @@ func (k Keeper) ExitSwapShareAmountIn(
 	return tokenOutAmount, nil
 }
 
+// ExitPoolToDenoms exits shareInAmount of sender's shares of the pool into tokenOutDenoms, a subset of the pool's denoms.
+// The shares are exited proportionally, and each exited asset not in tokenOutDenoms is then split evenly
+// between tokenOutDenoms and swapped against the pool into them, so the exiter pays the swap fee on those swaps.
+// tokenOutMins guards the exit against slippage as in ExitPool, against the coins the sender finally gets.
+// Only those aggregate amounts are checked: the swaps of the split each only require 1 token out,
+// as their amounts out are added to the proportionally exited coins before being checked.
+func (k Keeper) ExitPoolToDenoms(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	shareInAmount sdk.Int,
+	tokenOutDenoms []string,
+	tokenOutMins sdk.Coins,
+) (exitCoins sdk.Coins, err error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Coins{}, err
+	}
+	if len(tokenOutDenoms) == 0 {
+		return sdk.Coins{}, sdkerrors.Wrap(types.ErrInvalidTokenOutDenoms, "no token out denoms")
+	}
+	poolLiquidity := pool.GetTotalPoolLiquidity(ctx)
+	isTokenOutDenom := map[string]bool{}
+	for _, denom := range tokenOutDenoms {
+		if !poolLiquidity.AmountOf(denom).IsPositive() {
+			return sdk.Coins{}, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "pool %d has no %s", poolId, denom)
+		}
+		if isTokenOutDenom[denom] {
+			return sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidTokenOutDenoms, "duplicate token out denom %s", denom)
+		}
+		isTokenOutDenom[denom] = true
+	}
+
+	// the exit and the swaps are applied on a cache context, that only gets written once the mins are met.
+	cacheCtx, write := ctx.CacheContext()
+	proportionalExitCoins, err := k.ExitPool(cacheCtx, sender, poolId, shareInAmount, sdk.Coins{})
+	if err != nil {
+		return sdk.Coins{}, err
+	}
+	exitCoins = sdk.Coins{}
+	for _, coin := range proportionalExitCoins {
+		if isTokenOutDenom[coin.Denom] {
+			exitCoins = exitCoins.Add(coin)
+			continue
+		}
+
+		// the last token out denom gets the remainder of the even split.
+		splitAmount := coin.Amount.QuoRaw(int64(len(tokenOutDenoms)))
+		for i, tokenOutDenom := range tokenOutDenoms {
+			tokenInAmount := splitAmount
+			if i == len(tokenOutDenoms)-1 {
+				tokenInAmount = coin.Amount.Sub(splitAmount.MulRaw(int64(i)))
+			}
+			if !tokenInAmount.IsPositive() {
+				continue
+			}
+			tokenOutAmount, err := k.SwapExactAmountIn(cacheCtx, sender, poolId, sdk.NewCoin(coin.Denom, tokenInAmount), tokenOutDenom, sdk.OneInt())
+			if err != nil {
+				return sdk.Coins{}, err
+			}
+			exitCoins = exitCoins.Add(sdk.NewCoin(tokenOutDenom, tokenOutAmount))
+		}
+	}
+
+	if !tokenOutMins.DenomsSubsetOf(exitCoins) || tokenOutMins.IsAnyGT(exitCoins) {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrLimitMinAmount,
+			"Exit pool returned %s , minimum tokens out specified as %s",
+			exitCoins, tokenOutMins)
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return exitCoins, nil
+}
+
 func (k Keeper) ExitSwapExactAmountOut(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	balancertypes "github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
@@ -88,9 +91,13 @@ func (suite *KeeperTestSuite) TestCreateBalancerPool() {
 					Sub(sdk.Coins{
 						sdk.NewCoin("bar", sdk.NewInt(10000)),
 						sdk.NewCoin("foo", sdk.NewInt(10000)),
-					}).Add(sdk.NewCoin(types.GetPoolShareDenom(pool.GetId()), types.InitPoolSharesSupply)).String(),
+					}).Add(sdk.NewCoin(types.GetPoolShareDenom(pool.GetId()), types.InitPoolSharesSupply.Sub(types.MinimumLiquidityShares))).String(),
 			)
 
+			// check the minimum liquidity shares are locked
+			suite.Require().Equal(types.MinimumLiquidityShares,
+				suite.App.BankKeeper.GetBalance(suite.Ctx, types.MinimumLiquidityAddress, types.GetPoolShareDenom(pool.GetId())).Amount)
+
 			liquidity := suite.App.GAMMKeeper.GetTotalLiquidity(suite.Ctx)
 			suite.Require().Equal("10000bar,10000foo", liquidity.String())
 		},
@@ -114,6 +121,26 @@ func (suite *KeeperTestSuite) TestCreateBalancerPool() {
 			_, err := keeper.CreatePool(suite.Ctx, msg)
 			suite.Require().Error(err, "can't create a pool with negative exit fee")
 		},
+	}, {
+		fn: func() {
+			keeper := suite.App.GAMMKeeper
+			msg := balancer.NewMsgCreateBalancerPool(suite.TestAccs[0], balancer.PoolParams{
+				SwapFee: sdk.OneDec(),
+				ExitFee: sdk.NewDecWithPrec(1, 2),
+			}, defaultPoolAssets, defaultFutureGovernor)
+			_, err := keeper.CreatePool(suite.Ctx, msg)
+			suite.Require().ErrorIs(err, types.ErrTooMuchSwapFee, "can't create a pool with a swap fee of 1")
+		},
+	}, {
+		fn: func() {
+			keeper := suite.App.GAMMKeeper
+			msg := balancer.NewMsgCreateBalancerPool(suite.TestAccs[0], balancer.PoolParams{
+				SwapFee: sdk.NewDecWithPrec(1, 2),
+				ExitFee: sdk.OneDec(),
+			}, defaultPoolAssets, defaultFutureGovernor)
+			_, err := keeper.CreatePool(suite.Ctx, msg)
+			suite.Require().ErrorIs(err, types.ErrTooMuchExitFee, "can't create a pool with an exit fee of 1")
+		},
 	}, {
 		fn: func() {
 			keeper := suite.App.GAMMKeeper
@@ -210,9 +237,7 @@ func (suite *KeeperTestSuite) TestCreateBalancerPool() {
 	}, {
 		fn: func() {
 			keeper := suite.App.GAMMKeeper
-			keeper.SetParams(suite.Ctx, types.Params{
-				PoolCreationFee: sdk.Coins{},
-			})
+			keeper.SetParams(suite.Ctx, types.NewParams(sdk.Coins{}, types.DefaultMaxPoolAssets, types.DefaultCircuitBreakerThreshold, types.DefaultProtocolFeeShare))
 			msg := balancer.NewMsgCreateBalancerPool(suite.TestAccs[0], balancer.PoolParams{
 				SwapFee: sdk.NewDecWithPrec(1, 2),
 				ExitFee: sdk.NewDecWithPrec(1, 2),
@@ -226,9 +251,7 @@ func (suite *KeeperTestSuite) TestCreateBalancerPool() {
 	}, {
 		fn: func() {
 			keeper := suite.App.GAMMKeeper
-			keeper.SetParams(suite.Ctx, types.Params{
-				PoolCreationFee: nil,
-			})
+			keeper.SetParams(suite.Ctx, types.NewParams(nil, types.DefaultMaxPoolAssets, types.DefaultCircuitBreakerThreshold, types.DefaultProtocolFeeShare))
 			msg := balancer.NewMsgCreateBalancerPool(suite.TestAccs[0], balancer.PoolParams{
 				SwapFee: sdk.NewDecWithPrec(1, 2),
 				ExitFee: sdk.NewDecWithPrec(1, 2),
@@ -253,6 +276,260 @@ func (suite *KeeperTestSuite) TestCreateBalancerPool() {
 	}
 }
 
+// TestCreatePoolWithInitialReserves tests that creating a pool moves the initial reserves into the pool account,
+// and mints the initial shares to the creator, except for the locked minimum liquidity shares.
+func (suite *KeeperTestSuite) TestCreatePoolWithInitialReserves() {
+	bazAsset := balancertypes.PoolAsset{Weight: sdk.NewInt(300), Token: sdk.NewCoin("baz", sdk.NewInt(30000))}
+	tests := []struct {
+		name        string
+		poolAssets  []balancertypes.PoolAsset
+		expectedErr bool
+	}{
+		{name: "2 assets", poolAssets: defaultPoolAssets},
+		{name: "3 assets", poolAssets: []balancertypes.PoolAsset{defaultFooAsset, defaultBarAsset, bazAsset}},
+		{name: "1 asset", poolAssets: []balancertypes.PoolAsset{defaultFooAsset}, expectedErr: true},
+		{
+			name:        "zero balance",
+			poolAssets:  []balancertypes.PoolAsset{defaultFooAsset, {Weight: sdk.NewInt(100), Token: sdk.NewCoin("bar", sdk.ZeroInt())}},
+			expectedErr: true,
+		},
+		{
+			name:        "zero weight",
+			poolAssets:  []balancertypes.PoolAsset{defaultFooAsset, {Weight: sdk.ZeroInt(), Token: defaultBarAsset.Token}},
+			expectedErr: true,
+		},
+		{
+			name:        "weight too large",
+			poolAssets:  []balancertypes.PoolAsset{defaultFooAsset, {Weight: balancertypes.MaxUserSpecifiedWeight, Token: defaultBarAsset.Token}},
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate asset",
+			poolAssets:  []balancertypes.PoolAsset{defaultFooAsset, defaultFooAsset},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			creator := suite.TestAccs[0]
+			suite.FundAcc(creator, defaultAcctFunds)
+			creatorBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, creator)
+
+			msg := balancer.NewMsgCreateBalancerPool(creator, defaultPoolParams, test.poolAssets, defaultFutureGovernor)
+			poolId, err := keeper.CreatePool(suite.Ctx, msg)
+			if test.expectedErr {
+				suite.Require().Error(err)
+				pools, err := keeper.GetPoolsAndPoke(suite.Ctx)
+				suite.Require().NoError(err)
+				suite.Require().Empty(pools)
+				suite.Require().Equal(creatorBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, creator))
+				return
+			}
+			suite.Require().NoError(err)
+
+			initialReserves := sdk.Coins{}
+			for _, asset := range test.poolAssets {
+				initialReserves = initialReserves.Add(asset.Token)
+			}
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(initialReserves, pool.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(initialReserves, suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()))
+			suite.Require().Equal(initialReserves, keeper.GetTotalLiquidity(suite.Ctx))
+			for _, asset := range test.poolAssets {
+				weight, err := pool.(*balancer.Pool).GetTokenWeight(asset.Token.Denom)
+				suite.Require().NoError(err)
+				suite.Require().Equal(asset.Weight.MulRaw(balancertypes.GuaranteedWeightPrecision), weight)
+			}
+
+			shareDenom := types.GetPoolShareDenom(poolId)
+			suite.Require().Equal(types.InitPoolSharesSupply, pool.GetTotalShares())
+			suite.Require().Equal(types.InitPoolSharesSupply, suite.App.BankKeeper.GetSupply(suite.Ctx, shareDenom).Amount)
+			suite.Require().Equal(types.InitPoolSharesSupply.Sub(types.MinimumLiquidityShares),
+				suite.App.BankKeeper.GetBalance(suite.Ctx, creator, shareDenom).Amount)
+			suite.Require().Equal(types.MinimumLiquidityShares,
+				suite.App.BankKeeper.GetBalance(suite.Ctx, types.MinimumLiquidityAddress, shareDenom).Amount)
+
+			creatorBalancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, creator)
+			suite.Require().Equal(creatorBalancesBefore.Sub(keeper.GetParams(suite.Ctx).PoolCreationFee).Sub(initialReserves),
+				creatorBalancesAfter.Sub(sdk.NewCoins(sdk.NewCoin(shareDenom, creatorBalancesAfter.AmountOf(shareDenom)))))
+		})
+	}
+}
+
+// TestCreatePoolMaxPoolAssets tests that pools can be created with up to the MaxPoolAssets param of assets, but not more.
+func (suite *KeeperTestSuite) TestCreatePoolMaxPoolAssets() {
+	poolAssets := func(numAssets int) []balancertypes.PoolAsset {
+		assets := []balancertypes.PoolAsset{}
+		for i := 0; i < numAssets; i++ {
+			assets = append(assets, balancertypes.PoolAsset{Weight: sdk.NewInt(100), Token: sdk.NewInt64Coin(fmt.Sprintf("asset%02d", i), 10000)})
+		}
+		return assets
+	}
+	tests := []struct {
+		name          string
+		maxPoolAssets uint64
+		numAssets     int
+		expectedErr   error
+	}{
+		{name: "default max assets", numAssets: types.DefaultMaxPoolAssets},
+		{name: "default max assets + 1", numAssets: types.DefaultMaxPoolAssets + 1, expectedErr: types.ErrTooManyPoolAssets},
+		{name: "lowered max assets", maxPoolAssets: 3, numAssets: 3},
+		{name: "lowered max assets + 1", maxPoolAssets: 3, numAssets: 4, expectedErr: types.ErrTooManyPoolAssets},
+		{name: "raised max assets", maxPoolAssets: 10, numAssets: 10},
+		{name: "raised max assets + 1", maxPoolAssets: 10, numAssets: 11, expectedErr: types.ErrTooManyPoolAssets},
+		{name: "max assets limit", maxPoolAssets: types.MaxPoolAssetsLimit, numAssets: types.MaxPoolAssetsLimit},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			creator := suite.TestAccs[0]
+			expectedMaxPoolAssets := uint64(types.DefaultMaxPoolAssets)
+			if test.maxPoolAssets != 0 {
+				suite.Require().NoError(keeper.SetMaxPoolAssets(suite.Ctx, test.maxPoolAssets))
+				expectedMaxPoolAssets = test.maxPoolAssets
+			}
+			suite.Require().Equal(expectedMaxPoolAssets, keeper.GetMaxPoolAssets(suite.Ctx))
+
+			assets := poolAssets(test.numAssets)
+			funds := sdk.Coins{}
+			for _, asset := range assets {
+				funds = funds.Add(asset.Token)
+			}
+			suite.FundAcc(creator, funds.Add(defaultAcctFunds...))
+
+			msg := balancer.NewMsgCreateBalancerPool(creator, defaultPoolParams, assets, defaultFutureGovernor)
+			_, err := keeper.CreatePool(suite.Ctx, msg)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+		})
+	}
+
+	suite.SetupTest()
+	suite.Require().Error(suite.App.GAMMKeeper.SetMaxPoolAssets(suite.Ctx, types.MinPoolAssets-1))
+	suite.Require().Error(suite.App.GAMMKeeper.SetMaxPoolAssets(suite.Ctx, types.MaxPoolAssetsLimit+1))
+	suite.Require().Equal(uint64(types.DefaultMaxPoolAssets), suite.App.GAMMKeeper.GetMaxPoolAssets(suite.Ctx))
+
+	// messages with more assets than the limit are invalid, whatever the param.
+	msg := balancer.NewMsgCreateBalancerPool(suite.TestAccs[0], defaultPoolParams, poolAssets(types.MaxPoolAssetsLimit+1), defaultFutureGovernor)
+	suite.Require().ErrorIs(msg.ValidateBasic(), types.ErrTooManyPoolAssets)
+}
+
+// TestMigrate2to3 tests that the migration to version 3 sets the params added to Params to their defaults,
+// keeping the params that are already set.
+func (suite *KeeperTestSuite) TestMigrate2to3() {
+	suite.SetupTest()
+	gammKeeper := suite.App.GAMMKeeper
+	threshold := sdk.NewDecWithPrec(2, 1)
+	suite.Require().NoError(gammKeeper.SetCircuitBreakerThreshold(suite.Ctx, threshold))
+	paramStore := prefix.NewStore(suite.Ctx.KVStore(suite.App.GetKey(paramstypes.StoreKey)), []byte(types.ModuleName+"/"))
+	paramStore.Delete(types.KeyMaxPoolAssets)
+	paramStore.Delete(types.KeyProtocolFeeShare)
+
+	err := keeper.NewMigrator(*gammKeeper).Migrate2to3(suite.Ctx)
+	suite.Require().NoError(err)
+	expectedParams := types.DefaultParams()
+	expectedParams.CircuitBreakerThreshold = threshold
+	suite.Require().Equal(expectedParams, gammKeeper.GetParams(suite.Ctx))
+}
+
+// TestCalcNumSharesOutFromExactCoins tests that the remainder of non-proportional tokens in is what
+// JoinPoolNoSwap for the calculated shares leaves with the sender.
+func (suite *KeeperTestSuite) TestCalcNumSharesOutFromExactCoins() {
+	tests := []struct {
+		name              string
+		tokensIn          sdk.Coins
+		expectedSharesOut sdk.Int
+		expectedRemainder sdk.Coins
+		expectedErr       error
+	}{
+		{
+			name:              "proportional",
+			tokensIn:          sdk.NewCoins(sdk.NewInt64Coin("foo", 10000), sdk.NewInt64Coin("bar", 10000), sdk.NewInt64Coin("baz", 10000)),
+			expectedSharesOut: types.InitPoolSharesSupply.QuoRaw(500),
+		},
+		{
+			name:              "non-proportional",
+			tokensIn:          sdk.NewCoins(sdk.NewInt64Coin("foo", 10000), sdk.NewInt64Coin("bar", 25000), sdk.NewInt64Coin("baz", 12345)),
+			expectedSharesOut: types.InitPoolSharesSupply.QuoRaw(500),
+			expectedRemainder: sdk.NewCoins(sdk.NewInt64Coin("bar", 15000), sdk.NewInt64Coin("baz", 2345)),
+		},
+		{
+			name:              "non-proportional, fewest bar",
+			tokensIn:          sdk.NewCoins(sdk.NewInt64Coin("foo", 33333), sdk.NewInt64Coin("bar", 7777), sdk.NewInt64Coin("baz", 100000)),
+			expectedSharesOut: sdk.NewInt(155540000000000000),
+			expectedRemainder: sdk.NewCoins(sdk.NewInt64Coin("foo", 25556), sdk.NewInt64Coin("baz", 92223)),
+		},
+		{
+			name:        "missing a pool denom",
+			tokensIn:    sdk.NewCoins(sdk.NewInt64Coin("foo", 10000), sdk.NewInt64Coin("bar", 10000)),
+			expectedErr: types.ErrInvalidMathApprox,
+		},
+		{
+			name:        "denom not in pool",
+			tokensIn:    sdk.NewCoins(sdk.NewInt64Coin("foo", 10000), sdk.NewInt64Coin("bar", 10000), sdk.NewInt64Coin("baz", 10000), sdk.NewInt64Coin("uatom", 10000)),
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPool()
+			sender := sdk.AccAddress([]byte("exact_coins_joiner__"))
+
+			sharesOut, remainder, err := keeper.CalcNumSharesOutFromExactCoins(suite.Ctx, poolId, test.tokensIn)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedSharesOut, sharesOut)
+			suite.Require().Equal(test.expectedRemainder, remainder)
+
+			// a join with exactly tokensIn leaves the remainder with the sender.
+			suite.FundAcc(sender, test.tokensIn)
+			err = keeper.JoinPoolNoSwap(suite.Ctx, sender, poolId, sharesOut, test.tokensIn)
+			suite.Require().NoError(err)
+			shares := suite.App.BankKeeper.GetBalance(suite.Ctx, sender, types.GetPoolShareDenom(poolId))
+			suite.Require().True(shares.Amount.GTE(sharesOut))
+			suite.Require().Equal(remainder, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender).Sub(sdk.NewCoins(shares)))
+		})
+	}
+}
+
+// TestCreatePoolShareDenomMetadata tests that creating a pool registers the metadata of its share denom,
+// named after the pool's assets.
+func (suite *KeeperTestSuite) TestCreatePoolShareDenomMetadata() {
+	suite.SetupTest()
+	ibcDenom := "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin(ibcDenom, 1000000), sdk.NewInt64Coin("uosmo", 1000000))
+
+	metadata, found := suite.App.BankKeeper.GetDenomMetaData(suite.Ctx, types.GetPoolShareDenom(poolId))
+	suite.Require().True(found)
+	suite.Require().NoError(metadata.Validate())
+	suite.Require().Equal("27394FB0/UOSMO LP", metadata.Symbol)
+	suite.Require().Equal(fmt.Sprintf("GAMM-%d %s/uosmo pool share", poolId, ibcDenom), metadata.Name)
+	suite.Require().Equal(fmt.Sprintf("GAMM-%d", poolId), metadata.Display)
+	suite.Require().Equal(types.GetPoolShareDenom(poolId), metadata.Base)
+	suite.Require().Len(metadata.DenomUnits, 2)
+	suite.Require().Equal(uint32(types.OneShareExponent), metadata.DenomUnits[1].Exponent)
+
+	poolId = suite.PrepareBalancerPool()
+	metadata, found = suite.App.BankKeeper.GetDenomMetaData(suite.Ctx, types.GetPoolShareDenom(poolId))
+	suite.Require().True(found)
+	suite.Require().Equal("BAR/BAZ/FOO LP", metadata.Symbol)
+}
+
 // TODO: Add more edge cases around TokenInMaxs not containing every token in pool.
 func (suite *KeeperTestSuite) TestJoinPoolNoSwap() {
 	tests := []struct {
@@ -338,6 +615,57 @@ func (suite *KeeperTestSuite) TestJoinPoolNoSwap() {
 	}
 }
 
+// TestJoinPoolNoSwapMaxAmountsInAfterShift tests that an LP joining with the maximum amounts in
+// quoted before the pool's reserves were shifted, e.g. by a front-run, is rejected.
+func (suite *KeeperTestSuite) TestJoinPoolNoSwapMaxAmountsInAfterShift() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	lp, attacker := suite.TestAccs[1], suite.TestAccs[2]
+	suite.FundAcc(lp, sdk.NewCoins(sdk.NewInt64Coin("foo", 10000000), sdk.NewInt64Coin("bar", 10000000), sdk.NewInt64Coin("baz", 10000000)))
+	suite.FundAcc(attacker, sdk.NewCoins(sdk.NewInt64Coin("foo", 5000000)))
+	// 10 of the pool's 100 shares need 10% of each reserve.
+	joiningShares := types.OneShare.MulRaw(10)
+	tokenInMaxs := sdk.NewCoins(sdk.NewInt64Coin("foo", 500000), sdk.NewInt64Coin("bar", 500000), sdk.NewInt64Coin("baz", 500000))
+
+	cacheCtx, _ := suite.Ctx.CacheContext()
+	suite.Require().NoError(keeper.JoinPoolNoSwap(cacheCtx, lp, poolId, joiningShares, tokenInMaxs))
+
+	// the attacker swaps foo into the pool, so that the shares need more foo.
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, attacker, poolId, sdk.NewInt64Coin("foo", 5000000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	lpBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, lp)
+	tests := []struct {
+		name        string
+		tokenInMaxs sdk.Coins
+	}{
+		{name: "maximums quoted before the shift", tokenInMaxs: tokenInMaxs},
+		{name: "maximums missing a pool denom", tokenInMaxs: sdk.NewCoins(sdk.NewInt64Coin("foo", 10000000), sdk.NewInt64Coin("bar", 10000000))},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			err := keeper.JoinPoolNoSwap(suite.Ctx, lp, poolId, joiningShares, test.tokenInMaxs)
+			suite.Require().ErrorIs(err, types.ErrLimitMaxAmount)
+			// the rejected join didn't take the LP's tokens, or mint them shares.
+			suite.Require().Equal(lpBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, lp))
+		})
+	}
+
+	// the join goes through with maximums covering the shifted reserves, and takes more foo than before.
+	shiftedTokenInMaxs := sdk.NewCoins(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 500000), sdk.NewInt64Coin("baz", 500000))
+	suite.Require().NoError(keeper.JoinPoolNoSwap(suite.Ctx, lp, poolId, joiningShares, shiftedTokenInMaxs))
+	lpBalancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, lp)
+	for _, tokenInMax := range shiftedTokenInMaxs {
+		tokenJoined := lpBalancesBefore.AmountOf(tokenInMax.Denom).Sub(lpBalancesAfter.AmountOf(tokenInMax.Denom))
+		suite.Require().True(tokenJoined.LTE(tokenInMax.Amount))
+		if tokenInMax.Denom == "foo" {
+			suite.Require().True(tokenJoined.GT(tokenInMaxs.AmountOf("foo")))
+		}
+	}
+	suite.Require().True(lpBalancesAfter.AmountOf(types.GetPoolShareDenom(poolId)).GTE(joiningShares))
+}
+
 func (suite *KeeperTestSuite) TestExitPool() {
 	tests := []struct {
 		fn func(poolId uint64)
@@ -357,8 +685,8 @@ func (suite *KeeperTestSuite) TestExitPool() {
 				balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
 				_, err := keeper.ExitPool(suite.Ctx, suite.TestAccs[0], poolId, types.InitPoolSharesSupply.QuoRaw(2), sdk.Coins{})
 				suite.Require().NoError(err)
-				// (100 - 50) * OneShare should remain.
-				suite.Require().Equal(types.InitPoolSharesSupply.QuoRaw(2).String(), suite.App.BankKeeper.GetBalance(suite.Ctx, suite.TestAccs[0], "gamm/pool/1").Amount.String())
+				// (100 - 50) * OneShare, less the minimum liquidity shares, should remain.
+				suite.Require().Equal(types.InitPoolSharesSupply.QuoRaw(2).Sub(types.MinimumLiquidityShares).String(), suite.App.BankKeeper.GetBalance(suite.Ctx, suite.TestAccs[0], "gamm/pool/1").Amount.String())
 				balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
 
 				deltaBalances, _ := balancesBefore.SafeSub(balancesAfter)
@@ -430,6 +758,407 @@ func (suite *KeeperTestSuite) TestExitPool() {
 	}
 }
 
+// TestExitPoolTooSmall tests that exiting too few shares to get any tokens out,
+// here due to a 99% exit fee, errors rather than burning the shares for nothing.
+func (suite *KeeperTestSuite) TestExitPoolTooSmall() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.ZeroDec(),
+		ExitFee: sdk.NewDecWithPrec(99, 2),
+	})
+
+	sender := suite.TestAccs[0]
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+	_, err := suite.App.GAMMKeeper.ExitPool(suite.Ctx, sender, poolId, sdk.OneInt(), sdk.Coins{})
+	suite.Require().ErrorIs(err, types.ErrExitTooSmall)
+	suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+}
+
+// TestExitPoolMinAmountsOutAfterDrain tests that an LP exiting with the minimum amounts out
+// quoted before a reserve of the pool was drained, e.g. by a sandwich, is rejected.
+func (suite *KeeperTestSuite) TestExitPoolMinAmountsOutAfterDrain() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPool()
+	lp, attacker := suite.TestAccs[0], suite.TestAccs[1]
+	suite.FundAcc(attacker, sdk.NewCoins(sdk.NewInt64Coin("foo", 20000000)))
+	exitingShares := types.OneShare.MulRaw(10)
+	shareDenom := types.GetPoolShareDenom(poolId)
+
+	tokenOutMins, err := keeper.CalcExitPoolCoins(suite.Ctx, poolId, exitingShares)
+	suite.Require().NoError(err)
+
+	// the attacker drains the bar reserve, by swapping a lot of foo into the pool.
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, attacker, poolId, sdk.NewInt64Coin("foo", 20000000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	drainedCoins, err := keeper.CalcExitPoolCoins(suite.Ctx, poolId, exitingShares)
+	suite.Require().NoError(err)
+	suite.Require().True(drainedCoins.AmountOf("bar").LT(tokenOutMins.AmountOf("bar")))
+
+	lpBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, lp)
+	tests := []struct {
+		name         string
+		tokenOutMins sdk.Coins
+	}{
+		{name: "minimums quoted before the drain", tokenOutMins: tokenOutMins},
+		{name: "minimum of the drained denom only", tokenOutMins: sdk.NewCoins(sdk.NewCoin("bar", tokenOutMins.AmountOf("bar")))},
+		{name: "minimum of a denom not in the pool", tokenOutMins: sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1))},
+	}
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			_, err := keeper.ExitPool(suite.Ctx, lp, poolId, exitingShares, test.tokenOutMins)
+			suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+			// the rejected exit didn't burn the LP's shares, or send them anything.
+			suite.Require().Equal(lpBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, lp))
+		})
+	}
+
+	// the exit goes through with minimums the drained pool meets.
+	exitCoins, err := keeper.ExitPool(suite.Ctx, lp, poolId, exitingShares, drainedCoins)
+	suite.Require().NoError(err)
+	suite.Require().Equal(drainedCoins, exitCoins)
+	suite.Require().Equal(lpBalancesBefore.AmountOf(shareDenom).Sub(exitingShares).String(),
+		suite.App.BankKeeper.GetBalance(suite.Ctx, lp, shareDenom).Amount.String())
+}
+
+func (suite *KeeperTestSuite) TestExitPoolExitFeeRecipient() {
+	exitFee := sdk.NewDecWithPrec(1, 2)
+	exitingShares := types.InitPoolSharesSupply.QuoRaw(2)
+
+	tests := []struct {
+		name                      string
+		setRecipient              bool
+		expectWithheldAtRecipient bool
+	}{
+		{
+			name:         "exit fee stays in pool by default",
+			setRecipient: false,
+		},
+		{
+			name:                      "exit fee is sent to the recipient",
+			setRecipient:              true,
+			expectWithheldAtRecipient: true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.ZeroDec(),
+				ExitFee: exitFee,
+			})
+			keeper := suite.App.GAMMKeeper
+			sender, recipient := suite.TestAccs[0], suite.TestAccs[1]
+			if test.setRecipient {
+				suite.Require().NoError(keeper.SetExitFeeRecipient(suite.Ctx, poolId, recipient))
+			}
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			liquidityBefore := pool.GetTotalPoolLiquidity(suite.Ctx)
+			allCoins, err := pool.CalcExitPoolShares(suite.Ctx, exitingShares, sdk.ZeroDec())
+			suite.Require().NoError(err)
+			expectedExitCoins, err := pool.CalcExitPoolShares(suite.Ctx, exitingShares, exitFee)
+			suite.Require().NoError(err)
+			withheldCoins := allCoins.Sub(expectedExitCoins)
+			suite.Require().False(withheldCoins.Empty())
+			recipientBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, recipient)
+
+			exitCoins, err := keeper.ExitPool(suite.Ctx, sender, poolId, exitingShares, sdk.Coins{})
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedExitCoins, exitCoins)
+
+			pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			recipientBalancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, recipient)
+			if test.expectWithheldAtRecipient {
+				suite.Require().Equal(recipientBalancesBefore.Add(withheldCoins...), recipientBalancesAfter)
+				suite.Require().Equal(liquidityBefore.Sub(allCoins), pool.GetTotalPoolLiquidity(suite.Ctx))
+			} else {
+				suite.Require().Equal(recipientBalancesBefore, recipientBalancesAfter)
+				suite.Require().Equal(liquidityBefore.Sub(exitCoins), pool.GetTotalPoolLiquidity(suite.Ctx))
+			}
+			suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx), suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()))
+		})
+	}
+}
+
+// TestSingleAssetExitExitFeeRecipient tests that single asset exits leave their exit fee in the pool,
+// even for a pool with an exit fee recipient.
+func (suite *KeeperTestSuite) TestSingleAssetExitExitFeeRecipient() {
+	tests := []struct {
+		name string
+		exit func(k *keeper.Keeper, sender sdk.AccAddress, poolId uint64) (sdk.Coin, error)
+	}{
+		{
+			name: "ExitSwapShareAmountIn",
+			exit: func(k *keeper.Keeper, sender sdk.AccAddress, poolId uint64) (sdk.Coin, error) {
+				tokenOutAmount, err := k.ExitSwapShareAmountIn(suite.Ctx, sender, poolId, "foo", types.OneShare.MulRaw(10), sdk.OneInt())
+				return sdk.NewCoin("foo", tokenOutAmount), err
+			},
+		},
+		{
+			name: "ExitSwapExactAmountOut",
+			exit: func(k *keeper.Keeper, sender sdk.AccAddress, poolId uint64) (sdk.Coin, error) {
+				tokenOut := sdk.NewInt64Coin("foo", 10000)
+				_, err := k.ExitSwapExactAmountOut(suite.Ctx, sender, poolId, tokenOut, types.InitPoolSharesSupply)
+				return tokenOut, err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.ZeroDec(),
+				ExitFee: sdk.NewDecWithPrec(1, 2),
+			})
+			gammKeeper := suite.App.GAMMKeeper
+			sender, recipient := suite.TestAccs[0], suite.TestAccs[1]
+			suite.Require().NoError(gammKeeper.SetExitFeeRecipient(suite.Ctx, poolId, recipient))
+			pool, err := gammKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			liquidityBefore := pool.GetTotalPoolLiquidity(suite.Ctx)
+			recipientBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, recipient)
+
+			tokenOut, err := test.exit(gammKeeper, sender, poolId)
+			suite.Require().NoError(err)
+
+			// only the coins of the exiter leave the pool, and the recipient gets nothing.
+			pool, err = gammKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(liquidityBefore.Sub(sdk.NewCoins(tokenOut)), pool.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx), suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()))
+			suite.Require().Equal(recipientBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, recipient))
+		})
+	}
+}
+
+// TestMinimumLiquidityPreventsDonationAttack tests that the pool creator can't exit the minimum liquidity shares,
+// and that a later joiner isn't diluted when the creator exits all but a single share and donates tokens to the pool.
+func (suite *KeeperTestSuite) TestMinimumLiquidityPreventsDonationAttack() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.ZeroDec(),
+		ExitFee: sdk.ZeroDec(),
+	})
+	keeper := suite.App.GAMMKeeper
+	attacker, joiner := suite.TestAccs[0], suite.TestAccs[1]
+	shareDenom := types.GetPoolShareDenom(poolId)
+
+	// the attacker exits all of its shares but one.
+	attackerShares := suite.App.BankKeeper.GetBalance(suite.Ctx, attacker, shareDenom).Amount
+	suite.Require().Equal(types.InitPoolSharesSupply.Sub(types.MinimumLiquidityShares), attackerShares)
+	_, err := keeper.ExitPool(suite.Ctx, attacker, poolId, attackerShares.SubRaw(1), sdk.Coins{})
+	suite.Require().NoError(err)
+
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.MinimumLiquidityShares.AddRaw(1), pool.GetTotalShares())
+
+	// and donates tokens to the pool's address.
+	donation := sdk.NewCoins(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000), sdk.NewInt64Coin("baz", 1000000))
+	suite.Require().NoError(suite.App.BankKeeper.SendCoins(suite.Ctx, attacker, pool.GetAddress(), donation))
+
+	// the joiner joins for 1000 times the locked shares, and exits all of them again.
+	suite.FundAcc(joiner, sdk.NewCoins(sdk.NewInt64Coin("foo", 10000), sdk.NewInt64Coin("bar", 10000), sdk.NewInt64Coin("baz", 10000)))
+	joinerBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, joiner)
+	err = keeper.JoinPoolNoSwap(suite.Ctx, joiner, poolId, types.MinimumLiquidityShares.MulRaw(1000), sdk.Coins{})
+	suite.Require().NoError(err)
+	joinerBalancesJoined := suite.App.BankKeeper.GetAllBalances(suite.Ctx, joiner)
+	joinerShares := joinerBalancesJoined.AmountOf(shareDenom)
+	suite.Require().True(joinerShares.GTE(types.MinimumLiquidityShares.MulRaw(1000)))
+	_, err = keeper.ExitPool(suite.Ctx, joiner, poolId, joinerShares, sdk.Coins{})
+	suite.Require().NoError(err)
+
+	joinerBalancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, joiner)
+	for _, denom := range []string{"foo", "bar", "baz"} {
+		deposited := joinerBalancesBefore.AmountOf(denom).Sub(joinerBalancesJoined.AmountOf(denom))
+		suite.Require().True(deposited.IsPositive())
+		withdrawn := joinerBalancesAfter.AmountOf(denom).Sub(joinerBalancesJoined.AmountOf(denom))
+		// the joiner gets back all but the rounding of the exit.
+		suite.Require().True(withdrawn.MulRaw(100).GTE(deposited.MulRaw(99)), "deposited %s%s, withdrew %s%s", deposited, denom, withdrawn, denom)
+	}
+
+	// the attacker's last share can't exit, as the minimum liquidity shares remain in the pool.
+	_, err = keeper.ExitPool(suite.Ctx, attacker, poolId, sdk.OneInt(), sdk.Coins{})
+	suite.Require().ErrorIs(err, types.ErrExitTooSmall)
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().True(pool.GetTotalShares().GT(types.MinimumLiquidityShares))
+}
+
+// TestCalcExitPoolCoins tests that the exit estimate matches the coins given out by an actual exit.
+func (suite *KeeperTestSuite) TestCalcExitPoolCoins() {
+	testCases := []struct {
+		name          string
+		exitFee       sdk.Dec
+		setRecipient  bool
+		exitingShares sdk.Int
+		expectedErr   error
+	}{
+		{
+			name:          "no exit fee",
+			exitFee:       sdk.ZeroDec(),
+			exitingShares: types.OneShare.MulRaw(33),
+		},
+		{
+			name:          "exit fee",
+			exitFee:       sdk.NewDecWithPrec(3, 2),
+			exitingShares: types.OneShare.MulRaw(33),
+		},
+		{
+			name:          "exit fee sent to a recipient",
+			exitFee:       sdk.NewDecWithPrec(3, 2),
+			setRecipient:  true,
+			exitingShares: types.OneShare.MulRaw(33),
+		},
+		{
+			name:          "odd amount of shares",
+			exitFee:       sdk.NewDecWithPrec(1, 2),
+			exitingShares: sdk.NewInt(123456789123456789),
+		},
+		{
+			name:          "zero shares",
+			exitFee:       sdk.ZeroDec(),
+			exitingShares: sdk.ZeroInt(),
+			expectedErr:   types.ErrInvalidMathApprox,
+		},
+		{
+			name:          "all shares",
+			exitFee:       sdk.ZeroDec(),
+			exitingShares: types.InitPoolSharesSupply,
+			expectedErr:   types.ErrInvalidMathApprox,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.ZeroDec(),
+				ExitFee: tc.exitFee,
+			})
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+			if tc.setRecipient {
+				suite.Require().NoError(keeper.SetExitFeeRecipient(suite.Ctx, poolId, suite.TestAccs[1]))
+			}
+
+			estimatedCoins, err := keeper.CalcExitPoolCoins(suite.Ctx, poolId, tc.exitingShares)
+			if tc.expectedErr != nil {
+				suite.Require().ErrorIs(err, tc.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			exitCoins, err := keeper.ExitPool(suite.Ctx, sender, poolId, tc.exitingShares, sdk.Coins{})
+			suite.Require().NoError(err)
+			suite.Require().Equal(exitCoins, estimatedCoins)
+		})
+	}
+}
+
+// TestCalcSharesForExactCoinsOut tests that exiting the computed shares gives out at least the requested coins,
+// and that one share less doesn't.
+func (suite *KeeperTestSuite) TestCalcSharesForExactCoinsOut() {
+	testCases := []struct {
+		name         string
+		exitFee      sdk.Dec
+		setRecipient bool
+		coinsOut     sdk.Coins
+		expectedErr  error
+	}{
+		{
+			name:     "one coin",
+			exitFee:  sdk.ZeroDec(),
+			coinsOut: sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)),
+		},
+		{
+			name:     "basket of every denom",
+			exitFee:  sdk.ZeroDec(),
+			coinsOut: sdk.NewCoins(sdk.NewInt64Coin("bar", 1000), sdk.NewInt64Coin("baz", 3000), sdk.NewInt64Coin("foo", 2000)),
+		},
+		{
+			name:     "exit fee",
+			exitFee:  sdk.NewDecWithPrec(3, 2),
+			coinsOut: sdk.NewCoins(sdk.NewInt64Coin("bar", 123457), sdk.NewInt64Coin("foo", 98765)),
+		},
+		{
+			name:         "exit fee sent to a recipient",
+			exitFee:      sdk.NewDecWithPrec(3, 2),
+			setRecipient: true,
+			coinsOut:     sdk.NewCoins(sdk.NewInt64Coin("bar", 123457), sdk.NewInt64Coin("foo", 98765)),
+		},
+		{
+			name:     "one token",
+			exitFee:  sdk.NewDecWithPrec(1, 2),
+			coinsOut: sdk.NewCoins(sdk.NewInt64Coin("baz", 1)),
+		},
+		{
+			name:     "almost all of a denom",
+			exitFee:  sdk.ZeroDec(),
+			coinsOut: sdk.NewCoins(sdk.NewInt64Coin("foo", 4_999_999)),
+		},
+		{
+			name:        "all of a denom",
+			exitFee:     sdk.ZeroDec(),
+			coinsOut:    sdk.NewCoins(sdk.NewInt64Coin("foo", 5_000_000)),
+			expectedErr: types.ErrTooManyTokensOut,
+		},
+		{
+			name:        "all of a denom after the exit fee",
+			exitFee:     sdk.NewDecWithPrec(1, 2),
+			coinsOut:    sdk.NewCoins(sdk.NewInt64Coin("foo", 4_960_000)),
+			expectedErr: types.ErrTooManyTokensOut,
+		},
+		{
+			name:        "denom not in pool",
+			exitFee:     sdk.ZeroDec(),
+			coinsOut:    sdk.NewCoins(sdk.NewInt64Coin("foo", 1000), sdk.NewInt64Coin("uatom", 1000)),
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+		{
+			name:        "no coins",
+			exitFee:     sdk.ZeroDec(),
+			coinsOut:    sdk.Coins{},
+			expectedErr: types.ErrNotPositiveRequireAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.ZeroDec(),
+				ExitFee: tc.exitFee,
+			})
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+			if tc.setRecipient {
+				suite.Require().NoError(keeper.SetExitFeeRecipient(suite.Ctx, poolId, suite.TestAccs[1]))
+			}
+
+			sharesIn, err := keeper.CalcSharesForExactCoinsOut(suite.Ctx, poolId, tc.coinsOut)
+			if tc.expectedErr != nil {
+				suite.Require().ErrorIs(err, tc.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// one share less doesn't give out the requested coins.
+			fewerCoins, err := keeper.CalcExitPoolCoins(suite.Ctx, poolId, sharesIn.SubRaw(1))
+			suite.Require().False(err == nil && fewerCoins.IsAllGTE(tc.coinsOut), "%s shares give out %s", sharesIn.SubRaw(1), fewerCoins)
+
+			exitCoins, err := keeper.ExitPool(suite.Ctx, sender, poolId, sharesIn, tc.coinsOut)
+			suite.Require().NoError(err)
+			suite.Require().True(exitCoins.IsAllGTE(tc.coinsOut), "exit gave out %s", exitCoins)
+		})
+	}
+}
+
 // TestJoinPoolExitPool_InverseRelationship tests that joining pool and exiting pool
 // guarantees same amount in and out
 func (suite *KeeperTestSuite) TestJoinPoolExitPool_InverseRelationship() {
@@ -653,6 +1382,606 @@ func (suite *KeeperTestSuite) TestJoinSwapExactAmountInConsistency() {
 	}
 }
 
+// TestCalcJoinPoolShares tests that the join estimate matches the shares minted
+// and the tokens taken by an actual join, before and after the v10 fork.
+func (suite *KeeperTestSuite) TestCalcJoinPoolShares() {
+	testCases := []struct {
+		name     string
+		tokensIn sdk.Coins
+	}{
+		{
+			name:     "single asset",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("foo", 1000000)),
+		},
+		{
+			name:     "all assets in the pool's ratio",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("foo", 100000), sdk.NewInt64Coin("bar", 100000), sdk.NewInt64Coin("baz", 100000)),
+		},
+		{
+			name:     "all assets off the pool's ratio",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("foo", 100000), sdk.NewInt64Coin("bar", 250000), sdk.NewInt64Coin("baz", 30000)),
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, blockHeight := range []int64{1, 4713065} {
+			suite.Run(fmt.Sprintf("%s at height %d", tc.name, blockHeight), func() {
+				suite.SetupTest()
+				suite.Ctx = suite.Ctx.WithBlockHeight(blockHeight)
+				poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+					SwapFee: sdk.NewDecWithPrec(3, 3),
+					ExitFee: sdk.ZeroDec(),
+				})
+				keeper := suite.App.GAMMKeeper
+				sender := suite.TestAccs[0]
+
+				poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				estimatedShares, estimatedTokensJoined, err := keeper.CalcJoinPoolShares(suite.Ctx, poolId, tc.tokensIn)
+				suite.Require().NoError(err)
+
+				// the estimate doesn't change the pool.
+				poolAfterEstimate, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				suite.Require().Equal(poolBefore, poolAfterEstimate)
+
+				balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+				shares, err := keeper.JoinSwapExactAmountIn(suite.Ctx, sender, poolId, tc.tokensIn, sdk.OneInt())
+				suite.Require().NoError(err)
+				balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+				suite.Require().Equal(shares, estimatedShares)
+				suite.Require().Equal(balancesBefore.Sub(estimatedTokensJoined).Add(sdk.NewCoin(types.GetPoolShareDenom(poolId), shares)), balancesAfter)
+			})
+		}
+	}
+}
+
+// TestJoinSwapExternAmountIn tests that a single asset join mints the estimated shares,
+// and that it fails without taking any tokens if it would mint fewer than the min shares.
+func (suite *KeeperTestSuite) TestJoinSwapExternAmountIn() {
+	tokenIn := sdk.NewInt64Coin("foo", 1000000)
+
+	testCases := []struct {
+		name string
+		// added to the estimated shares to get the min shares out
+		shareOutMinAmountOffset int64
+		tokenIn                 sdk.Coin
+		expectedErr             error
+	}{
+		{
+			name:                    "min shares below estimate",
+			shareOutMinAmountOffset: -1,
+			tokenIn:                 tokenIn,
+		},
+		{
+			name:                    "min shares equal to estimate",
+			shareOutMinAmountOffset: 0,
+			tokenIn:                 tokenIn,
+		},
+		{
+			name:                    "min shares above estimate",
+			shareOutMinAmountOffset: 1,
+			tokenIn:                 tokenIn,
+			expectedErr:             types.ErrLimitMinAmount,
+		},
+		{
+			name:        "zero token in",
+			tokenIn:     sdk.NewInt64Coin("foo", 0),
+			expectedErr: types.ErrNotPositiveRequireAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.Ctx = suite.Ctx.WithBlockHeight(4713065)
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.NewDecWithPrec(3, 3),
+				ExitFee: sdk.ZeroDec(),
+			})
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			estimatedShares, err := keeper.EstimateJoinSwapExternAmountIn(suite.Ctx, poolId, tokenIn)
+			suite.Require().NoError(err)
+			shareOutMinAmount := estimatedShares.AddRaw(tc.shareOutMinAmountOffset)
+
+			shares, err := keeper.JoinSwapExternAmountIn(suite.Ctx, sender, poolId, tc.tokenIn, shareOutMinAmount)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			if tc.expectedErr != nil {
+				suite.Require().ErrorIs(err, tc.expectedErr)
+				suite.Require().Equal(balancesBefore, balancesAfter)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(estimatedShares, shares)
+			suite.Require().Equal(balancesBefore.Sub(sdk.NewCoins(tc.tokenIn)).Add(sdk.NewCoin(types.GetPoolShareDenom(poolId), shares)), balancesAfter)
+		})
+	}
+}
+
+// TestSingleAssetJoinImpliedSwapEvent tests that single asset joins emit the part of the token in that they
+// swap, (1 - normalizedWeight) * tokenIn, and that joins of all assets don't.
+func (suite *KeeperTestSuite) TestSingleAssetJoinImpliedSwapEvent() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	sender := suite.TestAccs[0]
+
+	impliedSwapAttributes := func() []string {
+		attributes := []string{}
+		for _, event := range suite.Ctx.EventManager().Events() {
+			if event.Type != types.TypeEvtSingleAssetJoinSwap {
+				continue
+			}
+			for _, attribute := range event.Attributes {
+				if string(attribute.Key) == types.AttributeKeyImpliedSwap {
+					attributes = append(attributes, string(attribute.Value))
+				}
+			}
+		}
+		return attributes
+	}
+	expectedImpliedSwap := func(tokenIn sdk.Coin) string {
+		assets, err := keeper.GetPoolAssetsAndWeights(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		for _, asset := range assets {
+			if asset.Token.Denom == tokenIn.Denom {
+				amount := tokenIn.Amount.ToDec().Mul(sdk.OneDec().Sub(asset.NormalizedWeight)).TruncateInt()
+				return sdk.NewCoin(tokenIn.Denom, amount).String()
+			}
+		}
+		suite.FailNow("denom not in pool", tokenIn.Denom)
+		return ""
+	}
+
+	// foo has a normalized weight of 1/6, so 5/6 of the token in are swapped.
+	tokenIn := sdk.NewInt64Coin("foo", 1000000)
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	_, err := keeper.JoinSwapExternAmountIn(suite.Ctx, sender, poolId, tokenIn, sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{"833333foo"}, impliedSwapAttributes())
+	suite.Require().Equal([]string{expectedImpliedSwap(tokenIn)}, impliedSwapAttributes())
+
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	tokenInAmount, err := keeper.JoinSwapShareAmountOut(suite.Ctx, sender, poolId, "baz", types.OneShare.MulRaw(10), sdk.NewInt(10000000))
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{expectedImpliedSwap(sdk.NewCoin("baz", tokenInAmount))}, impliedSwapAttributes())
+
+	// the join is rejected below the minimum shares, without an event.
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	estimatedShares, err := keeper.EstimateJoinSwapExternAmountIn(suite.Ctx, poolId, tokenIn)
+	suite.Require().NoError(err)
+	_, err = keeper.JoinSwapExternAmountIn(suite.Ctx, sender, poolId, tokenIn, estimatedShares.AddRaw(1))
+	suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+	suite.Require().Empty(impliedSwapAttributes())
+
+	// joins of all assets in proportion don't swap.
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	err = keeper.JoinPoolNoSwap(suite.Ctx, sender, poolId, types.OneShare.MulRaw(10), sdk.Coins{})
+	suite.Require().NoError(err)
+	suite.Require().Empty(impliedSwapAttributes())
+}
+
+// TestExitSwapExactAmountOutRoundTrip tests that exiting for an exact token out pays exactly that token out,
+// for shares rounded up from the v11 upgrade on, so that exiting the shares back into the token never gives
+// more than requested.
+func (suite *KeeperTestSuite) TestExitSwapExactAmountOutRoundTrip() {
+	for _, tokenOut := range []sdk.Coin{sdk.NewInt64Coin("foo", 1), sdk.NewInt64Coin("foo", 12345), sdk.NewInt64Coin("bar", 1000000)} {
+		suite.Run(tokenOut.String(), func() {
+			suite.SetupTest()
+			suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.NewDecWithPrec(3, 3),
+				ExitFee: sdk.NewDecWithPrec(1, 2),
+			})
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+			shareDenom := types.GetPoolShareDenom(poolId)
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			balancerPool := pool.(*balancer.Pool)
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			// exiting fails if the shares needed are more than the max shares in.
+			cacheCtx, _ := suite.Ctx.CacheContext()
+			shareInAmount, err := keeper.ExitSwapExactAmountOut(cacheCtx, sender, poolId, tokenOut, balancesBefore.AmountOf(shareDenom))
+			suite.Require().NoError(err)
+			_, err = keeper.ExitSwapExactAmountOut(suite.Ctx, sender, poolId, tokenOut, shareInAmount.SubRaw(1))
+			suite.Require().ErrorIs(err, types.ErrLimitMaxAmount)
+
+			// exiting one share fewer for the token is worth less than tokenOut.
+			tokenOutForFewerShares, err := balancerPool.CalcExitSwapShareAmountIn(suite.Ctx, tokenOut.Denom, shareInAmount.SubRaw(1))
+			suite.Require().NoError(err)
+			suite.Require().True(tokenOutForFewerShares.LTE(tokenOut.Amount), "%s for %s shares", tokenOutForFewerShares, shareInAmount.SubRaw(1))
+
+			sharesIn, err := keeper.ExitSwapExactAmountOut(suite.Ctx, sender, poolId, tokenOut, shareInAmount)
+			suite.Require().NoError(err)
+			suite.Require().Equal(shareInAmount, sharesIn)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			suite.Require().Equal(balancesBefore.AmountOf(tokenOut.Denom).Add(tokenOut.Amount), balancesAfter.AmountOf(tokenOut.Denom))
+			suite.Require().Equal(balancesBefore.AmountOf(shareDenom).Sub(sharesIn), balancesAfter.AmountOf(shareDenom))
+		})
+	}
+}
+
+// TestExitSwapShareAmountInVsProportionalExit tests that exiting shares into a single asset,
+// and then swapping part of it back into the other asset, leaves the exiter with no more
+// than a proportional exit of the same shares would have.
+// Without fees the two are equal up to the slippage of the two approaches.
+func (suite *KeeperTestSuite) TestExitSwapShareAmountInVsProportionalExit() {
+	testCases := []struct {
+		name          string
+		poolSwapFee   sdk.Dec
+		poolExitFee   sdk.Dec
+		shareInAmount sdk.Int
+		// relative tolerance of the single asset exit and swap back against a proportional exit
+		tolerance sdk.Dec
+	}{
+		{
+			name:          "1% of shares, no fees",
+			poolSwapFee:   sdk.ZeroDec(),
+			poolExitFee:   sdk.ZeroDec(),
+			shareInAmount: types.InitPoolSharesSupply.QuoRaw(100),
+			tolerance:     sdk.MustNewDecFromStr("0.0001"),
+		},
+		{
+			name:          "10% of shares, no fees",
+			poolSwapFee:   sdk.ZeroDec(),
+			poolExitFee:   sdk.ZeroDec(),
+			shareInAmount: types.InitPoolSharesSupply.QuoRaw(10),
+			tolerance:     sdk.MustNewDecFromStr("0.01"),
+		},
+		{
+			// the swap fee is paid twice, on the non-normalized 2/3 of the exit and on the swap back,
+			// each about 1% of the proportional foo amount times the 2:1 value of bar to foo.
+			name:          "1% of shares, 1% swap fee",
+			poolSwapFee:   sdk.MustNewDecFromStr("0.01"),
+			poolExitFee:   sdk.ZeroDec(),
+			shareInAmount: types.InitPoolSharesSupply.QuoRaw(100),
+			tolerance:     sdk.MustNewDecFromStr("0.05"),
+		},
+		{
+			name:          "1% of shares, 1% exit fee",
+			poolSwapFee:   sdk.ZeroDec(),
+			poolExitFee:   sdk.MustNewDecFromStr("0.01"),
+			shareInAmount: types.InitPoolSharesSupply.QuoRaw(100),
+			tolerance:     sdk.MustNewDecFromStr("0.0001"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+
+			poolID := suite.prepareCustomBalancerPool(
+				defaultAcctFunds,
+				[]balancertypes.PoolAsset{
+					{
+						Weight: sdk.NewInt(100),
+						Token:  sdk.NewCoin("foo", sdk.NewInt(5000000)),
+					},
+					{
+						Weight: sdk.NewInt(200),
+						Token:  sdk.NewCoin("bar", sdk.NewInt(5000000)),
+					},
+				},
+				balancer.PoolParams{
+					SwapFee: tc.poolSwapFee,
+					ExitFee: tc.poolExitFee,
+				},
+			)
+			sender := suite.TestAccs[0]
+
+			proportionalCtx, _ := suite.Ctx.CacheContext()
+			exitCoins, err := suite.App.GAMMKeeper.ExitPool(proportionalCtx, sender, poolID, tc.shareInAmount, sdk.Coins{})
+			suite.Require().NoError(err)
+
+			singleAssetCtx, _ := suite.Ctx.CacheContext()
+			fooBalanceBefore := suite.App.BankKeeper.GetBalance(singleAssetCtx, sender, "foo")
+			tokenOutAmount, err := suite.App.GAMMKeeper.ExitSwapShareAmountIn(singleAssetCtx, sender, poolID, "foo", tc.shareInAmount, sdk.ZeroInt())
+			suite.Require().NoError(err)
+			suite.Require().Equal(fooBalanceBefore.Amount.Add(tokenOutAmount), suite.App.BankKeeper.GetBalance(singleAssetCtx, sender, "foo").Amount)
+
+			barOut := sdk.NewCoin("bar", exitCoins.AmountOf("bar"))
+			fooSwappedBack, err := suite.App.GAMMKeeper.SwapExactAmountOut(singleAssetCtx, sender, poolID, "foo", tokenOutAmount, barOut)
+			suite.Require().NoError(err)
+
+			// the pool must not pay out more for a single asset exit than for a proportional one
+			fooKept := tokenOutAmount.Sub(fooSwappedBack)
+			fooProportional := exitCoins.AmountOf("foo")
+			suite.Require().True(fooKept.LTE(fooProportional),
+				"single asset exit and swap back kept %s foo, proportional exit gave %s foo", fooKept, fooProportional)
+
+			relativeDiff := fooProportional.Sub(fooKept).ToDec().QuoInt(fooProportional)
+			suite.Require().True(relativeDiff.LTE(tc.tolerance),
+				"single asset exit and swap back kept %s foo, proportional exit gave %s foo", fooKept, fooProportional)
+		})
+	}
+}
+
+// TestExitSwapShareAmountInTooManyTokensOut tests that a single asset exit that would
+// withdraw all of the asset from the pool fails, without modifying the pool.
+func (suite *KeeperTestSuite) TestExitSwapShareAmountInTooManyTokensOut() {
+	poolID := suite.prepareCustomBalancerPool(
+		defaultAcctFunds,
+		defaultPoolAssets,
+		balancer.PoolParams{
+			SwapFee: sdk.ZeroDec(),
+			ExitFee: sdk.ZeroDec(),
+		},
+	)
+	poolBefore, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolID)
+	suite.Require().NoError(err)
+
+	_, err = suite.App.GAMMKeeper.ExitSwapShareAmountIn(suite.Ctx, suite.TestAccs[0], poolID, "foo", types.InitPoolSharesSupply.SubRaw(1), sdk.ZeroInt())
+	suite.Require().ErrorIs(err, types.ErrTooManyTokensOut)
+
+	poolAfter, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(poolBefore.GetTotalShares(), poolAfter.GetTotalShares())
+	suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+}
+
+// TestExitPoolToDenoms tests exiting a pool of foo, bar and baz into foo and bar only.
+func (suite *KeeperTestSuite) TestExitPoolToDenoms() {
+	shareInAmount := types.InitPoolSharesSupply.QuoRaw(10)
+	tests := []struct {
+		name           string
+		tokenOutDenoms []string
+		tokenOutMins   sdk.Coins
+		expectedErr    error
+	}{
+		{name: "two of three denoms", tokenOutDenoms: []string{"foo", "bar"}},
+		{name: "one denom", tokenOutDenoms: []string{"bar"}},
+		{name: "all denoms", tokenOutDenoms: []string{"foo", "bar", "baz"}},
+		{
+			name:           "min amounts met",
+			tokenOutDenoms: []string{"foo", "bar"},
+			tokenOutMins:   sdk.NewCoins(sdk.NewInt64Coin("foo", 500000), sdk.NewInt64Coin("bar", 500000)),
+		},
+		{
+			name:           "min amount of an unwanted denom",
+			tokenOutDenoms: []string{"foo", "bar"},
+			tokenOutMins:   sdk.NewCoins(sdk.NewInt64Coin("baz", 1)),
+			expectedErr:    types.ErrLimitMinAmount,
+		},
+		{
+			name:           "min amount not met",
+			tokenOutDenoms: []string{"foo", "bar"},
+			tokenOutMins:   sdk.NewCoins(sdk.NewInt64Coin("foo", 5000000)),
+			expectedErr:    types.ErrLimitMinAmount,
+		},
+		{name: "no denoms", tokenOutDenoms: []string{}, expectedErr: types.ErrInvalidTokenOutDenoms},
+		{name: "duplicate denom", tokenOutDenoms: []string{"foo", "foo"}, expectedErr: types.ErrInvalidTokenOutDenoms},
+		{name: "denom not in pool", tokenOutDenoms: []string{"foo", "uatom"}, expectedErr: types.ErrDenomNotFoundInPool},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPool()
+			sender := suite.TestAccs[0]
+
+			proportionalCtx, _ := suite.Ctx.CacheContext()
+			proportionalExitCoins, err := keeper.ExitPool(proportionalCtx, sender, poolId, shareInAmount, sdk.Coins{})
+			suite.Require().NoError(err)
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+
+			exitCoins, err := keeper.ExitPoolToDenoms(suite.Ctx, sender, poolId, shareInAmount, test.tokenOutDenoms, test.tokenOutMins)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				// a failed exit leaves neither the shares burned nor the swaps applied.
+				poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+				suite.Require().Equal(poolBefore.GetTotalShares(), poolAfter.GetTotalShares())
+				suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+				return
+			}
+			suite.Require().NoError(err)
+
+			// the sender gets only the wanted denoms, more of each than a proportional exit gives
+			// when some other denom is swapped into them.
+			suite.Require().Len(exitCoins, len(test.tokenOutDenoms))
+			for _, denom := range test.tokenOutDenoms {
+				if len(test.tokenOutDenoms) == len(proportionalExitCoins) {
+					suite.Require().Equal(proportionalExitCoins.AmountOf(denom), exitCoins.AmountOf(denom))
+				} else {
+					suite.Require().True(exitCoins.AmountOf(denom).GT(proportionalExitCoins.AmountOf(denom)), "%s exited, proportionally %s", exitCoins, proportionalExitCoins)
+				}
+			}
+			expectedBalances := balancesBefore.Add(exitCoins...).Sub(sdk.NewCoins(sdk.NewCoin(types.GetPoolShareDenom(poolId), shareInAmount)))
+			suite.Require().Equal(expectedBalances, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestGetPoolTVL() {
+	suite.SetupTest()
+	poolId := suite.prepareCustomBalancerPool(defaultAcctFunds, []balancertypes.PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 2_000_000), Weight: sdk.NewInt(100)},
+		{Token: sdk.NewInt64Coin("bar", 6_000_000), Weight: sdk.NewInt(200)},
+		{Token: sdk.NewInt64Coin("baz", 3_000_000), Weight: sdk.NewInt(300)},
+	}, defaultPoolParams)
+
+	// in a balancer pool, every asset is worth the same share of the pool value as its weight,
+	// so the value in the reference denom is the reference balance * total weight / reference weight.
+	tests := []struct {
+		referenceDenom string
+		expectedTVL    sdk.Dec
+		expectedErr    error
+	}{
+		{referenceDenom: "foo", expectedTVL: sdk.NewDec(12_000_000)},
+		{referenceDenom: "bar", expectedTVL: sdk.NewDec(18_000_000)},
+		{referenceDenom: "baz", expectedTVL: sdk.NewDec(6_000_000)},
+		{referenceDenom: "uatom", expectedErr: types.ErrDenomNotFoundInPool},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.referenceDenom, func() {
+			tvl, err := suite.App.GAMMKeeper.GetPoolTVL(suite.Ctx, poolId, test.referenceDenom)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+			// spot prices are rounded to types.SigFigsExponent significant figures.
+			tolerance := test.expectedTVL.Quo(types.SigFigs.ToDec())
+			suite.Require().True(tvl.Sub(test.expectedTVL).Abs().LTE(tolerance),
+				"expected %s, got %s", test.expectedTVL, tvl)
+		})
+	}
+
+	// the weight shares hold at the spot prices after a swap, so the TVL follows the new balances.
+	_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 1_000_000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	tvl, err := suite.App.GAMMKeeper.GetPoolTVL(suite.Ctx, poolId, "foo")
+	suite.Require().NoError(err)
+	expectedTVL := sdk.NewDec(3_000_000 * 6)
+	suite.Require().True(tvl.Sub(expectedTVL).Abs().LTE(expectedTVL.Quo(types.SigFigs.ToDec())),
+		"expected %s, got %s", expectedTVL, tvl)
+
+	_, err = suite.App.GAMMKeeper.GetPoolTVL(suite.Ctx, poolId+1, "foo")
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestCalculateSpotPriceWithPrecision() {
+	suite.SetupTest()
+	// the spot prices of foo per bar are 0.125 and 0.375.
+	lowPricePoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1_000_000), sdk.NewInt64Coin("bar", 8_000_000))
+	highPricePoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 3_000_000), sdk.NewInt64Coin("bar", 8_000_000))
+	keeper := suite.App.GAMMKeeper
+
+	tests := []struct {
+		name          string
+		poolId        uint64
+		decimals      uint64
+		expectedPrice sdk.Dec
+	}{
+		{name: "halfway rounds down to even", poolId: lowPricePoolId, decimals: 2, expectedPrice: sdk.MustNewDecFromStr("0.12")},
+		{name: "halfway rounds up to even", poolId: highPricePoolId, decimals: 2, expectedPrice: sdk.MustNewDecFromStr("0.38")},
+		{name: "below halfway rounds down", poolId: highPricePoolId, decimals: 1, expectedPrice: sdk.MustNewDecFromStr("0.4")},
+		{name: "zero decimals", poolId: highPricePoolId, decimals: 0, expectedPrice: sdk.ZeroDec()},
+		{name: "as many decimals as the price", poolId: lowPricePoolId, decimals: 3, expectedPrice: sdk.MustNewDecFromStr("0.125")},
+		{name: "full precision", poolId: highPricePoolId, decimals: sdk.Precision, expectedPrice: sdk.MustNewDecFromStr("0.375")},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			price, err := keeper.CalculateSpotPriceWithPrecision(suite.Ctx, test.poolId, "foo", "bar", test.decimals)
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedPrice, price)
+		})
+	}
+
+	// the spot price without precision stays unrounded.
+	price, err := keeper.CalculateSpotPrice(suite.Ctx, lowPricePoolId, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.MustNewDecFromStr("0.125"), price)
+
+	_, err = keeper.CalculateSpotPriceWithPrecision(suite.Ctx, lowPricePoolId, "foo", "bar", sdk.Precision+1)
+	suite.Require().ErrorIs(err, types.ErrPrecisionTooLarge)
+	_, err = keeper.CalculateSpotPriceWithPrecision(suite.Ctx, lowPricePoolId, "foo", "uatom", 2)
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+}
+
+// TestCalculateSpotPriceWithSwapFee tests that a marginal swap of the base asset for the quote asset
+// gets 1 / CalculateSpotPriceWithSwapFee of the quote asset per base asset.
+func (suite *KeeperTestSuite) TestCalculateSpotPriceWithSwapFee() {
+	tests := []struct {
+		name               string
+		directionalSwapFee *sdk.Dec
+		expectedSwapFee    sdk.Dec
+	}{
+		{name: "pool swap fee", expectedSwapFee: sdk.NewDecWithPrec(1, 2)},
+		{name: "directional swap fee", directionalSwapFee: &sellFooSwapFee, expectedSwapFee: sellFooSwapFee},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			// the spot price is 2/3 foo per bar.
+			poolId := suite.prepareCustomBalancerPool(
+				sdk.NewCoins(sdk.NewInt64Coin("foo", 1_000_000_000_000), sdk.NewInt64Coin("bar", 3_000_000_000_000), sdk.NewInt64Coin("uosmo", 100_000_000_000)),
+				[]balancer.PoolAsset{
+					{Token: sdk.NewInt64Coin("foo", 1_000_000_000_000), Weight: sdk.NewInt(100)},
+					{Token: sdk.NewInt64Coin("bar", 3_000_000_000_000), Weight: sdk.NewInt(200)},
+				},
+				balancer.PoolParams{SwapFee: sdk.NewDecWithPrec(1, 2), ExitFee: sdk.ZeroDec()},
+			)
+			if test.directionalSwapFee != nil {
+				suite.Require().NoError(keeper.SetDirectionalSwapFees(suite.Ctx, poolId, types.DirectionalSwapFees{Fees: []types.DirectionalSwapFee{
+					{TokenInDenom: "foo", TokenOutDenom: "bar", SwapFee: *test.directionalSwapFee},
+				}}))
+			}
+
+			spotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+			suite.Require().NoError(err)
+			spotPriceWithSwapFee, err := keeper.CalculateSpotPriceWithSwapFee(suite.Ctx, poolId, "foo", "bar")
+			suite.Require().NoError(err)
+			suite.Require().Equal(spotPrice.Quo(sdk.OneDec().Sub(test.expectedSwapFee)), spotPriceWithSwapFee)
+
+			// a swap of a millionth of the pool's foo moves the price by about a millionth.
+			tokenIn := sdk.NewInt64Coin("foo", 1_000_000)
+			tokenOutAmount, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, "bar")
+			suite.Require().NoError(err)
+			marginalRate := tokenOutAmount.ToDec().Quo(tokenIn.Amount.ToDec())
+			expectedRate := sdk.OneDec().Quo(spotPriceWithSwapFee)
+			relativeError := marginalRate.Sub(expectedRate).Abs().Quo(expectedRate)
+			suite.Require().True(relativeError.LT(sdk.NewDecWithPrec(1, 5)),
+				"marginal rate %s, expected %s", marginalRate, expectedRate)
+		})
+	}
+
+	keeper := suite.App.GAMMKeeper
+	_, err := keeper.CalculateSpotPriceWithSwapFee(suite.Ctx, 1, "foo", "uatom")
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+	_, err = keeper.CalculateSpotPriceWithSwapFee(suite.Ctx, 2, "foo", "bar")
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestGetSpotPriceInBothDirections() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	// the spot prices of bar in foo are 2, 3 and 7000000/3, all but 2 and 1/2 of which are rounded.
+	evenPoolId := suite.PrepareBalancerPool()
+	roundedPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 3_000_000), sdk.NewInt64Coin("bar", 1_000_000))
+	skewedPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 7_000_000_000), sdk.NewInt64Coin("bar", 3_000))
+
+	for _, poolId := range []uint64{evenPoolId, roundedPoolId, skewedPoolId} {
+		spotPrice, inverseSpotPrice, err := keeper.GetSpotPriceInBothDirections(suite.Ctx, poolId, "foo", "bar")
+		suite.Require().NoError(err)
+
+		expectedSpotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedSpotPrice, spotPrice)
+		expectedInverseSpotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "bar", "foo")
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedInverseSpotPrice, inverseSpotPrice)
+
+		product := spotPrice.Mul(inverseSpotPrice)
+		suite.Require().True(product.Sub(sdk.OneDec()).Abs().LTE(types.SpotPriceReciprocalTolerance),
+			"pool %d: %s * %s = %s", poolId, spotPrice, inverseSpotPrice, product)
+	}
+
+	_, _, err := keeper.GetSpotPriceInBothDirections(suite.Ctx, evenPoolId, "foo", "uatom")
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+	_, _, err = keeper.GetSpotPriceInBothDirections(suite.Ctx, 10, "foo", "bar")
+	suite.Require().Error(err)
+}
+
 // func (suite *KeeperTestSuite) TestSetStableSwapScalingFactors() {
 // 	stableSwapPoolParams := stableswap.PoolParams{
 // 		SwapFee: defaultSwapFee,
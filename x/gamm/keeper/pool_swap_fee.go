@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/stableswap"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SetPoolSwapFee updates the swap fee of the live pool poolId to swapFee, which must be in [0, 1).
+// Later swaps through the pool are charged swapFee, unless the pool's directional swap fees
+// or swap fee tiers set a different fee for them, see GetPoolSwapFee.
+// It is called by governance through an UpdatePoolSwapFeeProposal.
+func (k Keeper) SetPoolSwapFee(ctx sdk.Context, poolId uint64, swapFee sdk.Dec) error {
+	if swapFee.IsNil() {
+		return types.ErrNegativeSwapFee
+	}
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return err
+	}
+
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	oldSwapFee := pool.GetSwapFee(ctx)
+	switch pool := pool.(type) {
+	case *balancer.Pool:
+		pool.PoolParams.SwapFee = swapFee
+	case *stableswap.Pool:
+		pool.PoolParams.SwapFee = swapFee
+	default:
+		return sdkerrors.Wrapf(types.ErrUnsupportedPoolType, "swap fee of pool %d of type %T can't be updated", poolId, pool)
+	}
+
+	if err := k.SetPool(ctx, pool); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(types.CreatePoolSwapFeeUpdatedEvent(ctx, poolId, oldSwapFee, swapFee))
+	return nil
+}
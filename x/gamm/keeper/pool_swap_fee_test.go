@@ -0,0 +1,86 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestSetPoolSwapFee() {
+	oldSwapFee := sdk.NewDecWithPrec(1, 2)
+	tests := []struct {
+		name        string
+		swapFee     sdk.Dec
+		expectedErr error
+	}{
+		{name: "higher fee", swapFee: sdk.NewDecWithPrec(5, 2)},
+		{name: "lower fee", swapFee: sdk.NewDecWithPrec(3, 3)},
+		{name: "no fee", swapFee: sdk.ZeroDec()},
+		{name: "negative fee", swapFee: sdk.NewDecWithPrec(-1, 2), expectedErr: types.ErrNegativeSwapFee},
+		{name: "fee of 1", swapFee: sdk.OneDec(), expectedErr: types.ErrTooMuchSwapFee},
+		{name: "nil fee", swapFee: sdk.Dec{}, expectedErr: types.ErrNegativeSwapFee},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: oldSwapFee, ExitFee: sdk.ZeroDec()})
+			tokenIn := sdk.NewInt64Coin("foo", 100000)
+
+			suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+			err := keeper.SetPoolSwapFee(suite.Ctx, poolId, test.swapFee)
+			pool, getErr := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(getErr)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				suite.Require().Equal(oldSwapFee, pool.GetSwapFee(suite.Ctx))
+				suite.Require().Empty(suite.Ctx.EventManager().Events())
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.swapFee, pool.GetSwapFee(suite.Ctx))
+
+			// the update is recorded with the old and new fee.
+			events := suite.Ctx.EventManager().Events()
+			suite.Require().Len(events, 1)
+			suite.Require().Equal(types.TypeEvtPoolSwapFeeUpdated, events[0].Type)
+			attributes := map[string]string{}
+			for _, attribute := range events[0].Attributes {
+				attributes[string(attribute.Key)] = string(attribute.Value)
+			}
+			suite.Require().Equal(oldSwapFee.String(), attributes[types.AttributeKeyOldSwapFee])
+			suite.Require().Equal(test.swapFee.String(), attributes[types.AttributeKeyNewSwapFee])
+
+			// later swaps are charged the new fee.
+			swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, suite.TestAccs[0], "foo", "bar")
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.swapFee, swapFee)
+			expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", test.swapFee)
+			suite.Require().NoError(err)
+			tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+		})
+	}
+
+	suite.Run("stableswap pool", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+		suite.Require().NoError(keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, ""))
+
+		err := keeper.SetPoolSwapFee(suite.Ctx, poolId, sdk.NewDecWithPrec(2, 3))
+		suite.Require().NoError(err)
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		suite.Require().Equal(sdk.NewDecWithPrec(2, 3), pool.GetSwapFee(suite.Ctx))
+	})
+
+	suite.Run("pool not found", func() {
+		suite.SetupTest()
+		err := suite.App.GAMMKeeper.SetPoolSwapFee(suite.Ctx, 10, sdk.NewDecWithPrec(2, 3))
+		suite.Require().Error(err)
+	})
+}
@@ -1,5 +1,118 @@
 package keeper_test
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestGetPoolAssetsAndWeights() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+
+	assets, err := suite.App.GAMMKeeper.GetPoolAssetsAndWeights(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+
+	pool, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Len(assets, 3)
+
+	sum := sdk.ZeroDec()
+	for _, asset := range assets {
+		suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf(asset.Token.Denom), asset.Token.Amount)
+		sum = sum.Add(asset.NormalizedWeight)
+	}
+	// every normalized weight can be off by at most one unit of precision.
+	tolerance := sdk.SmallestDec().MulInt64(int64(len(assets)))
+	suite.Require().True(sum.Sub(sdk.OneDec()).Abs().LTE(tolerance), "normalized weights sum to %s", sum)
+
+	// the pool has weights bar: 200, baz: 300 and foo: 100, with the assets sorted by denom.
+	suite.Require().Equal("foo", assets[2].Token.Denom)
+	suite.Require().Equal(sdk.OneDec().Quo(sdk.NewDec(6)), assets[2].NormalizedWeight)
+
+	_, err = suite.App.GAMMKeeper.GetPoolAssetsAndWeights(suite.Ctx, poolId+1)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestGetSwapableDenoms() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+
+	// the pool has the assets bar, baz and foo.
+	tests := []struct {
+		tokenInDenom   string
+		expectedDenoms []string
+	}{
+		{tokenInDenom: "foo", expectedDenoms: []string{"bar", "baz"}},
+		{tokenInDenom: "bar", expectedDenoms: []string{"baz", "foo"}},
+		{tokenInDenom: "baz", expectedDenoms: []string{"bar", "foo"}},
+	}
+	for _, test := range tests {
+		denoms, err := keeper.GetSwapableDenoms(suite.Ctx, poolId, test.tokenInDenom)
+		suite.Require().NoError(err)
+		suite.Require().Equal(test.expectedDenoms, denoms)
+
+		// every returned denom can be swapped for.
+		for _, denom := range denoms {
+			_, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, sdk.NewInt64Coin(test.tokenInDenom, 1000), denom)
+			suite.Require().NoError(err)
+		}
+	}
+
+	_, err := keeper.GetSwapableDenoms(suite.Ctx, poolId, "uatom")
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+	suite.Require().ErrorContains(err, "5000000bar,5000000baz,5000000foo")
+
+	_, err = keeper.GetSwapableDenoms(suite.Ctx, poolId+1, "foo")
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestGetPoolInvariantK() {
+	tokenIn := sdk.NewInt64Coin("foo", 100000)
+	tests := []struct {
+		name    string
+		swapFee sdk.Dec
+	}{
+		{name: "without swap fee", swapFee: sdk.ZeroDec()},
+		{name: "with swap fee", swapFee: sdk.NewDecWithPrec(25, 3)},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: test.swapFee,
+				ExitFee: sdk.ZeroDec(),
+			})
+			keeper := suite.App.GAMMKeeper
+
+			// all of the pool's balances are 5000000, so k is 5000000 whatever the weights.
+			kBefore, err := keeper.GetPoolInvariantK(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().True(kBefore.Sub(sdk.NewDec(5000000)).Abs().LTE(sdk.NewDecWithPrec(1, 9)), "k is %s", kBefore)
+
+			_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+			suite.Require().NoError(err)
+			kAfter, err := keeper.GetPoolInvariantK(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+
+			suite.Require().True(kAfter.GTE(kBefore), "k decreased from %s to %s", kBefore, kAfter)
+			// without a swap fee, k only changes by the rounding of the token out amount in favor of the pool.
+			relativeChange := kAfter.Sub(kBefore).Quo(kBefore)
+			if test.swapFee.IsZero() {
+				suite.Require().True(relativeChange.LT(sdk.NewDecWithPrec(1, 6)), "k changed by %s", relativeChange)
+			} else {
+				suite.Require().True(relativeChange.GT(sdk.NewDecWithPrec(1, 6)), "k changed by %s", relativeChange)
+			}
+		})
+	}
+
+	_, err := suite.App.GAMMKeeper.GetPoolInvariantK(suite.Ctx, 1000)
+	suite.Require().Error(err)
+}
+
 // import (
 // 	"math/rand"
 // 	"time"
@@ -220,3 +333,31 @@ package keeper_test
 // 			"Expected equal %s: %d, %d", amt.Denom, amt.Amount.Int64(), sdk.NewInt(1000).Int64())
 // 	}
 // }
+
+// TestIteratePools tests that IteratePools visits every pool in ascending pool id order,
+// past ids of more than one byte, and stops once the callback returns true.
+func (suite *KeeperTestSuite) TestIteratePools() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	const numPools = 260
+	for i := 0; i < numPools; i++ {
+		suite.PrepareBalancerPool()
+	}
+
+	visited := []uint64{}
+	keeper.IteratePools(suite.Ctx, func(pool types.PoolI) bool {
+		visited = append(visited, pool.GetId())
+		return false
+	})
+	suite.Require().Len(visited, numPools)
+	for i, poolId := range visited {
+		suite.Require().Equal(uint64(i+1), poolId)
+	}
+
+	visited = []uint64{}
+	keeper.IteratePools(suite.Ctx, func(pool types.PoolI) bool {
+		visited = append(visited, pool.GetId())
+		return pool.GetId() == 10
+	})
+	suite.Require().Equal([]uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, visited)
+}
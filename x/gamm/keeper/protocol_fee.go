@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// deductProtocolFee takes the protocol's share of the swap fee paid on tokenIn out of the pool's reserves,
+// only mutating the pool struct, and returns it. The swap fee paid is tokenIn * swapFee, and the protocol's
+// share of it, tokenIn * swapFee * GetProtocolFeeShare, is rounded down in favor of the pool.
+// Only balancer pools pay protocol fees, the swap fees of other pools fully stay in the pool.
+// Before the v11 upgrade no protocol fee is charged, whatever the ProtocolFeeShare param.
+func (k Keeper) deductProtocolFee(ctx sdk.Context, pool types.PoolI, tokenIn sdk.Coin, swapFee sdk.Dec) (sdk.Coin, error) {
+	protocolFee := sdk.NewCoin(tokenIn.Denom, sdk.ZeroInt())
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok || !swapFee.IsPositive() || ctx.BlockHeight() < types.V11UpgradeHeight {
+		return protocolFee, nil
+	}
+
+	share := k.GetProtocolFeeShare(ctx)
+	protocolFee.Amount = tokenIn.Amount.ToDec().Mul(swapFee).Mul(share).TruncateInt()
+	if !protocolFee.IsPositive() {
+		return protocolFee, nil
+	}
+
+	poolAsset, err := balancerPool.GetPoolAsset(tokenIn.Denom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	if err := balancerPool.UpdatePoolAssetBalance(poolAsset.Token.Sub(protocolFee)); err != nil {
+		return sdk.Coin{}, err
+	}
+	return protocolFee, nil
+}
+
+// sendProtocolFee sends the protocol fee of hop from its pool to the protocol fee module account,
+// once the pool received the tokens swapped in, and removes it from the total liquidity.
+func (k Keeper) sendProtocolFee(ctx sdk.Context, hop swapHop) error {
+	if !hop.protocolFee.IsPositive() {
+		return nil
+	}
+
+	protocolFee := sdk.Coins{hop.protocolFee}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, hop.pool.GetAddress(), types.ProtocolFeeModuleAcctName, protocolFee); err != nil {
+		return err
+	}
+	k.RecordTotalLiquidityDecrease(ctx, protocolFee)
+	return nil
+}
@@ -0,0 +1,129 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// TestProtocolFee tests that the protocol fee module account receives exactly fee * protocolFeeShare of every swap,
+// while the rest of the fee stays in the pool.
+func (suite *KeeperTestSuite) TestProtocolFee() {
+	swapFee := sdk.NewDecWithPrec(2, 2)
+	tests := []struct {
+		name                string
+		protocolFeeShare    sdk.Dec
+		swap                func(poolId uint64) (tokenIn sdk.Coin, err error)
+		expectedProtocolFee func(tokenIn sdk.Coin) sdk.Coins
+	}{
+		{
+			name:             "exact amount in, no protocol fee share",
+			protocolFeeShare: sdk.ZeroDec(),
+			swap: func(poolId uint64) (sdk.Coin, error) {
+				tokenIn := sdk.NewInt64Coin("foo", 100000)
+				_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+				return tokenIn, err
+			},
+			expectedProtocolFee: func(sdk.Coin) sdk.Coins { return sdk.Coins{} },
+		},
+		{
+			name:             "exact amount in",
+			protocolFeeShare: sdk.NewDecWithPrec(25, 2),
+			swap: func(poolId uint64) (sdk.Coin, error) {
+				tokenIn := sdk.NewInt64Coin("foo", 100000)
+				_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+				return tokenIn, err
+			},
+			// 100000 * 0.02 * 0.25
+			expectedProtocolFee: func(sdk.Coin) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("foo", 500)) },
+		},
+		{
+			name:             "exact amount out",
+			protocolFeeShare: sdk.NewDecWithPrec(25, 2),
+			swap: func(poolId uint64) (sdk.Coin, error) {
+				tokenInAmount, err := suite.App.GAMMKeeper.SwapExactAmountOut(suite.Ctx, suite.TestAccs[0], poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("bar", 100000))
+				return sdk.NewCoin("foo", tokenInAmount), err
+			},
+			expectedProtocolFee: func(tokenIn sdk.Coin) sdk.Coins {
+				return sdk.NewCoins(sdk.NewCoin("foo", tokenIn.Amount.ToDec().Mul(swapFee).Mul(sdk.NewDecWithPrec(25, 2)).TruncateInt()))
+			},
+		},
+		{
+			name:             "multihop, all of the fee",
+			protocolFeeShare: sdk.OneDec(),
+			swap: func(poolId uint64) (sdk.Coin, error) {
+				tokenIn := sdk.NewInt64Coin("foo", 100000)
+				_, err := suite.App.GAMMKeeper.MultihopSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], []types.SwapAmountInRoute{
+					{PoolId: poolId, TokenOutDenom: "bar"},
+					{PoolId: poolId, TokenOutDenom: "baz"},
+				}, tokenIn, sdk.OneInt())
+				return tokenIn, err
+			},
+			expectedProtocolFee: nil,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight)
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()})
+			suite.Require().NoError(keeper.SetProtocolFeeShare(suite.Ctx, test.protocolFeeShare))
+			suite.Require().Equal(test.protocolFeeShare, keeper.GetProtocolFeeShare(suite.Ctx))
+			protocolFeeAddr := suite.App.AccountKeeper.GetModuleAddress(types.ProtocolFeeModuleAcctName)
+			totalLiquidityBefore := keeper.GetTotalLiquidity(suite.Ctx)
+
+			suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+			tokenIn, err := test.swap(poolId)
+			suite.Require().NoError(err)
+
+			var expectedProtocolFee sdk.Coins
+			if test.expectedProtocolFee != nil {
+				expectedProtocolFee = test.expectedProtocolFee(tokenIn)
+			} else {
+				// every hop pays all of its fee on its own token in.
+				for _, event := range suite.Ctx.EventManager().Events() {
+					if event.Type != types.TypeEvtTokenSwapped {
+						continue
+					}
+					for _, attribute := range event.Attributes {
+						if string(attribute.Key) == types.AttributeKeyTokensIn {
+							hopTokenIn, err := sdk.ParseCoinNormalized(string(attribute.Value))
+							suite.Require().NoError(err)
+							expectedProtocolFee = expectedProtocolFee.Add(sdk.NewCoin(hopTokenIn.Denom, hopTokenIn.Amount.ToDec().Mul(swapFee).TruncateInt()))
+						}
+					}
+				}
+				suite.Require().Len(expectedProtocolFee, 2)
+			}
+			suite.Require().Equal(expectedProtocolFee, suite.App.BankKeeper.GetAllBalances(suite.Ctx, protocolFeeAddr))
+
+			// the pool's reserves match its balances, without the protocol fee.
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()), pool.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx), keeper.GetTotalLiquidity(suite.Ctx))
+			suite.Require().True(totalLiquidityBefore.Add(tokenIn).IsAllGTE(keeper.GetTotalLiquidity(suite.Ctx)))
+		})
+	}
+
+	suite.Require().Error(suite.App.GAMMKeeper.SetProtocolFeeShare(suite.Ctx, sdk.NewDecWithPrec(-1, 2)))
+	suite.Require().Error(suite.App.GAMMKeeper.SetProtocolFeeShare(suite.Ctx, sdk.NewDecWithPrec(101, 2)))
+}
+
+// TestNoProtocolFeeBeforeUpgrade tests that swaps before the v11 upgrade don't pay a protocol fee,
+// even with a protocol fee share set.
+func (suite *KeeperTestSuite) TestNoProtocolFeeBeforeUpgrade() {
+	suite.SetupTest()
+	suite.Ctx = suite.Ctx.WithBlockHeight(types.V11UpgradeHeight - 1)
+	keeper := suite.App.GAMMKeeper
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: sdk.NewDecWithPrec(2, 2), ExitFee: sdk.ZeroDec()})
+	suite.Require().NoError(keeper.SetProtocolFeeShare(suite.Ctx, sdk.OneDec()))
+
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 100000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	protocolFeeAddr := suite.App.AccountKeeper.GetModuleAddress(types.ProtocolFeeModuleAcctName)
+	suite.Require().True(suite.App.BankKeeper.GetAllBalances(suite.Ctx, protocolFeeAddr).IsZero())
+}
@@ -0,0 +1,25 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetPoolRoundingDust returns the cumulative fractions of tokens that swaps of an exact amount in
+// rounded down the tokens out of poolId by, for every denom swapped out.
+//
+// The dust isn't lost: it is never sent out of the pool, so it stays in the pool's balance and
+// accrues to its LPs. The pool's reserves only count whole tokens, so once the dust of a denom adds
+// up to whole tokens, they're part of the pool's balance without being part of its reserves.
+// The dust is only tracked for balancer pools.
+func (k Keeper) GetPoolRoundingDust(ctx sdk.Context, poolId uint64) sdk.DecCoins {
+	return k.GetPoolAccumulators(ctx, poolId).RoundingDust
+}
+
+// hopRoundingDust returns the fraction of a token the tokens out of hop were rounded down by,
+// and false if they weren't rounded.
+func hopRoundingDust(hop swapHop) (sdk.DecCoin, bool) {
+	if hop.tokenOutRemainder.IsNil() || !hop.tokenOutRemainder.IsPositive() {
+		return sdk.DecCoin{}, false
+	}
+	return sdk.NewDecCoinFromDec(hop.tokenOut.Denom, hop.tokenOutRemainder), true
+}
@@ -0,0 +1,66 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// TestPoolRoundingDust runs thousands of small swaps, and tests that the fractions of tokens they
+// round the tokens out down by add up to the pool's dust, which stays in the pool's balance.
+func (suite *KeeperTestSuite) TestPoolRoundingDust() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	tokenIn := sdk.NewInt64Coin("foo", 1000)
+
+	swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "foo", "bar")
+	suite.Require().NoError(err)
+	exactTokenOutSum := sdk.ZeroDec()
+	tokenOutSum := sdk.ZeroInt()
+	for i := 0; i < 2000; i++ {
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		exactTokenOut, _, err := pool.(*balancer.Pool).CalcOutAmtGivenInWithFee(suite.Ctx, sdk.Coins{tokenIn}, "bar", swapFee)
+		suite.Require().NoError(err)
+		exactTokenOutSum = exactTokenOutSum.Add(exactTokenOut.Amount)
+
+		tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+		tokenOutSum = tokenOutSum.Add(tokenOutAmount)
+	}
+
+	// the dust is exactly what the swaps didn't swap out, and it adds up to whole tokens.
+	dust := keeper.GetPoolRoundingDust(suite.Ctx, poolId)
+	suite.Require().Len(dust, 1)
+	suite.Require().Equal("bar", dust[0].Denom)
+	suite.Require().Equal(exactTokenOutSum.Sub(tokenOutSum.ToDec()), dust.AmountOf("bar"))
+	suite.Require().True(dust.AmountOf("bar").GT(sdk.NewDec(100)), "dust of %s", dust)
+
+	// the dust stays in the pool's balance, which is exactly accounted for by the tokens swapped out and the dust.
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	poolBalance := suite.App.BankKeeper.GetBalance(suite.Ctx, pool.GetAddress(), "bar").Amount
+	suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx).AmountOf("bar"), poolBalance)
+	suite.Require().Equal(sdk.NewDec(5000000).Sub(exactTokenOutSum).Add(dust.AmountOf("bar")), poolBalance.ToDec())
+
+	// multihop swaps record the dust of each hop.
+	_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, []types.SwapAmountInRoute{
+		{PoolId: poolId, TokenOutDenom: "baz"},
+		{PoolId: poolId, TokenOutDenom: "bar"},
+	}, tokenIn, sdk.OneInt())
+	suite.Require().NoError(err)
+	dustAfterMultihop := keeper.GetPoolRoundingDust(suite.Ctx, poolId)
+	suite.Require().True(dustAfterMultihop.AmountOf("baz").IsPositive())
+	suite.Require().True(dustAfterMultihop.AmountOf("bar").GTE(dust.AmountOf("bar")))
+
+	_, err = keeper.SwapExactAmountOut(suite.Ctx, trader, poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("baz", 1000))
+	suite.Require().NoError(err)
+	// swaps of an exact amount out round the token in up instead, and don't record any dust.
+	suite.Require().Equal(dustAfterMultihop, keeper.GetPoolRoundingDust(suite.Ctx, poolId))
+
+	// other pools have no dust.
+	suite.Require().Empty(keeper.GetPoolRoundingDust(suite.Ctx, poolId+1))
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
+// applyJoinPoolStateChange moves joinCoins from joiner into the pool, mints numShares to joiner, and writes the pool.
+// The AfterJoinPool hook is called once the join is fully applied.
 func (k Keeper) applyJoinPoolStateChange(ctx sdk.Context, pool types.PoolI, joiner sdk.AccAddress, numShares sdk.Int, joinCoins sdk.Coins) error {
 	err := k.bankKeeper.SendCoins(ctx, joiner, pool.GetAddress(), joinCoins)
 	if err != nil {
@@ -22,12 +24,14 @@ func (k Keeper) applyJoinPoolStateChange(ctx sdk.Context, pool types.PoolI, join
 		return err
 	}
 
-	ctx.EventManager().EmitEvent(types.CreateAddLiquidityEvent(ctx, joiner, pool.GetId(), joinCoins))
-	k.hooks.AfterJoinPool(ctx, joiner, pool.GetId(), joinCoins, numShares)
+	ctx.EventManager().EmitEvent(types.CreateAddLiquidityEvent(ctx, joiner, pool.GetId(), joinCoins, numShares))
 	k.RecordTotalLiquidityIncrease(ctx, joinCoins)
+	k.hooks.AfterJoinPool(ctx, joiner, pool.GetId(), joinCoins, numShares)
 	return nil
 }
 
+// applyExitPoolStateChange moves exitCoins from the pool to exiter, burns numShares of exiter, and writes the pool.
+// The AfterExitPool hook is called once the exit is fully applied.
 func (k Keeper) applyExitPoolStateChange(ctx sdk.Context, pool types.PoolI, exiter sdk.AccAddress, numShares sdk.Int, exitCoins sdk.Coins) error {
 	err := k.bankKeeper.SendCoins(ctx, pool.GetAddress(), exiter, exitCoins)
 	if err != nil {
@@ -44,9 +48,11 @@ func (k Keeper) applyExitPoolStateChange(ctx sdk.Context, pool types.PoolI, exit
 		return err
 	}
 
-	ctx.EventManager().EmitEvent(types.CreateRemoveLiquidityEvent(ctx, exiter, pool.GetId(), exitCoins))
-	k.hooks.AfterExitPool(ctx, exiter, pool.GetId(), numShares, exitCoins)
+	// every exit withholds the pool's exit fee of the exited shares, rounded down here to whole share units.
+	exitFee := sdk.NewCoin(types.GetPoolShareDenom(pool.GetId()), pool.GetExitFee(ctx).MulInt(numShares).TruncateInt())
+	ctx.EventManager().EmitEvent(types.CreateRemoveLiquidityEvent(ctx, exiter, pool.GetId(), exitCoins, numShares, exitFee))
 	k.RecordTotalLiquidityDecrease(ctx, exitCoins)
+	k.hooks.AfterExitPool(ctx, exiter, pool.GetId(), numShares, exitCoins)
 	return nil
 }
 
@@ -69,6 +75,26 @@ func (k Keeper) MintPoolShareToAccount(ctx sdk.Context, pool types.PoolI, addr s
 	return nil
 }
 
+// mintInitialPoolShares mints the initial shares of pool, locking MinimumLiquidityShares of them
+// at MinimumLiquidityAddress and sending the rest to creator.
+// The shares are minted at once, so that pool creation writes the share supply only once.
+func (k Keeper) mintInitialPoolShares(ctx sdk.Context, pool types.PoolI, creator sdk.AccAddress) error {
+	shareDenom := types.GetPoolShareDenom(pool.GetId())
+	err := k.bankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewCoin(shareDenom, pool.GetTotalShares())))
+	if err != nil {
+		return err
+	}
+
+	lockedShares := sdk.NewCoins(sdk.NewCoin(shareDenom, types.MinimumLiquidityShares))
+	err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, types.MinimumLiquidityAddress, lockedShares)
+	if err != nil {
+		return err
+	}
+
+	creatorShares := sdk.NewCoins(sdk.NewCoin(shareDenom, pool.GetTotalShares().Sub(types.MinimumLiquidityShares)))
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, creator, creatorShares)
+}
+
 // BurnPoolShareFromAccount burns `amount` of the given pools shares held by `addr`.
 func (k Keeper) BurnPoolShareFromAccount(ctx sdk.Context, pool types.PoolI, addr sdk.AccAddress, amount sdk.Int) error {
 	amt := sdk.Coins{
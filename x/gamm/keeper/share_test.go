@@ -0,0 +1,255 @@
+package keeper_test
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// joinExitCall is a recorded AfterJoinPool or AfterExitPool call,
+// with the sender's shares and the total liquidity as the hook observed them.
+type joinExitCall struct {
+	sender         sdk.AccAddress
+	poolId         uint64
+	shares         sdk.Int
+	coins          sdk.Coins
+	senderShares   sdk.Int
+	totalLiquidity sdk.Coins
+}
+
+// recordingJoinExitHooks are the app's gamm hooks, that also record the AfterJoinPool and AfterExitPool calls.
+type recordingJoinExitHooks struct {
+	types.GammHooks
+	keeper *keeper.Keeper
+	suite  *KeeperTestSuite
+	joins  *[]joinExitCall
+	exits  *[]joinExitCall
+}
+
+func (h recordingJoinExitHooks) record(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, shares sdk.Int, coins sdk.Coins) joinExitCall {
+	return joinExitCall{
+		sender:         sender,
+		poolId:         poolId,
+		shares:         shares,
+		coins:          coins,
+		senderShares:   h.suite.App.BankKeeper.GetBalance(ctx, sender, types.GetPoolShareDenom(poolId)).Amount,
+		totalLiquidity: h.keeper.GetTotalLiquidity(ctx),
+	}
+}
+
+func (h recordingJoinExitHooks) AfterJoinPool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, enterCoins sdk.Coins, shareOutAmount sdk.Int) {
+	*h.joins = append(*h.joins, h.record(ctx, sender, poolId, shareOutAmount, enterCoins))
+	h.GammHooks.AfterJoinPool(ctx, sender, poolId, enterCoins, shareOutAmount)
+}
+
+func (h recordingJoinExitHooks) AfterExitPool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, shareInAmount sdk.Int, exitCoins sdk.Coins) {
+	*h.exits = append(*h.exits, h.record(ctx, sender, poolId, shareInAmount, exitCoins))
+	h.GammHooks.AfterExitPool(ctx, sender, poolId, shareInAmount, exitCoins)
+}
+
+func (suite *KeeperTestSuite) TestAfterJoinPoolAndAfterExitPoolHooks() {
+	tests := []struct {
+		name   string
+		isJoin bool
+		apply  func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error
+	}{
+		{
+			name:   "JoinPoolNoSwap",
+			isJoin: true,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				return keeper.JoinPoolNoSwap(suite.Ctx, sender, poolId, types.OneShare.MulRaw(10), sdk.Coins{})
+			},
+		},
+		{
+			name:   "JoinSwapExactAmountIn",
+			isJoin: true,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.JoinSwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(100000))), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name:   "JoinSwapExternAmountIn",
+			isJoin: true,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.JoinSwapExternAmountIn(suite.Ctx, sender, poolId, sdk.NewCoin("bar", sdk.NewInt(100000)), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name:   "JoinSwapShareAmountOut",
+			isJoin: true,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.JoinSwapShareAmountOut(suite.Ctx, sender, poolId, "baz", types.OneShare, sdk.NewInt(1000000))
+				return err
+			},
+		},
+		{
+			name:   "ExitPool",
+			isJoin: false,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.ExitPool(suite.Ctx, sender, poolId, types.OneShare.MulRaw(10), sdk.Coins{})
+				return err
+			},
+		},
+		{
+			name:   "ExitSwapShareAmountIn",
+			isJoin: false,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.ExitSwapShareAmountIn(suite.Ctx, sender, poolId, "foo", types.OneShare, sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name:   "ExitSwapExactAmountOut",
+			isJoin: false,
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.ExitSwapExactAmountOut(suite.Ctx, sender, poolId, sdk.NewCoin("bar", sdk.NewInt(100000)), types.OneShare.MulRaw(10))
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			sender := suite.TestAccs[0]
+			keeper := suite.App.GAMMKeeper
+			shareDenom := types.GetPoolShareDenom(poolId)
+
+			var joins, exits []joinExitCall
+			hooks := keeper.ReplaceHooks(nil)
+			keeper.ReplaceHooks(recordingJoinExitHooks{
+				GammHooks: hooks,
+				keeper:    keeper,
+				suite:     suite,
+				joins:     &joins,
+				exits:     &exits,
+			})
+			defer keeper.ReplaceHooks(hooks)
+
+			senderBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			totalLiquidityBefore := keeper.GetTotalLiquidity(suite.Ctx)
+
+			err := test.apply(keeper, sender, poolId)
+			suite.Require().NoError(err)
+
+			senderBalancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			totalLiquidityAfter := keeper.GetTotalLiquidity(suite.Ctx)
+
+			var call joinExitCall
+			if test.isJoin {
+				suite.Require().Len(joins, 1)
+				suite.Require().Empty(exits)
+				call = joins[0]
+				// the hook is called with the shares minted and the coins consumed by the join.
+				suite.Require().Equal(senderBalancesAfter.AmountOf(shareDenom).Sub(senderBalancesBefore.AmountOf(shareDenom)).String(), call.shares.String())
+				suite.Require().Equal(senderBalancesBefore.Sub(senderBalancesAfter.Sub(sdk.NewCoins(sdk.NewCoin(shareDenom, call.shares)))), call.coins)
+				suite.Require().Equal(totalLiquidityBefore.Add(call.coins...), totalLiquidityAfter)
+			} else {
+				suite.Require().Len(exits, 1)
+				suite.Require().Empty(joins)
+				call = exits[0]
+				// the hook is called with the shares burned and the coins sent by the exit.
+				suite.Require().Equal(senderBalancesBefore.AmountOf(shareDenom).Sub(senderBalancesAfter.AmountOf(shareDenom)).String(), call.shares.String())
+				suite.Require().Equal(senderBalancesAfter.Sub(senderBalancesBefore.Sub(sdk.NewCoins(sdk.NewCoin(shareDenom, call.shares)))), call.coins)
+				suite.Require().Equal(totalLiquidityAfter.Add(call.coins...), totalLiquidityBefore)
+			}
+			suite.Require().Equal(sender, call.sender)
+			suite.Require().Equal(poolId, call.poolId)
+			suite.Require().True(call.shares.IsPositive())
+
+			// the hook observes the join or exit fully applied.
+			suite.Require().Equal(senderBalancesAfter.AmountOf(shareDenom).String(), call.senderShares.String())
+			suite.Require().Equal(totalLiquidityAfter, call.totalLiquidity)
+		})
+	}
+}
+
+// TestJoinAndExitEvents tests that joins and exits emit the shares they mint or burn, and exits the exit fee they charge.
+func (suite *KeeperTestSuite) TestJoinAndExitEvents() {
+	exitFee := sdk.NewDecWithPrec(1, 2)
+	tests := []struct {
+		name      string
+		eventType string
+		shares    sdk.Int
+		apply     func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64, shares sdk.Int) error
+	}{
+		{
+			name:      "JoinPoolNoSwap",
+			eventType: types.TypeEvtPoolJoined,
+			shares:    types.OneShare.MulRaw(10),
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64, shares sdk.Int) error {
+				return keeper.JoinPoolNoSwap(suite.Ctx, sender, poolId, shares, sdk.Coins{})
+			},
+		},
+		{
+			name:      "ExitPool",
+			eventType: types.TypeEvtPoolExited,
+			shares:    types.OneShare.MulRaw(10),
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64, shares sdk.Int) error {
+				_, err := keeper.ExitPool(suite.Ctx, sender, poolId, shares, sdk.Coins{})
+				return err
+			},
+		},
+		{
+			name:      "ExitSwapShareAmountIn",
+			eventType: types.TypeEvtPoolExited,
+			shares:    types.OneShare.MulRaw(3).AddRaw(7),
+			apply: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64, shares sdk.Int) error {
+				_, err := keeper.ExitSwapShareAmountIn(suite.Ctx, sender, poolId, "foo", shares, sdk.OneInt())
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: exitFee})
+			sender := suite.TestAccs[0]
+			keeper := suite.App.GAMMKeeper
+			shareDenom := types.GetPoolShareDenom(poolId)
+
+			suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			err := test.apply(keeper, sender, poolId, test.shares)
+			suite.Require().NoError(err)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			var attributes map[string]string
+			for _, event := range suite.Ctx.EventManager().Events() {
+				if event.Type != test.eventType {
+					continue
+				}
+				suite.Require().Nil(attributes, "more than one %s event", test.eventType)
+				attributes = map[string]string{}
+				for _, attribute := range event.Attributes {
+					attributes[string(attribute.Key)] = string(attribute.Value)
+				}
+			}
+			suite.Require().NotNil(attributes, "no %s event", test.eventType)
+			suite.Require().Equal(sender.String(), attributes[sdk.AttributeKeySender])
+			suite.Require().Equal(fmt.Sprint(poolId), attributes[types.AttributeKeyPoolId])
+
+			sharesBefore, sharesAfter := balancesBefore.AmountOf(shareDenom), balancesAfter.AmountOf(shareDenom)
+			if test.eventType == types.TypeEvtPoolJoined {
+				suite.Require().Equal(sharesAfter.Sub(sharesBefore).String(), attributes[types.AttributeKeySharesMinted])
+				suite.Require().Equal(balancesBefore.Sub(balancesAfter.Sub(sdk.NewCoins(sdk.NewCoin(shareDenom, sharesAfter.Sub(sharesBefore))))).String(), attributes[types.AttributeKeyTokensIn])
+				suite.Require().NotContains(attributes, types.AttributeKeyExitFee)
+				return
+			}
+			suite.Require().Equal(test.shares.String(), attributes[types.AttributeKeySharesBurned])
+			suite.Require().Equal(sharesBefore.Sub(test.shares).String(), sharesAfter.String())
+			suite.Require().Equal(balancesAfter.Sub(balancesBefore.Sub(sdk.NewCoins(sdk.NewCoin(shareDenom, test.shares)))).String(), attributes[types.AttributeKeyTokensOut])
+			// 1% of the exited shares, rounded down.
+			expectedExitFee := sdk.NewCoin(shareDenom, test.shares.QuoRaw(100))
+			suite.Require().Equal(expectedExitFee.String(), attributes[types.AttributeKeyExitFee])
+		})
+	}
+}
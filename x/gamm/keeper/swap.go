@@ -2,10 +2,13 @@ package keeper
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
@@ -13,7 +16,8 @@ import (
 // denominated via tokenOutDenom through a pool denoted by poolId specifying that
 // tokenOutMinAmount must be returned in the resulting asset returning an error
 // upon failure. Upon success, the resulting tokens swapped for are returned. A
-// swap fee is applied determined by the pool's parameters.
+// swap fee is applied determined by the pool's parameters, or by the sender's
+// swap volume if the pool has swap fee tiers, see SetSwapFeeTiers.
 func (k Keeper) SwapExactAmountIn(
 	ctx sdk.Context,
 	sender sdk.AccAddress,
@@ -27,10 +31,507 @@ func (k Keeper) SwapExactAmountIn(
 		return sdk.Int{}, err
 	}
 
-	swapFee := pool.GetSwapFee(ctx)
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
 	return k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
 }
 
+// SwapExactAmountInWithEffectivePrice is SwapExactAmountIn, also returning the realized effective price
+// of the swap in tokenIn per tokenOutDenom, for clients logging their execution quality.
+// The effective price is of the tokens actually swapped out, rounded down to an integer amount,
+// as the effective_price attribute of the swap event is.
+func (k Keeper) SwapExactAmountInWithEffectivePrice(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+) (tokenOutAmount sdk.Int, effectivePrice sdk.Dec, err error) {
+	tokenOutAmount, err = k.SwapExactAmountIn(ctx, sender, poolId, tokenIn, tokenOutDenom, tokenOutMinAmount)
+	if err != nil {
+		return sdk.Int{}, sdk.Dec{}, err
+	}
+	return tokenOutAmount, swapEffectivePrice(tokenIn.Amount, tokenOutAmount), nil
+}
+
+// swapEffectivePrice returns the effective price of a swap of tokenInAmount for tokenOutAmount,
+// in tokens in per token out.
+func swapEffectivePrice(tokenInAmount, tokenOutAmount sdk.Int) sdk.Dec {
+	return tokenInAmount.ToDec().Quo(tokenOutAmount.ToDec())
+}
+
+// EstimateSwapExactAmountIn returns the amount of tokenOutDenom that SwapExactAmountIn
+// would return for tokenIn, against the current state of the pool.
+// No state is written, no tokens are transferred, and no hooks are called.
+// The same errors as SwapExactAmountIn are returned, e.g. for an inactive pool.
+// The pool's fee of the swap direction is used, as for a sender without volume in a pool with swap fee tiers.
+//
+// Quotes are computed from the pool on every call, and not cached across calls. Queries run on
+// a branch of the committed state that is discarded afterwards, so a cache could only be kept
+// in the memory of the node, where it would make nodes of the same block use different gas.
+func (k Keeper) EstimateSwapExactAmountIn(
+	ctx sdk.Context,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+) (tokenOutAmount sdk.Int, err error) {
+	if tokenIn.Denom == tokenOutDenom {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenIn.Denom)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	swapFee := k.directionalSwapFee(ctx, pool, tokenIn.Denom, tokenOutDenom)
+	tokenOut, err := pool.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if !tokenOut.Amount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
+
+	return tokenOut.Amount, nil
+}
+
+// MarginalOutForNextUnit returns how much tokenOutDenom the very next unit of tokenInDenom would get
+// from the pool with poolId, at its current reserves and for the pool's fee of the swap direction,
+// e.g. for the top of an order book. It is the amount out of a swap of one unit in, before the amount out
+// is rounded down, so that it reflects the current slippage even where a single unit gets less than one unit out.
+// No state is written. Only balancer pools are supported.
+func (k Keeper) MarginalOutForNextUnit(
+	ctx sdk.Context,
+	poolId uint64,
+	tokenInDenom string,
+	tokenOutDenom string,
+) (sdk.Dec, error) {
+	if tokenInDenom == tokenOutDenom {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenInDenom)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolId)
+	}
+
+	swapFee := k.directionalSwapFee(ctx, pool, tokenInDenom, tokenOutDenom)
+	tokenOut, _, err := balancerPool.CalcOutAmtGivenInWithFee(ctx, sdk.Coins{sdk.NewCoin(tokenInDenom, sdk.OneInt())}, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return tokenOut.Amount, nil
+}
+
+// SimulateSwapExactAmountIn is a dry-run of SwapExactAmountIn by sender, that also returns the state of the pool after the swap:
+// its balances of tokenIn's denom and of tokenOutDenom, and its spot price of tokenIn per tokenOutDenom.
+// The swap is applied exactly as SwapExactAmountIn applies it, but only to the in-memory pool,
+// so no state is written, no tokens are transferred, and no hooks are called.
+// Unlike the execution, the pool is assumed to receive all of tokenIn, see sendTokenInToPool.
+func (k Keeper) SimulateSwapExactAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+) (tokenOut sdk.Coin, newInBalance sdk.Int, newOutBalance sdk.Int, newSpotPrice sdk.Dec, err error) {
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Coin{}, sdk.Int{}, sdk.Int{}, sdk.Dec{}, err
+	}
+
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	tokenOut, spotPriceBefore, _, err := applySwapExactAmountIn(ctx, pool, tokenIn, tokenOutDenom, sdk.OneInt(), swapFee)
+	if err != nil {
+		return sdk.Coin{}, sdk.Int{}, sdk.Int{}, sdk.Dec{}, err
+	}
+
+	hop, err := k.newSwapHop(ctx, pool, tokenIn, tokenOut, spotPriceBefore, swapFee)
+	if err != nil {
+		return sdk.Coin{}, sdk.Int{}, sdk.Int{}, sdk.Dec{}, err
+	}
+
+	liquidity := pool.GetTotalPoolLiquidity(ctx)
+	return tokenOut, liquidity.AmountOf(tokenIn.Denom), liquidity.AmountOf(tokenOutDenom), hop.spotPriceAfter, nil
+}
+
+// SwapAmountOutQuote is the amount of tokens that would have to be swapped into a pool,
+// to swap out a given amount of tokens, see RankPoolsForSwapExactAmountOut.
+type SwapAmountOutQuote struct {
+	PoolId  uint64
+	TokenIn sdk.Coin
+}
+
+// RankPoolsForSwapExactAmountOut quotes how much tokenInDenom sender would have to swap into each of the candidate pools
+// to swap out exactly tokenOut, and returns the quotes ordered from the least to the most tokenIn required.
+// The first quote is the pool to swap tokenOut from the cheapest. As the price impact of a swap grows with its size,
+// which pool that is can differ for different amounts of tokenOut.
+// Candidates that cannot swap out tokenOut, e.g. as they are inactive or lack the denoms, are left out,
+// and an error is returned if none of them can. No state is written.
+func (k Keeper) RankPoolsForSwapExactAmountOut(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolIds []uint64,
+	tokenInDenom string,
+	tokenOut sdk.Coin,
+) ([]SwapAmountOutQuote, error) {
+	if tokenInDenom == tokenOut.Denom {
+		return nil, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenInDenom)
+	}
+	if !tokenOut.Amount.IsPositive() {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
+
+	quotes := make([]SwapAmountOutQuote, 0, len(poolIds))
+	for _, poolId := range poolIds {
+		pool, err := k.getPoolForSwap(ctx, poolId)
+		if err != nil {
+			continue
+		}
+		// a pool cannot swap out all of its reserves, see applySwapExactAmountOut.
+		if tokenOut.Amount.GTE(pool.GetTotalPoolLiquidity(ctx).AmountOf(tokenOut.Denom)) {
+			continue
+		}
+		tokenIn, err := pool.CalcInAmtGivenOut(ctx, sdk.Coins{tokenOut}, tokenInDenom, k.swapFeeForSender(ctx, pool, sender, tokenInDenom, tokenOut.Denom))
+		if err != nil || !tokenIn.Amount.IsPositive() {
+			continue
+		}
+		quotes = append(quotes, SwapAmountOutQuote{PoolId: poolId, TokenIn: tokenIn})
+	}
+	if len(quotes) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrNoSwappablePools, "none of the pools %v can swap %s out for %s", poolIds, tokenOut, tokenInDenom)
+	}
+
+	// candidates requiring the same amount in keep their order.
+	sort.SliceStable(quotes, func(i, j int) bool {
+		return quotes[i].TokenIn.Amount.LT(quotes[j].TokenIn.Amount)
+	})
+	return quotes, nil
+}
+
+// CalcAmountInToReachSpotPrice returns how much tokenInDenom can be swapped for tokenOutDenom
+// through the pool before the spot price of tokenOutDenom in terms of tokenInDenom reaches targetSpotPrice.
+// The pool's fee of the swap direction is used, as for EstimateSwapExactAmountIn.
+// Only balancer pools are supported, see balancer.Pool.CalcAmountInToReachSpotPrice.
+// No state is written.
+func (k Keeper) CalcAmountInToReachSpotPrice(
+	ctx sdk.Context,
+	poolId uint64,
+	tokenInDenom string,
+	tokenOutDenom string,
+	targetSpotPrice sdk.Dec,
+) (tokenInAmount sdk.Int, err error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolId)
+	}
+
+	swapFee := k.directionalSwapFee(ctx, pool, tokenInDenom, tokenOutDenom)
+	return balancerPool.CalcAmountInToReachSpotPrice(ctx, tokenInDenom, tokenOutDenom, targetSpotPrice, swapFee)
+}
+
+// CalcArbCloseAmount returns the trade closing the arbitrage between poolIdA and poolIdB of the pair of
+// tokenInDenom and tokenOutDenom: swapping tokenIn for tokenOutDenom through buyPoolId, the pool with the lower
+// spot price of tokenOutDenom, and then the tokenOutDenom received for tokenInDenom through sellPoolId,
+// brings both pools to the same spot price up to their fees of the swap directions.
+// Pools whose spot prices are within their swap fees of each other return a tokenIn of zero.
+// Only balancer pools whose weights of the pair have the same ratio are supported, see balancer.CalcArbCloseAmount.
+// No state is written.
+func (k Keeper) CalcArbCloseAmount(
+	ctx sdk.Context,
+	poolIdA uint64,
+	poolIdB uint64,
+	tokenInDenom string,
+	tokenOutDenom string,
+) (tokenIn sdk.Coin, buyPoolId uint64, sellPoolId uint64, err error) {
+	pools := make([]*balancer.Pool, 2)
+	for i, poolId := range []uint64{poolIdA, poolIdB} {
+		pool, err := k.GetPoolAndPoke(ctx, poolId)
+		if err != nil {
+			return sdk.Coin{}, 0, 0, err
+		}
+		balancerPool, ok := pool.(*balancer.Pool)
+		if !ok {
+			return sdk.Coin{}, 0, 0, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolId)
+		}
+		pools[i] = balancerPool
+	}
+
+	// at most one of the pools can be bought from at a profit, as the swap fees are not negative.
+	for i := range pools {
+		buyPool, sellPool := pools[i], pools[1-i]
+		buySwapFee := k.directionalSwapFee(ctx, buyPool, tokenInDenom, tokenOutDenom)
+		sellSwapFee := k.directionalSwapFee(ctx, sellPool, tokenOutDenom, tokenInDenom)
+		tokenInAmount, err := balancer.CalcArbCloseAmount(*buyPool, *sellPool, tokenInDenom, tokenOutDenom, buySwapFee, sellSwapFee)
+		if err != nil {
+			return sdk.Coin{}, 0, 0, err
+		}
+		if tokenInAmount.IsPositive() {
+			return sdk.NewCoin(tokenInDenom, tokenInAmount), buyPool.GetId(), sellPool.GetId(), nil
+		}
+	}
+	return sdk.NewCoin(tokenInDenom, sdk.ZeroInt()), poolIdA, poolIdB, nil
+}
+
+// SwapExactAmountInWithMaxPriceImpact is SwapExactAmountIn that additionally aborts
+// the swap if its price impact is larger than maxPriceImpact.
+// The price impact is the relative difference between the effective price paid,
+// tokenIn / tokenOut, and the pool's spot price before the swap.
+// Note that the effective price includes the swap fee, so the price impact of
+// any swap is at least the swap fee of the pool.
+func (k Keeper) SwapExactAmountInWithMaxPriceImpact(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	maxPriceImpact sdk.Dec,
+) (sdk.Int, error) {
+	if maxPriceImpact.IsNegative() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNegativeMaxPriceImpact, "max price impact %s", maxPriceImpact)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	spotPriceBefore, err := pool.SpotPrice(ctx, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	// CalcOutAmtGivenIn does not mutate the pool, so the swap itself is
+	// only executed once the price impact is known to be acceptable.
+	tokenOut, err := pool.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if !tokenOut.Amount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
+
+	// priceImpact = (tokenIn / tokenOut) / spotPriceBefore - 1
+	effectivePrice := swapEffectivePrice(tokenIn.Amount, tokenOut.Amount)
+	priceImpact := effectivePrice.Quo(spotPriceBefore).Sub(sdk.OneDec())
+	if priceImpact.GT(maxPriceImpact) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrPriceImpactTooHigh,
+			"swapping %s for %s moves the price by %s, which is more than the max of %s",
+			tokenIn, tokenOutDenom, priceImpact, maxPriceImpact)
+	}
+
+	return k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+}
+
+// MaxAmountOutMode is how SwapExactAmountInWithMaxAmountOut handles a swap,
+// that would swap out more than the max amount out.
+type MaxAmountOutMode uint8
+
+const (
+	// MaxAmountOutModeError aborts the swap with ErrLimitMaxAmount. This is the default mode.
+	MaxAmountOutModeError MaxAmountOutMode = iota
+	// MaxAmountOutModeReduceIn only swaps in as much of tokenIn as is needed to swap out exactly
+	// the max amount out. The unused tokenIn is never taken from the sender.
+	MaxAmountOutModeReduceIn
+)
+
+// SwapExactAmountInWithMaxAmountOut is SwapExactAmountIn that additionally bounds the amount swapped out
+// by tokenOutMaxAmount, for integrators that must not over-fill an order.
+// If swapping in all of tokenIn would swap out more than tokenOutMaxAmount, the mode decides whether the swap
+// errors, or whether tokenIn is reduced to the amount that swaps out exactly tokenOutMaxAmount.
+// It returns the amount of tokenIn that was swapped in, along with the amount swapped out.
+func (k Keeper) SwapExactAmountInWithMaxAmountOut(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	tokenOutMaxAmount sdk.Int,
+	mode MaxAmountOutMode,
+) (tokenInAmount sdk.Int, tokenOutAmount sdk.Int, err error) {
+	if tokenOutMaxAmount.LT(tokenOutMinAmount) {
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidAmountLimits, "max amount out %s, min amount out %s", tokenOutMaxAmount, tokenOutMinAmount)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	// CalcOutAmtGivenIn does not mutate the pool, so the swap itself is
+	// only executed once it is known which way to swap.
+	tokenOut, err := pool.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+	if tokenOut.Amount.LTE(tokenOutMaxAmount) {
+		tokenOutAmount, err = k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+		if err != nil {
+			return sdk.Int{}, sdk.Int{}, err
+		}
+		return tokenIn.Amount, tokenOutAmount, nil
+	}
+
+	switch mode {
+	case MaxAmountOutModeError:
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount,
+			"swapping %s swaps out %s, which is greater than the max amount %s", tokenIn, tokenOut, tokenOutMaxAmount)
+	case MaxAmountOutModeReduceIn:
+		// as swapping in all of tokenIn swaps out more than tokenOutMaxAmount,
+		// swapping out exactly tokenOutMaxAmount requires at most tokenIn.
+		tokenInAmount, err = k.swapExactAmountOut(ctx, sender, pool, tokenIn.Denom, tokenIn.Amount, sdk.NewCoin(tokenOutDenom, tokenOutMaxAmount), swapFee)
+		if err != nil {
+			return sdk.Int{}, sdk.Int{}, err
+		}
+		return tokenInAmount, tokenOutMaxAmount, nil
+	default:
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrUnknownSwapMode, "max amount out mode %d", mode)
+	}
+}
+
+// SwapExactAmountInWithSpotPriceLimit is SwapExactAmountIn that only swaps in as much of tokenIn as keeps
+// the spot price of tokenOutDenom in terms of tokenIn's denom at most spotPriceLimit after the swap,
+// protecting the trader from being sandwiched. If swapping in all of tokenIn would move the spot price past
+// the limit, the swap is partially filled up to the limit, and the rest of tokenIn is never taken from the sender.
+// tokenOutMinAmount bounds the amount swapped out of a partial fill too. If the spot price already is at or
+// past the limit, or swapping in a single token would move it past the limit, ErrSpotPriceUnreachable is returned. Only balancer pools are supported, see CalcAmountInToReachSpotPrice.
+// It returns the amount of tokenIn that was swapped in, along with the amount swapped out.
+func (k Keeper) SwapExactAmountInWithSpotPriceLimit(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	spotPriceLimit sdk.Dec,
+) (tokenInAmount sdk.Int, tokenOutAmount sdk.Int, err error) {
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrNotBalancerPool, "pool %d", poolId)
+	}
+
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	maxTokenInAmount, err := balancerPool.CalcAmountInToReachSpotPrice(ctx, tokenIn.Denom, tokenOutDenom, spotPriceLimit, swapFee)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+	// the limit can be above the spot price by less than swapping in a single token moves it.
+	if !maxTokenInAmount.IsPositive() {
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrSpotPriceUnreachable,
+			"swapping in any %s moves the spot price of %s past the limit %s", tokenIn.Denom, tokenOutDenom, spotPriceLimit)
+	}
+	if tokenIn.Amount.GT(maxTokenInAmount) {
+		tokenIn = sdk.NewCoin(tokenIn.Denom, maxTokenInAmount)
+	}
+
+	tokenOutAmount, err = k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+	return tokenIn.Amount, tokenOutAmount, nil
+}
+
+// DustMode is how SwapExactAmountInWithDustMode handles a swap of tokenIn,
+// that is too small relative to the pool to swap out any tokens.
+type DustMode uint8
+
+const (
+	// DustModeError aborts the swap with ErrInvalidMathApprox, giving the minimum amount in
+	// that swaps out at least 1 token in the message. This is the default mode.
+	DustModeError DustMode = iota
+	// DustModeRaiseIn swaps in the minimum amount in that swaps out at least 1 token instead of tokenIn,
+	// if it is at most the tokenInMaxAmount the sender signed for, and covered by the sender's balance.
+	DustModeRaiseIn
+)
+
+// SwapExactAmountInWithDustMode is SwapExactAmountIn for dust-sweeping integrators, that handles a tokenIn
+// that would swap out nothing according to mode, rather than failing with an opaque math error.
+// tokenInMaxAmount bounds the amount in DustModeRaiseIn may raise tokenIn to, and is ignored by DustModeError.
+// It returns the amount of tokenIn that was swapped in, along with the amount swapped out.
+func (k Keeper) SwapExactAmountInWithDustMode(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	tokenInMaxAmount sdk.Int,
+	mode DustMode,
+) (tokenInAmount sdk.Int, tokenOutAmount sdk.Int, err error) {
+	if tokenIn.Denom == tokenOutDenom {
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenIn.Denom)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, sdk.Int{}, err
+	}
+
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenIn.Denom, tokenOutDenom)
+	// CalcOutAmtGivenIn fails with ErrInvalidMathApprox when tokenIn swaps out no tokens,
+	// but also when the swap is outside of the domain of the pool's math, e.g. for a huge tokenIn.
+	_, calcErr := pool.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, tokenOutDenom, swapFee)
+	if calcErr != nil && !errors.Is(calcErr, types.ErrInvalidMathApprox) {
+		return sdk.Int{}, sdk.Int{}, calcErr
+	}
+	if calcErr == nil {
+		tokenOutAmount, err = k.swapExactAmountIn(ctx, sender, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+		if err != nil {
+			return sdk.Int{}, sdk.Int{}, err
+		}
+		return tokenIn.Amount, tokenOutAmount, nil
+	}
+
+	// CalcInAmtGivenOut rounds the amount in up, so swapping it in swaps out at least 1 token.
+	// tokenIn is only dust if it is less than that, any other math error is returned as is.
+	minTokenIn, err := pool.CalcInAmtGivenOut(ctx, sdk.NewCoins(sdk.NewCoin(tokenOutDenom, sdk.OneInt())), tokenIn.Denom, swapFee)
+	if err != nil || tokenIn.Amount.GTE(minTokenIn.Amount) {
+		return sdk.Int{}, sdk.Int{}, calcErr
+	}
+
+	switch mode {
+	case DustModeError:
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox,
+			"swapping %s swaps out no %s, swap at least %s", tokenIn, tokenOutDenom, minTokenIn)
+	case DustModeRaiseIn:
+		if minTokenIn.Amount.GT(tokenInMaxAmount) {
+			return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount,
+				"swapping %s swaps out no %s, and the minimum amount in %s is greater than the max amount in %s", tokenIn, tokenOutDenom, minTokenIn, tokenInMaxAmount)
+		}
+		balance := k.bankKeeper.GetBalance(ctx, sender, tokenIn.Denom)
+		if balance.Amount.LT(minTokenIn.Amount) {
+			return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds,
+				"swapping %s swaps out no %s, and the balance %s is lesser than the minimum amount in %s", tokenIn, tokenOutDenom, balance, minTokenIn)
+		}
+		tokenOutAmount, err = k.swapExactAmountIn(ctx, sender, pool, minTokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+		if err != nil {
+			return sdk.Int{}, sdk.Int{}, err
+		}
+		return minTokenIn.Amount, tokenOutAmount, nil
+	default:
+		return sdk.Int{}, sdk.Int{}, sdkerrors.Wrapf(types.ErrUnknownSwapMode, "dust mode %d", mode)
+	}
+}
+
 // swapExactAmountIn is an internal method for swapping an exact amount of tokens
 // as input to a pool, using the provided swapFee. This is intended to allow
 // different swap fees as determined by multi-hops, or when recovering from
@@ -44,31 +545,88 @@ func (k Keeper) swapExactAmountIn(
 	tokenOutMinAmount sdk.Int,
 	swapFee sdk.Dec,
 ) (tokenOutAmount sdk.Int, err error) {
-	if tokenIn.Denom == tokenOutDenom {
-		return sdk.Int{}, errors.New("cannot trade same denomination in and out")
+	tokenOutCoin, spotPriceBefore, tokenOutRemainder, err := applySwapExactAmountIn(ctx, pool, tokenIn, tokenOutDenom, tokenOutMinAmount, swapFee)
+	if err != nil {
+		return sdk.Int{}, err
 	}
-	tokensIn := sdk.Coins{tokenIn}
 
-	tokenOutCoin, err := pool.SwapOutAmtGivenIn(ctx, tokensIn, tokenOutDenom, swapFee)
+	hop, err := k.newSwapHop(ctx, pool, tokenIn, tokenOutCoin, spotPriceBefore, swapFee)
 	if err != nil {
 		return sdk.Int{}, err
 	}
+	hop.tokenOutRemainder = tokenOutRemainder
+	if err := k.updatePoolForSwapHop(ctx, sender, hop); err != nil {
+		return sdk.Int{}, err
+	}
 
-	tokenOutAmount = tokenOutCoin.Amount
+	return tokenOutCoin.Amount, nil
+}
 
-	if !tokenOutAmount.IsPositive() {
-		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+// applySwapExactAmountIn swaps tokenIn for tokenOutDenom against the pool, only mutating the pool struct.
+// It returns the tokens swapped out, the spot price of tokenIn per tokenOutDenom before the swap,
+// and the fraction of a token by which tokenOut was rounded down, which is zero for pools other than balancer pools.
+// The caller has to write the pool and move the tokens, see updatePoolForSwap.
+func applySwapExactAmountIn(
+	ctx sdk.Context,
+	pool types.PoolI,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	swapFee sdk.Dec,
+) (tokenOut sdk.Coin, spotPriceBefore sdk.Dec, tokenOutRemainder sdk.Dec, err error) {
+	if tokenIn.Denom == tokenOutDenom {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenIn.Denom)
+	}
+	if err := validateTokenOutBalance(ctx, pool, tokenOutDenom); err != nil {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, err
 	}
+	tokensIn := sdk.Coins{tokenIn}
 
-	if tokenOutAmount.LT(tokenOutMinAmount) {
-		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMinAmount, "%s token is lesser than min amount", tokenOutDenom)
+	spotPriceBefore, err = pool.SpotPrice(ctx, tokenIn.Denom, tokenOutDenom)
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, err
 	}
 
-	if err := k.updatePoolForSwap(ctx, pool, sender, tokenIn, tokenOutCoin); err != nil {
-		return sdk.Int{}, err
+	if balancerPool, ok := pool.(*balancer.Pool); ok {
+		tokenOut, tokenOutRemainder, err = balancerPool.SwapOutAmtGivenInWithRemainder(ctx, tokensIn, tokenOutDenom, swapFee)
+	} else {
+		tokenOut, err = pool.SwapOutAmtGivenIn(ctx, tokensIn, tokenOutDenom, swapFee)
+		tokenOutRemainder = sdk.ZeroDec()
+	}
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, err
 	}
 
-	return tokenOutAmount, nil
+	if !tokenOut.Amount.IsPositive() {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
+
+	if tokenOut.Amount.LT(tokenOutMinAmount) {
+		return sdk.Coin{}, sdk.Dec{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrLimitMinAmount, "%s token is lesser than min amount", tokenOutDenom)
+	}
+
+	return tokenOut, spotPriceBefore, tokenOutRemainder, nil
+}
+
+// validateTokenOutBalance returns an error if the pool can't pay tokenOutDenom out.
+// That is ErrDenomNotFoundInPool, listing the pool's denoms, if tokenOutDenom isn't in the pool,
+// and ErrPoolAssetDepleted if the pool holds no balance of it, rather than leaving
+// the degenerate zero balance output asset to the pool's math.
+func validateTokenOutBalance(ctx sdk.Context, pool types.PoolI, tokenOutDenom string) error {
+	if pool.GetTotalPoolLiquidity(ctx).AmountOfNoDenomValidation(tokenOutDenom).IsPositive() {
+		return nil
+	}
+	// balancer pools keep their assets' balances apart from their liquidity coins, so an asset
+	// with a zero balance is in the pool but not in its liquidity.
+	// The liquidity of other pools is their assets, which the pool's math checks the denom against.
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return nil
+	}
+	if _, err := balancerPool.GetPoolAsset(tokenOutDenom); err != nil {
+		return err
+	}
+	return sdkerrors.Wrapf(types.ErrPoolAssetDepleted, "denom %s of pool %d", tokenOutDenom, pool.GetId())
 }
 
 func (k Keeper) SwapExactAmountOut(
@@ -83,11 +641,85 @@ func (k Keeper) SwapExactAmountOut(
 	if err != nil {
 		return sdk.Int{}, err
 	}
-	swapFee := pool.GetSwapFee(ctx)
+	swapFee := k.swapFeeForSender(ctx, pool, sender, tokenInDenom, tokenOut.Denom)
 	return k.swapExactAmountOut(ctx, sender, pool, tokenInDenom, tokenInMaxAmount, tokenOut, swapFee)
 }
 
-// swapExactAmountIn is an internal method for swapping to get an exact number of tokens out of a pool,
+// SwapExactAmountOutMulti swaps tokenInDenom for exactly every coin of tokensOut, all through the pool with poolId,
+// e.g. to rebalance a portfolio in a single transaction. It returns the total amount of tokenInDenom swapped in.
+// The coins of tokensOut are swapped out one after the other in coin order, each against the pool as left by the
+// previous ones, and each with the swap fee of its own direction. It errors with ErrLimitMaxAmount if the swaps
+// together require more than tokenInMaxAmount, in which case none of them is written.
+func (k Keeper) SwapExactAmountOutMulti(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenInDenom string,
+	tokenInMaxAmount sdk.Int,
+	tokensOut sdk.Coins,
+) (tokenInAmount sdk.Int, err error) {
+	if tokensOut.Empty() {
+		return sdk.Int{}, sdkerrors.Wrap(types.ErrInvalidTokenOutDenoms, "no tokens out to swap for")
+	}
+	if err := tokensOut.Validate(); err != nil {
+		return sdk.Int{}, err
+	}
+	if tokensOut.AmountOf(tokenInDenom).IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenInDenom)
+	}
+
+	pool, err := k.getPoolForSwap(ctx, poolId)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	// every swap is limited to what the previous swaps left of tokenInMaxAmount, and written on a
+	// cache context, so that a later swap exceeding the combined maximum reverts the earlier ones.
+	cacheCtx, write := ctx.CacheContext()
+	tokenInAmount = sdk.ZeroInt()
+	for _, tokenOut := range tokensOut {
+		swapFee := k.swapFeeForSender(cacheCtx, pool, sender, tokenInDenom, tokenOut.Denom)
+		amount, err := k.swapExactAmountOut(cacheCtx, sender, pool, tokenInDenom, tokenInMaxAmount.Sub(tokenInAmount), tokenOut, swapFee)
+		if err != nil {
+			return sdk.Int{}, err
+		}
+		tokenInAmount = tokenInAmount.Add(amount)
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return tokenInAmount, nil
+}
+
+// BatchSwapExactAmountIn executes the independent exact amount in swaps of swaps atomically, in order,
+// e.g. for the rebalances of an arbitrage across several pools. Unlike a multihop swap, the token out of a swap
+// is not swapped on by the next one. Each swap is charged the swap fee of its own pool and direction, and must
+// get its own minimum amount out. It returns the amount out of every swap, and if any swap fails, none of them
+// is written.
+func (k Keeper) BatchSwapExactAmountIn(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	swaps []types.BatchSwapAmountIn,
+) (tokenOutAmounts []sdk.Int, err error) {
+	if len(swaps) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmptySwaps, "no swaps to batch")
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	tokenOutAmounts = make([]sdk.Int, len(swaps))
+	for i, swap := range swaps {
+		tokenOutAmounts[i], err = k.SwapExactAmountIn(cacheCtx, sender, swap.PoolId, swap.TokenIn, swap.TokenOutDenom, swap.TokenOutMinAmount)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "swap %d of pool %d", i, swap.PoolId)
+		}
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return tokenOutAmounts, nil
+}
+
+// swapExactAmountOut is an internal method for swapping to get an exact number of tokens out of a pool,
 // using the provided swapFee.
 // This is intended to allow different swap fees as determined by multi-hops,
 // or when recovering from chain liveness failures.
@@ -100,72 +732,258 @@ func (k Keeper) swapExactAmountOut(
 	tokenOut sdk.Coin,
 	swapFee sdk.Dec,
 ) (tokenInAmount sdk.Int, err error) {
+	tokenIn, spotPriceBefore, err := applySwapExactAmountOut(ctx, pool, tokenInDenom, tokenInMaxAmount, tokenOut, swapFee)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	err = k.updatePoolForSwap(ctx, pool, sender, tokenIn, tokenOut, spotPriceBefore, swapFee)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return tokenIn.Amount, nil
+}
+
+// applySwapExactAmountOut swaps tokenInDenom for exactly tokenOut against the pool, only mutating the pool struct.
+// It returns the tokens swapped in, and the spot price of tokenInDenom per tokenOut before the swap.
+// The caller has to write the pool and move the tokens, see updatePoolForSwap.
+func applySwapExactAmountOut(
+	ctx sdk.Context,
+	pool types.PoolI,
+	tokenInDenom string,
+	tokenInMaxAmount sdk.Int,
+	tokenOut sdk.Coin,
+	swapFee sdk.Dec,
+) (tokenIn sdk.Coin, spotPriceBefore sdk.Dec, err error) {
 	if tokenInDenom == tokenOut.Denom {
-		return sdk.Int{}, errors.New("cannot trade same denomination in and out")
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenInDenom)
+	}
+
+	if !tokenOut.Amount.IsPositive() {
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
 	}
 
 	poolOutBal := pool.GetTotalPoolLiquidity(ctx).AmountOf(tokenOut.Denom)
 	if tokenOut.Amount.GTE(poolOutBal) {
-		return sdk.Int{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut,
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut,
 			"can't get more tokens out than there are tokens in the pool")
 	}
-	tokenIn, err := pool.SwapInAmtGivenOut(ctx, sdk.Coins{tokenOut}, tokenInDenom, swapFee)
+
+	spotPriceBefore, err = pool.SpotPrice(ctx, tokenInDenom, tokenOut.Denom)
 	if err != nil {
-		return sdk.Int{}, err
+		return sdk.Coin{}, sdk.Dec{}, err
 	}
-	tokenInAmount = tokenIn.Amount
 
-	if tokenInAmount.LTE(sdk.ZeroInt()) {
-		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount is zero or negative")
+	tokenIn, err = pool.SwapInAmtGivenOut(ctx, sdk.Coins{tokenOut}, tokenInDenom, swapFee)
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, err
 	}
 
-	if tokenInAmount.GT(tokenInMaxAmount) {
-		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, "Swap requires %s, which is greater than the amount %s", tokenIn, tokenInMaxAmount)
+	if tokenIn.Amount.LTE(sdk.ZeroInt()) {
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount is zero or negative")
 	}
 
-	err = k.updatePoolForSwap(ctx, pool, sender, tokenIn, tokenOut)
-	if err != nil {
-		return sdk.Int{}, err
+	if tokenIn.Amount.GT(tokenInMaxAmount) {
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, "Swap requires %s, which is greater than the amount %s", tokenIn, tokenInMaxAmount)
 	}
-	return tokenInAmount, nil
+
+	return tokenIn, spotPriceBefore, nil
 }
 
 // updatePoolForSwap takes a pool, sender, and tokenIn, tokenOut amounts
 // It then updates the pool's balances to the new reserve amounts, and
 // sends the in tokens from the sender to the pool, and the out tokens from the pool to the sender.
+// spotPriceBefore is the pool's spot price of tokenIn per tokenOut before the swap,
+// which is emitted in the swap event along with the spot price after the swap.
+// The AfterSwap hook is called once the swap is fully applied, and if it fails none of the swap is written.
+// If the pool receives less than tokenIn, e.g. for a denom with a transfer tax, the swap is rejected,
+// see sendTokenInToPool.
+// The amounts swapped in and out are added to the pool's volume, see GetPoolVolume.
+// The protocol's share of the swapFee paid is sent from the pool to the protocol fee module account, see newSwapHop,
+// and the rest of it is added to the pool's fees per share, see GetPoolFeesPerShare.
 func (k Keeper) updatePoolForSwap(
 	ctx sdk.Context,
 	pool types.PoolI,
 	sender sdk.AccAddress,
 	tokenIn sdk.Coin,
 	tokenOut sdk.Coin,
+	spotPriceBefore sdk.Dec,
+	swapFee sdk.Dec,
 ) error {
+	hop, err := k.newSwapHop(ctx, pool, tokenIn, tokenOut, spotPriceBefore, swapFee)
+	if err != nil {
+		return err
+	}
+	return k.updatePoolForSwapHop(ctx, sender, hop)
+}
+
+// updatePoolForSwapHop is updatePoolForSwap for a swap whose hop has already been created, see newSwapHop.
+func (k Keeper) updatePoolForSwapHop(ctx sdk.Context, sender sdk.AccAddress, hop swapHop) error {
+	pool, tokenIn, tokenOut := hop.pool, hop.tokenIn, hop.tokenOut
 	tokensIn := sdk.Coins{tokenIn}
 	tokensOut := sdk.Coins{tokenOut}
 
-	err := k.SetPool(ctx, pool)
-	if err != nil {
+	// the swap is applied on a cache context, that only gets written once the AfterSwap hook succeeded.
+	cacheCtx, write := ctx.CacheContext()
+	if err := k.sendTokenInToPool(cacheCtx, sender, pool, tokenIn); err != nil {
 		return err
 	}
 
-	err = k.bankKeeper.SendCoins(ctx, sender, pool.GetAddress(), sdk.Coins{
-		tokenIn,
+	err := k.bankKeeper.SendCoins(cacheCtx, pool.GetAddress(), sender, sdk.Coins{
+		tokenOut,
 	})
 	if err != nil {
 		return err
 	}
+	if err := k.sendProtocolFee(cacheCtx, hop); err != nil {
+		return err
+	}
 
-	err = k.bankKeeper.SendCoins(ctx, pool.GetAddress(), sender, sdk.Coins{
-		tokenOut,
-	})
+	err = k.SetPool(cacheCtx, pool)
 	if err != nil {
 		return err
 	}
 
-	ctx.EventManager().EmitEvent(types.CreateSwapEvent(ctx, sender, pool.GetId(), tokensIn, tokensOut))
-	k.hooks.AfterSwap(ctx, sender, pool.GetId(), tokensIn, tokensOut)
-	k.RecordTotalLiquidityIncrease(ctx, tokensIn)
-	k.RecordTotalLiquidityDecrease(ctx, tokensOut)
+	k.recordSwapVolume(cacheCtx, sender, hop)
+	k.recordPoolAccumulators(cacheCtx, hop)
+	k.checkCircuitBreaker(cacheCtx, hop)
+	k.RecordTotalLiquidityIncrease(cacheCtx, tokensIn)
+	k.RecordTotalLiquidityDecrease(cacheCtx, tokensOut)
+
+	err = k.emitSwapHop(cacheCtx, sender, hop)
+	if err != nil {
+		return err
+	}
+
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return nil
+}
+
+// sendTokenInToPool sends tokenIn from sender to the pool, and checks that the pool received all of it,
+// measured by the change in the pool's balance. The swap already added all of tokenIn to the pool's reserves,
+// so a denom that deducts a tax on transfer would otherwise leave the pool's LPs paying the tax.
+// Such swaps fail with ErrTokenInNotReceived, rather than being recomputed on the amount the pool received,
+// so that the amount out is never computed from a tokenIn other than the one the sender signed for.
+func (k Keeper) sendTokenInToPool(ctx sdk.Context, sender sdk.AccAddress, pool types.PoolI, tokenIn sdk.Coin) error {
+	balanceBefore := k.bankKeeper.GetBalance(ctx, pool.GetAddress(), tokenIn.Denom)
+	err := k.bankKeeper.SendCoins(ctx, sender, pool.GetAddress(), sdk.Coins{tokenIn})
+	if err != nil {
+		return err
+	}
+	balanceAfter := k.bankKeeper.GetBalance(ctx, pool.GetAddress(), tokenIn.Denom)
+	if !balanceAfter.Amount.Sub(balanceBefore.Amount).Equal(tokenIn.Amount) {
+		return sdkerrors.Wrapf(types.ErrTokenInNotReceived, "balance of pool %d went from %s to %s on receiving %s",
+			pool.GetId(), balanceBefore, balanceAfter, tokenIn)
+	}
+	return nil
+}
+
+// swapHop is a swap of tokenIn for tokenOut that has been applied to the pool struct,
+// but not yet written to state.
+type swapHop struct {
+	pool            types.PoolI
+	tokenIn         sdk.Coin
+	tokenOut        sdk.Coin
+	spotPriceBefore sdk.Dec
+	spotPriceAfter  sdk.Dec
+	// feeFree is whether the swap was charged no swap fee, see SwapExactAmountInNoFee.
+	feeFree bool
+	// tokenOutRemainder is the fraction of a token tokenOut was rounded down by, see GetPoolRoundingDust.
+	// It is only set for swaps of an exact amount in.
+	tokenOutRemainder sdk.Dec
+	// protocolFee is the part of the swap fee taken out of the pool for the protocol, see GetProtocolFeeShare.
+	protocolFee sdk.Coin
+	// lpFee is the part of the swap fee paid on tokenIn that stays in the pool for its LPs, see GetPoolFeesPerShare.
+	lpFee sdk.Dec
+}
+
+// newSwapHop returns the swapHop of a swap that has just been applied to the pool struct,
+// recording the pool's spot price after the swap, before any later swap through the same pool.
+// It errors if the swap took the pool's reserve of tokenOut below its minimum, see GetMinPoolReserve,
+// or if the swap's direction is disabled on the pool, see SetDisabledSwapDirections.
+// The protocol's share of swapFee is taken out of the pool's reserves of tokenIn's denom, before the spot price
+// after the swap is recorded, so later swaps through the pool swap against the reserves without it.
+func (k Keeper) newSwapHop(ctx sdk.Context, pool types.PoolI, tokenIn sdk.Coin, tokenOut sdk.Coin, spotPriceBefore sdk.Dec, swapFee sdk.Dec) (swapHop, error) {
+	if err := k.checkSwapDirectionEnabled(ctx, pool.GetId(), tokenIn.Denom, tokenOut.Denom); err != nil {
+		return swapHop{}, err
+	}
+	if err := k.checkMinPoolReserve(ctx, pool, tokenOut.Denom); err != nil {
+		return swapHop{}, err
+	}
+
+	if checkSwapInvariant {
+		if err := checkSwapInvariantNotDecreased(ctx, pool, tokenIn, tokenOut); err != nil {
+			return swapHop{}, err
+		}
+	}
 
-	return err
+	protocolFee, err := k.deductProtocolFee(ctx, pool, tokenIn, swapFee)
+	if err != nil {
+		return swapHop{}, err
+	}
+
+	spotPriceAfter, err := pool.SpotPrice(ctx, tokenIn.Denom, tokenOut.Denom)
+	if err != nil {
+		return swapHop{}, err
+	}
+
+	return swapHop{
+		pool:            pool,
+		tokenIn:         tokenIn,
+		tokenOut:        tokenOut,
+		spotPriceBefore: spotPriceBefore,
+		spotPriceAfter:  spotPriceAfter,
+		protocolFee:     protocolFee,
+		lpFee:           tokenIn.Amount.ToDec().Mul(swapFee).Sub(protocolFee.Amount.ToDec()),
+	}, nil
+}
+
+// emitSwapHop emits the swap event of hop, and calls the AfterSwap hook.
+// It is called after all state of the swap is written, so the hook observes the swap fully applied.
+func (k Keeper) emitSwapHop(ctx sdk.Context, sender sdk.AccAddress, hop swapHop) error {
+	tokensIn := sdk.Coins{hop.tokenIn}
+	tokensOut := sdk.Coins{hop.tokenOut}
+	event := types.CreateSwapEvent(ctx, sender, hop.pool.GetId(), tokensIn, tokensOut, hop.spotPriceBefore, hop.spotPriceAfter)
+	if hop.feeFree {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyFeeFree, "true"))
+	}
+	if hop.protocolFee.IsPositive() {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeyProtocolFee, hop.protocolFee.String()))
+	}
+	ctx.EventManager().EmitEvent(event)
+	return k.hooks.AfterSwap(ctx, sender, hop.pool.GetId(), tokensIn, tokensOut)
+}
+
+// checkSwapInvariant enables checking in updatePoolForSwap that swaps through balancer pools
+// don't decrease the pool's invariant by more than swapInvariantTolerance, to catch rounding
+// in favor of the swapper. It recomputes the invariant on every swap, so it is off by default,
+// and enabled in tests.
+var checkSwapInvariant = false
+
+// swapInvariantTolerance is the relative decrease of the invariant allowed by checkSwapInvariant,
+// to account for the precision of the power approximation used in computing the invariant.
+var swapInvariantTolerance = sdk.NewDecWithPrec(1, 12)
+
+// checkSwapInvariantNotDecreased returns ErrInvariantDecreased if swapping tokenIn for tokenOut,
+// which has already been applied to the pool, decreased its invariant by more than swapInvariantTolerance.
+// Pools other than balancer pools are not checked.
+func checkSwapInvariantNotDecreased(ctx sdk.Context, pool types.PoolI, tokenIn sdk.Coin, tokenOut sdk.Coin) error {
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return nil
+	}
+
+	invariantRatio, err := balancerPool.SwapInvariantRatio(tokenIn, tokenOut)
+	if err != nil {
+		return err
+	}
+	if invariantRatio.LT(sdk.OneDec().Sub(swapInvariantTolerance)) {
+		delta := sdk.OneDec().Sub(invariantRatio)
+		ctx.Logger().Error(fmt.Sprintf("swapping %s for %s decreased the invariant of pool %d by a relative %s",
+			tokenIn, tokenOut, pool.GetId(), delta))
+		return sdkerrors.Wrapf(types.ErrInvariantDecreased,
+			"swapping %s for %s decreased the invariant of pool %d by a relative %s", tokenIn, tokenOut, pool.GetId(), delta)
+	}
+	return nil
 }
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// SwapExactAmountInWithDeadline is SwapExactAmountIn, rejected with ErrDeadlineExceeded if the block is past deadline.
+func (k Keeper) SwapExactAmountInWithDeadline(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenIn sdk.Coin,
+	tokenOutDenom string,
+	tokenOutMinAmount sdk.Int,
+	deadline types.SwapDeadline,
+) (sdk.Int, error) {
+	if err := deadline.Check(ctx); err != nil {
+		return sdk.Int{}, err
+	}
+	return k.SwapExactAmountIn(ctx, sender, poolId, tokenIn, tokenOutDenom, tokenOutMinAmount)
+}
+
+// SwapExactAmountOutWithDeadline is SwapExactAmountOut, rejected with ErrDeadlineExceeded if the block is past deadline.
+func (k Keeper) SwapExactAmountOutWithDeadline(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	poolId uint64,
+	tokenInDenom string,
+	tokenInMaxAmount sdk.Int,
+	tokenOut sdk.Coin,
+	deadline types.SwapDeadline,
+) (sdk.Int, error) {
+	if err := deadline.Check(ctx); err != nil {
+		return sdk.Int{}, err
+	}
+	return k.SwapExactAmountOut(ctx, sender, poolId, tokenInDenom, tokenInMaxAmount, tokenOut)
+}
+
+// MultihopSwapExactAmountInWithDeadline is MultihopSwapExactAmountIn, rejected with ErrDeadlineExceeded
+// if the block is past deadline.
+func (k Keeper) MultihopSwapExactAmountInWithDeadline(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	routes []types.SwapAmountInRoute,
+	tokenIn sdk.Coin,
+	tokenOutMinAmount sdk.Int,
+	deadline types.SwapDeadline,
+) (sdk.Int, error) {
+	if err := deadline.Check(ctx); err != nil {
+		return sdk.Int{}, err
+	}
+	return k.MultihopSwapExactAmountIn(ctx, sender, routes, tokenIn, tokenOutMinAmount)
+}
+
+// MultihopSwapExactAmountOutWithDeadline is MultihopSwapExactAmountOut, rejected with ErrDeadlineExceeded
+// if the block is past deadline.
+func (k Keeper) MultihopSwapExactAmountOutWithDeadline(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	routes []types.SwapAmountOutRoute,
+	tokenInMaxAmount sdk.Int,
+	tokenOut sdk.Coin,
+	deadline types.SwapDeadline,
+) (sdk.Int, error) {
+	if err := deadline.Check(ctx); err != nil {
+		return sdk.Int{}, err
+	}
+	return k.MultihopSwapExactAmountOut(ctx, sender, routes, tokenInMaxAmount, tokenOut)
+}
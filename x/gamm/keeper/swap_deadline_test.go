@@ -0,0 +1,97 @@
+package keeper_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestSwapsWithDeadline() {
+	tests := []struct {
+		name        string
+		deadline    func(ctx sdk.Context) types.SwapDeadline
+		expectedErr error
+	}{
+		{
+			name:     "no deadline",
+			deadline: func(sdk.Context) types.SwapDeadline { return types.SwapDeadline{} },
+		},
+		{
+			name:     "future height",
+			deadline: func(ctx sdk.Context) types.SwapDeadline { return types.SwapDeadline{Height: ctx.BlockHeight() + 1} },
+		},
+		{
+			name:     "current height",
+			deadline: func(ctx sdk.Context) types.SwapDeadline { return types.SwapDeadline{Height: ctx.BlockHeight()} },
+		},
+		{
+			name:        "past height",
+			deadline:    func(ctx sdk.Context) types.SwapDeadline { return types.SwapDeadline{Height: ctx.BlockHeight() - 1} },
+			expectedErr: types.ErrDeadlineExceeded,
+		},
+		{
+			name: "future time",
+			deadline: func(ctx sdk.Context) types.SwapDeadline {
+				return types.SwapDeadline{Time: ctx.BlockTime().Add(time.Minute)}
+			},
+		},
+		{
+			name: "past time",
+			deadline: func(ctx sdk.Context) types.SwapDeadline {
+				return types.SwapDeadline{Time: ctx.BlockTime().Add(-time.Minute)}
+			},
+			expectedErr: types.ErrDeadlineExceeded,
+		},
+		{
+			name: "future height and past time",
+			deadline: func(ctx sdk.Context) types.SwapDeadline {
+				return types.SwapDeadline{Height: ctx.BlockHeight() + 1, Time: ctx.BlockTime().Add(-time.Minute)}
+			},
+			expectedErr: types.ErrDeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			suite.Ctx = suite.Ctx.WithBlockHeight(10)
+			keeper := suite.App.GAMMKeeper
+			poolId := suite.PrepareBalancerPool()
+			sender := suite.TestAccs[0]
+			deadline := test.deadline(suite.Ctx)
+			tokenIn := sdk.NewInt64Coin("foo", 10000)
+			tokenOut := sdk.NewInt64Coin("bar", 10000)
+
+			swaps := map[string]func(ctx sdk.Context) (sdk.Int, error){
+				"exact amount in": func(ctx sdk.Context) (sdk.Int, error) {
+					return keeper.SwapExactAmountInWithDeadline(ctx, sender, poolId, tokenIn, "bar", sdk.OneInt(), deadline)
+				},
+				"exact amount out": func(ctx sdk.Context) (sdk.Int, error) {
+					return keeper.SwapExactAmountOutWithDeadline(ctx, sender, poolId, "foo", sdk.NewInt(1000000), tokenOut, deadline)
+				},
+				"multihop exact amount in": func(ctx sdk.Context) (sdk.Int, error) {
+					routes := []types.SwapAmountInRoute{{PoolId: poolId, TokenOutDenom: "bar"}, {PoolId: poolId, TokenOutDenom: "baz"}}
+					return keeper.MultihopSwapExactAmountInWithDeadline(ctx, sender, routes, tokenIn, sdk.OneInt(), deadline)
+				},
+				"multihop exact amount out": func(ctx sdk.Context) (sdk.Int, error) {
+					routes := []types.SwapAmountOutRoute{{PoolId: poolId, TokenInDenom: "foo"}, {PoolId: poolId, TokenInDenom: "baz"}}
+					return keeper.MultihopSwapExactAmountOutWithDeadline(ctx, sender, routes, sdk.NewInt(1000000), tokenOut, deadline)
+				},
+			}
+			for name, swap := range swaps {
+				ctx, _ := suite.Ctx.CacheContext()
+				balancesBefore := suite.App.BankKeeper.GetAllBalances(ctx, sender)
+				amount, err := swap(ctx)
+				if test.expectedErr != nil {
+					suite.Require().ErrorIs(err, test.expectedErr, name)
+					suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(ctx, sender), name)
+					continue
+				}
+				suite.Require().NoError(err, name)
+				suite.Require().True(amount.IsPositive(), name)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// A pool with swap fee tiers charges traders a swap fee depending on their swap volume through the pool
+// over the last types.SwapVolumeWindow. The volume of every trader through such a pool is recorded in buckets
+// of types.SwapVolumeBucketDuration, which are pruned once they leave the window.
+// Pools without swap fee tiers don't record any volume, so their swaps don't pay for it.
+
+// SetSwapFeeTiers sets the swap fee tiers of the balancer pool poolId, in the pool's params.
+// Tiers without any tier remove the pool's tiers, so that all traders pay the pool's swap fee again.
+// Pools can also be created with swap fee tiers, see balancer.PoolParams.
+// It is called by governance through a SetSwapFeeTiersProposal.
+func (k Keeper) SetSwapFeeTiers(ctx sdk.Context, poolId uint64, tiers types.SwapFeeTiers) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrUnsupportedPoolType, "swap fee tiers of pool %d of type %T can't be set", poolId, pool)
+	}
+
+	if len(tiers.Tiers) == 0 {
+		balancerPool.PoolParams.SwapFeeTiers = nil
+		return k.SetPool(ctx, balancerPool)
+	}
+
+	if err := tiers.Validate(pool.GetSwapFee(ctx)); err != nil {
+		return err
+	}
+	if pool.GetTotalPoolLiquidity(ctx).AmountOf(tiers.VolumeDenom).IsZero() {
+		return sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "volume denom %s is not an asset of pool %d", tiers.VolumeDenom, poolId)
+	}
+	balancerPool.PoolParams.SwapFeeTiers = &tiers
+	return k.SetPool(ctx, balancerPool)
+}
+
+// GetSwapFeeTiers returns the swap fee tiers of poolId, and false if the pool has none.
+func (k Keeper) GetSwapFeeTiers(ctx sdk.Context, poolId uint64) (types.SwapFeeTiers, bool) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return types.SwapFeeTiers{}, false
+	}
+	return swapFeeTiers(pool)
+}
+
+// swapFeeTiers returns the swap fee tiers in the params of pool, and false if it has none.
+// Only balancer pools have swap fee tiers.
+func swapFeeTiers(pool types.PoolI) (types.SwapFeeTiers, bool) {
+	balancerPool, ok := pool.(*balancer.Pool)
+	if !ok || balancerPool.PoolParams.SwapFeeTiers == nil {
+		return types.SwapFeeTiers{}, false
+	}
+	return *balancerPool.PoolParams.SwapFeeTiers, true
+}
+
+// GetPoolSwapFee returns the swap fee that sender currently pays on swapping tokenInDenom for tokenOutDenom through poolId.
+func (k Keeper) GetPoolSwapFee(ctx sdk.Context, poolId uint64, sender sdk.AccAddress, tokenInDenom, tokenOutDenom string) (sdk.Dec, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return k.swapFeeForSender(ctx, pool, sender, tokenInDenom, tokenOutDenom), nil
+}
+
+// swapFeeForSender returns the swap fee sender pays on swapping tokenInDenom for tokenOutDenom through the pool.
+// That is the pool's fee of the swap direction, see directionalSwapFee, unless the fee of the pool's tier
+// for sender's swap volume is lower.
+func (k Keeper) swapFeeForSender(ctx sdk.Context, pool types.PoolI, sender sdk.AccAddress, tokenInDenom, tokenOutDenom string) sdk.Dec {
+	swapFee := k.directionalSwapFee(ctx, pool, tokenInDenom, tokenOutDenom)
+	tiers, found := swapFeeTiers(pool)
+	if !found {
+		return swapFee
+	}
+	return sdk.MinDec(swapFee, tiers.SwapFeeForVolume(swapFee, k.GetSwapVolume(ctx, pool.GetId(), sender)))
+}
+
+// swapVolumeWindowStart returns the start of the oldest volume bucket that overlaps
+// the volume window ending at the block time.
+func swapVolumeWindowStart(ctx sdk.Context) time.Time {
+	return ctx.BlockTime().Add(-types.SwapVolumeWindow).Truncate(types.SwapVolumeBucketDuration)
+}
+
+// GetSwapVolume returns trader's swap volume through poolId over the last types.SwapVolumeWindow,
+// in the volume denom of the pool's swap fee tiers.
+func (k Keeper) GetSwapVolume(ctx sdk.Context, poolId uint64, trader sdk.AccAddress) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	windowStart := swapVolumeWindowStart(ctx)
+	iter := store.Iterator(
+		types.GetKeySwapVolume(poolId, trader, windowStart),
+		sdk.PrefixEndBytes(types.GetKeyPrefixSwapVolumes(poolId, trader)),
+	)
+	defer iter.Close()
+
+	volume := sdk.ZeroInt()
+	for ; iter.Valid(); iter.Next() {
+		var bucketVolume sdk.Int
+		if err := bucketVolume.Unmarshal(iter.Value()); err != nil {
+			panic(err)
+		}
+		volume = volume.Add(bucketVolume)
+	}
+	return volume
+}
+
+// recordSwapVolume adds the volume of the swap hop to sender's volume through the hop's pool,
+// if the pool has swap fee tiers, and prunes sender's volume buckets that left the window.
+// The volume of a swap is its amount in or out of the volume denom, or if neither is the volume denom,
+// its amount in valued at the pool's spot price after the swap.
+func (k Keeper) recordSwapVolume(ctx sdk.Context, sender sdk.AccAddress, hop swapHop) {
+	poolId := hop.pool.GetId()
+	tiers, found := swapFeeTiers(hop.pool)
+	if !found {
+		return
+	}
+
+	var volume sdk.Int
+	switch tiers.VolumeDenom {
+	case hop.tokenIn.Denom:
+		volume = hop.tokenIn.Amount
+	case hop.tokenOut.Denom:
+		volume = hop.tokenOut.Amount
+	default:
+		spotPrice, err := hop.pool.SpotPrice(ctx, tiers.VolumeDenom, hop.tokenIn.Denom)
+		if err != nil {
+			ctx.Logger().Error(fmt.Sprintf("failed to value the swap volume of %s through pool %d: %s", hop.tokenIn, poolId, err))
+			return
+		}
+		volume = spotPrice.MulInt(hop.tokenIn.Amount).TruncateInt()
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	windowStart := swapVolumeWindowStart(ctx)
+	iter := store.Iterator(types.GetKeyPrefixSwapVolumes(poolId, sender), types.GetKeySwapVolume(poolId, sender, windowStart))
+	expiredKeys := [][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		expiredKeys = append(expiredKeys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range expiredKeys {
+		store.Delete(key)
+	}
+
+	bucketKey := types.GetKeySwapVolume(poolId, sender, ctx.BlockTime().Truncate(types.SwapVolumeBucketDuration))
+	bucketVolume := sdk.ZeroInt()
+	if bz := store.Get(bucketKey); bz != nil {
+		if err := bucketVolume.Unmarshal(bz); err != nil {
+			panic(err)
+		}
+	}
+	bz, err := bucketVolume.Add(volume).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	store.Set(bucketKey, bz)
+}
+
+// getAllSwapVolumeBuckets returns the swap volume buckets of all traders through all pools.
+func (k Keeper) getAllSwapVolumeBuckets(ctx sdk.Context) []types.SwapVolumeBucket {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixSwapVolumes)
+	defer iter.Close()
+
+	buckets := []types.SwapVolumeBucket{}
+	for ; iter.Valid(); iter.Next() {
+		// keys are the pool ID, the length prefixed trader address, and the bucket start.
+		key := iter.Key()[len(types.KeyPrefixSwapVolumes):]
+		poolId := sdk.BigEndianToUint64(key[:8])
+		traderLen := int(key[8])
+		trader := sdk.AccAddress(key[9 : 9+traderLen])
+		bucketStart, err := sdk.ParseTimeBytes(key[9+traderLen:])
+		if err != nil {
+			panic(err)
+		}
+
+		var volume sdk.Int
+		if err := volume.Unmarshal(iter.Value()); err != nil {
+			panic(err)
+		}
+		buckets = append(buckets, types.SwapVolumeBucket{
+			PoolId:      poolId,
+			Trader:      trader.String(),
+			BucketStart: bucketStart,
+			Volume:      volume,
+		})
+	}
+	return buckets
+}
+
+// setSwapVolumeBucket sets the volume of the bucket, e.g. from genesis.
+func (k Keeper) setSwapVolumeBucket(ctx sdk.Context, bucket types.SwapVolumeBucket) error {
+	trader, err := sdk.AccAddressFromBech32(bucket.Trader)
+	if err != nil {
+		return err
+	}
+	bz, err := bucket.Volume.Marshal()
+	if err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set(types.GetKeySwapVolume(bucket.PoolId, trader, bucket.BucketStart), bz)
+	return nil
+}
@@ -0,0 +1,204 @@
+package keeper_test
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+var defaultSwapFeeTiers = types.SwapFeeTiers{
+	VolumeDenom: "foo",
+	Tiers: []types.SwapFeeTier{
+		{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+		{MinVolume: sdk.NewInt(300000), SwapFee: sdk.NewDecWithPrec(1, 3)},
+	},
+}
+
+// prepareTieredSwapFeePool creates a pool with defaultSwapFeeTiers in its params.
+func (suite *KeeperTestSuite) prepareTieredSwapFeePool() uint64 {
+	tiers := defaultSwapFeeTiers
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee:      sdk.NewDecWithPrec(1, 2),
+		ExitFee:      sdk.ZeroDec(),
+		SwapFeeTiers: &tiers,
+	})
+	tiers, found := suite.App.GAMMKeeper.GetSwapFeeTiers(suite.Ctx, poolId)
+	suite.Require().True(found)
+	suite.Require().Equal(defaultSwapFeeTiers, tiers)
+	return poolId
+}
+
+func (suite *KeeperTestSuite) TestSetSwapFeeTiers() {
+	tests := []struct {
+		name        string
+		tiers       types.SwapFeeTiers
+		expectedErr error
+	}{
+		{
+			name:  "valid tiers",
+			tiers: defaultSwapFeeTiers,
+		},
+		{
+			name:  "no tiers",
+			tiers: types.SwapFeeTiers{VolumeDenom: "foo"},
+		},
+		{
+			name: "increasing swap fee",
+			tiers: types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(1, 3)},
+				{MinVolume: sdk.NewInt(300000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+			}},
+			expectedErr: types.ErrInvalidSwapFeeTiers,
+		},
+		{
+			name: "swap fee above the pool's",
+			tiers: types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(2, 2)},
+			}},
+			expectedErr: types.ErrInvalidSwapFeeTiers,
+		},
+		{
+			name: "non-increasing min volume",
+			tiers: types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(1, 3)},
+			}},
+			expectedErr: types.ErrInvalidSwapFeeTiers,
+		},
+		{
+			name: "negative swap fee",
+			tiers: types.SwapFeeTiers{VolumeDenom: "foo", Tiers: []types.SwapFeeTier{
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(-1, 3)},
+			}},
+			expectedErr: types.ErrNegativeSwapFee,
+		},
+		{
+			name: "volume denom not in pool",
+			tiers: types.SwapFeeTiers{VolumeDenom: "uatom", Tiers: []types.SwapFeeTier{
+				{MinVolume: sdk.NewInt(100000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+			}},
+			expectedErr: types.ErrDenomNotFoundInPool,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+				SwapFee: sdk.NewDecWithPrec(1, 2),
+				ExitFee: sdk.ZeroDec(),
+			})
+			keeper := suite.App.GAMMKeeper
+
+			err := keeper.SetSwapFeeTiers(suite.Ctx, poolId, test.tiers)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+
+			tiers, found := keeper.GetSwapFeeTiers(suite.Ctx, poolId)
+			suite.Require().Equal(len(test.tiers.Tiers) > 0, found)
+			if found {
+				suite.Require().Equal(test.tiers, tiers)
+			}
+
+			// the tiers are kept in the pool's params.
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(found, pool.(*balancer.Pool).PoolParams.SwapFeeTiers != nil)
+		})
+	}
+
+	suite.Run("stableswap pool", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+		suite.Require().NoError(keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, ""))
+
+		err := keeper.SetSwapFeeTiers(suite.Ctx, poolId, defaultSwapFeeTiers)
+		suite.Require().ErrorIs(err, types.ErrUnsupportedPoolType)
+	})
+}
+
+// TestSwapFeeTierCrossedMidWindow tests that a trader pays the lower swap fee of a tier once its volume
+// crosses the tier's min volume, and the pool's swap fee again once that volume left the volume window.
+func (suite *KeeperTestSuite) TestSwapFeeTierCrossedMidWindow() {
+	suite.SetupTest()
+	poolId := suite.prepareTieredSwapFeePool()
+	keeper := suite.App.GAMMKeeper
+	trader, otherTrader := suite.TestAccs[0], suite.TestAccs[1]
+	t0 := suite.Ctx.BlockTime()
+
+	requireSwapFee := func(trader sdk.AccAddress, expectedSwapFee sdk.Dec) {
+		swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "foo", "bar")
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedSwapFee, swapFee)
+	}
+	// swap checks that swapping tokenIn for bar is charged expectedSwapFee.
+	swap := func(tokenIn sdk.Coin, expectedSwapFee sdk.Dec) {
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		expectedTokenOut, err := pool.CalcOutAmtGivenIn(suite.Ctx, sdk.Coins{tokenIn}, "bar", expectedSwapFee)
+		suite.Require().NoError(err)
+
+		tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+		suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+	}
+
+	poolSwapFee := sdk.NewDecWithPrec(1, 2)
+	requireSwapFee(trader, poolSwapFee)
+	swap(sdk.NewInt64Coin("foo", 60000), poolSwapFee)
+	requireSwapFee(trader, poolSwapFee)
+
+	// 12 hours later, the trader crosses the first tier, and pays its swap fee from the next swap on.
+	suite.Ctx = suite.Ctx.WithBlockTime(t0.Add(12 * time.Hour))
+	swap(sdk.NewInt64Coin("foo", 60000), poolSwapFee)
+	requireSwapFee(trader, defaultSwapFeeTiers.Tiers[0].SwapFee)
+	swap(sdk.NewInt64Coin("foo", 10000), defaultSwapFeeTiers.Tiers[0].SwapFee)
+	requireSwapFee(otherTrader, poolSwapFee)
+
+	// swaps without the volume denom count their value in the volume denom.
+	volumeBefore := keeper.GetSwapVolume(suite.Ctx, poolId, trader)
+	_, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, sdk.NewInt64Coin("baz", 10000), "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().True(keeper.GetSwapVolume(suite.Ctx, poolId, trader).GT(volumeBefore))
+
+	// a day after the first swap, its volume left the window.
+	suite.Ctx = suite.Ctx.WithBlockTime(t0.Add(types.SwapVolumeWindow + types.SwapVolumeBucketDuration))
+	suite.Require().True(keeper.GetSwapVolume(suite.Ctx, poolId, trader).LT(volumeBefore))
+	requireSwapFee(trader, defaultSwapFeeTiers.Tiers[0].SwapFee)
+
+	// and a day after the later swaps, so did theirs.
+	suite.Ctx = suite.Ctx.WithBlockTime(t0.Add(12*time.Hour + types.SwapVolumeWindow + types.SwapVolumeBucketDuration))
+	suite.Require().True(keeper.GetSwapVolume(suite.Ctx, poolId, trader).IsZero())
+	requireSwapFee(trader, poolSwapFee)
+	swap(sdk.NewInt64Coin("foo", 60000), poolSwapFee)
+	suite.Require().Equal(sdk.NewInt(60000), keeper.GetSwapVolume(suite.Ctx, poolId, trader))
+}
+
+// TestSwapFeeTierMultihop tests that multihop swaps are charged the trader's tier, and count towards its volume.
+func (suite *KeeperTestSuite) TestSwapFeeTierMultihop() {
+	suite.SetupTest()
+	poolId := suite.prepareTieredSwapFeePool()
+	suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+
+	routes := []types.SwapAmountInRoute{
+		{PoolId: poolId, TokenOutDenom: "bar"},
+		{PoolId: poolId + 1, TokenOutDenom: "baz"},
+	}
+	_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, trader, routes, sdk.NewInt64Coin("foo", 300000), sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(300000), keeper.GetSwapVolume(suite.Ctx, poolId, trader))
+	suite.Require().True(keeper.GetSwapVolume(suite.Ctx, poolId+1, trader).IsZero())
+
+	swapFee, err := keeper.GetPoolSwapFee(suite.Ctx, poolId, trader, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(defaultSwapFeeTiers.Tiers[1].SwapFee, swapFee)
+}
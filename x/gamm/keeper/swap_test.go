@@ -1,11 +1,17 @@
 package keeper_test
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
 func (suite *KeeperTestSuite) TestBalancerPoolSimpleSwapExactAmountIn() {
@@ -207,6 +213,539 @@ func (suite *KeeperTestSuite) TestBalancerPoolSimpleSwapExactAmountOut() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestSwapExactAmountOutNotPositiveTokenOut() {
+	for _, amount := range []int64{0, -1} {
+		suite.Run(fmt.Sprintf("token out amount %d", amount), func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+
+			// sdk.NewInt64Coin panics on negative amounts.
+			tokenOut := sdk.Coin{Denom: "bar", Amount: sdk.NewInt(amount)}
+			_, err := keeper.SwapExactAmountOut(suite.Ctx, sender, poolId, "foo", sdk.NewInt(100000), tokenOut)
+			suite.Require().ErrorIs(err, types.ErrInvalidMathApprox)
+			suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+			suite.Require().Empty(suite.Ctx.EventManager().Events())
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestEstimateSwapExactAmountIn() {
+	tests := []struct {
+		name          string
+		poolId        uint64
+		tokenIn       sdk.Coin
+		tokenOutDenom string
+		expectPass    bool
+	}{
+		{
+			name:          "estimate matches swap",
+			poolId:        1,
+			tokenIn:       sdk.NewCoin("foo", sdk.NewInt(100000)),
+			tokenOutDenom: "bar",
+			expectPass:    true,
+		},
+		{
+			name:          "in and out denom are same",
+			poolId:        1,
+			tokenIn:       sdk.NewCoin("foo", sdk.NewInt(100000)),
+			tokenOutDenom: "foo",
+		},
+		{
+			name:          "unknown out denom",
+			poolId:        1,
+			tokenIn:       sdk.NewCoin("foo", sdk.NewInt(100000)),
+			tokenOutDenom: "bara",
+		},
+		{
+			name:          "pool does not exist",
+			poolId:        2,
+			tokenIn:       sdk.NewCoin("foo", sdk.NewInt(100000)),
+			tokenOutDenom: "bar",
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+
+			poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+			suite.Require().NoError(err)
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
+
+			estimatedAmount, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, test.poolId, test.tokenIn, test.tokenOutDenom)
+			if !test.expectPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// estimating does not modify the pool, nor any balance.
+			poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+			suite.Require().NoError(err)
+			suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0]))
+
+			tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], test.poolId, test.tokenIn, test.tokenOutDenom, sdk.OneInt())
+			suite.Require().NoError(err)
+			suite.Require().Equal(tokenOutAmount, estimatedAmount)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestSimulateSwapExactAmountIn() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(100000))
+
+	poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
+
+	tokenOut, newInBalance, newOutBalance, newSpotPrice, err := keeper.SimulateSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar")
+	suite.Require().NoError(err)
+
+	// simulating does not modify the pool, nor any balance.
+	poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+	suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0]))
+
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoin("bar", tokenOutAmount), tokenOut)
+
+	poolAfter, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	liquidity := poolAfter.GetTotalPoolLiquidity(suite.Ctx)
+	suite.Require().Equal(liquidity.AmountOf("foo"), newInBalance)
+	suite.Require().Equal(liquidity.AmountOf("bar"), newOutBalance)
+	spotPrice, err := poolAfter.SpotPrice(suite.Ctx, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(spotPrice, newSpotPrice)
+
+	// the same errors as the swap are returned.
+	_, _, _, _, err = keeper.SimulateSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "foo")
+	suite.Require().Error(err)
+	_, _, _, _, err = keeper.SimulateSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId+1, tokenIn, "bar")
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestRankPoolsForSwapExactAmountOut() {
+	suite.SetupTest()
+	// the shallow pool sells bar at 0.5 foo, the deep pool at 0.625 foo,
+	// so the shallow pool is cheaper for small amounts out, but its price impact grows faster.
+	shallowPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewCoin("foo", sdk.NewInt(1000000)), sdk.NewCoin("bar", sdk.NewInt(2000000)))
+	deepPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewCoin("foo", sdk.NewInt(1000000000)), sdk.NewCoin("bar", sdk.NewInt(1600000000)))
+	otherPoolId := suite.PrepareUni2PoolWithAssets(sdk.NewCoin("foo", sdk.NewInt(1000000)), sdk.NewCoin("baz", sdk.NewInt(1000000)))
+	poolIds := []uint64{deepPoolId, shallowPoolId, otherPoolId}
+	keeper := suite.App.GAMMKeeper
+	suite.FundAcc(suite.TestAccs[0], sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))))
+
+	tests := []struct {
+		name            string
+		tokenOut        sdk.Coin
+		expectedPoolIds []uint64
+	}{
+		{
+			name:            "small amount out is cheapest from the shallow pool",
+			tokenOut:        sdk.NewCoin("bar", sdk.NewInt(1000)),
+			expectedPoolIds: []uint64{shallowPoolId, deepPoolId},
+		},
+		{
+			name:            "large amount out is cheapest from the deep pool",
+			tokenOut:        sdk.NewCoin("bar", sdk.NewInt(900000)),
+			expectedPoolIds: []uint64{deepPoolId, shallowPoolId},
+		},
+		{
+			name:            "amount out beyond the shallow pool's reserves",
+			tokenOut:        sdk.NewCoin("bar", sdk.NewInt(2000000)),
+			expectedPoolIds: []uint64{deepPoolId},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			quotes, err := keeper.RankPoolsForSwapExactAmountOut(suite.Ctx, suite.TestAccs[0], poolIds, "foo", test.tokenOut)
+			suite.Require().NoError(err)
+			suite.Require().Len(quotes, len(test.expectedPoolIds))
+			for i, quote := range quotes {
+				suite.Require().Equal(test.expectedPoolIds[i], quote.PoolId)
+
+				// each quote is what swapping out tokenOut from the pool would take.
+				cacheCtx, _ := suite.Ctx.CacheContext()
+				tokenInAmount, err := keeper.SwapExactAmountOut(cacheCtx, suite.TestAccs[0], quote.PoolId, "foo", quote.TokenIn.Amount, test.tokenOut)
+				suite.Require().NoError(err)
+				suite.Require().Equal(quote.TokenIn, sdk.NewCoin("foo", tokenInAmount))
+			}
+		})
+	}
+
+	_, err := keeper.RankPoolsForSwapExactAmountOut(suite.Ctx, suite.TestAccs[0], []uint64{otherPoolId}, "foo", sdk.NewCoin("bar", sdk.NewInt(1000)))
+	suite.Require().ErrorIs(err, types.ErrNoSwappablePools)
+}
+
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithMaxPriceImpact() {
+	// swapping 100000 foo for bar against the default balancer pool
+	// gets 49262 bar at a spot price of 2, a price impact of ~1.5%.
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(100000))
+
+	tests := []struct {
+		name           string
+		maxPriceImpact sdk.Dec
+		expectedErr    error
+	}{
+		{
+			name:           "price impact below max",
+			maxPriceImpact: sdk.NewDecWithPrec(3, 2),
+		},
+		{
+			name:           "price impact above max",
+			maxPriceImpact: sdk.NewDecWithPrec(1, 2),
+			expectedErr:    types.ErrPriceImpactTooHigh,
+		},
+		{
+			name:           "zero max price impact",
+			maxPriceImpact: sdk.ZeroDec(),
+			expectedErr:    types.ErrPriceImpactTooHigh,
+		},
+		{
+			name:           "negative max price impact",
+			maxPriceImpact: sdk.NewDecWithPrec(-1, 2),
+			expectedErr:    types.ErrNegativeMaxPriceImpact,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0])
+
+			tokenOutAmount, err := keeper.SwapExactAmountInWithMaxPriceImpact(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt(), test.maxPriceImpact)
+			if test.expectedErr != nil {
+				suite.Require().ErrorContains(err, test.expectedErr.Error())
+				suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, suite.TestAccs[0]))
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(sdk.NewInt(49262), tokenOutAmount)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithMaxAmountOut() {
+	// swapping 100000 foo for bar against the default balancer pool gets 49262 bar.
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(100000))
+
+	tests := []struct {
+		name                   string
+		tokenOutMaxAmount      sdk.Int
+		mode                   keeper.MaxAmountOutMode
+		expectedTokenOutAmount sdk.Int
+		expectReducedIn        bool
+		expectedErr            error
+	}{
+		{
+			name:                   "amount out below max",
+			tokenOutMaxAmount:      sdk.NewInt(50000),
+			mode:                   keeper.MaxAmountOutModeError,
+			expectedTokenOutAmount: sdk.NewInt(49262),
+		},
+		{
+			name:                   "amount out equal to max",
+			tokenOutMaxAmount:      sdk.NewInt(49262),
+			mode:                   keeper.MaxAmountOutModeReduceIn,
+			expectedTokenOutAmount: sdk.NewInt(49262),
+		},
+		{
+			name:              "amount out above max errors by default",
+			tokenOutMaxAmount: sdk.NewInt(40000),
+			mode:              keeper.MaxAmountOutModeError,
+			expectedErr:       types.ErrLimitMaxAmount,
+		},
+		{
+			name:                   "amount out above max reduces amount in",
+			tokenOutMaxAmount:      sdk.NewInt(40000),
+			mode:                   keeper.MaxAmountOutModeReduceIn,
+			expectedTokenOutAmount: sdk.NewInt(40000),
+			expectReducedIn:        true,
+		},
+		{
+			name:              "max amount out below min amount out",
+			tokenOutMaxAmount: sdk.ZeroInt(),
+			mode:              keeper.MaxAmountOutModeReduceIn,
+			expectedErr:       types.ErrInvalidAmountLimits,
+		},
+		{
+			name:              "unknown mode",
+			tokenOutMaxAmount: sdk.NewInt(40000),
+			mode:              keeper.MaxAmountOutMode(100),
+			expectedErr:       types.ErrUnknownSwapMode,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			sender := suite.TestAccs[0]
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			tokenInAmount, tokenOutAmount, err := suite.App.GAMMKeeper.SwapExactAmountInWithMaxAmountOut(
+				suite.Ctx, sender, poolId, tokenIn, "bar", sdk.OneInt(), test.tokenOutMaxAmount, test.mode)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			if test.expectedErr != nil {
+				suite.Require().ErrorContains(err, test.expectedErr.Error())
+				suite.Require().Equal(balancesBefore, balancesAfter)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedTokenOutAmount, tokenOutAmount)
+			if test.expectReducedIn {
+				suite.Require().True(tokenInAmount.LT(tokenIn.Amount))
+			} else {
+				suite.Require().Equal(tokenIn.Amount, tokenInAmount)
+			}
+			// only the swapped in tokens are taken from the sender.
+			suite.Require().Equal(balancesBefore.AmountOf("foo").Sub(tokenInAmount), balancesAfter.AmountOf("foo"))
+			suite.Require().Equal(balancesBefore.AmountOf("bar").Add(tokenOutAmount), balancesAfter.AmountOf("bar"))
+		})
+	}
+}
+
+// TestSwapExactAmountInWithSpotPriceLimit tests swaps of foo for bar against the default balancer pool,
+// whose spot price of bar in foo is 2, limited to a spot price of 2.02.
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithSpotPriceLimit() {
+	spotPriceLimit := sdk.MustNewDecFromStr("2.02")
+	tests := []struct {
+		name              string
+		swapFee           sdk.Dec
+		tokenIn           sdk.Coin
+		tokenOutMinAmount sdk.Int
+		spotPriceLimit    sdk.Dec
+		expectPartialFill bool
+		expectedErr       error
+	}{
+		{
+			name:    "full fill within the limit",
+			tokenIn: sdk.NewInt64Coin("foo", 10000),
+		},
+		{
+			name:              "partial fill at the limit",
+			tokenIn:           sdk.NewInt64Coin("foo", 1000000),
+			expectPartialFill: true,
+		},
+		{
+			name:              "partial fill at the limit with a swap fee",
+			swapFee:           sdk.NewDecWithPrec(1, 2),
+			tokenIn:           sdk.NewInt64Coin("foo", 1000000),
+			expectPartialFill: true,
+		},
+		{
+			name:              "partial fill below the min amount out",
+			tokenIn:           sdk.NewInt64Coin("foo", 1000000),
+			tokenOutMinAmount: sdk.NewInt(100000),
+			expectedErr:       types.ErrLimitMinAmount,
+		},
+		{
+			name:           "limit at the spot price",
+			tokenIn:        sdk.NewInt64Coin("foo", 10000),
+			spotPriceLimit: sdk.NewDec(2),
+			expectedErr:    types.ErrSpotPriceUnreachable,
+		},
+		{
+			name:           "limit below the spot price",
+			tokenIn:        sdk.NewInt64Coin("foo", 10000),
+			spotPriceLimit: sdk.MustNewDecFromStr("1.9"),
+			expectedErr:    types.ErrSpotPriceUnreachable,
+		},
+		{
+			name:           "limit allows no amount in",
+			tokenIn:        sdk.NewInt64Coin("foo", 10000),
+			spotPriceLimit: sdk.MustNewDecFromStr("2.000000000001"),
+			expectedErr:    types.ErrSpotPriceUnreachable,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			swapFee := sdk.ZeroDec()
+			if !test.swapFee.IsNil() {
+				swapFee = test.swapFee
+			}
+			poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()})
+			limit := spotPriceLimit
+			if !test.spotPriceLimit.IsNil() {
+				limit = test.spotPriceLimit
+			}
+			tokenOutMinAmount := sdk.OneInt()
+			if !test.tokenOutMinAmount.IsNil() {
+				tokenOutMinAmount = test.tokenOutMinAmount
+			}
+			sender := suite.TestAccs[1]
+			suite.FundAcc(sender, sdk.NewCoins(test.tokenIn))
+			balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+
+			tokenInAmount, tokenOutAmount, err := keeper.SwapExactAmountInWithSpotPriceLimit(
+				suite.Ctx, sender, poolId, test.tokenIn, "bar", tokenOutMinAmount, limit)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				suite.Require().Equal(balancesBefore, balancesAfter)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// only the swapped in tokens are taken from the sender.
+			suite.Require().Equal(balancesBefore.AmountOf("foo").Sub(tokenInAmount).String(), balancesAfter.AmountOf("foo").String())
+			suite.Require().Equal(balancesBefore.AmountOf("bar").Add(tokenOutAmount).String(), balancesAfter.AmountOf("bar").String())
+
+			spotPriceAfter, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+			suite.Require().NoError(err)
+			suite.Require().True(spotPriceAfter.LTE(limit), "spot price after %s", spotPriceAfter)
+			if !test.expectPartialFill {
+				suite.Require().Equal(test.tokenIn.Amount, tokenInAmount)
+				return
+			}
+			suite.Require().True(tokenInAmount.LT(test.tokenIn.Amount))
+			// the swap is filled up to the limit, within the precision of the spot price.
+			suite.Require().True(spotPriceAfter.GT(limit.Sub(sdk.NewDecWithPrec(1, 3))), "spot price after %s", spotPriceAfter)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithDustMode() {
+	// swapping 1 foo against a pool of 10^12 foo and 10^6 bar swaps out no bar.
+	tokenIn := sdk.NewCoin("foo", sdk.OneInt())
+
+	tests := []struct {
+		name        string
+		tokenIn     sdk.Coin
+		senderFunds sdk.Coins
+		maxAmountIn sdk.Int
+		mode        keeper.DustMode
+		expectedErr error
+	}{
+		{
+			name:        "amount out not dust",
+			tokenIn:     sdk.NewCoin("foo", sdk.NewInt(10000000)),
+			senderFunds: sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))),
+			mode:        keeper.DustModeError,
+		},
+		{
+			name:        "dust errors with the minimum amount in by default",
+			tokenIn:     tokenIn,
+			senderFunds: sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))),
+			mode:        keeper.DustModeError,
+			expectedErr: types.ErrInvalidMathApprox,
+		},
+		{
+			name:        "dust raises amount in",
+			tokenIn:     tokenIn,
+			senderFunds: sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))),
+			maxAmountIn: sdk.NewInt(10000000),
+			mode:        keeper.DustModeRaiseIn,
+		},
+		{
+			name:        "dust with max amount in below the minimum amount in",
+			tokenIn:     tokenIn,
+			senderFunds: sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))),
+			maxAmountIn: tokenIn.Amount,
+			mode:        keeper.DustModeRaiseIn,
+			expectedErr: types.ErrLimitMaxAmount,
+		},
+		{
+			name:        "dust with balance below the minimum amount in",
+			tokenIn:     tokenIn,
+			senderFunds: sdk.NewCoins(tokenIn),
+			maxAmountIn: sdk.NewInt(10000000),
+			mode:        keeper.DustModeRaiseIn,
+			expectedErr: sdkerrors.ErrInsufficientFunds,
+		},
+		{
+			name:        "unknown mode",
+			tokenIn:     tokenIn,
+			senderFunds: sdk.NewCoins(sdk.NewCoin("foo", sdk.NewInt(10000000))),
+			mode:        keeper.DustModeRaiseIn + 1,
+			expectedErr: types.ErrUnknownSwapMode,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareUni2PoolWithAssets(sdk.NewCoin("foo", sdk.NewInt(1000000000000)), sdk.NewCoin("bar", sdk.NewInt(1000000)))
+			sender := suite.TestAccs[1]
+			suite.FundAcc(sender, test.senderFunds)
+			pool, err := suite.App.GAMMKeeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			minTokenIn, err := pool.CalcInAmtGivenOut(suite.Ctx, sdk.NewCoins(sdk.NewCoin("bar", sdk.OneInt())), "foo", pool.GetSwapFee(suite.Ctx))
+			suite.Require().NoError(err)
+
+			maxAmountIn := test.tokenIn.Amount
+			if !test.maxAmountIn.IsNil() {
+				maxAmountIn = test.maxAmountIn
+			}
+
+			tokenInAmount, tokenOutAmount, err := suite.App.GAMMKeeper.SwapExactAmountInWithDustMode(
+				suite.Ctx, sender, poolId, test.tokenIn, "bar", sdk.OneInt(), maxAmountIn, test.mode)
+			balancesAfter := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				if test.mode <= keeper.DustModeRaiseIn {
+					suite.Require().ErrorContains(err, minTokenIn.String())
+				}
+				suite.Require().Equal(test.senderFunds, balancesAfter)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().True(tokenOutAmount.IsPositive())
+			if test.tokenIn.Amount.LT(minTokenIn.Amount) {
+				suite.Require().Equal(minTokenIn.Amount, tokenInAmount)
+			} else {
+				suite.Require().Equal(test.tokenIn.Amount, tokenInAmount)
+			}
+			suite.Require().Equal(test.senderFunds.AmountOf("foo").Sub(tokenInAmount).String(), balancesAfter.AmountOf("foo").String())
+			suite.Require().Equal(tokenOutAmount.String(), balancesAfter.AmountOf("bar").String())
+		})
+	}
+}
+
+// TestSwapExactAmountInWithDustModeMathError tests that a tokenIn that fails the pool's math for
+// another reason than swapping out no tokens errors, rather than being raised or lowered to another amount.
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithDustModeMathError() {
+	for _, mode := range []keeper.DustMode{keeper.DustModeError, keeper.DustModeRaiseIn} {
+		suite.SetupTest()
+		// at a 3:10 weight ratio, swapping in 5000x the pool's balance of foo doesn't converge within the pow bound.
+		poolId := suite.PrepareBalancerPoolWithPoolAsset([]balancer.PoolAsset{
+			{Token: sdk.NewInt64Coin("foo", 1000000), Weight: sdk.NewInt(3)},
+			{Token: sdk.NewInt64Coin("bar", 1000000), Weight: sdk.NewInt(10)},
+		})
+		tokenIn := sdk.NewInt64Coin("foo", 5000000000)
+		sender := suite.TestAccs[1]
+		suite.FundAcc(sender, sdk.NewCoins(tokenIn))
+
+		_, _, err := suite.App.GAMMKeeper.SwapExactAmountInWithDustMode(
+			suite.Ctx, sender, poolId, tokenIn, "bar", sdk.OneInt(), tokenIn.Amount, mode)
+		suite.Require().ErrorIs(err, types.ErrInvalidMathApprox, "mode %d", mode)
+		suite.Require().NotContains(err.Error(), "swaps out no", "mode %d", mode)
+		suite.Require().Equal(sdk.NewCoins(tokenIn), suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender), "mode %d", mode)
+	}
+}
+
 func (suite *KeeperTestSuite) TestActiveBalancerPoolSwap() {
 	type testCase struct {
 		blockTime  time.Time
@@ -248,3 +787,904 @@ func (suite *KeeperTestSuite) TestActiveBalancerPoolSwap() {
 		}
 	}
 }
+
+func (suite *KeeperTestSuite) TestSwapAtSmoothWeightChangeMidpoint() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	startTime := suite.Ctx.BlockTime()
+	duration := 100 * time.Second
+	poolAssets := []balancer.PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(1)},
+		{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(1)},
+	}
+	poolId := suite.prepareCustomBalancerPool(defaultAcctFunds, poolAssets, balancer.PoolParams{
+		SwapFee: sdk.ZeroDec(),
+		ExitFee: sdk.ZeroDec(),
+		SmoothWeightChangeParams: &balancer.SmoothWeightChangeParams{
+			StartTime: startTime,
+			Duration:  duration,
+			TargetPoolWeights: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("foo", 0), Weight: sdk.NewInt(1)},
+				{Token: sdk.NewInt64Coin("bar", 0), Weight: sdk.NewInt(3)},
+			},
+		},
+	})
+
+	suite.Ctx = suite.Ctx.WithBlockTime(startTime.Add(duration / 2))
+
+	// at the midpoint, the weights are the average of the initial and target weights.
+	poolI, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	pool := poolI.(*balancer.Pool)
+	fooWeight, err := pool.GetTokenWeight("foo")
+	suite.Require().NoError(err)
+	barWeight, err := pool.GetTokenWeight("bar")
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(1*balancer.GuaranteedWeightPrecision), fooWeight)
+	suite.Require().Equal(sdk.NewInt(2*balancer.GuaranteedWeightPrecision), barWeight)
+	suite.Require().Equal(fooWeight.Add(barWeight), pool.GetTotalWeight())
+
+	// swaps at the midpoint are priced as in a pool with the midpoint weights.
+	midpointPool, err := balancer.NewBalancerPool(poolId, balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()}, []balancer.PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(1)},
+		{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(2)},
+	}, "", suite.Ctx.BlockTime())
+	suite.Require().NoError(err)
+
+	tokenIn := sdk.NewInt64Coin("foo", 10_000)
+	expectedTokenOut, err := midpointPool.CalcOutAmtGivenIn(suite.Ctx, sdk.NewCoins(tokenIn), "bar", sdk.ZeroDec())
+	suite.Require().NoError(err)
+
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount, tokenOutAmount)
+}
+
+func (suite *KeeperTestSuite) TestSwapEventSpotPrices() {
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	tokenIn := sdk.NewCoin("foo", sdk.NewInt(100000))
+
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	expectedSpotPriceBefore, err := pool.SpotPrice(suite.Ctx, "foo", "bar")
+	suite.Require().NoError(err)
+
+	suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+	tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	expectedSpotPriceAfter, err := pool.SpotPrice(suite.Ctx, "foo", "bar")
+	suite.Require().NoError(err)
+
+	var swapEvents []sdk.Event
+	for _, event := range suite.Ctx.EventManager().Events() {
+		if event.Type == types.TypeEvtTokenSwapped {
+			swapEvents = append(swapEvents, event)
+		}
+	}
+	suite.Require().Len(swapEvents, 1)
+
+	attributes := map[string]string{}
+	for _, attribute := range swapEvents[0].Attributes {
+		attributes[string(attribute.Key)] = string(attribute.Value)
+	}
+	suite.Require().Equal(sdk.NewCoins(tokenIn).String(), attributes[types.AttributeKeyTokensIn])
+	suite.Require().Equal(sdk.NewCoins(sdk.NewCoin("bar", tokenOutAmount)).String(), attributes[types.AttributeKeyTokensOut])
+
+	expectedPrices := map[string]sdk.Dec{
+		types.AttributeKeySpotPriceBefore: expectedSpotPriceBefore,
+		types.AttributeKeySpotPriceAfter:  expectedSpotPriceAfter,
+		types.AttributeKeyEffectivePrice:  tokenIn.Amount.ToDec().Quo(tokenOutAmount.ToDec()),
+	}
+	for key, expectedPrice := range expectedPrices {
+		price, err := sdk.NewDecFromStr(attributes[key])
+		suite.Require().NoError(err, key)
+		suite.Require().Equal(expectedPrice, price, key)
+	}
+
+	// the effective price of a swap lies between the spot prices before and after it.
+	suite.Require().True(expectedSpotPriceBefore.LT(expectedPrices[types.AttributeKeyEffectivePrice]))
+	suite.Require().True(expectedPrices[types.AttributeKeyEffectivePrice].LT(expectedSpotPriceAfter))
+}
+
+func (suite *KeeperTestSuite) TestCalcAmountInToReachSpotPrice() {
+	tests := []struct {
+		name             string
+		poolId           uint64
+		spotPriceFactor  sdk.Dec
+		expectedErr      error
+		expectedAnyError bool
+	}{
+		{
+			name:            "double the spot price",
+			poolId:          1,
+			spotPriceFactor: sdk.NewDec(2),
+		},
+		{
+			name:            "halve the spot price",
+			poolId:          1,
+			spotPriceFactor: sdk.NewDecWithPrec(5, 1),
+			expectedErr:     types.ErrSpotPriceUnreachable,
+		},
+		{
+			name:             "pool does not exist",
+			poolId:           2,
+			spotPriceFactor:  sdk.NewDec(2),
+			expectedAnyError: true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+
+			poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+			suite.Require().NoError(err)
+			spotPriceBefore, err := poolBefore.SpotPrice(suite.Ctx, "foo", "bar")
+			suite.Require().NoError(err)
+			targetSpotPrice := spotPriceBefore.Mul(test.spotPriceFactor)
+
+			tokenInAmount, err := keeper.CalcAmountInToReachSpotPrice(suite.Ctx, test.poolId, "foo", "bar", targetSpotPrice)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			if test.expectedAnyError {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+
+			// computing the amount does not modify the pool.
+			poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, 1)
+			suite.Require().NoError(err)
+			suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+
+			_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], test.poolId, sdk.NewCoin("foo", tokenInAmount), "bar", sdk.OneInt())
+			suite.Require().NoError(err)
+			spotPriceAfter, err := keeper.CalculateSpotPrice(suite.Ctx, test.poolId, "foo", "bar")
+			suite.Require().NoError(err)
+			suite.Require().True(spotPriceAfter.LTE(targetSpotPrice), "spot price %s passed the target %s", spotPriceAfter, targetSpotPrice)
+			suite.Require().True(spotPriceAfter.GT(spotPriceBefore.MulInt64(19).QuoInt64(10)), "spot price %s is far below the target %s", spotPriceAfter, targetSpotPrice)
+		})
+	}
+}
+
+// TestCalcArbCloseAmount tests that the arbitrage closing trade between two pools of the same pair,
+// swapped through both pools, brings them to the same spot price, at a profit.
+// With swap fees, the trade is tested to return the most profit instead.
+func (suite *KeeperTestSuite) TestCalcArbCloseAmount() {
+	poolAssets := func(foo, fooWeight, bar, barWeight int64) []balancer.PoolAsset {
+		return []balancer.PoolAsset{
+			{Token: sdk.NewInt64Coin("foo", foo), Weight: sdk.NewInt(fooWeight)},
+			{Token: sdk.NewInt64Coin("bar", bar), Weight: sdk.NewInt(barWeight)},
+		}
+	}
+	tests := []struct {
+		name                string
+		poolA, poolB        []balancer.PoolAsset
+		swapFee             sdk.Dec
+		expectedBuyPoolIsA  bool
+		expectedZeroTokenIn bool
+		expectedErr         error
+	}{
+		{
+			name:               "equal weights, buy from the first pool",
+			poolA:              poolAssets(1_000_000_000, 1, 1_000_000_000, 1),
+			poolB:              poolAssets(4_000_000_000, 1, 1_000_000_000, 1),
+			expectedBuyPoolIsA: true,
+		},
+		{
+			name:  "equal weights, buy from the second pool",
+			poolA: poolAssets(4_000_000_000, 1, 1_000_000_000, 1),
+			poolB: poolAssets(1_000_000_000, 1, 1_000_000_000, 1),
+		},
+		{
+			name:  "same weight ratios, pools of different sizes",
+			poolA: poolAssets(3_000_000_000, 100, 2_000_000_000, 300),
+			poolB: poolAssets(100_000_000, 50, 90_000_000, 150),
+		},
+		{
+			name:                "same spot price",
+			poolA:               poolAssets(2_000_000_000, 1, 1_000_000_000, 1),
+			poolB:               poolAssets(200_000_000, 1, 100_000_000, 1),
+			expectedBuyPoolIsA:  true,
+			expectedZeroTokenIn: true,
+		},
+		{
+			name:               "swap fees, buy from the first pool",
+			poolA:              poolAssets(1_000_000_000, 1, 1_000_000_000, 1),
+			poolB:              poolAssets(4_000_000_000, 1, 1_000_000_000, 1),
+			swapFee:            sdk.MustNewDecFromStr("0.01"),
+			expectedBuyPoolIsA: true,
+		},
+		{
+			name:    "swap fees, same weight ratios, pools of different sizes",
+			poolA:   poolAssets(3_000_000_000, 100, 2_000_000_000, 300),
+			poolB:   poolAssets(100_000_000, 50, 90_000_000, 150),
+			swapFee: sdk.MustNewDecFromStr("0.003"),
+		},
+		{
+			name:                "spot prices within the swap fees",
+			poolA:               poolAssets(1_000_000_000, 1, 1_000_000_000, 1),
+			poolB:               poolAssets(1_010_000_000, 1, 1_000_000_000, 1),
+			swapFee:             sdk.MustNewDecFromStr("0.01"),
+			expectedBuyPoolIsA:  true,
+			expectedZeroTokenIn: true,
+		},
+		{
+			name:        "different weight ratios",
+			poolA:       poolAssets(1_000_000_000, 1, 1_000_000_000, 1),
+			poolB:       poolAssets(4_000_000_000, 1, 1_000_000_000, 3),
+			expectedErr: types.ErrArbWeightRatioMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+			trader := suite.TestAccs[0]
+			swapFee := sdk.ZeroDec()
+			if !test.swapFee.IsNil() {
+				swapFee = test.swapFee
+			}
+			poolParams := balancer.PoolParams{SwapFee: swapFee, ExitFee: sdk.ZeroDec()}
+			funds := sdk.NewCoins(sdk.NewInt64Coin("foo", 10_000_000_000), sdk.NewInt64Coin("bar", 10_000_000_000), sdk.NewInt64Coin("uosmo", 10_000_000_000))
+			poolIdA := suite.prepareCustomBalancerPool(funds, test.poolA, poolParams)
+			poolIdB := suite.prepareCustomBalancerPool(funds, test.poolB, poolParams)
+
+			tokenIn, buyPoolId, sellPoolId, err := keeper.CalcArbCloseAmount(suite.Ctx, poolIdA, poolIdB, "foo", "bar")
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedBuyPoolIsA, buyPoolId == poolIdA)
+			suite.Require().NotEqual(buyPoolId, sellPoolId)
+			suite.Require().Equal("foo", tokenIn.Denom)
+			if test.expectedZeroTokenIn {
+				suite.Require().True(tokenIn.IsZero())
+				return
+			}
+
+			if swapFee.IsPositive() {
+				// the profit of the arbitrage, swapping tokenInAmount through both pools.
+				profit := func(tokenInAmount sdk.Int) sdk.Int {
+					cacheCtx, _ := suite.Ctx.CacheContext()
+					tokenOutAmount, err := keeper.SwapExactAmountIn(cacheCtx, trader, buyPoolId, sdk.NewCoin("foo", tokenInAmount), "bar", sdk.OneInt())
+					suite.Require().NoError(err)
+					arbTokenOutAmount, err := keeper.SwapExactAmountIn(cacheCtx, trader, sellPoolId, sdk.NewCoin("bar", tokenOutAmount), "foo", sdk.OneInt())
+					suite.Require().NoError(err)
+					return arbTokenOutAmount.Sub(tokenInAmount)
+				}
+				arbProfit := profit(tokenIn.Amount)
+				suite.Require().True(arbProfit.IsPositive(), "arbitrage of %s lost %sfoo", tokenIn, arbProfit.Neg())
+				for _, tokenInAmount := range []sdk.Int{tokenIn.Amount.MulRaw(99).QuoRaw(100), tokenIn.Amount.MulRaw(101).QuoRaw(100)} {
+					suite.Require().True(profit(tokenInAmount).LTE(arbProfit), "swapping %sfoo returns more than %s", tokenInAmount, tokenIn)
+				}
+				return
+			}
+
+			tokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, buyPoolId, tokenIn, "bar", sdk.OneInt())
+			suite.Require().NoError(err)
+			arbTokenOutAmount, err := keeper.SwapExactAmountIn(suite.Ctx, trader, sellPoolId, sdk.NewCoin("bar", tokenOutAmount), "foo", sdk.OneInt())
+			suite.Require().NoError(err)
+			suite.Require().True(arbTokenOutAmount.GT(tokenIn.Amount), "arbitrage of %s returned %sfoo", tokenIn, arbTokenOutAmount)
+
+			buySpotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, buyPoolId, "foo", "bar")
+			suite.Require().NoError(err)
+			sellSpotPrice, err := keeper.CalculateSpotPrice(suite.Ctx, sellPoolId, "foo", "bar")
+			suite.Require().NoError(err)
+			relativeDiff := sellSpotPrice.Sub(buySpotPrice).Abs().Quo(sellSpotPrice)
+			suite.Require().True(relativeDiff.LT(sdk.NewDecWithPrec(1, 6)), "spot prices %s and %s after the arbitrage", buySpotPrice, sellSpotPrice)
+		})
+	}
+
+	suite.Run("pool not found", func() {
+		suite.SetupTest()
+		poolId := suite.PrepareBalancerPool()
+		_, _, _, err := suite.App.GAMMKeeper.CalcArbCloseAmount(suite.Ctx, poolId, poolId+1, "foo", "bar")
+		suite.Require().Error(err)
+	})
+}
+
+// TestSwapExactAmountInTokenOutNotPayable tests that swapping for a denom the pool can't pay out
+// errors precisely, whether the denom isn't in the pool or the pool holds no balance of it.
+func (suite *KeeperTestSuite) TestSwapExactAmountInTokenOutNotPayable() {
+	suite.Run("denom not in pool", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareBalancerPool()
+
+		_, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 1000), "uatom", sdk.OneInt())
+		suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+		suite.Require().ErrorContains(err, "bar, baz, foo")
+	})
+
+	suite.Run("stableswap pool denom not in pool", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareUni2PoolWithAssets(sdk.NewInt64Coin("foo", 1000000), sdk.NewInt64Coin("bar", 1000000))
+		suite.Require().NoError(keeper.MigrateBalancerPoolToStableswap(suite.Ctx, poolId, 100, []uint64{1, 1}, ""))
+
+		_, err := keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 1000), "uatom", sdk.OneInt())
+		suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+		suite.Require().ErrorContains(err, "bar, foo")
+	})
+
+	suite.Run("zero balance of the denom", func() {
+		suite.SetupTest()
+		keeper := suite.App.GAMMKeeper
+		poolId := suite.PrepareBalancerPool()
+
+		// such pools can't be created or swapped to, so the pool's bar balance is zeroed in state.
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+		suite.Require().NoError(err)
+		balancerPool := pool.(*balancer.Pool)
+		for i, asset := range balancerPool.PoolAssets {
+			if asset.Token.Denom == "bar" {
+				balancerPool.PoolAssets[i].Token.Amount = sdk.ZeroInt()
+			}
+		}
+		suite.Require().NoError(keeper.SetPool(suite.Ctx, balancerPool))
+
+		_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 1000), "bar", sdk.OneInt())
+		suite.Require().ErrorIs(err, types.ErrPoolAssetDepleted)
+		_, err = keeper.MultihopSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], []types.SwapAmountInRoute{
+			{PoolId: poolId, TokenOutDenom: "bar"},
+		}, sdk.NewInt64Coin("foo", 1000), sdk.OneInt())
+		suite.Require().ErrorIs(err, types.ErrPoolAssetDepleted)
+
+		// the pool's other denoms can still be swapped for.
+		_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("foo", 1000), "baz", sdk.OneInt())
+		suite.Require().NoError(err)
+	})
+}
+
+// TestUpdatePoolForSwapInvariantDecreased tests that updatePoolForSwap, which checks swap invariants
+// in all tests, errors for a swap that pays out more than the pool's invariant allows.
+func (suite *KeeperTestSuite) TestUpdatePoolForSwapInvariantDecreased() {
+	tests := []struct {
+		name      string
+		leak      sdk.Int
+		expectErr bool
+	}{
+		{
+			name: "no leak",
+			leak: sdk.ZeroInt(),
+		},
+		{
+			name:      "one token leaked",
+			leak:      sdk.OneInt(),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			spotPriceBefore, err := pool.SpotPrice(suite.Ctx, "foo", "bar")
+			suite.Require().NoError(err)
+
+			tokenIn := sdk.NewCoin("foo", sdk.NewInt(1000000))
+			tokenOut, err := pool.SwapOutAmtGivenIn(suite.Ctx, sdk.NewCoins(tokenIn), "bar", sdk.ZeroDec())
+			suite.Require().NoError(err)
+
+			// pay out the leak on top of the swap's amount out
+			balancerPool, ok := pool.(*balancer.Pool)
+			suite.Require().True(ok)
+			barAsset, err := balancerPool.GetPoolAsset("bar")
+			suite.Require().NoError(err)
+			suite.Require().NoError(balancerPool.UpdatePoolAssetBalance(barAsset.Token.SubAmount(test.leak)))
+			tokenOut = tokenOut.AddAmount(test.leak)
+
+			err = keeper.UpdatePoolForSwap(suite.Ctx, pool, suite.TestAccs[0], tokenIn, tokenOut, spotPriceBefore, pool.GetSwapFee(suite.Ctx))
+			if test.expectErr {
+				suite.Require().ErrorIs(err, types.ErrInvariantDecreased)
+				return
+			}
+			suite.Require().NoError(err)
+		})
+	}
+}
+
+// failingAfterSwapHooks are the app's gamm hooks, with an AfterSwap hook that writes to state and then fails.
+type failingAfterSwapHooks struct {
+	types.GammHooks
+	bankKeeper types.BankKeeper
+}
+
+var errAfterSwapHook = errors.New("after swap hook failed")
+
+func (h failingAfterSwapHooks) AfterSwap(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins) error {
+	// the hook sees the swap fully applied.
+	if h.bankKeeper.GetBalance(ctx, sender, output[0].Denom).Amount.LT(output[0].Amount) {
+		return errors.New("after swap hook called before the swap was applied")
+	}
+	// and what the hook writes is reverted along with the swap.
+	if err := h.bankKeeper.SendCoins(ctx, sender, authtypes.NewModuleAddress(types.ModuleName), output); err != nil {
+		return err
+	}
+	return errAfterSwapHook
+}
+
+func (suite *KeeperTestSuite) TestSwapRevertedOnAfterSwapHookError() {
+	tests := []struct {
+		name string
+		swap func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error
+	}{
+		{
+			name: "SwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewInt64Coin("foo", 100000), "bar", sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountOut(suite.Ctx, sender, poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("bar", 100000))
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				routes := []types.SwapAmountInRoute{
+					{PoolId: poolId, TokenOutDenom: "bar"},
+					{PoolId: poolId, TokenOutDenom: "baz"},
+				}
+				_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, routes, sdk.NewInt64Coin("foo", 100000), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				routes := []types.SwapAmountOutRoute{
+					{PoolId: poolId, TokenInDenom: "foo"},
+					{PoolId: poolId, TokenInDenom: "bar"},
+				}
+				_, err := keeper.MultihopSwapExactAmountOut(suite.Ctx, sender, routes, sdk.NewInt(1000000), sdk.NewInt64Coin("baz", 100000))
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+
+			hooks := keeper.ReplaceHooks(nil)
+			keeper.ReplaceHooks(failingAfterSwapHooks{GammHooks: hooks, bankKeeper: suite.App.BankKeeper})
+			defer keeper.ReplaceHooks(hooks)
+
+			poolBefore, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			senderBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender)
+			poolBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, poolBefore.GetAddress())
+			totalLiquidityBefore := keeper.GetTotalLiquidity(suite.Ctx)
+			suite.Ctx = suite.Ctx.WithEventManager(sdk.NewEventManager())
+
+			err = test.swap(keeper, sender, poolId)
+			suite.Require().ErrorIs(err, errAfterSwapHook)
+
+			poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			suite.Require().Equal(poolBefore.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(senderBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, sender))
+			suite.Require().Equal(poolBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, poolBefore.GetAddress()))
+			suite.Require().Equal(totalLiquidityBefore, keeper.GetTotalLiquidity(suite.Ctx))
+			suite.Require().Empty(suite.Ctx.EventManager().Events())
+		})
+	}
+}
+
+// transferTaxBankKeeper is a bank keeper for a chain where transfers of foo are taxed 1%,
+// the tax being deducted from the recipient.
+type transferTaxBankKeeper struct {
+	types.BankKeeper
+}
+
+var (
+	transferTax          = sdk.NewDecWithPrec(1, 2)
+	transferTaxCollector = sdk.AccAddress([]byte("transfer_tax_collect"))
+)
+
+func (bk transferTaxBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := bk.BankKeeper.SendCoins(ctx, fromAddr, toAddr, amt); err != nil {
+		return err
+	}
+	tax := amt.AmountOf("foo").ToDec().Mul(transferTax).TruncateInt()
+	if tax.IsZero() {
+		return nil
+	}
+	return bk.BankKeeper.SendCoins(ctx, toAddr, transferTaxCollector, sdk.NewCoins(sdk.NewCoin("foo", tax)))
+}
+
+// TestSwapTransferTaxedDenom tests that swaps are rejected if a pool receives less than the tokens swapped in,
+// as the swap already added them to the pool's reserves, and that none of the swap is written.
+func (suite *KeeperTestSuite) TestSwapTransferTaxedDenom() {
+	tests := []struct {
+		name        string
+		swap        func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error
+		expectedErr error
+	}{
+		{
+			name: "SwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewInt64Coin("foo", 100000), "bar", sdk.OneInt())
+				return err
+			},
+			expectedErr: types.ErrTokenInNotReceived,
+		},
+		{
+			name: "SwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountOut(suite.Ctx, sender, poolId, "foo", sdk.NewInt(1000000), sdk.NewInt64Coin("bar", 100000))
+				return err
+			},
+			expectedErr: types.ErrTokenInNotReceived,
+		},
+		{
+			name: "MultihopSwapExactAmountIn taxed first hop",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				routes := []types.SwapAmountInRoute{{PoolId: poolId, TokenOutDenom: "bar"}, {PoolId: poolId, TokenOutDenom: "baz"}}
+				_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, routes, sdk.NewInt64Coin("foo", 100000), sdk.OneInt())
+				return err
+			},
+			expectedErr: types.ErrTokenInNotReceived,
+		},
+		{
+			name: "MultihopSwapExactAmountIn taxed intermediate hop",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				secondPoolId := suite.PrepareBalancerPool()
+				routes := []types.SwapAmountInRoute{{PoolId: poolId, TokenOutDenom: "foo"}, {PoolId: secondPoolId, TokenOutDenom: "baz"}}
+				_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, routes, sdk.NewInt64Coin("bar", 100000), sdk.OneInt())
+				return err
+			},
+			expectedErr: types.ErrTokenInNotReceived,
+		},
+		{
+			name: "SwapExactAmountIn taxed tokenOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			keeper := suite.App.GAMMKeeper
+			sender := suite.TestAccs[0]
+
+			bankKeeper := keeper.ReplaceBankKeeper(transferTaxBankKeeper{BankKeeper: suite.App.BankKeeper})
+			defer keeper.ReplaceBankKeeper(bankKeeper)
+
+			pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+			suite.Require().NoError(err)
+			poolBalancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress())
+
+			err = test.swap(keeper, sender, poolId)
+			if test.expectedErr != nil {
+				suite.Require().ErrorIs(err, test.expectedErr)
+				suite.Require().Equal(poolBalancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()))
+				poolAfter, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+				suite.Require().NoError(err)
+				suite.Require().Equal(pool.GetTotalPoolLiquidity(suite.Ctx), poolAfter.GetTotalPoolLiquidity(suite.Ctx))
+				return
+			}
+			suite.Require().NoError(err)
+			suite.requireTotalLiquidityEqualsPoolBalances()
+		})
+	}
+}
+
+// TestSwapExactAmountOutMulti tests that swapping for several tokens out at once requires as much in,
+// and leaves the pool with the same reserves, as swapping for them one after the other.
+func (suite *KeeperTestSuite) TestSwapExactAmountOutMulti() {
+	tokensOut := sdk.NewCoins(sdk.NewInt64Coin("bar", 300000), sdk.NewInt64Coin("baz", 200000))
+	tokenInMaxAmount := sdk.NewInt(10000000)
+
+	suite.SetupTest()
+	poolId := suite.PrepareBalancerPool()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+
+	// swap for the tokens out one after the other.
+	sequentialCtx, _ := suite.Ctx.CacheContext()
+	expectedTokenInAmount := sdk.ZeroInt()
+	for _, tokenOut := range tokensOut {
+		tokenInAmount, err := keeper.SwapExactAmountOut(sequentialCtx, trader, poolId, "foo", tokenInMaxAmount, tokenOut)
+		suite.Require().NoError(err)
+		expectedTokenInAmount = expectedTokenInAmount.Add(tokenInAmount)
+	}
+	expectedPool, err := keeper.GetPoolAndPoke(sequentialCtx, poolId)
+	suite.Require().NoError(err)
+
+	// the same total amount in is required for fewer than the combined max, and none is written.
+	cacheCtx, _ := suite.Ctx.CacheContext()
+	_, err = keeper.SwapExactAmountOutMulti(cacheCtx, trader, poolId, "foo", expectedTokenInAmount.SubRaw(1), tokensOut)
+	suite.Require().ErrorIs(err, types.ErrLimitMaxAmount)
+	pool, err := keeper.GetPoolAndPoke(cacheCtx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("bar", 5000000), sdk.NewInt64Coin("baz", 5000000), sdk.NewInt64Coin("foo", 5000000)), pool.GetTotalPoolLiquidity(cacheCtx))
+
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, trader)
+	tokenInAmount, err := keeper.SwapExactAmountOutMulti(suite.Ctx, trader, poolId, "foo", expectedTokenInAmount, tokensOut)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenInAmount, tokenInAmount)
+
+	pool, err = keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedPool.GetTotalPoolLiquidity(sequentialCtx), pool.GetTotalPoolLiquidity(suite.Ctx))
+	suite.Require().Equal(sdk.NewCoins(
+		sdk.NewInt64Coin("bar", 4700000), sdk.NewInt64Coin("baz", 4800000), sdk.NewCoin("foo", sdk.NewInt(5000000).Add(tokenInAmount)),
+	), pool.GetTotalPoolLiquidity(suite.Ctx))
+	expectedBalances := balancesBefore.Add(tokensOut...).Sub(sdk.NewCoins(sdk.NewCoin("foo", tokenInAmount)))
+	suite.Require().Equal(expectedBalances, suite.App.BankKeeper.GetAllBalances(suite.Ctx, trader))
+
+	_, err = keeper.SwapExactAmountOutMulti(suite.Ctx, trader, poolId, "foo", tokenInMaxAmount, sdk.Coins{})
+	suite.Require().ErrorIs(err, types.ErrInvalidTokenOutDenoms)
+}
+
+func (suite *KeeperTestSuite) TestBatchSwapExactAmountIn() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	poolId := suite.PrepareBalancerPool()
+	otherPoolId := suite.PrepareBalancerPool()
+	swaps := []types.BatchSwapAmountIn{
+		{PoolId: poolId, TokenIn: sdk.NewInt64Coin("foo", 100000), TokenOutDenom: "bar", TokenOutMinAmount: sdk.OneInt()},
+		{PoolId: otherPoolId, TokenIn: sdk.NewInt64Coin("bar", 200000), TokenOutDenom: "baz", TokenOutMinAmount: sdk.OneInt()},
+		{PoolId: poolId, TokenIn: sdk.NewInt64Coin("baz", 300000), TokenOutDenom: "foo", TokenOutMinAmount: sdk.OneInt()},
+	}
+
+	// the swaps of the batch are independent, so they get what they would get one after the other.
+	sequentialCtx, _ := suite.Ctx.CacheContext()
+	expectedTokenOutAmounts := make([]sdk.Int, len(swaps))
+	for i, swap := range swaps {
+		tokenOutAmount, err := keeper.SwapExactAmountIn(sequentialCtx, trader, swap.PoolId, swap.TokenIn, swap.TokenOutDenom, swap.TokenOutMinAmount)
+		suite.Require().NoError(err)
+		expectedTokenOutAmounts[i] = tokenOutAmount
+	}
+	expectedBalances := suite.App.BankKeeper.GetAllBalances(sequentialCtx, trader)
+
+	// a failing swap reverts the batch, including the swaps before it.
+	balancesBefore := suite.App.BankKeeper.GetAllBalances(suite.Ctx, trader)
+	failingSwaps := append([]types.BatchSwapAmountIn{}, swaps...)
+	failingSwaps[2].TokenOutMinAmount = expectedTokenOutAmounts[2].AddRaw(1)
+	_, err := keeper.BatchSwapExactAmountIn(suite.Ctx, trader, failingSwaps)
+	suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+	suite.Require().Equal(balancesBefore, suite.App.BankKeeper.GetAllBalances(suite.Ctx, trader))
+	for _, id := range []uint64{poolId, otherPoolId} {
+		pool, err := keeper.GetPoolAndPoke(suite.Ctx, id)
+		suite.Require().NoError(err)
+		suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("bar", 5000000), sdk.NewInt64Coin("baz", 5000000), sdk.NewInt64Coin("foo", 5000000)), pool.GetTotalPoolLiquidity(suite.Ctx))
+	}
+
+	tokenOutAmounts, err := keeper.BatchSwapExactAmountIn(suite.Ctx, trader, swaps)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOutAmounts, tokenOutAmounts)
+	suite.Require().Equal(expectedBalances, suite.App.BankKeeper.GetAllBalances(suite.Ctx, trader))
+
+	_, err = keeper.BatchSwapExactAmountIn(suite.Ctx, trader, nil)
+	suite.Require().ErrorIs(err, types.ErrEmptySwaps)
+}
+
+// TestSwapExactAmountInWithEffectivePrice tests that the effective price of a sizeable swap is of its
+// integer amount out, and shows the slippage beyond the spot price with the swap fee.
+func (suite *KeeperTestSuite) TestSwapExactAmountInWithEffectivePrice() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.ZeroDec(),
+	})
+	// a tenth of the pool's foo.
+	tokenIn := sdk.NewInt64Coin("foo", 500000)
+	suite.FundAcc(trader, sdk.Coins{tokenIn})
+
+	spotPriceWithSwapFee, err := keeper.CalculateSpotPriceWithSwapFee(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	expectedTokenOutAmount, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, "bar")
+	suite.Require().NoError(err)
+
+	tokenOutAmount, effectivePrice, err := keeper.SwapExactAmountInWithEffectivePrice(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOutAmount, tokenOutAmount)
+	suite.Require().Equal(tokenIn.Amount.ToDec().Quo(tokenOutAmount.ToDec()), effectivePrice)
+
+	// the swap pays more foo per bar than the marginal swap, by a slippage of several percent.
+	slippage := effectivePrice.Quo(spotPriceWithSwapFee).Sub(sdk.OneDec())
+	suite.Require().True(slippage.GT(sdk.NewDecWithPrec(1, 2)), "slippage %s", slippage)
+	suite.Require().True(slippage.LT(sdk.NewDecWithPrec(10, 2)), "slippage %s", slippage)
+
+	_, _, err = keeper.SwapExactAmountInWithEffectivePrice(suite.Ctx, trader, poolId, tokenIn, "bar", tokenOutAmount.MulRaw(2))
+	suite.Require().ErrorIs(err, types.ErrLimitMinAmount)
+}
+
+// TestMarginalOutForNextUnit tests that the marginal amount out of a unit in is the unrounded amount out
+// of swapping one unit in, which decreases as swaps grow the pool's reserve of the token in.
+func (suite *KeeperTestSuite) TestMarginalOutForNextUnit() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	trader := suite.TestAccs[0]
+	poolId := suite.PrepareBalancerPoolWithPoolParams(balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.ZeroDec(),
+	})
+	tokenIn := sdk.NewInt64Coin("foo", 500000)
+	suite.FundAcc(trader, sdk.NewCoins(sdk.NewCoin("foo", tokenIn.Amount.MulRaw(5))))
+
+	marginalOut, err := keeper.MarginalOutForNextUnit(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	pool, err := keeper.GetPoolAndPoke(suite.Ctx, poolId)
+	suite.Require().NoError(err)
+	expectedTokenOut, _, err := pool.(*balancer.Pool).CalcOutAmtGivenInWithFee(suite.Ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1)), "bar", sdk.NewDecWithPrec(1, 2))
+	suite.Require().NoError(err)
+	suite.Require().Equal(expectedTokenOut.Amount, marginalOut)
+	// a single foo gets less than a bar, which the amount out of the swap would round to zero.
+	suite.Require().True(marginalOut.LT(sdk.OneDec()), "marginal out %s", marginalOut)
+
+	for i := 0; i < 5; i++ {
+		_, err := keeper.SwapExactAmountIn(suite.Ctx, trader, poolId, tokenIn, "bar", sdk.OneInt())
+		suite.Require().NoError(err)
+
+		nextMarginalOut, err := keeper.MarginalOutForNextUnit(suite.Ctx, poolId, "foo", "bar")
+		suite.Require().NoError(err)
+		suite.Require().True(nextMarginalOut.LT(marginalOut), "swap %d: marginal out %s, was %s", i, nextMarginalOut, marginalOut)
+		marginalOut = nextMarginalOut
+	}
+
+	_, err = keeper.MarginalOutForNextUnit(suite.Ctx, poolId, "foo", "foo")
+	suite.Require().ErrorIs(err, types.ErrSameDenom)
+	_, err = keeper.MarginalOutForNextUnit(suite.Ctx, poolId, "foo", "uatom")
+	suite.Require().ErrorIs(err, types.ErrDenomNotFoundInPool)
+}
+
+// TestSameDenomSwaps tests that every quote and swap path errors with ErrSameDenom
+// for swapping a denom for itself, before looking at the pool's math.
+func (suite *KeeperTestSuite) TestSameDenomSwaps() {
+	tokenIn := sdk.NewInt64Coin("foo", 100000)
+	tests := []struct {
+		name string
+		swap func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error
+	}{
+		{
+			name: "EstimateSwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.EstimateSwapExactAmountIn(suite.Ctx, poolId, tokenIn, "foo")
+				return err
+			},
+		},
+		{
+			name: "SimulateSwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, _, _, _, err := keeper.SimulateSwapExactAmountIn(suite.Ctx, sender, poolId, tokenIn, "foo")
+				return err
+			},
+		},
+		{
+			name: "RankPoolsForSwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.RankPoolsForSwapExactAmountOut(suite.Ctx, sender, []uint64{poolId}, "foo", tokenIn)
+				return err
+			},
+		},
+		{
+			name: "CalcAmountInToReachSpotPrice",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.CalcAmountInToReachSpotPrice(suite.Ctx, poolId, "foo", "foo", sdk.OneDec())
+				return err
+			},
+		},
+		{
+			name: "CalculateSpotPrice",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "foo")
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountIn(suite.Ctx, sender, poolId, tokenIn, "foo", sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountInWithMaxPriceImpact",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountInWithMaxPriceImpact(suite.Ctx, sender, poolId, tokenIn, "foo", sdk.OneInt(), sdk.OneDec())
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountInWithMaxAmountOut",
+			swap: func(k *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, _, err := k.SwapExactAmountInWithMaxAmountOut(suite.Ctx, sender, poolId, tokenIn, "foo", sdk.OneInt(), tokenIn.Amount, keeper.MaxAmountOutModeError)
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountInWithDustMode",
+			swap: func(k *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, _, err := k.SwapExactAmountInWithDustMode(suite.Ctx, sender, poolId, tokenIn, "foo", sdk.OneInt(), tokenIn.Amount, keeper.DustModeRaiseIn)
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountOut(suite.Ctx, sender, poolId, "foo", sdk.NewInt(1000000), tokenIn)
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountOutMulti",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.SwapExactAmountOutMulti(suite.Ctx, sender, poolId, "foo", sdk.NewInt(1000000), sdk.NewCoins(sdk.NewInt64Coin("bar", 100000), tokenIn))
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, []types.SwapAmountInRoute{
+					{PoolId: poolId, TokenOutDenom: "bar"},
+					{PoolId: poolId, TokenOutDenom: "bar"},
+				}, tokenIn, sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountOut",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.MultihopSwapExactAmountOut(suite.Ctx, sender, []types.SwapAmountOutRoute{
+					{PoolId: poolId, TokenInDenom: "bar"},
+					{PoolId: poolId, TokenInDenom: "foo"},
+				}, sdk.NewInt(1000000), tokenIn)
+				return err
+			},
+		},
+		{
+			name: "MultiSwapExactAmountIn",
+			swap: func(keeper *keeper.Keeper, sender sdk.AccAddress, poolId uint64) error {
+				_, err := keeper.MultiSwapExactAmountIn(suite.Ctx, sender, []types.SwapAmountInSplit{
+					{PoolId: poolId, Fraction: sdk.OneDec()},
+				}, tokenIn, "foo", sdk.OneInt())
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			poolId := suite.PrepareBalancerPool()
+			err := test.swap(suite.App.GAMMKeeper, suite.TestAccs[0], poolId)
+			suite.Require().ErrorIs(err, types.ErrSameDenom)
+		})
+	}
+}
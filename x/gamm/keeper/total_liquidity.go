@@ -7,6 +7,9 @@ import (
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
+// GetTotalLiquidity returns the liquidity of every denom summed over all pools.
+// It is recorded on every change of the pools' reserves, see RecordTotalLiquidityIncrease,
+// so it doesn't iterate the pools. TotalLiquidityInvariant checks it against the pools.
 func (k Keeper) GetTotalLiquidity(ctx sdk.Context) sdk.Coins {
 	coins := sdk.Coins{}
 	k.IterateDenomLiquidity(ctx, func(coin sdk.Coin) bool {
@@ -16,6 +19,15 @@ func (k Keeper) GetTotalLiquidity(ctx sdk.Context) sdk.Coins {
 	return coins
 }
 
+// GetPoolLiquidity returns the liquidity of the pool with poolId.
+func (k Keeper) GetPoolLiquidity(ctx sdk.Context, poolId uint64) (sdk.Coins, error) {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+	return pool.GetTotalPoolLiquidity(ctx), nil
+}
+
 func (k Keeper) SetTotalLiquidity(ctx sdk.Context, coins sdk.Coins) {
 	for _, coin := range coins {
 		k.SetDenomLiquidity(ctx, coin.Denom, coin.Amount)
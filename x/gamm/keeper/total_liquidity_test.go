@@ -0,0 +1,140 @@
+package keeper_test
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// requireTotalLiquidityEqualsPoolBalances checks that the recorded total liquidity equals
+// both the sum of the pools' liquidity, and the sum of the pool accounts' balances.
+func (suite *KeeperTestSuite) requireTotalLiquidityEqualsPoolBalances() {
+	msg, broken := keeper.TotalLiquidityInvariant(*suite.App.GAMMKeeper)(suite.Ctx)
+	suite.Require().False(broken, msg)
+
+	pools, err := suite.App.GAMMKeeper.GetPoolsAndPoke(suite.Ctx)
+	suite.Require().NoError(err)
+	poolBalances := sdk.Coins{}
+	for _, pool := range pools {
+		poolBalances = poolBalances.Add(suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress())...)
+
+		poolLiquidity, err := suite.App.GAMMKeeper.GetPoolLiquidity(suite.Ctx, pool.GetId())
+		suite.Require().NoError(err)
+		suite.Require().Equal(suite.App.BankKeeper.GetAllBalances(suite.Ctx, pool.GetAddress()), poolLiquidity)
+	}
+	suite.Require().Equal(poolBalances, suite.App.GAMMKeeper.GetTotalLiquidity(suite.Ctx))
+}
+
+func (suite *KeeperTestSuite) TestTotalLiquidityEqualsPoolBalances() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+	sender, exitFeeRecipient := suite.TestAccs[0], suite.TestAccs[1]
+	poolParams := balancer.PoolParams{
+		SwapFee: sdk.NewDecWithPrec(1, 2),
+		ExitFee: sdk.NewDecWithPrec(1, 2),
+	}
+
+	poolId := suite.PrepareBalancerPoolWithPoolParams(poolParams)
+	otherPoolId := suite.PrepareBalancerPoolWithPoolParams(poolParams)
+	suite.Require().NoError(keeper.SetExitFeeRecipient(suite.Ctx, otherPoolId, exitFeeRecipient))
+	suite.requireTotalLiquidityEqualsPoolBalances()
+
+	operations := []struct {
+		name    string
+		operate func() error
+	}{
+		{
+			name: "JoinPoolNoSwap",
+			operate: func() error {
+				return keeper.JoinPoolNoSwap(suite.Ctx, sender, poolId, types.OneShare.MulRaw(10), sdk.Coins{})
+			},
+		},
+		{
+			name: "JoinSwapExactAmountIn",
+			operate: func() error {
+				_, err := keeper.JoinSwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewCoins(sdk.NewInt64Coin("foo", 10000)), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "JoinSwapShareAmountOut",
+			operate: func() error {
+				_, err := keeper.JoinSwapShareAmountOut(suite.Ctx, sender, poolId, "bar", types.OneShare, sdk.NewInt(1000000))
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountIn",
+			operate: func() error {
+				_, err := keeper.SwapExactAmountIn(suite.Ctx, sender, poolId, sdk.NewInt64Coin("foo", 10000), "bar", sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "SwapExactAmountOut",
+			operate: func() error {
+				_, err := keeper.SwapExactAmountOut(suite.Ctx, sender, poolId, "bar", sdk.NewInt(1000000), sdk.NewInt64Coin("baz", 10000))
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountIn",
+			operate: func() error {
+				routes := []types.SwapAmountInRoute{
+					{PoolId: poolId, TokenOutDenom: "bar"},
+					{PoolId: otherPoolId, TokenOutDenom: "baz"},
+				}
+				_, err := keeper.MultihopSwapExactAmountIn(suite.Ctx, sender, routes, sdk.NewInt64Coin("foo", 10000), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "MultihopSwapExactAmountOut",
+			operate: func() error {
+				routes := []types.SwapAmountOutRoute{
+					{PoolId: otherPoolId, TokenInDenom: "foo"},
+					{PoolId: poolId, TokenInDenom: "bar"},
+				}
+				_, err := keeper.MultihopSwapExactAmountOut(suite.Ctx, sender, routes, sdk.NewInt(1000000), sdk.NewInt64Coin("baz", 10000))
+				return err
+			},
+		},
+		{
+			name: "ExitPool",
+			operate: func() error {
+				_, err := keeper.ExitPool(suite.Ctx, sender, poolId, types.OneShare.MulRaw(5), sdk.Coins{})
+				return err
+			},
+		},
+		{
+			name: "ExitPool with exit fee recipient",
+			operate: func() error {
+				_, err := keeper.ExitPool(suite.Ctx, sender, otherPoolId, types.OneShare.MulRaw(5), sdk.Coins{})
+				return err
+			},
+		},
+		{
+			name: "ExitSwapShareAmountIn",
+			operate: func() error {
+				_, err := keeper.ExitSwapShareAmountIn(suite.Ctx, sender, poolId, "foo", types.OneShare, sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "ExitSwapExactAmountOut",
+			operate: func() error {
+				_, err := keeper.ExitSwapExactAmountOut(suite.Ctx, sender, poolId, sdk.NewInt64Coin("baz", 10000), types.OneShare.MulRaw(10))
+				return err
+			},
+		},
+	}
+
+	for _, operation := range operations {
+		suite.Run(operation.name, func() {
+			suite.Require().NoError(operation.operate())
+			suite.requireTotalLiquidityEqualsPoolBalances()
+		})
+	}
+}
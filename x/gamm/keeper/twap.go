@@ -0,0 +1,260 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// The TWAP accumulators of a pool are not updated on every swap, join or exit.
+// Instead, every change of a pool's reserves marks the pool as changed, and the TWAP
+// records of all the changed pools are written in UpdateTwapRecords at the end of the block.
+// As the block time doesn't change within a block, the accumulator at the block time only
+// depends on the spot price at the end of the previous changing block, so this yields the
+// same accumulators, while keeping the gas cost of swaps and LP'ing low.
+//
+// Note that the weights of pools with a smooth weight change are poked when their records
+// are written, so their spot prices in between two changes of their reserves are approximated
+// by the spot price at the first change.
+//
+// Writing a record of a denom pair prunes the pair's records older than TwapRecordHistoryKeepPeriod,
+// but for the latest of them, from which the accumulator can still be computed at the start
+// of the keep period.
+
+// TwapRecordHistoryKeepPeriod is how long the TWAP records of pools are kept for.
+// TWAPs can be computed over windows starting at most this long before the block time.
+const TwapRecordHistoryKeepPeriod = 48 * time.Hour
+
+// markPoolChanged marks poolId as having changed reserves in the current block.
+func (k Keeper) markPoolChanged(ctx sdk.Context, poolId uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetKeyChangedPool(poolId), []byte{1})
+}
+
+// UpdateTwapRecords writes a TWAP record for every denom pair of every pool
+// whose reserves changed in the current block.
+// It is meant to be called at the end of every block.
+func (k Keeper) UpdateTwapRecords(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixChangedPools)
+	poolIds := []uint64{}
+	for ; iter.Valid(); iter.Next() {
+		poolIds = append(poolIds, sdk.BigEndianToUint64(iter.Key()[len(types.KeyPrefixChangedPools):]))
+	}
+	iter.Close()
+
+	for _, poolId := range poolIds {
+		store.Delete(types.GetKeyChangedPool(poolId))
+		if err := k.updatePoolTwapRecords(ctx, poolId); err != nil {
+			return sdkerrors.Wrapf(err, "failed to update the TWAP records of pool %d", poolId)
+		}
+	}
+	return nil
+}
+
+// updatePoolTwapRecords writes a record at the block time for every denom pair of poolId,
+// with the pool's current spot price.
+func (k Keeper) updatePoolTwapRecords(ctx sdk.Context, poolId uint64) error {
+	pool, err := k.GetPoolAndPoke(ctx, poolId)
+	if err != nil {
+		return err
+	}
+
+	// all spot prices are computed before writing any record, so that a pool
+	// either has all of its records updated, or none of them.
+	liquidity := pool.GetTotalPoolLiquidity(ctx)
+	records := make([]types.TwapRecord, 0, len(liquidity)*(len(liquidity)-1))
+	for _, base := range liquidity {
+		for _, quote := range liquidity {
+			if base.Denom == quote.Denom {
+				continue
+			}
+
+			spotPrice, err := pool.SpotPrice(ctx, base.Denom, quote.Denom)
+			if err != nil {
+				return err
+			}
+
+			record := types.TwapRecord{
+				PoolId:      poolId,
+				BaseDenom:   base.Denom,
+				QuoteDenom:  quote.Denom,
+				Height:      ctx.BlockHeight(),
+				Time:        ctx.BlockTime(),
+				SpotPrice:   spotPrice,
+				Accumulator: sdk.ZeroDec(),
+			}
+			if lastRecord, found := k.getLastTwapRecordAtOrBefore(ctx, poolId, base.Denom, quote.Denom, ctx.BlockTime()); found {
+				record.Accumulator = lastRecord.AccumulatorAt(ctx.BlockTime())
+			}
+			records = append(records, record)
+		}
+	}
+
+	for _, record := range records {
+		k.setTwapRecord(ctx, record)
+		k.pruneTwapRecords(ctx, record.PoolId, record.BaseDenom, record.QuoteDenom, ctx.BlockTime().Add(-TwapRecordHistoryKeepPeriod))
+	}
+	return nil
+}
+
+func (k Keeper) setTwapRecord(ctx sdk.Context, record types.TwapRecord) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetKeyTwapRecord(record.PoolId, record.BaseDenom, record.QuoteDenom, record.Time), k.cdc.MustMarshal(&record))
+	store.Set(types.GetKeyTwapRecordHeight(record.PoolId, record.BaseDenom, record.QuoteDenom, record.Height), sdk.FormatTimeBytes(record.Time))
+}
+
+// pruneTwapRecords deletes the TWAP records of poolId for the denom pair from before cutoff,
+// but for the latest of them, along with their heights.
+func (k Keeper) pruneTwapRecords(ctx sdk.Context, poolId uint64, baseDenom, quoteDenom string, cutoff time.Time) {
+	keptRecord, found := k.getLastTwapRecordAtOrBefore(ctx, poolId, baseDenom, quoteDenom, cutoff)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(
+		types.GetKeyPrefixTwapRecords(poolId, baseDenom, quoteDenom),
+		types.GetKeyTwapRecord(poolId, baseDenom, quoteDenom, keptRecord.Time),
+	)
+	keys := [][]byte{}
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	// heights and times of records both increase, so the heights to delete are the ones before the kept record's.
+	heightIter := store.Iterator(
+		types.GetKeyPrefixTwapRecordHeights(poolId, baseDenom, quoteDenom),
+		types.GetKeyTwapRecordHeight(poolId, baseDenom, quoteDenom, keptRecord.Height),
+	)
+	for ; heightIter.Valid(); heightIter.Next() {
+		keys = append(keys, heightIter.Key())
+	}
+	heightIter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// GetAllTwapRecords returns the TWAP records of all pools.
+func (k Keeper) GetAllTwapRecords(ctx sdk.Context) []types.TwapRecord {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyPrefixTwapRecords)
+	defer iter.Close()
+
+	records := []types.TwapRecord{}
+	for ; iter.Valid(); iter.Next() {
+		var record types.TwapRecord
+		k.cdc.MustUnmarshal(iter.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+
+// getLastTwapRecordAtOrBeforeHeight returns the latest TWAP record of poolId for the denom pair
+// written at a height not after height, and false if there is no such record.
+func (k Keeper) getLastTwapRecordAtOrBeforeHeight(ctx sdk.Context, poolId uint64, baseDenom, quoteDenom string, height int64) (types.TwapRecord, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.ReverseIterator(
+		types.GetKeyPrefixTwapRecordHeights(poolId, baseDenom, quoteDenom),
+		sdk.PrefixEndBytes(types.GetKeyTwapRecordHeight(poolId, baseDenom, quoteDenom, height)),
+	)
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return types.TwapRecord{}, false
+	}
+
+	recordTime, err := sdk.ParseTimeBytes(iter.Value())
+	if err != nil {
+		panic(err)
+	}
+	return k.getLastTwapRecordAtOrBefore(ctx, poolId, baseDenom, quoteDenom, recordTime)
+}
+
+// getLastTwapRecordAtOrBefore returns the latest TWAP record of poolId for the denom pair
+// whose time is not after t, and false if there is no such record.
+func (k Keeper) getLastTwapRecordAtOrBefore(ctx sdk.Context, poolId uint64, baseDenom, quoteDenom string, t time.Time) (types.TwapRecord, bool) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.ReverseIterator(
+		types.GetKeyPrefixTwapRecords(poolId, baseDenom, quoteDenom),
+		sdk.PrefixEndBytes(types.GetKeyTwapRecord(poolId, baseDenom, quoteDenom, t)),
+	)
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return types.TwapRecord{}, false
+	}
+
+	var record types.TwapRecord
+	k.cdc.MustUnmarshal(iter.Value(), &record)
+	return record, true
+}
+
+// GetArithmeticTWAP returns the arithmetic time weighted average, between startTime and endTime,
+// of the spot price of the quote asset in terms of the base asset (as in CalculateSpotPrice) in poolId.
+// startTime must be at least a millisecond before endTime, and endTime must not be after the block time.
+// An error is returned if the pool has no TWAP record at or before startTime,
+// e.g. if the pool was created after startTime, or if its records at startTime were pruned.
+func (k Keeper) GetArithmeticTWAP(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	startTime time.Time,
+	endTime time.Time,
+) (sdk.Dec, error) {
+	// accumulators are in milliseconds, so shorter windows can't be averaged over.
+	windowMs := endTime.Sub(startTime).Milliseconds()
+	if windowMs <= 0 {
+		return sdk.Dec{}, fmt.Errorf("start time %s must be at least a millisecond before end time %s", startTime, endTime)
+	}
+	if endTime.After(ctx.BlockTime()) {
+		return sdk.Dec{}, fmt.Errorf("end time %s must not be after the block time %s", endTime, ctx.BlockTime())
+	}
+
+	startRecord, found := k.getLastTwapRecordAtOrBefore(ctx, poolId, baseAssetDenom, quoteAssetDenom, startTime)
+	if !found {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrTwapRecordNotFound,
+			"pool %d has no record for base %s and quote %s at or before %s", poolId, baseAssetDenom, quoteAssetDenom, startTime)
+	}
+	// a record at or before startTime exists, so there is one at or before endTime too.
+	endRecord, _ := k.getLastTwapRecordAtOrBefore(ctx, poolId, baseAssetDenom, quoteAssetDenom, endTime)
+
+	accumulatorDiff := endRecord.AccumulatorAt(endTime).Sub(startRecord.AccumulatorAt(startTime))
+	return accumulatorDiff.QuoInt64(windowMs), nil
+}
+
+// CalculateSpotPriceAtHeight returns the spot price of the quote asset in terms of the base asset
+// (as in CalculateSpotPrice) in poolId, as of the end of the block at height.
+// The spot prices are read from the pool's TWAP records, which are written at the end of every block
+// changing the pool's reserves, so height must be before the current block height.
+// An error is returned if the pool has no TWAP record at or before height, e.g. if the pool was created after height,
+// or if its records at height were pruned.
+func (k Keeper) CalculateSpotPriceAtHeight(
+	ctx sdk.Context,
+	poolId uint64,
+	baseAssetDenom string,
+	quoteAssetDenom string,
+	height int64,
+) (sdk.Dec, error) {
+	if height < 0 {
+		return sdk.Dec{}, fmt.Errorf("height %d must not be negative", height)
+	}
+	if height >= ctx.BlockHeight() {
+		return sdk.Dec{}, fmt.Errorf("height %d must be before the block height %d", height, ctx.BlockHeight())
+	}
+
+	record, found := k.getLastTwapRecordAtOrBeforeHeight(ctx, poolId, baseAssetDenom, quoteAssetDenom, height)
+	if !found {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrTwapRecordNotFound,
+			"pool %d has no record for base %s and quote %s at or before height %d", poolId, baseAssetDenom, quoteAssetDenom, height)
+	}
+	return record.SpotPrice, nil
+}
@@ -0,0 +1,274 @@
+package keeper_test
+
+import (
+	"errors"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func (suite *KeeperTestSuite) TestGetArithmeticTWAP() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	// the pool is created at t0, and its reserves are changed at t1 by two swaps.
+	t0 := suite.Ctx.BlockTime()
+	poolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+	spotPrice0, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+
+	t1 := t0.Add(10 * time.Second)
+	suite.Ctx = suite.Ctx.WithBlockTime(t1)
+	for i := 0; i < 2; i++ {
+		_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+		suite.Require().NoError(err)
+	}
+	suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+	spotPrice1, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(spotPrice0, spotPrice1)
+
+	t2 := t1.Add(30 * time.Second)
+	suite.Ctx = suite.Ctx.WithBlockTime(t2)
+
+	tests := []struct {
+		name         string
+		startTime    time.Time
+		endTime      time.Time
+		expectedTwap sdk.Dec
+		expectedErr  error
+	}{
+		{
+			name:         "window before the swaps",
+			startTime:    t0,
+			endTime:      t1,
+			expectedTwap: spotPrice0,
+		},
+		{
+			name:         "window after the swaps",
+			startTime:    t1.Add(5 * time.Second),
+			endTime:      t2,
+			expectedTwap: spotPrice1,
+		},
+		{
+			name:         "window over the swaps",
+			startTime:    t0,
+			endTime:      t2,
+			expectedTwap: spotPrice0.MulInt64(10).Add(spotPrice1.MulInt64(30)).QuoInt64(40),
+		},
+		{
+			name:        "window starts before the pool was created",
+			startTime:   t0.Add(-time.Second),
+			endTime:     t2,
+			expectedErr: types.ErrTwapRecordNotFound,
+		},
+		{
+			name:        "window ends after the block time",
+			startTime:   t0,
+			endTime:     t2.Add(time.Second),
+			expectedErr: errors.New("must not be after the block time"),
+		},
+		{
+			name:        "empty window",
+			startTime:   t1,
+			endTime:     t1,
+			expectedErr: errors.New("must be at least a millisecond before end time"),
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			twap, err := keeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", test.startTime, test.endTime)
+			if test.expectedErr != nil {
+				suite.Require().ErrorContains(err, test.expectedErr.Error())
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedTwap, twap)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTwapRecordsUpdatedOnJoinAndExit() {
+	tests := []struct {
+		name         string
+		changePoolFn func(poolId uint64) error
+	}{
+		{
+			name: "single asset join",
+			changePoolFn: func(poolId uint64) error {
+				_, err := suite.App.GAMMKeeper.JoinSwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewCoins(sdk.NewInt64Coin("bar", 100000)), sdk.OneInt())
+				return err
+			},
+		},
+		{
+			name: "single asset exit",
+			changePoolFn: func(poolId uint64) error {
+				_, err := suite.App.GAMMKeeper.ExitSwapShareAmountIn(suite.Ctx, suite.TestAccs[0], poolId, "bar", types.OneShare.MulRaw(10), sdk.OneInt())
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			suite.SetupTest()
+			keeper := suite.App.GAMMKeeper
+
+			t0 := suite.Ctx.BlockTime()
+			poolId := suite.PrepareBalancerPool()
+			suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+			spotPrice0, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+			suite.Require().NoError(err)
+
+			t1 := t0.Add(10 * time.Second)
+			suite.Ctx = suite.Ctx.WithBlockTime(t1)
+			suite.Require().NoError(test.changePoolFn(poolId))
+			suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+			spotPrice1, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+			suite.Require().NoError(err)
+			suite.Require().NotEqual(spotPrice0, spotPrice1)
+
+			suite.Ctx = suite.Ctx.WithBlockTime(t1.Add(10 * time.Second))
+			twap, err := keeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", t1, suite.Ctx.BlockTime())
+			suite.Require().NoError(err)
+			suite.Require().Equal(spotPrice1, twap)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestCalculateSpotPriceAtHeight() {
+	suite.SetupTest()
+	keeper := suite.App.GAMMKeeper
+
+	// the pool is created at h0, has its reserves changed by a swap at h1, and is left unchanged at h2.
+	h0, t0 := suite.Ctx.BlockHeight(), suite.Ctx.BlockTime()
+	poolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+	spotPrice0, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+
+	suite.Ctx = suite.Ctx.WithBlockHeight(h0 + 1).WithBlockTime(t0.Add(5 * time.Second))
+	_, err = keeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+	spotPrice1, err := keeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(spotPrice0, spotPrice1)
+
+	suite.Ctx = suite.Ctx.WithBlockHeight(h0 + 2).WithBlockTime(t0.Add(10 * time.Second))
+	suite.Require().NoError(keeper.UpdateTwapRecords(suite.Ctx))
+	suite.Ctx = suite.Ctx.WithBlockHeight(h0 + 3).WithBlockTime(t0.Add(15 * time.Second))
+
+	tests := []struct {
+		name              string
+		height            int64
+		expectedSpotPrice sdk.Dec
+		expectedErr       error
+	}{
+		{
+			name:              "height of the pool creation",
+			height:            h0,
+			expectedSpotPrice: spotPrice0,
+		},
+		{
+			name:              "height of the swap",
+			height:            h0 + 1,
+			expectedSpotPrice: spotPrice1,
+		},
+		{
+			name:              "height without pool changes",
+			height:            h0 + 2,
+			expectedSpotPrice: spotPrice1,
+		},
+		{
+			name:        "height before the pool was created",
+			height:      h0 - 1,
+			expectedErr: types.ErrTwapRecordNotFound,
+		},
+		{
+			name:        "current height",
+			height:      h0 + 3,
+			expectedErr: errors.New("must be before the block height"),
+		},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			spotPrice, err := keeper.CalculateSpotPriceAtHeight(suite.Ctx, poolId, "foo", "bar", test.height)
+			if test.expectedErr != nil {
+				suite.Require().ErrorContains(err, test.expectedErr.Error())
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Require().Equal(test.expectedSpotPrice, spotPrice)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestTwapRecordsPruned() {
+	suite.SetupTest()
+	gammKeeper := suite.App.GAMMKeeper
+
+	// the pool is created at h0, and has its reserves changed by swaps at h1, an hour later,
+	// and at h2, once the records of h0 and h1 are both past the keep period.
+	h0, t0 := suite.Ctx.BlockHeight(), suite.Ctx.BlockTime()
+	poolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(gammKeeper.UpdateTwapRecords(suite.Ctx))
+
+	h1, t1 := h0+1, t0.Add(time.Hour)
+	suite.Ctx = suite.Ctx.WithBlockHeight(h1).WithBlockTime(t1)
+	_, err := gammKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().NoError(gammKeeper.UpdateTwapRecords(suite.Ctx))
+	spotPrice1, err := gammKeeper.CalculateSpotPrice(suite.Ctx, poolId, "foo", "bar")
+	suite.Require().NoError(err)
+
+	h2, t2 := h0+2, t1.Add(keeper.TwapRecordHistoryKeepPeriod+time.Hour)
+	suite.Ctx = suite.Ctx.WithBlockHeight(h2).WithBlockTime(t2)
+	_, err = gammKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().NoError(gammKeeper.UpdateTwapRecords(suite.Ctx))
+	suite.Ctx = suite.Ctx.WithBlockHeight(h2 + 1).WithBlockTime(t2.Add(time.Second))
+
+	// the records of h0 are pruned, while the ones of h1 are kept as the latest before the keep period,
+	// leaving two records for each of the 6 denom pairs of the pool.
+	suite.Require().Len(gammKeeper.GetAllTwapRecords(suite.Ctx), 12)
+	_, err = gammKeeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", t0, t2)
+	suite.Require().ErrorIs(err, types.ErrTwapRecordNotFound)
+	_, err = gammKeeper.CalculateSpotPriceAtHeight(suite.Ctx, poolId, "foo", "bar", h0)
+	suite.Require().ErrorIs(err, types.ErrTwapRecordNotFound)
+
+	twap, err := gammKeeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", t1, t2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(spotPrice1, twap)
+	spotPrice, err := gammKeeper.CalculateSpotPriceAtHeight(suite.Ctx, poolId, "foo", "bar", h1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(spotPrice1, spotPrice)
+}
+
+func (suite *KeeperTestSuite) TestTwapRecordsGenesis() {
+	suite.SetupTest()
+
+	t0 := suite.Ctx.BlockTime()
+	poolId := suite.PrepareBalancerPool()
+	suite.Require().NoError(suite.App.GAMMKeeper.UpdateTwapRecords(suite.Ctx))
+	suite.Ctx = suite.Ctx.WithBlockHeight(suite.Ctx.BlockHeight() + 1).WithBlockTime(t0.Add(10 * time.Second))
+	_, err := suite.App.GAMMKeeper.SwapExactAmountIn(suite.Ctx, suite.TestAccs[0], poolId, sdk.NewInt64Coin("bar", 100000), "foo", sdk.OneInt())
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.App.GAMMKeeper.UpdateTwapRecords(suite.Ctx))
+	suite.Ctx = suite.Ctx.WithBlockTime(t0.Add(20 * time.Second))
+	twap, err := suite.App.GAMMKeeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", t0, suite.Ctx.BlockTime())
+	suite.Require().NoError(err)
+
+	genesis := suite.exportAndImportGenesis()
+	suite.Require().Len(genesis.TwapRecords, 12)
+	suite.Require().Equal(genesis.TwapRecords, suite.App.GAMMKeeper.GetAllTwapRecords(suite.Ctx))
+	importedTwap, err := suite.App.GAMMKeeper.GetArithmeticTWAP(suite.Ctx, poolId, "foo", "bar", t0, suite.Ctx.BlockTime())
+	suite.Require().NoError(err)
+	suite.Require().Equal(twap, importedTwap)
+}
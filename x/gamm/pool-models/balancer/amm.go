@@ -19,7 +19,15 @@ const (
 	errMsgFormatSharesLargerThanMax           = "%d resulted shares is larger than the max amount of %d"
 	errMsgFormatFailedInterimLiquidityUpdate  = "failed to update interim liquidity - pool asset %s does not exist"
 	errMsgFormatRepeatingPoolAssetsNotAllowed = "repeating pool assets not allowed, found %s"
+	errMsgFormatInsufficientNoSwapTokensIn    = "tokens in %s are less than the %s needed to join for %s shares without a swap"
 	v10Fork                                   = 4713065
+	// maxPowIterations bounds the number of series terms computed per fractional power,
+	// which are approximated to osmomath.GetPowPrecision(). Balance ratios far from one need
+	// more terms to converge, e.g. swapping in 100x the pool's balance of an asset takes ~1k terms
+	// at a 3:10 weight ratio, and 1000x takes ~8k terms.
+	// Operations that would need more than this bound are rejected with an error.
+	// This affects swap results, so it must never be changed on a live chain.
+	maxPowIterations int64 = 10_000
 )
 
 // solveConstantFunctionInvariant solves the constant function of an AMM
@@ -31,61 +39,186 @@ const (
 // balanceYDelta is positive when the balance liquidity decreases.
 // balanceYDelta is negative when the balance liquidity increases.
 //
-// panics if tokenWeightUnknown is 0.
+// returns an error if the power does not reach osmomath.GetPowPrecision() within maxPowIterations.
+// Inputs outside of the domain of the power approximation, i.e. non-positive balances or weights,
+// or balanceXBefore/balanceXAfter not in (0, 2), return an ErrInvalidMathApprox error.
+// So does an sdk.Dec overflow in the computation, which is possible for extremely large balances.
 func solveConstantFunctionInvariant(
 	tokenBalanceFixedBefore,
 	tokenBalanceFixedAfter,
 	tokenWeightFixed,
 	tokenBalanceUnknownBefore,
 	tokenWeightUnknown sdk.Dec,
-) sdk.Dec {
+) (amountY sdk.Dec, err error) {
+	if !tokenBalanceFixedBefore.IsPositive() || !tokenBalanceFixedAfter.IsPositive() || !tokenBalanceUnknownBefore.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "balances must be positive, got %s, %s and %s",
+			tokenBalanceFixedBefore, tokenBalanceFixedAfter, tokenBalanceUnknownBefore)
+	}
+	if !tokenWeightFixed.IsPositive() || !tokenWeightUnknown.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "weights must be positive, got %s and %s",
+			tokenWeightFixed, tokenWeightUnknown)
+	}
+	// the power is only approximated for bases in (0, 2), so balanceXBefore must be less than 2 * balanceXAfter.
+	// This is checked without dividing, as the quotient itself could overflow.
+	if tokenBalanceFixedBefore.Sub(tokenBalanceFixedAfter).GTE(tokenBalanceFixedAfter) {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "balance before %s must be less than twice the balance after %s",
+			tokenBalanceFixedBefore, tokenBalanceFixedAfter)
+	}
+
+	// With the base in (0, 2), the power can still exceed sdk.Dec's range for large weight ratios,
+	// as can multiplying it with an extremely large balanceY. sdk.Dec panics on overflow.
+	defer func() {
+		if r := recover(); r != nil {
+			amountY, err = sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "overflow solving the constant function invariant: %v", r)
+		}
+	}()
+
 	// weightRatio = (weightX/weightY)
 	weightRatio := tokenWeightFixed.Quo(tokenWeightUnknown)
 
 	// y = balanceXBefore/balanceXAfter
 	y := tokenBalanceFixedBefore.Quo(tokenBalanceFixedAfter)
 
+	// y ^ 1 is y, so for a weight ratio of one, e.g. swaps between two assets of equal weight,
+	// the power isn't approximated. It returns exactly what pow does for an exponent of one.
+	if weightRatio.Equal(sdk.OneDec()) {
+		amountY = tokenBalanceUnknownBefore.Mul(sdk.OneDec().Sub(y))
+		return amountY, nil
+	}
+
 	// amountY = balanceY * (1 - (y ^ weightRatio))
-	yToWeightRatio := osmomath.Pow(y, weightRatio)
+	// The series converges slowly for y close to 2, e.g. for single asset joins of almost the entire
+	// pool balance, so such bases are reduced by square roots first, see powAtLeastOne.
+	yToWeightRatio, err := powAtLeastOne(y, weightRatio, osmomath.GetPowPrecision())
+	if err != nil {
+		return sdk.Dec{}, err
+	}
 	paranthetical := sdk.OneDec().Sub(yToWeightRatio)
-	amountY := tokenBalanceUnknownBefore.Mul(paranthetical)
-	return amountY
+	amountY = tokenBalanceUnknownBefore.Mul(paranthetical)
+	return amountY, nil
 }
 
 // CalcOutAmtGivenIn calculates tokens to be swapped out given the provided
-// amount and fee deducted, using solveConstantFunctionInvariant.
+// amount and fee deducted, by solving the pool's invariant curve.
+// If multiple tokensIn are provided, they are swapped one after the other (in coin order)
+// for tokenOutDenom, each with the swap fee deducted, and the sum of the outputs is returned.
+// This yields the same output as doing the swaps one at a time.
+// It is the canonical computation of the amount out of a swap: SwapOutAmtGivenIn and
+// SwapOutAmtGivenInWithRemainder apply its result, so quotes and swaps can't diverge.
 func (p Pool) CalcOutAmtGivenIn(
 	ctx sdk.Context,
 	tokensIn sdk.Coins,
 	tokenOutDenom string,
 	swapFee sdk.Dec,
 ) (sdk.Coin, error) {
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	if len(tokensIn) > 1 {
+		return p.calcOutAmtGivenMultipleIn(ctx, tokensIn, tokenOutDenom, swapFee)
+	}
+
+	tokenOut, _, err := p.calcOutAmtGivenInWithRemainder(ctx, tokensIn, tokenOutDenom, swapFee)
+	return tokenOut, err
+}
+
+// calcOutAmtGivenInWithRemainder is CalcOutAmtGivenIn for a single token in, that also returns the
+// fraction of a token by which the amount out was rounded down.
+func (p Pool) calcOutAmtGivenInWithRemainder(
+	ctx sdk.Context,
+	tokensIn sdk.Coins,
+	tokenOutDenom string,
+	swapFee sdk.Dec,
+) (tokenOut sdk.Coin, remainder sdk.Dec, err error) {
+	tokenOutDec, _, err := p.CalcOutAmtGivenInWithFee(ctx, tokensIn, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, err
+	}
+
+	// We ignore the decimal component, as we round down the token amount out.
+	tokenAmountOutInt := tokenOutDec.Amount.TruncateInt()
+	if !tokenAmountOutInt.IsPositive() {
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
+
+	return sdk.NewCoin(tokenOutDenom, tokenAmountOutInt), tokenOutDec.Amount.Sub(tokenAmountOutInt.ToDec()), nil
+}
+
+// CalcOutAmtGivenInWithFee is CalcOutAmtGivenIn for a single token in, that also returns the part
+// of tokenIn charged as swap fee, tokenIn * swapFee. The rest of tokenIn is swapped against the invariant.
+// tokenOut is not rounded, CalcOutAmtGivenIn rounds it down.
+func (p Pool) CalcOutAmtGivenInWithFee(
+	ctx sdk.Context,
+	tokensIn sdk.Coins,
+	tokenOutDenom string,
+	swapFee sdk.Dec,
+) (tokenOut sdk.DecCoin, feeCharged sdk.DecCoin, err error) {
+	// a swap fee of 1 or more would leave nothing, or a negative amount, of tokenIn to swap.
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.DecCoin{}, sdk.DecCoin{}, err
+	}
+
 	tokenIn, poolAssetIn, poolAssetOut, err := p.parsePoolAssets(tokensIn, tokenOutDenom)
 	if err != nil {
-		return sdk.Coin{}, err
+		return sdk.DecCoin{}, sdk.DecCoin{}, err
 	}
 
-	tokenAmountInAfterFee := tokenIn.Amount.ToDec().Mul(sdk.OneDec().Sub(swapFee))
+	// deduct swapfee on the tokensIn
+	tokenAmountFee := tokenIn.Amount.ToDec().Mul(swapFee)
+	tokenAmountInAfterFee := tokenIn.Amount.ToDec().Sub(tokenAmountFee)
 	poolTokenInBalance := poolAssetIn.Token.Amount.ToDec()
 	poolPostSwapInBalance := poolTokenInBalance.Add(tokenAmountInAfterFee)
 
-	// deduct swapfee on the tokensIn
 	// delta balanceOut is positive(tokens inside the pool decreases)
-	tokenAmountOut := solveConstantFunctionInvariant(
+	tokenAmountOut, err := p.solveSwapInvariant(
 		poolTokenInBalance,
 		poolPostSwapInBalance,
 		poolAssetIn.Weight.ToDec(),
 		poolAssetOut.Token.Amount.ToDec(),
 		poolAssetOut.Weight.ToDec(),
 	)
+	if err != nil {
+		return sdk.DecCoin{}, sdk.DecCoin{}, err
+	}
+	if tokenAmountOut.IsNegative() {
+		return sdk.DecCoin{}, sdk.DecCoin{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	}
 
-	// We ignore the decimal component, as we round down the token amount out.
-	tokenAmountOutInt := tokenAmountOut.TruncateInt()
-	if !tokenAmountOutInt.IsPositive() {
-		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "token amount must be positive")
+	return sdk.NewDecCoinFromDec(tokenOutDenom, tokenAmountOut), sdk.NewDecCoinFromDec(tokenIn.Denom, tokenAmountFee), nil
+}
+
+// calcOutAmtGivenMultipleIn swaps every coin of tokensIn for tokenOutDenom in sequence,
+// against a copy of the pool that is updated after every swap.
+func (p Pool) calcOutAmtGivenMultipleIn(
+	ctx sdk.Context,
+	tokensIn sdk.Coins,
+	tokenOutDenom string,
+	swapFee sdk.Dec,
+) (sdk.Coin, error) {
+	// copy the pool assets, so that the interim swaps don't mutate the pool.
+	poolCopy := p
+	poolCopy.PoolAssets = make([]PoolAsset, len(p.PoolAssets))
+	copy(poolCopy.PoolAssets, p.PoolAssets)
+
+	tokenOut := sdk.NewCoin(tokenOutDenom, sdk.ZeroInt())
+	for _, tokenIn := range tokensIn {
+		if tokenIn.Denom == tokenOutDenom {
+			return sdk.Coin{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenIn.Denom)
+		}
+
+		interimTokenOut, err := poolCopy.CalcOutAmtGivenIn(ctx, sdk.Coins{tokenIn}, tokenOutDenom, swapFee)
+		if err != nil {
+			return sdk.Coin{}, err
+		}
+
+		if err := poolCopy.applySwap(ctx, sdk.Coins{tokenIn}, sdk.Coins{interimTokenOut}); err != nil {
+			return sdk.Coin{}, err
+		}
+		tokenOut = tokenOut.Add(interimTokenOut)
 	}
 
-	return sdk.NewCoin(tokenOutDenom, tokenAmountOutInt), nil
+	return tokenOut, nil
 }
 
 // SwapOutAmtGivenIn is a mutative method for CalcOutAmtGivenIn, which includes the actual swap.
@@ -109,12 +242,43 @@ func (p *Pool) SwapOutAmtGivenIn(
 	return tokenOutCoin, nil
 }
 
+// SwapOutAmtGivenInWithRemainder is SwapOutAmtGivenIn for a single token in, that also returns the
+// fraction of a token of tokenOut's denom by which tokenOut was rounded down. The remainder is not
+// swapped out, so it stays in the pool's balance, while the pool's reserves only count whole tokens.
+func (p *Pool) SwapOutAmtGivenInWithRemainder(
+	ctx sdk.Context,
+	tokensIn sdk.Coins,
+	tokenOutDenom string,
+	swapFee sdk.Dec,
+) (tokenOut sdk.Coin, remainder sdk.Dec, err error) {
+	if len(tokensIn) != 1 {
+		return sdk.Coin{}, sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidSwapTokens, "expected a single token in, got %s", tokensIn)
+	}
+
+	tokenOut, remainder, err = p.calcOutAmtGivenInWithRemainder(ctx, tokensIn, tokenOutDenom, swapFee)
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, err
+	}
+
+	err = p.applySwap(ctx, tokensIn, sdk.Coins{tokenOut})
+	if err != nil {
+		return sdk.Coin{}, sdk.Dec{}, err
+	}
+	return tokenOut, remainder, nil
+}
+
 // CalcInAmtGivenOut calculates token to be provided, fee added,
-// given the swapped out amount, using solveConstantFunctionInvariant.
+// given the swapped out amount, by solving the pool's invariant curve.
+// It is the canonical computation of the amount in of a swap, which SwapInAmtGivenOut applies.
 func (p Pool) CalcInAmtGivenOut(
 	ctx sdk.Context, tokensOut sdk.Coins, tokenInDenom string, swapFee sdk.Dec) (
 	tokenIn sdk.Coin, err error,
 ) {
+	// the input is divided by (1 - swapFee) below.
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Coin{}, err
+	}
+
 	tokenOut, poolAssetOut, poolAssetIn, err := p.parsePoolAssets(tokensOut, tokenInDenom)
 	if err != nil {
 		return sdk.Coin{}, err
@@ -124,9 +288,13 @@ func (p Pool) CalcInAmtGivenOut(
 	poolTokenOutBalance := poolAssetOut.Token.Amount.ToDec()
 	poolPostSwapOutBalance := poolTokenOutBalance.Sub(tokenOut.Amount.ToDec())
 	// (x_0)(y_0) = (x_0 + in)(y_0 - out)
-	tokenAmountIn := solveConstantFunctionInvariant(
+	tokenAmountIn, err := p.solveSwapInvariant(
 		poolTokenOutBalance, poolPostSwapOutBalance, poolAssetOut.Weight.ToDec(),
-		poolAssetIn.Token.Amount.ToDec(), poolAssetIn.Weight.ToDec()).Neg()
+		poolAssetIn.Token.Amount.ToDec(), poolAssetIn.Weight.ToDec())
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	tokenAmountIn = tokenAmountIn.Neg()
 
 	// We deduct a swap fee on the input asset. The swap happens by following the invariant curve on the input * (1 - swap fee)
 	// and then the swap fee is added to the pool.
@@ -144,7 +312,7 @@ func (p Pool) CalcInAmtGivenOut(
 	return sdk.NewCoin(tokenInDenom, tokenInAmt), nil
 }
 
-// SwapInAmtGivenOut is a mutative method for CalcOutAmtGivenIn, which includes the actual swap.
+// SwapInAmtGivenOut is a mutative method for CalcInAmtGivenOut, which includes the actual swap.
 func (p *Pool) SwapInAmtGivenOut(
 	ctx sdk.Context, tokensOut sdk.Coins, tokenInDenom string, swapFee sdk.Dec) (
 	tokenIn sdk.Coin, err error,
@@ -162,7 +330,26 @@ func (p *Pool) SwapInAmtGivenOut(
 }
 
 // ApplySwap.
+// tokensIn may contain multiple coins, tokensOut must be of length one.
 func (p *Pool) applySwap(ctx sdk.Context, tokensIn sdk.Coins, tokensOut sdk.Coins) error {
+	if len(tokensIn) > 1 {
+		if len(tokensOut) != 1 {
+			return sdkerrors.Wrapf(types.ErrInvalidSwapTokens, "expected tokensOut to be of length one, got %s", tokensOut)
+		}
+		if tokensIn.AmountOf(tokensOut[0].Denom).IsPositive() {
+			return sdkerrors.Wrapf(types.ErrSameDenom, "token out denom %s can't be one of the tokens in", tokensOut[0].Denom)
+		}
+		_, outPoolAsset, err := p.getPoolAssetAndIndex(tokensOut[0].Denom)
+		if err != nil {
+			return err
+		}
+		if err := p.addToPoolAssetBalances(tokensIn); err != nil {
+			return err
+		}
+		outPoolAsset.Token.Amount = outPoolAsset.Token.Amount.Sub(tokensOut[0].Amount)
+		return p.UpdatePoolAssetBalance(outPoolAsset.Token)
+	}
+
 	// Also ensures that len(tokensIn) = 1 = len(tokensOut)
 	inPoolAsset, outPoolAsset, err := p.parsePoolAssetsCoins(tokensIn, tokensOut)
 	if err != nil {
@@ -184,26 +371,267 @@ func (p *Pool) applySwap(ctx sdk.Context, tokensIn sdk.Coins, tokensOut sdk.Coin
 // this is equivalent to spot_price = (Base_supply / Weight_base) / (Quote_supply / Weight_quote)
 // but cancels out the common term in weight.
 //
-// panics if pool is misconfigured and has any weight as 0.
+// returns ErrSpotPriceInternal if pool is misconfigured and has any weight or balance as 0.
 func (p Pool) SpotPrice(ctx sdk.Context, baseAsset, quoteAsset string) (sdk.Dec, error) {
+	return p.NormalizedSpotPrice(ctx, baseAsset, quoteAsset, sdk.OneDec(), sdk.OneDec())
+}
+
+// NormalizedSpotPrice is SpotPrice with the balances of the base and quote assets normalized,
+// by dividing them by their scaling factors, e.g. 10^decimals to price whole tokens rather than base units.
+// The balances are normalized before their ratio is taken, so that pairs of assets with very different decimals
+// don't lose the precision of a raw spot price far below one.
+// Swap amounts don't depend on the scaling factors, as scaling the balance of an asset and its amount in or out
+// by the same factor leaves the solution of the invariant unchanged.
+func (p Pool) NormalizedSpotPrice(ctx sdk.Context, baseAsset, quoteAsset string, baseScalingFactor, quoteScalingFactor sdk.Dec) (sdk.Dec, error) {
+	if !baseScalingFactor.IsPositive() || !quoteScalingFactor.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "scaling factors must be positive, got %s and %s", baseScalingFactor, quoteScalingFactor)
+	}
 	quote, base, err := p.parsePoolAssetsByDenoms(quoteAsset, baseAsset)
 	if err != nil {
 		return sdk.Dec{}, err
 	}
-	if base.Weight.IsZero() || quote.Weight.IsZero() {
-		return sdk.Dec{}, errors.New("pool is misconfigured, got 0 weight")
+	if !base.Weight.IsPositive() || !quote.Weight.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "pool is misconfigured, got 0 weight")
+	}
+	if !base.Token.Amount.IsPositive() || !quote.Token.Amount.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "pool is misconfigured, got 0 balance of %s or %s", baseAsset, quoteAsset)
 	}
 
 	// spot_price = (Base_supply / Weight_base) / (Quote_supply / Weight_quote)
 	// spot_price = (weight_quote / weight_base) * (base_supply / quote_supply)
 	invWeightRatio := quote.Weight.ToDec().Quo(base.Weight.ToDec())
-	supplyRatio := base.Token.Amount.ToDec().Quo(quote.Token.Amount.ToDec())
+	// (base_supply / base_scaling_factor) / (quote_supply / quote_scaling_factor)
+	supplyRatio := base.Token.Amount.ToDec().Mul(quoteScalingFactor).Quo(quote.Token.Amount.ToDec().Mul(baseScalingFactor))
 	fullRatio := supplyRatio.Mul(invWeightRatio)
 	// we want to round this to `SigFigs` of precision
 	ratio := osmomath.SigFigRound(fullRatio, types.SigFigs)
 	return ratio, nil
 }
 
+// CalcAmountInToReachSpotPrice returns how much tokenInDenom can be swapped for tokenOutDenom with the given
+// swap fee before the spot price of tokenOutDenom in terms of tokenInDenom reaches targetSpotPrice.
+// Swapping tokenInDenom in only raises this spot price, so a targetSpotPrice that is not
+// above the current spot price is unreachable, and ErrSpotPriceUnreachable is returned.
+//
+// Swapping a tokens in against the curve, the out balance becomes B_o' = B_o (B_i / (B_i + a))^(W_i / W_o), from which
+// spot_price' = spot_price * ((B_i + a) / B_i)^(1 + W_i / W_o), so that
+// a = B_i ((target_spot_price / spot_price)^(W_o / (W_i + W_o)) - 1).
+// As only tokenIn * (1 - swapFee) is swapped against the curve, tokenIn is a / (1 - swapFee). The swap fee stays
+// in the pool though, raising the spot price by another (B_i + tokenIn) / (B_i + a). So the curve is moved to
+// the target divided by this factor, which is at most the factor at the returned amount, and the amount is
+// rounded down, so that the spot price after the swap is at most targetSpotPrice. Does not mutate the pool.
+func (p Pool) CalcAmountInToReachSpotPrice(
+	ctx sdk.Context,
+	tokenInDenom string,
+	tokenOutDenom string,
+	targetSpotPrice sdk.Dec,
+	swapFee sdk.Dec,
+) (tokenInAmount sdk.Int, err error) {
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Int{}, err
+	}
+	tokenIn, tokenOut, err := p.parsePoolAssetsByDenoms(tokenInDenom, tokenOutDenom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if !tokenIn.Weight.IsPositive() || !tokenOut.Weight.IsPositive() ||
+		!tokenIn.Token.Amount.IsPositive() || !tokenOut.Token.Amount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "pool is misconfigured, got 0 weight or balance of %s or %s", tokenInDenom, tokenOutDenom)
+	}
+
+	// the spot price is computed without SpotPrice's rounding, to not lose precision in the ratio.
+	weightIn, weightOut := tokenIn.Weight.ToDec(), tokenOut.Weight.ToDec()
+	balanceIn, balanceOut := tokenIn.Token.Amount.ToDec(), tokenOut.Token.Amount.ToDec()
+	spotPrice := balanceIn.Mul(weightOut).Quo(balanceOut.Mul(weightIn))
+	if targetSpotPrice.IsNil() || targetSpotPrice.LTE(spotPrice) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrSpotPriceUnreachable,
+			"target spot price %s is not above the current spot price %s of %s in terms of %s", targetSpotPrice, spotPrice, tokenOutDenom, tokenInDenom)
+	}
+
+	exp := weightOut.Quo(weightIn.Add(weightOut))
+	curveAmountIn, err := curveAmountInToReachSpotPrice(balanceIn, targetSpotPrice.Quo(spotPrice), exp)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	if swapFee.IsZero() {
+		return curveAmountIn.TruncateInt(), nil
+	}
+
+	oneMinusSwapFee := sdk.OneDec().Sub(swapFee)
+	feeFactor := balanceIn.Add(curveAmountIn.Quo(oneMinusSwapFee)).Quo(balanceIn.Add(curveAmountIn))
+	curveSpotPriceRatio := targetSpotPrice.Quo(spotPrice.Mul(feeFactor))
+	if curveSpotPriceRatio.LTE(sdk.OneDec()) {
+		return sdk.ZeroInt(), nil
+	}
+	curveAmountIn, err = curveAmountInToReachSpotPrice(balanceIn, curveSpotPriceRatio, exp)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	return curveAmountIn.Quo(oneMinusSwapFee).TruncateInt(), nil
+}
+
+// curveAmountInToReachSpotPrice returns the amount in, swapped against the curve without a swap fee,
+// that multiplies the spot price by spotPriceRatio, B_i (spotPriceRatio^exp - 1) with exp = W_o / (W_i + W_o).
+func curveAmountInToReachSpotPrice(balanceIn, spotPriceRatio, exp sdk.Dec) (sdk.Dec, error) {
+	balanceInRatio, err := powAtLeastOne(spotPriceRatio, exp, osmomath.GetPowPrecision())
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return balanceIn.Mul(balanceInRatio.Sub(sdk.OneDec())), nil
+}
+
+// CalcArbCloseAmount returns the amount of tokenInDenom to swap for tokenOutDenom through buyPool, such that
+// swapping the tokenOutDenom received through sellPool for tokenInDenom returns the most tokenInDenom,
+// closing the arbitrage between the pools up to their swap fees. buySwapFee is the fee of swapping tokenInDenom
+// for tokenOutDenom through buyPool, and sellSwapFee the one of swapping back through sellPool.
+// If the trade returns no profit, i.e. the spot price of tokenOutDenom in terms of tokenInDenom of buyPool is not
+// below the one of sellPool by more than the swap fees, an amount of zero is returned.
+//
+// Let e = W_i / (W_i + W_o), which must be the same in both pools, otherwise ErrArbWeightRatioMismatch is returned.
+// Swapping against the curves moves the spot price of the buy pool from P_A up to P, where it swaps out
+// O_A (1 - (P_A / P)^e), and the one of the sell pool from P_B down to P', where it swaps in O_B ((P_B / P')^e - 1).
+// Only the tokens in after the swap fee are swapped against the curves, so the profit of the next unit
+// swapped in is zero at P = k P' with k = (1 - buySwapFee)(1 - sellSwapFee). The sell pool swaps in
+// (1 - sellSwapFee) of what the buy pool swaps out, which gives
+// (P / P_A)^e = ((1 - sellSwapFee) O_A + O_B (k P_B / P_A)^e) / ((1 - sellSwapFee) O_A + O_B), and by
+// CalcAmountInToReachSpotPrice the amount in to move the curve of the buy pool to P is
+// B_i ((P / P_A)^(1 - e) - 1) / (1 - buySwapFee). The amount is rounded down.
+func CalcArbCloseAmount(buyPool, sellPool Pool, tokenInDenom, tokenOutDenom string, buySwapFee, sellSwapFee sdk.Dec) (tokenInAmount sdk.Int, err error) {
+	for _, swapFee := range []sdk.Dec{buySwapFee, sellSwapFee} {
+		if err := types.ValidateSwapFee(swapFee); err != nil {
+			return sdk.Int{}, err
+		}
+	}
+	buyIn, buyOut, err := buyPool.parsePoolAssetsByDenoms(tokenInDenom, tokenOutDenom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	sellIn, sellOut, err := sellPool.parsePoolAssetsByDenoms(tokenInDenom, tokenOutDenom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	for _, asset := range []PoolAsset{buyIn, buyOut, sellIn, sellOut} {
+		if !asset.Weight.IsPositive() || !asset.Token.Amount.IsPositive() {
+			return sdk.Int{}, sdkerrors.Wrapf(types.ErrSpotPriceInternal, "pool is misconfigured, got 0 weight or balance of %s", asset.Token.Denom)
+		}
+	}
+	// W_i,A / (W_i,A + W_o,A) = W_i,B / (W_i,B + W_o,B) is W_i,A * W_o,B = W_i,B * W_o,A.
+	if !buyIn.Weight.Mul(sellOut.Weight).Equal(sellIn.Weight.Mul(buyOut.Weight)) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrArbWeightRatioMismatch, "%s:%s weights of %s:%s and %s:%s",
+			tokenInDenom, tokenOutDenom, buyIn.Weight, buyOut.Weight, sellIn.Weight, sellOut.Weight)
+	}
+
+	// the spot prices are computed without SpotPrice's rounding, to not lose precision in their ratio.
+	spotPrice := func(tokenIn, tokenOut PoolAsset) sdk.Dec {
+		return tokenIn.Token.Amount.ToDec().Mul(tokenOut.Weight.ToDec()).Quo(tokenOut.Token.Amount.ToDec().Mul(tokenIn.Weight.ToDec()))
+	}
+	oneMinusBuySwapFee, oneMinusSellSwapFee := sdk.OneDec().Sub(buySwapFee), sdk.OneDec().Sub(sellSwapFee)
+	buySpotPrice := spotPrice(buyIn, buyOut)
+	sellSpotPriceAfterFees := spotPrice(sellIn, sellOut).Mul(oneMinusBuySwapFee).Mul(oneMinusSellSwapFee)
+	if sellSpotPriceAfterFees.LTE(buySpotPrice) {
+		return sdk.ZeroInt(), nil
+	}
+
+	weightIn, weightOut := buyIn.Weight.ToDec(), buyOut.Weight.ToDec()
+	e := weightIn.Quo(weightIn.Add(weightOut))
+	spotPriceRatioToE, err := powAtLeastOne(sellSpotPriceAfterFees.Quo(buySpotPrice), e, osmomath.GetPowPrecision())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	balanceOutBuy, balanceOutSell := buyOut.Token.Amount.ToDec().Mul(oneMinusSellSwapFee), sellOut.Token.Amount.ToDec()
+	targetSpotPriceRatioToE := balanceOutBuy.Add(balanceOutSell.Mul(spotPriceRatioToE)).Quo(balanceOutBuy.Add(balanceOutSell))
+	// (P / P_A)^(1 - e) = ((P / P_A)^e)^((1 - e) / e) = ((P / P_A)^e)^(W_o / W_i)
+	balanceInRatio, err := powAtLeastOne(targetSpotPriceRatioToE, weightOut.Quo(weightIn), osmomath.GetPowPrecision())
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	curveAmountIn := buyIn.Token.Amount.ToDec().Mul(balanceInRatio.Sub(sdk.OneDec()))
+	return curveAmountIn.Quo(oneMinusBuySwapFee).TruncateInt(), nil
+}
+
+// powAtLeastOneThreshold is the base below which powAtLeastOne computes the power directly.
+var powAtLeastOneThreshold = sdk.MustNewDecFromStr("1.5")
+
+// powAtLeastOne returns base^exp for a positive base, approximated to the given precision.
+// osmomath.Pow only supports bases below 2, and converges slowly for bases close to 2, so while
+// the base is above powAtLeastOneThreshold it is replaced by its square root, and the exponent doubled.
+// A non-positive base returns ErrInvalidPowBase, see pow.
+func powAtLeastOne(base, exp, precision sdk.Dec) (sdk.Dec, error) {
+	for base.GTE(powAtLeastOneThreshold) {
+		sqrt, err := base.ApproxSqrt()
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		base = sqrt
+		exp = exp.MulInt64(2)
+	}
+	return pow(base, exp, precision)
+}
+
+// pow returns base^exp for a base in (0, 2), approximated to the given precision within maxPowIterations.
+// osmomath.PowBounded panics for a non-positive base, for which the power is undefined, so pow returns
+// ErrInvalidPowBase instead. Pool balances are always positive, so that's a pool in an invalid state.
+func pow(base, exp, precision sdk.Dec) (sdk.Dec, error) {
+	if !base.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidPowBase, "got %s", base)
+	}
+	result, err := osmomath.PowBounded(base, exp, precision, maxPowIterations)
+	if err != nil {
+		return sdk.Dec{}, sdkerrors.Wrap(types.ErrInvalidMathApprox, err.Error())
+	}
+	return result, nil
+}
+
+// swapInvariantPowPrecision is the precision of the powers in SwapInvariantRatio. It is much finer
+// than osmomath.GetPowPrecision(), so that the approximation error doesn't hide rounding errors of swaps.
+var swapInvariantPowPrecision = sdk.NewDecWithPrec(1, 16)
+
+// SwapInvariantRatio returns the ratio of the pool's invariant after a swap of tokenIn for tokenOut
+// to the invariant before it, where the swap has already been applied to the pool.
+// The invariant is the weighted geometric mean of the balances, prod(B_i^(W_i / W_total)),
+// so the ratio is the relative change in the value of the pool, which swaps with a swap fee and
+// rounding in favor of the pool can only increase.
+// Only the balances of tokenIn and tokenOut change, so the ratio is
+// (B_in / (B_in - tokenIn))^(W_in / W_total) / ((B_out + tokenOut) / B_out)^(W_out / W_total).
+func (p Pool) SwapInvariantRatio(tokenIn sdk.Coin, tokenOut sdk.Coin) (sdk.Dec, error) {
+	tokenInAsset, tokenOutAsset, err := p.parsePoolAssetsByDenoms(tokenIn.Denom, tokenOut.Denom)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	balanceInBefore := tokenInAsset.Token.Amount.Sub(tokenIn.Amount)
+	if !balanceInBefore.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrPoolAssetDepleted, "pool has %s after swapping in %s", tokenInAsset.Token, tokenIn)
+	}
+
+	balanceInRatio := tokenInAsset.Token.Amount.ToDec().QuoInt(balanceInBefore)
+	inFactor, err := powAtLeastOne(balanceInRatio, p.normalizedWeight(tokenInAsset), swapInvariantPowPrecision)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	balanceOutRatio := tokenOutAsset.Token.Amount.Add(tokenOut.Amount).ToDec().QuoInt(tokenOutAsset.Token.Amount)
+	outFactor, err := powAtLeastOne(balanceOutRatio, p.normalizedWeight(tokenOutAsset), swapInvariantPowPrecision)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return inFactor.Quo(outFactor), nil
+}
+
+// InvariantK returns the pool's constant function value, the weighted geometric mean of its balances,
+// k = prod(B_i^(W_i / W_total)).
+// This is the value solveConstantFunctionInvariant keeps constant across a swap without a swap fee,
+// while swap fees and rounding in favor of the pool increase it.
+func (p Pool) InvariantK() (sdk.Dec, error) {
+	k := sdk.OneDec()
+	for _, asset := range p.PoolAssets {
+		factor, err := powAtLeastOne(asset.Token.Amount.ToDec(), p.normalizedWeight(asset), swapInvariantPowPrecision)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		k = k.Mul(factor)
+	}
+	return k, nil
+}
+
 // balancer notation: pAo - pool shares amount out, given single asset in
 // the second argument requires the tokenWeightIn / total token weight.
 func calcPoolSharesOutGivenSingleAssetIn(
@@ -212,7 +640,7 @@ func calcPoolSharesOutGivenSingleAssetIn(
 	poolShares,
 	tokenAmountIn,
 	swapFee sdk.Dec,
-) sdk.Dec {
+) (sdk.Dec, error) {
 	// deduct swapfee on the in asset.
 	// We don't charge swap fee on the token amount that we imagine as unswapped (the normalized weight).
 	// So effective_swapfee = swapfee * (1 - normalized_token_weight)
@@ -227,13 +655,16 @@ func calcPoolSharesOutGivenSingleAssetIn(
 	// The number of new shares we need to make is then `old_shares * ((k'/k) - 1)`
 	// Whats very cool, is that this turns out to be the exact same `solveConstantFunctionInvariant` code
 	// with the answer's sign reversed.
-	poolAmountOut := solveConstantFunctionInvariant(
+	poolAmountOut, err := solveConstantFunctionInvariant(
 		tokenBalanceIn.Add(tokenAmountInAfterFee),
 		tokenBalanceIn,
 		normalizedTokenWeightIn,
 		poolShares,
-		sdk.OneDec()).Neg()
-	return poolAmountOut
+		sdk.OneDec())
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	return poolAmountOut.Neg(), nil
 }
 
 // calcPoolOutGivenSingleIn - balance pAo.
@@ -248,27 +679,78 @@ func (p *Pool) calcSingleAssetJoin(tokenIn sdk.Coin, swapFee sdk.Dec, tokenInPoo
 		return sdk.ZeroInt(), errors.New("pool misconfigured, total weight = 0")
 	}
 	normalizedWeight := tokenInPoolAsset.Weight.ToDec().Quo(totalWeight.ToDec())
-	return calcPoolSharesOutGivenSingleAssetIn(
+	poolAmountOut, err := calcPoolSharesOutGivenSingleAssetIn(
 		tokenInPoolAsset.Token.Amount.ToDec(),
 		normalizedWeight,
 		totalShares.ToDec(),
 		tokenIn.Amount.ToDec(),
 		swapFee,
-	).TruncateInt(), nil
+	)
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+	return poolAmountOut.TruncateInt(), nil
+}
+
+// SingleAssetJoinImpliedSwap returns the part of tokenIn that a single asset join of tokenIn
+// implicitly swaps for the pool's other assets, (1 - normalizedWeight) * tokenIn, rounded down.
+// This is the part of tokenIn the join charges the swap fee on, see feeRatio.
+func (p Pool) SingleAssetJoinImpliedSwap(tokenIn sdk.Coin) (sdk.Coin, error) {
+	tokenInAsset, err := p.GetPoolAsset(tokenIn.Denom)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+	impliedSwapAmount := tokenIn.Amount.ToDec().Mul(sdk.OneDec().Sub(p.normalizedWeight(tokenInAsset))).TruncateInt()
+	return sdk.NewCoin(tokenIn.Denom, impliedSwapAmount), nil
 }
 
 // JoinPool calculates the number of shares needed given tokensIn with swapFee applied.
 // It updates the liquidity if the pool is joined successfully. If not, returns error.
 // and updates pool accordingly.
-func (p *Pool) JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, err error) {
-	numShares, newLiquidity, err := p.CalcJoinPoolShares(ctx, tokensIn, swapFee)
+// tokensJoined is the liquidity consumed by the join, single asset joins consume all of tokensIn.
+func (p *Pool) JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, tokensJoined sdk.Coins, err error) {
+	numShares, tokensJoined, err = p.CalcJoinPoolShares(ctx, tokensIn, swapFee)
 	if err != nil {
-		return sdk.Int{}, err
+		return sdk.Int{}, sdk.Coins{}, err
 	}
 
 	// update pool with the calculated share and liquidity needed to join pool
-	p.IncreaseLiquidity(numShares, newLiquidity)
-	return numShares, nil
+	p.IncreaseLiquidity(numShares, tokensJoined)
+	return numShares, tokensJoined, nil
+}
+
+// JoinPoolNoSwap mints exactly shareOutAmount shares, consuming the proportional amount
+// of every pool asset. Unlike JoinPool, no single asset join (and thus no swap fee) is involved.
+// The proportional amounts needed are rounded up, so that the pool never mints shares
+// for less liquidity than they represent.
+// Returns an error if tokensIn do not cover the needed amount of every pool asset.
+// tokensInNeeded are the coins taken from tokensIn, any excess is left to the caller.
+func (p *Pool) JoinPoolNoSwap(ctx sdk.Context, tokensIn sdk.Coins, shareOutAmount sdk.Int) (tokensInNeeded sdk.Coins, err error) {
+	if !shareOutAmount.IsPositive() {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrNotPositiveRequireAmount, errMsgFormatSharesAmountNotPositive, shareOutAmount.Int64())
+	}
+	if err := types.ValidateTotalSharesAfterJoin(p.GetTotalShares(), shareOutAmount); err != nil {
+		return sdk.Coins{}, err
+	}
+
+	// shareRatio = shareOutAmount / totalShares
+	shareRatio := shareOutAmount.ToDec().QuoInt(p.GetTotalShares())
+	tokensInNeeded = sdk.Coins{}
+	for _, poolAsset := range p.PoolAssets {
+		// neededAmount = ceil(poolAssetAmount * shareRatio)
+		neededAmount := poolAsset.Token.Amount.ToDec().Mul(shareRatio).Ceil().TruncateInt()
+		if !neededAmount.IsPositive() {
+			return sdk.Coins{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, errMsgFormatTokenAmountNotPositive, neededAmount.Int64())
+		}
+		tokensInNeeded = tokensInNeeded.Add(sdk.NewCoin(poolAsset.Token.Denom, neededAmount))
+	}
+
+	if !tokensIn.IsAllGTE(tokensInNeeded) {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, errMsgFormatInsufficientNoSwapTokensIn, tokensIn, tokensInNeeded, shareOutAmount)
+	}
+
+	p.IncreaseLiquidity(shareOutAmount, tokensInNeeded)
+	return tokensInNeeded, nil
 }
 
 func (p *Pool) calcJoinPoolSharesBroken(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, newLiquidity sdk.Coins, err error) {
@@ -333,10 +815,24 @@ func (p *Pool) calcJoinPoolSharesBroken(ctx sdk.Context, tokensIn sdk.Coins, swa
 //
 // It returns the number of shares created, the amount of coins actually joined into the pool
 // (in case of not being able to fully join), or an error.
+// The join errors with ErrTooManyPoolShares if the shares created would take the pool's total shares past types.MaxTotalShares.
 func (p *Pool) CalcJoinPoolShares(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, tokensJoined sdk.Coins, err error) {
 	if ctx.BlockHeight() < v10Fork {
-		return p.calcJoinPoolSharesBroken(ctx, tokensIn, swapFee)
+		numShares, tokensJoined, err = p.calcJoinPoolSharesBroken(ctx, tokensIn, swapFee)
+	} else {
+		numShares, tokensJoined, err = p.calcJoinPoolShares(tokensIn, swapFee)
 	}
+	if err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	if err := types.ValidateTotalSharesAfterJoin(p.GetTotalShares(), numShares); err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	return numShares, tokensJoined, nil
+}
+
+// calcJoinPoolShares is CalcJoinPoolShares from the v10 fork on.
+func (p *Pool) calcJoinPoolShares(tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, tokensJoined sdk.Coins, err error) {
 	// 1) Get pool current liquidity + and token weights
 	// 2) If single token provided, do single asset join and exit.
 	// 3) If multi-asset join, first do as much of a join as we can with no swaps.
@@ -362,6 +858,11 @@ func (p *Pool) CalcJoinPoolShares(ctx sdk.Context, tokensIn sdk.Coins, swapFee s
 		if err != nil {
 			return sdk.ZeroInt(), sdk.NewCoins(), err
 		}
+		// the shares are rounded down, to zero for a tiny tokenIn against a large pool,
+		// which would take all of tokenIn for nothing.
+		if !numShares.IsPositive() {
+			return sdk.ZeroInt(), sdk.NewCoins(), sdkerrors.Wrapf(types.ErrInvalidMathApprox, "joining %s gets no shares", tokensIn[0])
+		}
 		// we join all the tokens.
 		tokensJoined = tokensIn
 		return numShares, tokensJoined, nil
@@ -516,13 +1017,16 @@ func calcSingleAssetInGivenPoolSharesOut(
 	totalPoolSharesSupply,
 	sharesAmountOut,
 	swapFee sdk.Dec,
-) sdk.Dec {
+) (sdk.Dec, error) {
 	// delta balanceIn is negative(tokens inside the pool increases)
 	// pool weight is always 1
-	tokenAmountIn := solveConstantFunctionInvariant(totalPoolSharesSupply.Add(sharesAmountOut), totalPoolSharesSupply, sdk.OneDec(), tokenBalanceIn, normalizedTokenWeightIn).Neg()
+	tokenAmountIn, err := solveConstantFunctionInvariant(totalPoolSharesSupply.Add(sharesAmountOut), totalPoolSharesSupply, sdk.OneDec(), tokenBalanceIn, normalizedTokenWeightIn)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
 	// deduct swapfee on the in asset
-	tokenAmountInFeeIncluded := tokenAmountIn.Quo(feeRatio(normalizedTokenWeightIn, swapFee))
-	return tokenAmountInFeeIncluded
+	tokenAmountInFeeIncluded := tokenAmountIn.Neg().Quo(feeRatio(normalizedTokenWeightIn, swapFee))
+	return tokenAmountInFeeIncluded, nil
 }
 
 func (p *Pool) CalcTokenInShareAmountOut(
@@ -536,17 +1040,27 @@ func (p *Pool) CalcTokenInShareAmountOut(
 		return sdk.Int{}, err
 	}
 
-	normalizedWeight := poolAssetIn.Weight.ToDec().Quo(p.GetTotalWeight().ToDec())
+	// (totalShares + shareOutAmount) / totalShares is the base of the power taken
+	// in the invariant, which must be less than two.
+	if shareOutAmount.GTE(p.GetTotalShares()) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, "%s shares out is not less than the total shares of %s", shareOutAmount, p.GetTotalShares())
+	}
+
+	normalizedWeight := p.normalizedWeight(poolAssetIn)
 
 	// We round up tokenInAmount, as this is whats charged for the swap, for the precise amount out.
 	// Otherwise, the pool would under-charge by this rounding error.
-	tokenInAmount = calcSingleAssetInGivenPoolSharesOut(
+	tokenInAmountDec, err := calcSingleAssetInGivenPoolSharesOut(
 		poolAssetIn.Token.Amount.ToDec(),
 		normalizedWeight,
 		p.GetTotalShares().ToDec(),
 		shareOutAmount.ToDec(),
 		swapFee,
-	).Ceil().TruncateInt()
+	)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenInAmount = tokenInAmountDec.Ceil().TruncateInt()
 
 	if !tokenInAmount.IsPositive() {
 		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, errMsgFormatTokenAmountNotPositive, tokenInAmount.Int64())
@@ -565,15 +1079,34 @@ func (p *Pool) JoinPoolTokenInMaxShareAmountOut(
 		return sdk.Int{}, err
 	}
 
-	normalizedWeight := poolAssetIn.Weight.ToDec().Quo(p.GetTotalWeight().ToDec())
+	// (totalShares + shareOutAmount) / totalShares is the base of the power taken
+	// in the invariant, which must be less than two.
+	if shareOutAmount.GTE(p.GetTotalShares()) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, "%s shares out is not less than the total shares of %s", shareOutAmount, p.GetTotalShares())
+	}
+	if err := types.ValidateTotalSharesAfterJoin(p.GetTotalShares(), shareOutAmount); err != nil {
+		return sdk.Int{}, err
+	}
+
+	normalizedWeight := p.normalizedWeight(poolAssetIn)
 
-	tokenInAmount = calcSingleAssetInGivenPoolSharesOut(
+	tokenInAmountDec, err := calcSingleAssetInGivenPoolSharesOut(
 		poolAssetIn.Token.Amount.ToDec(),
 		normalizedWeight,
 		p.GetTotalShares().ToDec(),
 		shareOutAmount.ToDec(),
 		p.GetSwapFee(ctx),
-	).TruncateInt()
+	)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	// As in CalcTokenInShareAmountOut, round up so that the pool isn't under-charged.
+	// Before the v11 upgrade, the token in was truncated.
+	if ctx.BlockHeight() < types.V11UpgradeHeight {
+		tokenInAmount = tokenInAmountDec.TruncateInt()
+	} else {
+		tokenInAmount = tokenInAmountDec.Ceil().TruncateInt()
+	}
 
 	if !tokenInAmount.IsPositive() {
 		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, errMsgFormatTokenAmountNotPositive, tokenInAmount.Int64())
@@ -598,19 +1131,132 @@ func calcPoolSharesInGivenSingleAssetOut(
 	tokenAmountOut,
 	swapFee,
 	exitFee sdk.Dec,
-) sdk.Dec {
+) (sdk.Dec, error) {
 	tokenAmountOutFeeIncluded := tokenAmountOut.Quo(feeRatio(normalizedTokenWeightOut, swapFee))
 
 	// delta poolSupply is positive(total pool shares decreases)
 	// pool weight is always 1
-	sharesIn := solveConstantFunctionInvariant(tokenBalanceOut.Sub(tokenAmountOutFeeIncluded), tokenBalanceOut, normalizedTokenWeightOut, totalPoolSharesSupply, sdk.OneDec())
+	sharesIn, err := solveConstantFunctionInvariant(tokenBalanceOut.Sub(tokenAmountOutFeeIncluded), tokenBalanceOut, normalizedTokenWeightOut, totalPoolSharesSupply, sdk.OneDec())
+	if err != nil {
+		return sdk.Dec{}, err
+	}
 
 	// charge exit fee on the pool token side
 	// pAi = pAiAfterExitFee/(1-exitFee)
 	sharesInFeeIncluded := sharesIn.Quo(sdk.OneDec().Sub(exitFee))
-	return sharesInFeeIncluded
+	return sharesInFeeIncluded, nil
+}
+
+// calcSingleAssetOutGivenPoolSharesIn returns token amount out, given the amount of pool shares in.
+// This is the mirror of calcPoolSharesOutGivenSingleAssetIn, exit fee is charged on the shares in
+// and swap fee is charged only on the non-normalized portion (the part we imagine as swapped).
+// the second argument requires the tokenWeightOut / total token weight.
+func calcSingleAssetOutGivenPoolSharesIn(
+	tokenBalanceOut,
+	normalizedTokenWeightOut,
+	totalPoolSharesSupply,
+	sharesAmountIn,
+	swapFee,
+	exitFee sdk.Dec,
+) (sdk.Dec, error) {
+	// charge exit fee on the pool token side
+	// pAiAfterExitFee = pAi*(1-exitFee)
+	sharesInAfterExitFee := sharesAmountIn.Mul(sdk.OneDec().Sub(exitFee))
+
+	// tokenAmountOut = tokenBalanceOut * (1 - (newPoolSupply / oldPoolSupply)^(1 / normalizedTokenWeightOut))
+	// pool weight is always 1
+	tokenAmountOutBeforeFee, err := solveConstantFunctionInvariant(
+		totalPoolSharesSupply.Sub(sharesInAfterExitFee),
+		totalPoolSharesSupply,
+		sdk.OneDec(),
+		tokenBalanceOut,
+		normalizedTokenWeightOut)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+
+	// deduct swapfee on the out asset.
+	return tokenAmountOutBeforeFee.Mul(feeRatio(normalizedTokenWeightOut, swapFee)), nil
 }
 
+// CalcExitSwapShareAmountIn returns the amount of tokenOutDenom that would be received
+// for burning shareInAmount shares, with the whole withdrawal taken in that denom.
+// The output is rounded down, so that the pool never pays out more than the shares are worth.
+// Does not mutate the pool.
+func (p *Pool) CalcExitSwapShareAmountIn(
+	ctx sdk.Context,
+	tokenOutDenom string,
+	shareInAmount sdk.Int,
+) (tokenOutAmount sdk.Int, err error) {
+	_, poolAssetOut, err := p.getPoolAssetAndIndex(tokenOutDenom)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if !shareInAmount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrNotPositiveRequireAmount, errMsgFormatSharesAmountNotPositive, shareInAmount.Int64())
+	}
+
+	totalShares := p.GetTotalShares()
+	if shareInAmount.GTE(totalShares) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, "%s shares in is not less than the total shares of %s", shareInAmount, totalShares)
+	}
+
+	// The remaining share ratio is the base of the power taken in the invariant.
+	// If it rounds to zero, the exit would withdraw the entirety of tokenOutDenom.
+	exitFee := p.GetExitFee(ctx)
+	remainingShareRatio := totalShares.ToDec().Sub(shareInAmount.ToDec().Mul(sdk.OneDec().Sub(exitFee))).QuoInt(totalShares)
+	if !remainingShareRatio.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut, "exiting %s shares would withdraw all %s from the pool", shareInAmount, tokenOutDenom)
+	}
+
+	tokenOutAmountDec, err := calcSingleAssetOutGivenPoolSharesIn(
+		poolAssetOut.Token.Amount.ToDec(),
+		p.normalizedWeight(poolAssetOut),
+		totalShares.ToDec(),
+		shareInAmount.ToDec(),
+		p.GetSwapFee(ctx),
+		exitFee,
+	)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	tokenOutAmount = tokenOutAmountDec.TruncateInt()
+
+	if !tokenOutAmount.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, errMsgFormatTokenAmountNotPositive, tokenOutAmount.Int64())
+	}
+
+	if tokenOutAmount.GTE(poolAssetOut.Token.Amount) {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut, "requested %s%s, but pool only holds %s", tokenOutAmount, tokenOutDenom, poolAssetOut.Token)
+	}
+
+	return tokenOutAmount, nil
+}
+
+// ExitSwapShareAmountIn burns shareInAmount shares and returns the amount of
+// tokenOutDenom the exiting user receives, updating the pool accordingly.
+// See CalcExitSwapShareAmountIn for the math.
+func (p *Pool) ExitSwapShareAmountIn(
+	ctx sdk.Context,
+	tokenOutDenom string,
+	shareInAmount sdk.Int,
+) (tokenOutAmount sdk.Int, err error) {
+	tokenOutAmount, err = p.CalcExitSwapShareAmountIn(ctx, tokenOutDenom, shareInAmount)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+
+	if err := p.exitPool(ctx, sdk.NewCoins(sdk.NewCoin(tokenOutDenom, tokenOutAmount)), shareInAmount); err != nil {
+		return sdk.Int{}, err
+	}
+
+	return tokenOutAmount, nil
+}
+
+// ExitSwapExactAmountOut burns the shares needed to exit the pool with exactly tokenOut,
+// using the single asset exit math of calcPoolSharesInGivenSingleAssetOut.
+// It fails with ErrLimitMaxAmount if more than shareInMaxAmount shares are needed.
 func (p *Pool) ExitSwapExactAmountOut(
 	ctx sdk.Context,
 	tokenOut sdk.Coin,
@@ -621,14 +1267,25 @@ func (p *Pool) ExitSwapExactAmountOut(
 		return sdk.Int{}, err
 	}
 
-	sharesIn := calcPoolSharesInGivenSingleAssetOut(
+	sharesInDec, err := calcPoolSharesInGivenSingleAssetOut(
 		poolAssetOut.Token.Amount.ToDec(),
-		poolAssetOut.Weight.ToDec().Quo(p.TotalWeight.ToDec()),
+		p.normalizedWeight(poolAssetOut),
 		p.GetTotalShares().ToDec(),
 		tokenOut.Amount.ToDec(),
 		p.GetSwapFee(ctx),
 		p.GetExitFee(ctx),
-	).TruncateInt()
+	)
+	if err != nil {
+		return sdk.Int{}, err
+	}
+	// the shares in are rounded up, so that the exiter never gets tokenOut for fewer shares than it is worth.
+	// Before the v11 upgrade, they were truncated.
+	var sharesIn sdk.Int
+	if ctx.BlockHeight() < types.V11UpgradeHeight {
+		sharesIn = sharesInDec.TruncateInt()
+	} else {
+		sharesIn = sharesInDec.Ceil().TruncateInt()
+	}
 
 	if !sharesIn.IsPositive() {
 		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, errMsgFormatSharesAmountNotPositive, sharesIn.Int64())
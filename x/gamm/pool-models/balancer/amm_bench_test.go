@@ -0,0 +1,89 @@
+package balancer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func BenchmarkSwapOutAmtGivenIn(b *testing.B) {
+	pool, err := NewBalancerPool(1, PoolParams{SwapFee: sdk.MustNewDecFromStr("0.003"), ExitFee: sdk.ZeroDec()}, []PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 1_000_000_000_000), Weight: sdk.NewInt(80)},
+		{Token: sdk.NewInt64Coin("bar", 1_000_000_000_000), Weight: sdk.NewInt(20)},
+	}, "", time.Now())
+	if err != nil {
+		b.Fatal(err)
+	}
+	tokensIn := sdk.NewCoins(sdk.NewInt64Coin("foo", 1_000_000))
+	swapFee := pool.GetSwapFee(sdk.Context{})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := pool.SwapOutAmtGivenIn(sdk.Context{}, tokensIn, "bar", swapFee); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSolve5050Swap solves the invariant of a swap in a 50/50 pool,
+// on the constant mean curve and on the constant product curve.
+// Neither approximates a power, as the weight ratio of the swap is one.
+func BenchmarkSolve5050Swap(b *testing.B) {
+	balanceIn := sdk.NewDec(1_000_000_000_000)
+	balanceInAfter := balanceIn.Add(sdk.NewDec(1_000_000))
+	balanceOut := sdk.NewDec(1_000_000_000_000)
+	weight := sdk.NewDec(50 * GuaranteedWeightPrecision)
+
+	b.Run(ConstantMeanCurve.String(), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := solveConstantFunctionInvariant(balanceIn, balanceInAfter, weight, balanceOut, weight); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run(ConstantProductCurve.String(), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := solveConstantProductInvariant(balanceIn, balanceInAfter, balanceOut); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// eightAssetPool returns a pool of 8 equally weighted assets, the most a pool has at the default MaxPoolAssets.
+func eightAssetPool(b *testing.B) *Pool {
+	assets := make([]PoolAsset, 8)
+	for i := range assets {
+		assets[i] = PoolAsset{Token: sdk.NewInt64Coin(fmt.Sprintf("asset%d", i), 1_000_000_000_000), Weight: sdk.NewInt(100)}
+	}
+	pool, err := NewBalancerPool(1, PoolParams{SwapFee: sdk.MustNewDecFromStr("0.003"), ExitFee: sdk.ZeroDec()}, assets, "", time.Now())
+	if err != nil {
+		b.Fatal(err)
+	}
+	return &pool
+}
+
+// BenchmarkEightAssetPoolSwap compares a swap through an 8 asset pool to the lookups of its pool assets,
+// which are binary searches of the assets sorted by denom.
+func BenchmarkEightAssetPoolSwap(b *testing.B) {
+	pool := eightAssetPool(b)
+	tokensIn := sdk.NewCoins(sdk.NewInt64Coin("asset0", 1_000_000))
+	swapFee := pool.GetSwapFee(sdk.Context{})
+
+	b.Run("swap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := pool.CalcOutAmtGivenIn(sdk.Context{}, tokensIn, "asset7", swapFee); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("asset lookups", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, _, err := pool.parsePoolAssetsByDenoms("asset0", "asset7"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
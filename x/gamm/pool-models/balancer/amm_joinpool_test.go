@@ -352,7 +352,7 @@ var calcSingleAssetJoinTestCases = []calcJoinSharesTestCase{
 	{
 		// Currently, our Pow approximation function does not work correctly when one tries
 		// to add liquidity that is larger than the existing liquidity.
-		// The ratio of tokenIn / existing liquidity that is larger than or equal to 1 causes a panic.
+		// The ratio of tokenIn / existing liquidity that is larger than or equal to 1 returns an error.
 		// This has been deemed as acceptable since it causes code complexity to fix
 		// & only affects UX in an edge case (user has to split up single asset joins)
 		name:    "single asset - (exactly 1 == tokenIn / liquidity ratio - failure), token in weight is smaller than the other token, with zero swap fee",
@@ -368,8 +368,8 @@ var calcSingleAssetJoinTestCases = []calcJoinSharesTestCase{
 			},
 		},
 		tokensIn:     sdk.NewCoins(sdk.NewInt64Coin("uosmo", 500_000)),
-		expectShares: sdk.NewIntFromUint64(6_504_099_261_800_144_638),
-		expectPanic:  true,
+		expectShares: sdk.ZeroInt(),
+		expErr:       types.ErrInvalidMathApprox,
 	},
 	{
 		name:         "tokenIn asset does not exist in pool",
@@ -1006,6 +1006,116 @@ func TestCalcJoinSingleAssetTokensIn(t *testing.T) {
 	}
 }
 
+// TestJoinPoolNoSwap tests that JoinPoolNoSwap mints exactly shareOutAmount for the proportional amounts of tokensIn.
+func TestJoinPoolNoSwap(t *testing.T) {
+	// 1% of the pool's total shares of 100 * 10^18.
+	onePercentShares := types.InitPoolSharesSupply.QuoRaw(100)
+	// 1% of the pool's liquidity of 10^12 of each asset.
+	onePercentLiquidity := oneTrillion.QuoRaw(100)
+
+	testCases := []struct {
+		name              string
+		tokensIn          sdk.Coins
+		shareOutAmount    sdk.Int
+		expTokensInNeeded sdk.Coins
+		expErr            error
+	}{
+		{
+			name:              "exact proportional amounts",
+			tokensIn:          sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity), sdk.NewCoin("uatom", onePercentLiquidity)),
+			shareOutAmount:    onePercentShares,
+			expTokensInNeeded: sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity), sdk.NewCoin("uatom", onePercentLiquidity)),
+		},
+		{
+			name:              "excess of one denom is not consumed",
+			tokensIn:          sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity.AddRaw(5)), sdk.NewCoin("uatom", onePercentLiquidity)),
+			shareOutAmount:    onePercentShares,
+			expTokensInNeeded: sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity), sdk.NewCoin("uatom", onePercentLiquidity)),
+		},
+		{
+			name:           "one denom short by one unit",
+			tokensIn:       sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity), sdk.NewCoin("uatom", onePercentLiquidity.SubRaw(1))),
+			shareOutAmount: onePercentShares,
+			expErr:         types.ErrLimitMaxAmount,
+		},
+		{
+			name:           "one denom missing",
+			tokensIn:       sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity)),
+			shareOutAmount: onePercentShares,
+			expErr:         types.ErrLimitMaxAmount,
+		},
+		{
+			name:           "zero shares out",
+			tokensIn:       sdk.NewCoins(sdk.NewCoin("uosmo", onePercentLiquidity), sdk.NewCoin("uatom", onePercentLiquidity)),
+			shareOutAmount: sdk.ZeroInt(),
+			expErr:         types.ErrNotPositiveRequireAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(), oneTrillionEvenPoolAssets...)
+			balancerPool, ok := pool.(*balancer.Pool)
+			require.True(t, ok)
+
+			initialLiquidity := pool.GetTotalPoolLiquidity(sdk.Context{})
+			initialShares := pool.GetTotalShares()
+
+			if tc.expErr != nil {
+				assertPoolStateNotModified(t, balancerPool, func() {
+					_, err := balancerPool.JoinPoolNoSwap(sdk.Context{}, tc.tokensIn, tc.shareOutAmount)
+					require.ErrorIs(t, err, tc.expErr)
+				})
+				return
+			}
+
+			tokensInNeeded, err := balancerPool.JoinPoolNoSwap(sdk.Context{}, tc.tokensIn, tc.shareOutAmount)
+			require.NoError(t, err)
+			require.Equal(t, tc.expTokensInNeeded, tokensInNeeded)
+			require.Equal(t, initialShares.Add(tc.shareOutAmount), pool.GetTotalShares())
+			require.Equal(t, initialLiquidity.Add(tc.expTokensInNeeded...), pool.GetTotalPoolLiquidity(sdk.Context{}))
+		})
+	}
+}
+
+// TestJoinPoolTokensJoined tests that JoinPool reports the liquidity it consumed,
+// and that the pool liquidity grows by exactly that amount.
+func (suite *KeeperTestSuite) TestJoinPoolTokensJoined() {
+	testCases := []struct {
+		name     string
+		tokensIn sdk.Coins
+	}{
+		{
+			name:     "single asset join consumes all of tokenIn",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("uosmo", 50_000)),
+		},
+		{
+			name:     "exact ratio join",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("uosmo", 50_000), sdk.NewInt64Coin("uatom", 50_000)),
+		},
+		{
+			name:     "multi asset join with remainder",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("uosmo", 50_000), sdk.NewInt64Coin("uatom", 75_000)),
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			pool := createTestPool(suite.T(), sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(), oneTrillionEvenPoolAssets...)
+			liquidityBefore := pool.GetTotalPoolLiquidity(suite.Ctx)
+			sharesBefore := pool.GetTotalShares()
+
+			numShares, tokensJoined, err := pool.JoinPool(suite.Ctx, tc.tokensIn, pool.GetSwapFee(suite.Ctx))
+			suite.Require().NoError(err)
+
+			suite.Require().True(tc.tokensIn.IsAllGTE(tokensJoined))
+			suite.Require().Equal(liquidityBefore.Add(tokensJoined...), pool.GetTotalPoolLiquidity(suite.Ctx))
+			suite.Require().Equal(sharesBefore.Add(numShares), pool.GetTotalShares())
+		})
+	}
+}
+
 // TestGetPoolAssetsByDenom tests if `GetPoolAssetsByDenom` succesfully creates a map of denom to pool asset
 // given pool asset as parameter
 func TestGetPoolAssetsByDenom(t *testing.T) {
@@ -1151,8 +1261,9 @@ func (suite *KeeperTestSuite) TestRandomizedJoinPoolExitPoolInvariants() {
 			sdk.NewCoin(denomIn, sdk.NewInt(tc.initialTokensDenomIn).MulRaw(tc.percentRatio).QuoRaw(100)),
 			sdk.NewCoin(denomOut, sdk.NewInt(tc.initialTokensDenomOut).MulRaw(tc.percentRatio).QuoRaw(100)),
 		}
-		numShares, err := pool.JoinPool(suite.Ctx, tokensIn, swapFeeDec)
+		numShares, tokensJoined, err := pool.JoinPool(suite.Ctx, tokensIn, swapFeeDec)
 		suite.Require().NoError(err)
+		suite.Require().True(tokensIn.IsAllGTE(tokensJoined))
 		tc.numShares = numShares
 	}
 
@@ -1,12 +1,18 @@
 package balancer_test
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 
+	"github.com/osmosis-labs/osmosis/v7/osmomath"
 	"github.com/osmosis-labs/osmosis/v7/osmoutils"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/balancer"
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
@@ -320,21 +326,23 @@ func TestCalcSingleAssetInAndOut_InverseRelationship(t *testing.T) {
 				initialTotalShares := types.InitPoolSharesSupply.ToDec()
 				initialCalcTokenOut := sdk.NewInt(tc.tokenOut)
 
-				actualSharesOut := balancer.CalcPoolSharesOutGivenSingleAssetIn(
+				actualSharesOut, err := balancer.CalcPoolSharesOutGivenSingleAssetIn(
 					initialPoolBalanceOut.ToDec(),
 					initialWeightOut.ToDec().Quo(initialWeightOut.Add(initialWeightIn).ToDec()),
 					initialTotalShares,
 					initialCalcTokenOut.ToDec(),
 					swapFeeDec,
 				)
+				require.NoError(t, err)
 
-				inverseCalcTokenOut := balancer.CalcSingleAssetInGivenPoolSharesOut(
+				inverseCalcTokenOut, err := balancer.CalcSingleAssetInGivenPoolSharesOut(
 					initialPoolBalanceOut.Add(initialCalcTokenOut).ToDec(),
 					initialWeightOut.ToDec().Quo(initialWeightOut.Add(initialWeightIn).ToDec()),
 					initialTotalShares.Add(actualSharesOut),
 					actualSharesOut,
 					swapFeeDec,
 				)
+				require.NoError(t, err)
 
 				tol := sdk.NewDec(1)
 				require.True(osmoutils.DecApproxEq(t, initialCalcTokenOut.ToDec(), inverseCalcTokenOut, tol))
@@ -342,3 +350,1234 @@ func TestCalcSingleAssetInAndOut_InverseRelationship(t *testing.T) {
 		}
 	}
 }
+
+func TestExitSwapShareAmountIn(t *testing.T) {
+	testCases := []struct {
+		name              string
+		swapFee           sdk.Dec
+		exitFee           sdk.Dec
+		shareInAmount     sdk.Int
+		expTokenOutAmount sdk.Int
+		expErr            error
+	}{
+		{
+			// exiting 1% of 50/50 pool shares to a single asset:
+			// 10^12 * (1 - 0.99^2) = 19900000000
+			name:              "1% of shares, no fees",
+			swapFee:           sdk.ZeroDec(),
+			exitFee:           sdk.ZeroDec(),
+			shareInAmount:     types.InitPoolSharesSupply.QuoRaw(100),
+			expTokenOutAmount: sdk.NewInt(19900000000),
+		},
+		{
+			// fee is only charged on the non-normalized half:
+			// 19900000000 * (1 - 0.5 * 0.01) = 19800500000
+			name:              "1% of shares, 1% swap fee",
+			swapFee:           sdk.MustNewDecFromStr("0.01"),
+			exitFee:           sdk.ZeroDec(),
+			shareInAmount:     types.InitPoolSharesSupply.QuoRaw(100),
+			expTokenOutAmount: sdk.NewInt(19800500000),
+		},
+		{
+			// exit fee is charged on shares in, leaving 0.5% of shares:
+			// 10^12 * (1 - 0.995^2) = 9975000000
+			name:              "1% of shares, 50% exit fee",
+			swapFee:           sdk.ZeroDec(),
+			exitFee:           sdk.MustNewDecFromStr("0.5"),
+			shareInAmount:     types.InitPoolSharesSupply.QuoRaw(100),
+			expTokenOutAmount: sdk.NewInt(9975000000),
+		},
+		{
+			name:          "all but one share drains the denom",
+			swapFee:       sdk.ZeroDec(),
+			exitFee:       sdk.ZeroDec(),
+			shareInAmount: types.InitPoolSharesSupply.SubRaw(1),
+			expErr:        types.ErrTooManyTokensOut,
+		},
+		{
+			name:          "all shares",
+			swapFee:       sdk.ZeroDec(),
+			exitFee:       sdk.ZeroDec(),
+			shareInAmount: types.InitPoolSharesSupply,
+			expErr:        types.ErrLimitMaxAmount,
+		},
+		{
+			name:          "zero shares",
+			swapFee:       sdk.ZeroDec(),
+			exitFee:       sdk.ZeroDec(),
+			shareInAmount: sdk.ZeroInt(),
+			expErr:        types.ErrNotPositiveRequireAmount,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, tc.swapFee, tc.exitFee, oneTrillionEvenPoolAssets...)
+			balancerPool, ok := pool.(*balancer.Pool)
+			require.True(t, ok)
+
+			if tc.expErr != nil {
+				assertPoolStateNotModified(t, balancerPool, func() {
+					_, err := balancerPool.ExitSwapShareAmountIn(sdk.Context{}, "uosmo", tc.shareInAmount)
+					require.ErrorIs(t, err, tc.expErr)
+				})
+				return
+			}
+
+			tokenOutAmount, err := balancerPool.ExitSwapShareAmountIn(sdk.Context{}, "uosmo", tc.shareInAmount)
+			require.NoError(t, err)
+			require.Equal(t, tc.expTokenOutAmount, tokenOutAmount)
+
+			require.Equal(t, types.InitPoolSharesSupply.Sub(tc.shareInAmount), pool.GetTotalShares())
+			liquidity := pool.GetTotalPoolLiquidity(sdk.Context{})
+			require.Equal(t, oneTrillion.Sub(tc.expTokenOutAmount), liquidity.AmountOf("uosmo"))
+			require.Equal(t, oneTrillion, liquidity.AmountOf("uatom"))
+		})
+	}
+}
+
+// TestExitSwapExactAmountOutRoundTrip tests that ExitSwapExactAmountOut rounds the shares in up from the v11 upgrade on,
+// truncating them before it, so that the exiter never gets tokenOut for fewer shares than it is worth,
+// and that exiting those shares back into the denom gets no more than tokenOut, or exactly tokenOut without fees.
+func TestExitSwapExactAmountOutRoundTrip(t *testing.T) {
+	for _, fees := range [][2]sdk.Dec{{sdk.ZeroDec(), sdk.ZeroDec()}, {sdk.MustNewDecFromStr("0.003"), sdk.MustNewDecFromStr("0.01")}} {
+		swapFee, exitFee := fees[0], fees[1]
+		for _, tokenOutAmount := range []int64{100, 12345, 10_000_000_000} {
+			tokenOut := sdk.NewInt64Coin("uosmo", tokenOutAmount)
+			t.Run(fmt.Sprintf("swapFee: %s, exitFee: %s, tokenOut: %s", swapFee, exitFee, tokenOut), func(t *testing.T) {
+				poolI := createTestPool(t, swapFee, exitFee, oneTrillionEvenPoolAssets...)
+				pool := poolI.(*balancer.Pool)
+				poolAssetOut, err := pool.GetPoolAsset("uosmo")
+				require.NoError(t, err)
+				normalizedWeight := poolAssetOut.Weight.ToDec().Quo(pool.GetTotalWeight().ToDec())
+				sharesInDec, err := balancer.CalcPoolSharesInGivenSingleAssetOut(
+					poolAssetOut.Token.Amount.ToDec(), normalizedWeight, pool.GetTotalShares().ToDec(), tokenOut.Amount.ToDec(), swapFee, exitFee)
+				require.NoError(t, err)
+
+				// before the v11 upgrade, the shares in are truncated.
+				preUpgradeCtx := createTestContext(t).WithBlockHeight(types.V11UpgradeHeight - 1)
+				preUpgradePool := createTestPool(t, swapFee, exitFee, oneTrillionEvenPoolAssets...).(*balancer.Pool)
+				sharesIn, err := preUpgradePool.ExitSwapExactAmountOut(preUpgradeCtx, tokenOut, pool.GetTotalShares())
+				require.NoError(t, err)
+				require.Equal(t, sharesInDec.TruncateInt(), sharesIn)
+
+				// the pool assets are shared by copies of the pool, so the exit is done on a new pool.
+				ctx := createTestContext(t).WithBlockHeight(types.V11UpgradeHeight)
+				exitedPool := createTestPool(t, swapFee, exitFee, oneTrillionEvenPoolAssets...).(*balancer.Pool)
+				sharesIn, err = exitedPool.ExitSwapExactAmountOut(ctx, tokenOut, pool.GetTotalShares())
+				require.NoError(t, err)
+				require.True(t, sharesIn.ToDec().GTE(sharesInDec), "shares in %s less than %s", sharesIn, sharesInDec)
+				require.True(t, sharesIn.SubRaw(1).ToDec().LT(sharesInDec), "shares in %s not rounded up from %s", sharesIn, sharesInDec)
+				require.Equal(t, oneTrillion.Sub(tokenOut.Amount), exitedPool.GetTotalPoolLiquidity(ctx).AmountOf("uosmo"))
+
+				_, err = pool.ExitSwapExactAmountOut(ctx, tokenOut, sharesIn.SubRaw(1))
+				require.ErrorIs(t, err, types.ErrLimitMaxAmount)
+
+				roundTripTokenOut, err := pool.CalcExitSwapShareAmountIn(sdk.Context{}, "uosmo", sharesIn)
+				require.NoError(t, err)
+				require.True(t, roundTripTokenOut.LTE(tokenOut.Amount), "exiting %s shares got %s", sharesIn, roundTripTokenOut)
+				// exiting shares charges the fees differently, so only the exit without fees is the exact inverse.
+				if !swapFee.IsZero() || !exitFee.IsZero() {
+					return
+				}
+				tol := osmoutils.ErrTolerance{AdditiveTolerance: sdk.OneInt(), MultiplicativeTolerance: sdk.NewDecWithPrec(1, 7)}
+				require.Equal(t, 0, tol.Compare(tokenOut.Amount, roundTripTokenOut), "exiting %s shares got %s", sharesIn, roundTripTokenOut)
+			})
+		}
+	}
+}
+
+// TestSpotPriceZeroBalanceOrWeight tests that SpotPrice errors, rather than panics or
+// loops, on pools with an asset that has a zero balance or weight.
+// Such pools can't be created, so the pools are constructed as struct literals.
+func TestSpotPriceZeroBalanceOrWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		poolAssets []balancer.PoolAsset
+	}{
+		{
+			name: "zero base balance",
+			poolAssets: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("bar", 100), Weight: sdk.NewInt(100)},
+				{Token: sdk.NewInt64Coin("foo", 0), Weight: sdk.NewInt(100)},
+			},
+		},
+		{
+			name: "zero quote balance",
+			poolAssets: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("bar", 0), Weight: sdk.NewInt(100)},
+				{Token: sdk.NewInt64Coin("foo", 100), Weight: sdk.NewInt(100)},
+			},
+		},
+		{
+			name: "zero weight",
+			poolAssets: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("bar", 100), Weight: sdk.ZeroInt()},
+				{Token: sdk.NewInt64Coin("foo", 100), Weight: sdk.NewInt(100)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := balancer.Pool{
+				PoolAssets:  tc.poolAssets,
+				TotalWeight: tc.poolAssets[0].Weight.Add(tc.poolAssets[1].Weight),
+			}
+
+			require.NotPanics(t, func() {
+				_, err := pool.SpotPrice(sdk.Context{}, "foo", "bar")
+				require.ErrorIs(t, err, types.ErrSpotPriceInternal)
+			})
+		})
+	}
+}
+
+// TestCalcOutAmtGivenMultipleIn tests that swapping multiple tokens in at once
+// gives the same result as swapping them in one at a time.
+func TestCalcOutAmtGivenMultipleIn(t *testing.T) {
+	swapFee := sdk.MustNewDecFromStr("0.003")
+	poolAssets := []balancer.PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(100)},
+		{Token: sdk.NewInt64Coin("bar", 2_000_000_000), Weight: sdk.NewInt(200)},
+		{Token: sdk.NewInt64Coin("baz", 3_000_000_000), Weight: sdk.NewInt(300)},
+	}
+	tokensIn := sdk.NewCoins(sdk.NewInt64Coin("bar", 12_345_678), sdk.NewInt64Coin("baz", 98_765_432))
+
+	// swap the tokens in one at a time.
+	sequentialPool := createTestPool(t, swapFee, sdk.ZeroDec(), poolAssets...)
+	expectedTokenOut := sdk.NewInt64Coin("foo", 0)
+	for _, tokenIn := range tokensIn {
+		tokenOut, err := sequentialPool.SwapOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "foo", swapFee)
+		require.NoError(t, err)
+		expectedTokenOut = expectedTokenOut.Add(tokenOut)
+	}
+
+	// swap all the tokens in at once.
+	multiplePool := createTestPool(t, swapFee, sdk.ZeroDec(), poolAssets...)
+	balancerPool, ok := multiplePool.(*balancer.Pool)
+	require.True(t, ok)
+
+	var calcTokenOut sdk.Coin
+	assertPoolStateNotModified(t, balancerPool, func() {
+		var err error
+		calcTokenOut, err = multiplePool.CalcOutAmtGivenIn(sdk.Context{}, tokensIn, "foo", swapFee)
+		require.NoError(t, err)
+	})
+	require.Equal(t, expectedTokenOut, calcTokenOut)
+
+	tokenOut, err := multiplePool.SwapOutAmtGivenIn(sdk.Context{}, tokensIn, "foo", swapFee)
+	require.NoError(t, err)
+	require.Equal(t, expectedTokenOut, tokenOut)
+	require.Equal(t, sequentialPool.GetTotalPoolLiquidity(sdk.Context{}), multiplePool.GetTotalPoolLiquidity(sdk.Context{}))
+
+	// the token out can't also be a token in.
+	_, err = multiplePool.CalcOutAmtGivenIn(sdk.Context{}, sdk.NewCoins(sdk.NewInt64Coin("bar", 100), sdk.NewInt64Coin("foo", 100)), "foo", swapFee)
+	require.Error(t, err)
+}
+
+func TestCalcOutAmtGivenInWithFee(t *testing.T) {
+	poolAssets := []balancer.PoolAsset{
+		{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(100)},
+		{Token: sdk.NewInt64Coin("bar", 2_000_000_000), Weight: sdk.NewInt(300)},
+	}
+	tests := []struct {
+		name          string
+		tokenIn       sdk.Coin
+		tokenOutDenom string
+		swapFee       sdk.Dec
+	}{
+		{"no swap fee", sdk.NewInt64Coin("bar", 12_345_678), "foo", sdk.ZeroDec()},
+		{"0.3% swap fee", sdk.NewInt64Coin("bar", 12_345_678), "foo", sdk.MustNewDecFromStr("0.003")},
+		{"swap fee with many decimals", sdk.NewInt64Coin("foo", 98_765_431), "bar", sdk.MustNewDecFromStr("0.012345678901234567")},
+		{"swap fee of one token", sdk.NewInt64Coin("foo", 1000), "bar", sdk.MustNewDecFromStr("0.001")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, tc.swapFee, sdk.ZeroDec(), poolAssets...)
+			balancerPool, ok := pool.(*balancer.Pool)
+			require.True(t, ok)
+
+			var tokenOut, feeCharged sdk.DecCoin
+			assertPoolStateNotModified(t, balancerPool, func() {
+				var err error
+				tokenOut, feeCharged, err = balancerPool.CalcOutAmtGivenInWithFee(sdk.Context{}, sdk.Coins{tc.tokenIn}, tc.tokenOutDenom, tc.swapFee)
+				require.NoError(t, err)
+			})
+			require.Equal(t, sdk.NewDecCoinFromDec(tc.tokenIn.Denom, tc.tokenIn.Amount.ToDec().Mul(tc.swapFee)), feeCharged)
+
+			// CalcOutAmtGivenIn returns tokenOut rounded down.
+			calcTokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, sdk.Coins{tc.tokenIn}, tc.tokenOutDenom, tc.swapFee)
+			require.NoError(t, err)
+			require.Equal(t, tokenOut.Amount.TruncateInt(), calcTokenOut.Amount)
+		})
+	}
+
+	// only one token in is supported.
+	pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(), poolAssets...)
+	_, _, err := pool.(*balancer.Pool).CalcOutAmtGivenInWithFee(sdk.Context{}, sdk.NewCoins(sdk.NewInt64Coin("bar", 100), sdk.NewInt64Coin("foo", 100)), "foo", sdk.ZeroDec())
+	require.Error(t, err)
+}
+
+// TestCalcAndSwapAmountsAgree cross-checks the computations of the amounts of a swap, that quotes use,
+// against the mutative swaps that the keeper applies, for weighted and equally weighted pools.
+func TestCalcAndSwapAmountsAgree(t *testing.T) {
+	tests := []struct {
+		name       string
+		poolAssets []balancer.PoolAsset
+	}{
+		{
+			name: "weighted",
+			poolAssets: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(100)},
+				{Token: sdk.NewInt64Coin("bar", 3_000_000_000), Weight: sdk.NewInt(300)},
+			},
+		},
+		{
+			name: "equal weights",
+			poolAssets: []balancer.PoolAsset{
+				{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(100)},
+				{Token: sdk.NewInt64Coin("bar", 3_000_000_000), Weight: sdk.NewInt(100)},
+			},
+		},
+	}
+	swapFees := []sdk.Dec{sdk.ZeroDec(), sdk.MustNewDecFromStr("0.003"), sdk.MustNewDecFromStr("0.5")}
+	amounts := []int64{1_000, 123_456, 98_765_432}
+
+	for _, test := range tests {
+		for _, swapFee := range swapFees {
+			for _, amount := range amounts {
+				name := fmt.Sprintf("%s, swap fee %s, amount %d", test.name, swapFee, amount)
+				t.Run(name, func(t *testing.T) {
+					tokenIn := sdk.NewInt64Coin("foo", amount)
+					pool := createTestPool(t, swapFee, sdk.ZeroDec(), test.poolAssets...)
+					calcTokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", swapFee)
+					require.NoError(t, err)
+					calcTokenOutDec, _, err := pool.(*balancer.Pool).CalcOutAmtGivenInWithFee(sdk.Context{}, sdk.Coins{tokenIn}, "bar", swapFee)
+					require.NoError(t, err)
+
+					swapPool := createTestPool(t, swapFee, sdk.ZeroDec(), test.poolAssets...)
+					swapTokenOut, err := swapPool.SwapOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", swapFee)
+					require.NoError(t, err)
+					require.Equal(t, calcTokenOut, swapTokenOut)
+
+					remainderPool := createTestPool(t, swapFee, sdk.ZeroDec(), test.poolAssets...)
+					remainderTokenOut, remainder, err := remainderPool.(*balancer.Pool).SwapOutAmtGivenInWithRemainder(sdk.Context{}, sdk.Coins{tokenIn}, "bar", swapFee)
+					require.NoError(t, err)
+					require.Equal(t, calcTokenOut, remainderTokenOut)
+					require.Equal(t, calcTokenOutDec.Amount, remainderTokenOut.Amount.ToDec().Add(remainder))
+					require.Equal(t, swapPool.GetTotalPoolLiquidity(sdk.Context{}), remainderPool.GetTotalPoolLiquidity(sdk.Context{}))
+
+					tokenOut := sdk.NewInt64Coin("bar", amount)
+					calcTokenIn, err := pool.CalcInAmtGivenOut(sdk.Context{}, sdk.Coins{tokenOut}, "foo", swapFee)
+					require.NoError(t, err)
+					swapPool = createTestPool(t, swapFee, sdk.ZeroDec(), test.poolAssets...)
+					swapTokenIn, err := swapPool.SwapInAmtGivenOut(sdk.Context{}, sdk.Coins{tokenOut}, "foo", swapFee)
+					require.NoError(t, err)
+					require.Equal(t, calcTokenIn, swapTokenIn)
+
+					// swapping the amount in needed for tokenOut swaps out at least tokenOut.
+					roundTripTokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, sdk.Coins{calcTokenIn}, "bar", swapFee)
+					require.NoError(t, err)
+					require.True(t, roundTripTokenOut.Amount.GTE(tokenOut.Amount), "%s < %s", roundTripTokenOut, tokenOut)
+				})
+			}
+		}
+	}
+}
+
+// TestSingleAssetJoinOfZeroSharesRejected tests that a single asset join too small to get a share of a large pool
+// is rejected, rather than taking the tokens in for no shares.
+func TestSingleAssetJoinOfZeroSharesRejected(t *testing.T) {
+	largeAmount := sdk.NewIntWithDecimal(1, 24)
+	pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewCoin("foo", largeAmount), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewCoin("bar", largeAmount), Weight: sdk.NewInt(100)},
+	)
+	// joins are computed as after the v10 fork.
+	ctx := createTestContext(t).WithBlockHeight(4713065)
+	swapFee := pool.GetSwapFee(ctx)
+	tokensIn := sdk.NewCoins(sdk.NewInt64Coin("foo", 1))
+
+	_, _, err := pool.CalcJoinPoolShares(ctx, tokensIn, swapFee)
+	require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+	_, _, err = pool.JoinPool(ctx, tokensIn, swapFee)
+	require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("bar", largeAmount), sdk.NewCoin("foo", largeAmount)), pool.GetTotalPoolLiquidity(ctx))
+	require.Equal(t, types.InitPoolSharesSupply, pool.GetTotalShares())
+
+	// a join large enough to get a share still goes through.
+	shares, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewCoin("foo", sdk.NewIntWithDecimal(1, 12))), swapFee)
+	require.NoError(t, err)
+	require.True(t, shares.IsPositive())
+}
+
+// TestJoinPastMaxTotalShares tests that joins that would take the total shares of a pool past MaxTotalShares
+// error with ErrTooManyPoolShares, without panicking or changing the pool, before and after the v10 fork.
+func TestJoinPastMaxTotalShares(t *testing.T) {
+	amount := sdk.NewInt(1_000_000_000_000)
+	totalShares := types.MaxTotalShares.QuoRaw(4).MulRaw(3)
+	tests := []struct {
+		name        string
+		join        func(ctx sdk.Context, pool *balancer.Pool) error
+		expectedErr error
+	}{
+		{
+			name: "proportional join within the max",
+			join: func(ctx sdk.Context, pool *balancer.Pool) error {
+				_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewCoin("foo", amount.QuoRaw(100)), sdk.NewCoin("bar", amount.QuoRaw(100))), pool.GetSwapFee(ctx))
+				return err
+			},
+		},
+		{
+			name: "proportional join past the max",
+			join: func(ctx sdk.Context, pool *balancer.Pool) error {
+				_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewCoin("foo", amount.MulRaw(2)), sdk.NewCoin("bar", amount.MulRaw(2))), pool.GetSwapFee(ctx))
+				return err
+			},
+			expectedErr: types.ErrTooManyPoolShares,
+		},
+		{
+			name: "single asset join within the max",
+			join: func(ctx sdk.Context, pool *balancer.Pool) error {
+				_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewCoin("foo", amount.QuoRaw(100))), pool.GetSwapFee(ctx))
+				return err
+			},
+		},
+		{
+			name: "single asset join past the max",
+			join: func(ctx sdk.Context, pool *balancer.Pool) error {
+				_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewCoin("foo", amount.MulRaw(9).QuoRaw(10))), pool.GetSwapFee(ctx))
+				return err
+			},
+			expectedErr: types.ErrTooManyPoolShares,
+		},
+		{
+			name: "no swap join past the max",
+			join: func(ctx sdk.Context, pool *balancer.Pool) error {
+				_, err := pool.JoinPoolNoSwap(ctx, sdk.NewCoins(sdk.NewCoin("foo", amount.MulRaw(2)), sdk.NewCoin("bar", amount.MulRaw(2))), pool.GetTotalShares().AddRaw(1))
+				return err
+			},
+			expectedErr: types.ErrTooManyPoolShares,
+		},
+	}
+
+	for _, test := range tests {
+		for _, height := range []int64{1, 4713065} {
+			t.Run(fmt.Sprintf("%s at height %d", test.name, height), func(t *testing.T) {
+				pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+					balancer.PoolAsset{Token: sdk.NewCoin("foo", amount), Weight: sdk.NewInt(100)},
+					balancer.PoolAsset{Token: sdk.NewCoin("bar", amount), Weight: sdk.NewInt(100)},
+				).(*balancer.Pool)
+				pool.TotalShares.Amount = totalShares
+				ctx := createTestContext(t).WithBlockHeight(height)
+
+				var err error
+				require.NotPanics(t, func() { err = test.join(ctx, pool) })
+				if test.expectedErr == nil {
+					require.NoError(t, err)
+					require.True(t, pool.GetTotalShares().LTE(types.MaxTotalShares))
+					return
+				}
+				require.ErrorIs(t, err, test.expectedErr)
+				require.Equal(t, totalShares, pool.GetTotalShares())
+				require.Equal(t, sdk.NewCoins(sdk.NewCoin("bar", amount), sdk.NewCoin("foo", amount)), pool.GetTotalPoolLiquidity(ctx))
+			})
+		}
+	}
+}
+
+// TestDenomNotFoundInPoolListsPoolDenoms tests that joining or swapping with a denom that isn't in the pool
+// returns ErrDenomNotFoundInPool, with a message listing the pool's denoms.
+func TestDenomNotFoundInPoolListsPoolDenoms(t *testing.T) {
+	pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("baz", 1_000_000), Weight: sdk.NewInt(100)},
+	)
+	// joins are computed as after the v10 fork.
+	ctx := createTestContext(t).WithBlockHeight(4713065)
+	swapFee := pool.GetSwapFee(ctx)
+
+	tests := map[string]func() error{
+		"single asset JoinPool": func() error {
+			_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)), swapFee)
+			return err
+		},
+		"all asset JoinPool": func() error {
+			_, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewInt64Coin("bar", 1000), sdk.NewInt64Coin("foo", 1000), sdk.NewInt64Coin("uatom", 1000)), swapFee)
+			return err
+		},
+		"CalcOutAmtGivenIn token in": func() error {
+			_, err := pool.CalcOutAmtGivenIn(ctx, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)), "foo", swapFee)
+			return err
+		},
+		"CalcOutAmtGivenIn token out": func() error {
+			_, err := pool.CalcOutAmtGivenIn(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "uatom", swapFee)
+			return err
+		},
+		"CalcInAmtGivenOut token out": func() error {
+			_, err := pool.CalcInAmtGivenOut(ctx, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)), "foo", swapFee)
+			return err
+		},
+		"SpotPrice": func() error {
+			_, err := pool.SpotPrice(ctx, "foo", "uatom")
+			return err
+		},
+	}
+
+	for name, sut := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := sut()
+			require.ErrorIs(t, err, types.ErrDenomNotFoundInPool)
+			require.ErrorContains(t, err, "uatom")
+			require.ErrorContains(t, err, "bar, baz, foo")
+		})
+	}
+}
+
+func TestSameDenomErrors(t *testing.T) {
+	pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(100)},
+	)
+	balancerPool, ok := pool.(*balancer.Pool)
+	require.True(t, ok)
+	ctx := createTestContext(t)
+	swapFee := pool.GetSwapFee(ctx)
+
+	tests := map[string]func() error{
+		"CalcOutAmtGivenIn": func() error {
+			_, err := pool.CalcOutAmtGivenIn(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"CalcOutAmtGivenIn multiple tokens in": func() error {
+			_, err := pool.CalcOutAmtGivenIn(ctx, sdk.NewCoins(sdk.NewInt64Coin("bar", 1000), sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"CalcOutAmtGivenInWithFee": func() error {
+			_, _, err := balancerPool.CalcOutAmtGivenInWithFee(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"CalcInAmtGivenOut": func() error {
+			_, err := pool.CalcInAmtGivenOut(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"SwapOutAmtGivenIn": func() error {
+			_, err := pool.SwapOutAmtGivenIn(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"SwapInAmtGivenOut": func() error {
+			_, err := pool.SwapInAmtGivenOut(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "foo", swapFee)
+			return err
+		},
+		"SpotPrice": func() error {
+			_, err := pool.SpotPrice(ctx, "foo", "foo")
+			return err
+		},
+	}
+
+	for name, sut := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, sut(), types.ErrSameDenom)
+		})
+	}
+	// the failed swaps didn't change the pool.
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("bar", 1_000_000), sdk.NewInt64Coin("foo", 1_000_000)), pool.GetTotalPoolLiquidity(ctx))
+}
+
+// maxSdkInt is the largest sdk.Int, 2^256 - 1.
+var maxSdkInt = sdk.NewIntFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)))
+
+func TestSolveConstantFunctionInvariantErrors(t *testing.T) {
+	maxBalance := maxSdkInt.ToDec()
+	tests := []struct {
+		name                                                      string
+		balanceXBefore, balanceXAfter, weightX, balanceY, weightY sdk.Dec
+	}{
+		{"zero balance after", sdk.NewDec(100), sdk.ZeroDec(), sdk.OneDec(), sdk.NewDec(100), sdk.OneDec()},
+		{"zero balance before", sdk.ZeroDec(), sdk.NewDec(100), sdk.OneDec(), sdk.NewDec(100), sdk.OneDec()},
+		{"negative balance after", sdk.NewDec(100), sdk.NewDec(-1), sdk.OneDec(), sdk.NewDec(100), sdk.OneDec()},
+		{"zero balance y", sdk.NewDec(100), sdk.NewDec(101), sdk.OneDec(), sdk.ZeroDec(), sdk.OneDec()},
+		{"zero weight y", sdk.NewDec(100), sdk.NewDec(101), sdk.OneDec(), sdk.NewDec(100), sdk.ZeroDec()},
+		{"balance ratio of 2", sdk.NewDec(200), sdk.NewDec(100), sdk.OneDec(), sdk.NewDec(100), sdk.OneDec()},
+		{"balance ratio overflowing sdk.Dec", maxBalance, sdk.SmallestDec(), sdk.OneDec(), sdk.NewDec(100), sdk.OneDec()},
+		{"power overflowing sdk.Dec", sdk.NewDec(199), sdk.NewDec(100), sdk.NewDec(1 << 20), sdk.NewDec(100), sdk.OneDec()},
+		{"amount overflowing sdk.Dec", sdk.NewDec(19), sdk.NewDec(10), sdk.NewDec(10), maxBalance, sdk.OneDec()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				_, err := balancer.SolveConstantFunctionInvariant(tc.balanceXBefore, tc.balanceXAfter, tc.weightX, tc.balanceY, tc.weightY)
+				require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+			})
+		})
+	}
+}
+
+// TestSolveConstantFunctionInvariantWeightRatioOne tests that the invariant solved for a weight ratio of one,
+// which skips the power approximation, is exactly what the power approximation gives, and what the constant
+// product invariant gives, for balances going up and down.
+func TestSolveConstantFunctionInvariantWeightRatioOne(t *testing.T) {
+	tests := []struct {
+		name                          string
+		balanceXBefore, balanceXAfter sdk.Dec
+		balanceY                      sdk.Dec
+		weight                        sdk.Dec
+	}{
+		{"small swap in", sdk.NewDec(1_000_000), sdk.NewDec(1_000_001), sdk.NewDec(2_000_000), sdk.OneDec()},
+		{"large swap in", sdk.NewDec(1_000_000), sdk.NewDec(7_654_321), sdk.NewDec(3_000_000), sdk.NewDec(50 * balancer.GuaranteedWeightPrecision)},
+		{"swap out", sdk.NewDec(1_000_000), sdk.NewDec(999_999), sdk.NewDec(2_000_000), sdk.NewDec(3)},
+		{"swap out of almost half", sdk.NewDec(1_999_999), sdk.NewDec(1_000_000), sdk.NewDec(5_000_000), sdk.NewDec(1 << 20)},
+		{"fractional balances", sdk.MustNewDecFromStr("123.456789"), sdk.MustNewDecFromStr("130.000001"), sdk.MustNewDecFromStr("987.654321"), sdk.MustNewDecFromStr("0.5")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			amountY, err := balancer.SolveConstantFunctionInvariant(tc.balanceXBefore, tc.balanceXAfter, tc.weight, tc.balanceY, tc.weight)
+			require.NoError(t, err)
+
+			y := tc.balanceXBefore.Quo(tc.balanceXAfter)
+			yToWeightRatio, err := osmomath.PowBounded(y, sdk.OneDec(), osmomath.GetPowPrecision(), balancer.MaxPowIterations)
+			require.NoError(t, err)
+			require.Equal(t, tc.balanceY.Mul(sdk.OneDec().Sub(yToWeightRatio)), amountY)
+
+			constantProductAmountY, err := balancer.SolveConstantProductInvariant(tc.balanceXBefore, tc.balanceXAfter, tc.balanceY)
+			require.NoError(t, err)
+			require.Equal(t, constantProductAmountY, amountY)
+		})
+	}
+}
+
+// TestNonPositivePowBase tests that powers of a non-positive base, e.g. of a pool with a negative balance
+// due to a bug, return ErrInvalidPowBase rather than panicking in osmomath.Pow.
+func TestNonPositivePowBase(t *testing.T) {
+	for _, base := range []sdk.Dec{sdk.ZeroDec(), sdk.NewDec(-1), sdk.NewDec(-1000000)} {
+		require.NotPanics(t, func() {
+			_, err := balancer.PowAtLeastOne(base, sdk.MustNewDecFromStr("0.5"), osmomath.GetPowPrecision())
+			require.ErrorIs(t, err, types.ErrInvalidPowBase)
+		}, base.String())
+	}
+
+	pool := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(300)},
+	)
+	balancerPool, ok := pool.(*balancer.Pool)
+	require.True(t, ok)
+	// sdk.NewCoin panics on negative amounts, so the balance is injected directly.
+	require.Equal(t, "bar", balancerPool.PoolAssets[0].Token.Denom)
+	balancerPool.PoolAssets[0].Token = sdk.Coin{Denom: "bar", Amount: sdk.NewInt(-1_000_000)}
+
+	require.NotPanics(t, func() {
+		_, err := balancerPool.InvariantK()
+		require.ErrorIs(t, err, types.ErrInvalidPowBase)
+		// (-1_000_000 + 2_000_000) / -1_000_000 is a negative ratio of the out balances.
+		_, err = balancerPool.SwapInvariantRatio(sdk.NewInt64Coin("foo", 1000), sdk.NewInt64Coin("bar", 2_000_000))
+		require.ErrorIs(t, err, types.ErrInvalidPowBase)
+		// swaps against the negative balance are rejected before the power is computed.
+		_, err = balancerPool.CalcOutAmtGivenIn(createTestContext(t), sdk.NewCoins(sdk.NewInt64Coin("foo", 1000)), "bar", sdk.ZeroDec())
+		require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+	})
+}
+
+// FuzzSolveConstantFunctionInvariant checks that solveConstantFunctionInvariant returns an error rather than
+// panicking, for balances up to the largest sdk.Int. Balances are mantissa << shift, capped at 256 bits.
+func FuzzSolveConstantFunctionInvariant(f *testing.F) {
+	f.Add(uint64(1_000_000), uint8(0), uint64(1_000_001), uint8(0), uint64(1_000_000), uint8(0), uint32(1), uint32(1))
+	f.Add(uint64(1_000_000), uint8(0), uint64(999_999), uint8(0), uint64(1_000_000), uint8(0), uint32(100), uint32(300))
+	// balances near the largest sdk.Int.
+	f.Add(uint64(math.MaxUint64), uint8(192), uint64(math.MaxUint64-1), uint8(192), uint64(math.MaxUint64), uint8(192), uint32(1), uint32(1))
+	f.Add(uint64(math.MaxUint64-1), uint8(192), uint64(math.MaxUint64), uint8(192), uint64(math.MaxUint64), uint8(192), uint32(1<<20), uint32(1))
+	f.Add(uint64(math.MaxUint64), uint8(192), uint64(1), uint8(0), uint64(math.MaxUint64), uint8(192), uint32(1), uint32(1<<20))
+	f.Add(uint64(19), uint8(0), uint64(10), uint8(0), uint64(math.MaxUint64), uint8(192), uint32(1<<20), uint32(1))
+
+	toDec := func(mantissa uint64, shift uint8) sdk.Dec {
+		return sdk.NewIntFromBigInt(new(big.Int).Lsh(new(big.Int).SetUint64(mantissa), uint(shift%193))).ToDec()
+	}
+	f.Fuzz(func(t *testing.T, xBefore uint64, xBeforeShift uint8, xAfter uint64, xAfterShift uint8, y uint64, yShift uint8, weightX uint32, weightY uint32) {
+		require.NotPanics(t, func() {
+			amountY, err := balancer.SolveConstantFunctionInvariant(
+				toDec(xBefore, xBeforeShift), toDec(xAfter, xAfterShift), sdk.NewDec(int64(weightX)), toDec(y, yShift), sdk.NewDec(int64(weightY)))
+			if err != nil {
+				require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+				return
+			}
+			// balance y can't decrease by more than all of it.
+			require.True(t, amountY.LTE(toDec(y, yShift)))
+		})
+	})
+}
+
+func TestInvariantCurve(t *testing.T) {
+	equalWeights := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(50)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 2_000_000_000), Weight: sdk.NewInt(50)},
+	)
+	unequalWeights := createTestPool(t, sdk.MustNewDecFromStr("0.003"), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(50)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 2_000_000_000), Weight: sdk.NewInt(50)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("baz", 3_000_000_000), Weight: sdk.NewInt(100)},
+	)
+	require.Equal(t, balancer.ConstantProductCurve, equalWeights.(*balancer.Pool).InvariantCurve())
+	require.Equal(t, balancer.ConstantMeanCurve, unequalWeights.(*balancer.Pool).InvariantCurve())
+
+	// a swap between two assets of equal weight has the same result on either curve.
+	ctx := createTestContext(t)
+	swapFee := equalWeights.GetSwapFee(ctx)
+	for _, amount := range []int64{1, 1_000, 1_000_000, 999_999_999} {
+		tokensIn := sdk.NewCoins(sdk.NewInt64Coin("foo", amount))
+		constantProductOut, err := equalWeights.CalcOutAmtGivenIn(ctx, tokensIn, "bar", swapFee)
+		require.NoError(t, err)
+		constantMeanOut, err := unequalWeights.CalcOutAmtGivenIn(ctx, tokensIn, "bar", swapFee)
+		require.NoError(t, err)
+		require.Equal(t, constantMeanOut, constantProductOut)
+
+		tokensOut := sdk.NewCoins(sdk.NewInt64Coin("bar", amount))
+		constantProductIn, err := equalWeights.CalcInAmtGivenOut(ctx, tokensOut, "foo", swapFee)
+		require.NoError(t, err)
+		constantMeanIn, err := unequalWeights.CalcInAmtGivenOut(ctx, tokensOut, "foo", swapFee)
+		require.NoError(t, err)
+		require.Equal(t, constantMeanIn, constantProductIn)
+	}
+}
+
+// FuzzSolveConstantProductInvariant checks that solveConstantProductInvariant returns
+// exactly what solveConstantFunctionInvariant returns for equal weights, errors included.
+func FuzzSolveConstantProductInvariant(f *testing.F) {
+	f.Add(int64(1_000_000), int64(1_000_001), int64(1_000_000))
+	f.Add(int64(1_000_000), int64(999_999), int64(3_000_000))
+	f.Add(int64(200), int64(100), int64(100))
+	f.Add(int64(0), int64(100), int64(100))
+	f.Add(int64(100), int64(101), int64(-1))
+
+	f.Fuzz(func(t *testing.T, xBefore, xAfter, y int64) {
+		weight := sdk.NewDec(balancer.GuaranteedWeightPrecision)
+		expectedAmountY, expectedErr := balancer.SolveConstantFunctionInvariant(sdk.NewDec(xBefore), sdk.NewDec(xAfter), weight, sdk.NewDec(y), weight)
+		amountY, err := balancer.SolveConstantProductInvariant(sdk.NewDec(xBefore), sdk.NewDec(xAfter), sdk.NewDec(y))
+		if expectedErr != nil {
+			require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedAmountY, amountY)
+	})
+}
+
+// TestSwapInvariantNeverDecreases is a property test, that randomly swaps in
+// both directions against random pools, and asserts that the pool's invariant,
+// k = balanceA^weightA * balanceB^weightB, never decreases from a swap.
+// This holds because the output amount is rounded down, and the input amount is rounded up.
+// Weights are kept small so that k can be computed exactly with integers.
+//
+// With no swap fee, pools whose weight ratio is not an integer rely on osmomath.Pow's
+// approximation, whose error (~1e-10 relative) can outweigh the rounding.
+// So zero swap fee is only tested on pools with equal weights.
+func TestSwapInvariantNeverDecreases(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	swapFees := []sdk.Dec{sdk.ZeroDec(), sdk.MustNewDecFromStr("0.001"), sdk.MustNewDecFromStr("0.003"), sdk.MustNewDecFromStr("0.1")}
+
+	invariant := func(pool types.PoolI) *big.Int {
+		k := big.NewInt(1)
+		for _, asset := range pool.(*balancer.Pool).GetAllPoolAssets() {
+			// the pool scales weights by GuaranteedWeightPrecision, undo it to keep the exponent small.
+			weight := asset.Weight.QuoRaw(balancer.GuaranteedWeightPrecision).Int64()
+			k.Mul(k, new(big.Int).Exp(asset.Token.Amount.BigInt(), big.NewInt(weight), nil))
+		}
+		return k
+	}
+
+	for i := 0; i < 1000; i++ {
+		balanceA := r.Int63n(1e12-1e6) + 1e6
+		balanceB := r.Int63n(1e12-1e6) + 1e6
+		swapFee := swapFees[r.Intn(len(swapFees))]
+		weightA, weightB := r.Int63n(4)+1, r.Int63n(4)+1
+		if swapFee.IsZero() {
+			weightB = weightA
+		}
+		pool := createTestPool(t, swapFee, sdk.ZeroDec(),
+			balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", balanceA), Weight: sdk.NewInt(weightA)},
+			balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", balanceB), Weight: sdk.NewInt(weightB)},
+		)
+		kBefore := invariant(pool)
+
+		var err error
+		if r.Intn(2) == 0 {
+			tokenIn := sdk.NewInt64Coin("foo", r.Int63n(balanceA/10)+1)
+			_, err = pool.SwapOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", swapFee)
+		} else {
+			tokenOut := sdk.NewInt64Coin("bar", r.Int63n(balanceB/10)+1)
+			_, err = pool.SwapInAmtGivenOut(sdk.Context{}, sdk.Coins{tokenOut}, "foo", swapFee)
+		}
+		// too small trades error rather than swap, which keeps k constant.
+		if err != nil {
+			require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+			continue
+		}
+
+		kAfter := invariant(pool)
+		require.True(t, kAfter.Cmp(kBefore) >= 0, "k decreased from %s to %s, pool %v", kBefore, kAfter, pool)
+	}
+}
+
+// TestCalcOutAmtGivenInMaxPowIterations tests that swaps whose fractional power
+// does not converge to osmomath.GetPowPrecision() within balancer.MaxPowIterations error,
+// rather than returning an inaccurate amount.
+func TestCalcOutAmtGivenInMaxPowIterations(t *testing.T) {
+	// At a weight ratio of 0.3, a swap that takes the in balance ratio to 0.01
+	// needs ~1k series terms to converge, and one that takes it to 0.0002 needs ~20k.
+	tests := []struct {
+		name      string
+		tokensIn  sdk.Coins
+		expectErr bool
+	}{
+		{
+			name:     "converges within bound",
+			tokensIn: sdk.NewCoins(sdk.NewInt64Coin("foo", 99_000)),
+		},
+		{
+			name:      "does not converge within bound",
+			tokensIn:  sdk.NewCoins(sdk.NewInt64Coin("foo", 5_000_000)),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(),
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000), Weight: sdk.NewInt(3)},
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000), Weight: sdk.NewInt(10)},
+			)
+
+			tokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, tc.tokensIn, "bar", sdk.ZeroDec())
+			if tc.expectErr {
+				require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tokenOut.Amount.IsPositive())
+		})
+	}
+}
+
+// TestCalcInAmtGivenOutSwapFeeBounds tests that CalcInAmtGivenOut errors for swap fees
+// outside of [0, 1), rather than dividing by (1 - swapFee) <= 0.
+func TestCalcInAmtGivenOutSwapFeeBounds(t *testing.T) {
+	pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(), oneTrillionEvenPoolAssets...)
+	tokensOut := sdk.NewCoins(sdk.NewInt64Coin("uosmo", 1_000_000))
+
+	tests := []struct {
+		swapFee     sdk.Dec
+		expectedErr error
+	}{
+		{swapFee: sdk.NewDecWithPrec(99, 2)},
+		{swapFee: sdk.OneDec(), expectedErr: types.ErrTooMuchSwapFee},
+		{swapFee: sdk.NewDecWithPrec(15, 1), expectedErr: types.ErrTooMuchSwapFee},
+		{swapFee: sdk.NewDec(2), expectedErr: types.ErrTooMuchSwapFee},
+		{swapFee: sdk.NewDecWithPrec(-1, 2), expectedErr: types.ErrNegativeSwapFee},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.swapFee.String(), func(t *testing.T) {
+			require.NotPanics(t, func() {
+				tokenIn, err := pool.CalcInAmtGivenOut(sdk.Context{}, tokensOut, "uatom", tc.swapFee)
+				if tc.expectedErr != nil {
+					require.ErrorIs(t, err, tc.expectedErr)
+					return
+				}
+				require.NoError(t, err)
+				require.True(t, tokenIn.Amount.IsPositive())
+			})
+		})
+	}
+}
+
+// TestCalcOutAmtGivenInSwapFeeBounds tests that CalcOutAmtGivenIn errors for swap fees
+// outside of [0, 1), rather than swapping a zero or negative amount of tokenIn.
+func TestCalcOutAmtGivenInSwapFeeBounds(t *testing.T) {
+	pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(), oneTrillionEvenPoolAssets...)
+	balancerPool, ok := pool.(*balancer.Pool)
+	require.True(t, ok)
+	tokenIn := sdk.NewInt64Coin("uosmo", 1_000_000)
+
+	tests := []struct {
+		swapFee     sdk.Dec
+		expectedErr error
+	}{
+		{swapFee: sdk.NewDecWithPrec(99, 2)},
+		{swapFee: sdk.OneDec(), expectedErr: types.ErrTooMuchSwapFee},
+		{swapFee: sdk.NewDecWithPrec(15, 1), expectedErr: types.ErrTooMuchSwapFee},
+		{swapFee: sdk.NewDecWithPrec(-1, 2), expectedErr: types.ErrNegativeSwapFee},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.swapFee.String(), func(t *testing.T) {
+			require.NotPanics(t, func() {
+				tokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, sdk.NewCoins(tokenIn), "uatom", tc.swapFee)
+				_, _, errWithFee := balancerPool.CalcOutAmtGivenInWithFee(sdk.Context{}, sdk.NewCoins(tokenIn), "uatom", tc.swapFee)
+				if tc.expectedErr != nil {
+					require.ErrorIs(t, err, tc.expectedErr)
+					require.ErrorIs(t, errWithFee, tc.expectedErr)
+					return
+				}
+				require.NoError(t, err)
+				require.NoError(t, errWithFee)
+				require.True(t, tokenOut.Amount.IsPositive())
+			})
+		})
+	}
+}
+
+// TestCalcTokenInShareAmountOutRoundTrip tests that the token amount in required to join
+// for an exact amount of shares out is rounded up, in the pool's favor:
+// joining with that amount gets at least the requested shares, and joining with
+// one token less gets less than the requested shares.
+// This only holds exactly for equal weights, where both directions take exact powers
+// (a square and a square root). For other weights, the forward direction's pow
+// approximation can be off by more than a token, so only approximate equality is checked.
+func TestCalcTokenInShareAmountOutRoundTrip(t *testing.T) {
+	tests := []struct {
+		name          string
+		weightIn      int64
+		weightOther   int64
+		exactRounding bool
+	}{
+		{name: "equal weights", weightIn: 50, weightOther: 50, exactRounding: true},
+		{name: "uneven weights", weightIn: 30, weightOther: 70},
+	}
+
+	for _, tc := range tests {
+		for _, swapFee := range []sdk.Dec{sdk.ZeroDec(), sdk.MustNewDecFromStr("0.003")} {
+			for _, shareOutAmount := range []sdk.Int{types.OneShare, types.InitPoolSharesSupply.QuoRaw(100), types.InitPoolSharesSupply.QuoRaw(10)} {
+				t.Run(fmt.Sprintf("%s, swapFee: %s, shareOutAmount: %s", tc.name, swapFee, shareOutAmount), func(t *testing.T) {
+					poolI := createTestPool(t, swapFee, sdk.ZeroDec(),
+						balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(tc.weightIn)},
+						balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 3_000_000_000), Weight: sdk.NewInt(tc.weightOther)},
+					)
+					pool := poolI.(*balancer.Pool)
+					poolAssetIn, err := pool.GetPoolAsset("foo")
+					require.NoError(t, err)
+					normalizedWeight := poolAssetIn.Weight.ToDec().Quo(pool.GetTotalWeight().ToDec())
+					calcSharesOut := func(tokenInAmount sdk.Int) sdk.Dec {
+						sharesOut, err := balancer.CalcPoolSharesOutGivenSingleAssetIn(
+							poolAssetIn.Token.Amount.ToDec(), normalizedWeight, pool.GetTotalShares().ToDec(), tokenInAmount.ToDec(), swapFee)
+						require.NoError(t, err)
+						return sharesOut
+					}
+
+					tokenInAmount, err := pool.CalcTokenInShareAmountOut(sdk.Context{}, "foo", shareOutAmount, swapFee)
+					require.NoError(t, err)
+
+					if !tc.exactRounding {
+						tol := osmoutils.ErrTolerance{MultiplicativeTolerance: sdk.NewDecWithPrec(1, 7)}
+						require.Equal(t, 0, tol.Compare(shareOutAmount, calcSharesOut(tokenInAmount).TruncateInt()))
+						return
+					}
+
+					sharesOut := calcSharesOut(tokenInAmount)
+					require.True(t, sharesOut.GTE(shareOutAmount.ToDec()), "shares out %s less than %s", sharesOut, shareOutAmount)
+					sharesOut = calcSharesOut(tokenInAmount.SubRaw(1))
+					require.True(t, sharesOut.LT(shareOutAmount.ToDec()), "shares out %s not less than %s", sharesOut, shareOutAmount)
+				})
+			}
+		}
+	}
+}
+
+func TestCalcTokenInShareAmountOutTooManyShares(t *testing.T) {
+	poolI := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(), oneTrillionEvenPoolAssets...)
+	pool := poolI.(*balancer.Pool)
+
+	require.NotPanics(t, func() {
+		_, err := pool.CalcTokenInShareAmountOut(sdk.Context{}, "uosmo", pool.GetTotalShares(), sdk.ZeroDec())
+		require.ErrorIs(t, err, types.ErrLimitMaxAmount)
+	})
+}
+
+// TestJoinPoolTokenInMaxShareAmountOutRounding tests that the token in of a single asset join
+// for an exact number of shares is rounded up from the v11 upgrade on, and truncated before it.
+func TestJoinPoolTokenInMaxShareAmountOutRounding(t *testing.T) {
+	swapFee := sdk.MustNewDecFromStr("0.003")
+	shareOutAmount := types.InitPoolSharesSupply.QuoRaw(100)
+	tests := []struct {
+		name   string
+		height int64
+		round  func(sdk.Dec) sdk.Int
+	}{
+		{name: "before the v11 upgrade", height: types.V11UpgradeHeight - 1, round: sdk.Dec.TruncateInt},
+		{name: "from the v11 upgrade", height: types.V11UpgradeHeight, round: func(d sdk.Dec) sdk.Int { return d.Ceil().TruncateInt() }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, swapFee, sdk.ZeroDec(),
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000_000), Weight: sdk.NewInt(30)},
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 3_000_000_000), Weight: sdk.NewInt(70)},
+			).(*balancer.Pool)
+			poolAssetIn, err := pool.GetPoolAsset("foo")
+			require.NoError(t, err)
+			tokenInAmountDec, err := balancer.CalcSingleAssetInGivenPoolSharesOut(
+				poolAssetIn.Token.Amount.ToDec(), poolAssetIn.Weight.ToDec().Quo(pool.GetTotalWeight().ToDec()),
+				pool.GetTotalShares().ToDec(), shareOutAmount.ToDec(), swapFee)
+			require.NoError(t, err)
+			require.False(t, tokenInAmountDec.TruncateDec().Equal(tokenInAmountDec), "token in %s has no fraction to round", tokenInAmountDec)
+
+			tokenInAmount, err := pool.JoinPoolTokenInMaxShareAmountOut(createTestContext(t).WithBlockHeight(tc.height), "foo", shareOutAmount)
+			require.NoError(t, err)
+			require.Equal(t, tc.round(tokenInAmountDec), tokenInAmount)
+		})
+	}
+}
+
+// TestCalcAmountInToReachSpotPrice tests that swapping the amount returned by CalcAmountInToReachSpotPrice
+// with a swap fee moves the spot price to just below the target.
+func TestCalcAmountInToReachSpotPrice(t *testing.T) {
+	tests := map[string]struct {
+		swapFee          sdk.Dec
+		fooWeight        int64
+		barWeight        int64
+		targetSpotPrice  sdk.Dec
+		expTokenInAmount sdk.Int
+		expErr           error
+	}{
+		// a = 10^12 * (4^(1/2) - 1)
+		"equal weights, quadruple the spot price": {
+			swapFee:          sdk.ZeroDec(),
+			fooWeight:        100,
+			barWeight:        100,
+			targetSpotPrice:  sdk.NewDec(4),
+			expTokenInAmount: oneTrillion,
+		},
+		"unequal weights, small move": {
+			swapFee:         sdk.ZeroDec(),
+			fooWeight:       100,
+			barWeight:       300,
+			targetSpotPrice: sdk.MustNewDecFromStr("3.03"),
+		},
+		"unequal weights, large move": {
+			swapFee:         sdk.ZeroDec(),
+			fooWeight:       300,
+			barWeight:       100,
+			targetSpotPrice: sdk.NewDec(1000),
+		},
+		"swap fee": {
+			swapFee:         sdk.MustNewDecFromStr("0.01"),
+			fooWeight:       100,
+			barWeight:       200,
+			targetSpotPrice: sdk.NewDec(10),
+		},
+		"swap fee, small move": {
+			swapFee:         sdk.MustNewDecFromStr("0.003"),
+			fooWeight:       100,
+			barWeight:       300,
+			targetSpotPrice: sdk.MustNewDecFromStr("3.03"),
+		},
+		"large swap fee, large move": {
+			swapFee:         sdk.MustNewDecFromStr("0.2"),
+			fooWeight:       300,
+			barWeight:       100,
+			targetSpotPrice: sdk.NewDec(1000),
+		},
+		"swap fee of one": {
+			swapFee:         sdk.OneDec(),
+			fooWeight:       100,
+			barWeight:       100,
+			targetSpotPrice: sdk.NewDec(4),
+			expErr:          types.ErrTooMuchSwapFee,
+		},
+		"target equal to the spot price": {
+			swapFee:         sdk.ZeroDec(),
+			fooWeight:       100,
+			barWeight:       100,
+			targetSpotPrice: sdk.OneDec(),
+			expErr:          types.ErrSpotPriceUnreachable,
+		},
+		"target below the spot price": {
+			swapFee:         sdk.ZeroDec(),
+			fooWeight:       100,
+			barWeight:       100,
+			targetSpotPrice: sdk.MustNewDecFromStr("0.5"),
+			expErr:          types.ErrSpotPriceUnreachable,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(),
+				balancer.PoolAsset{Token: sdk.NewCoin("foo", oneTrillion), Weight: sdk.NewInt(tc.fooWeight)},
+				balancer.PoolAsset{Token: sdk.NewCoin("bar", oneTrillion), Weight: sdk.NewInt(tc.barWeight)},
+			)
+			balancerPool, ok := pool.(*balancer.Pool)
+			require.True(t, ok)
+
+			var tokenInAmount sdk.Int
+			var err error
+			assertPoolStateNotModified(t, balancerPool, func() {
+				tokenInAmount, err = balancerPool.CalcAmountInToReachSpotPrice(sdk.Context{}, "foo", "bar", tc.targetSpotPrice, tc.swapFee)
+			})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			if !tc.expTokenInAmount.IsNil() {
+				require.Equal(t, tc.expTokenInAmount, tokenInAmount)
+			}
+
+			_, err = pool.SwapOutAmtGivenIn(sdk.Context{}, sdk.NewCoins(sdk.NewCoin("foo", tokenInAmount)), "bar", tc.swapFee)
+			require.NoError(t, err)
+			spotPriceAfter, err := pool.SpotPrice(sdk.Context{}, "foo", "bar")
+			require.NoError(t, err)
+
+			require.True(t, spotPriceAfter.LTE(tc.targetSpotPrice), "spot price %s passed the target %s", spotPriceAfter, tc.targetSpotPrice)
+			// with a swap fee, the spot price falls short of the target by less than the swap fee squared.
+			tolerance := sdk.NewDecWithPrec(1, 8)
+			if tc.swapFee.IsPositive() {
+				tolerance = tc.swapFee.Power(2)
+			}
+			relativeDiff := tc.targetSpotPrice.Sub(spotPriceAfter).Quo(tc.targetSpotPrice)
+			require.True(t, relativeDiff.LTE(tolerance), "spot price %s, target %s", spotPriceAfter, tc.targetSpotPrice)
+		})
+	}
+}
+
+// TestPoolSnapshotRoundTrip tests that a pool restored from its JSON snapshot is identical to it,
+// midway through a smooth weight change and after a join, and swaps and joins the same as it.
+func TestPoolSnapshotRoundTrip(t *testing.T) {
+	ctx := createTestContext(t).WithBlockHeight(4713065)
+	startTime := time.Unix(1650000000, 0).UTC()
+	assets := []balancer.PoolAsset{
+		{Token: sdk.NewInt64Coin("atom", 3_000_000), Weight: sdk.NewInt(17)},
+		{Token: sdk.NewInt64Coin("foo", 5_000_000), Weight: sdk.NewInt(23)},
+		{Token: sdk.NewInt64Coin("uosmo", 7_000_000), Weight: sdk.NewInt(31)},
+		{Token: sdk.NewInt64Coin("usdc", 11_000_000), Weight: sdk.NewInt(41)},
+	}
+	poolParams := balancer.NewPoolParams(sdk.MustNewDecFromStr("0.0037"), sdk.MustNewDecFromStr("0.0011"), &balancer.SmoothWeightChangeParams{
+		StartTime: startTime,
+		Duration:  time.Hour,
+		TargetPoolWeights: []balancer.PoolAsset{
+			{Token: sdk.NewInt64Coin("atom", 0), Weight: sdk.NewInt(43)},
+			{Token: sdk.NewInt64Coin("foo", 0), Weight: sdk.NewInt(7)},
+			{Token: sdk.NewInt64Coin("uosmo", 0), Weight: sdk.NewInt(29)},
+			{Token: sdk.NewInt64Coin("usdc", 0), Weight: sdk.NewInt(19)},
+		},
+	})
+	pool, err := balancer.NewBalancerPool(7, poolParams, assets, "uosmo,168h", startTime)
+	require.NoError(t, err)
+	pool.PokePool(startTime.Add(23 * time.Minute))
+	_, _, err = pool.JoinPool(ctx, sdk.NewCoins(sdk.NewInt64Coin("foo", 123_457)), pool.GetSwapFee(ctx))
+	require.NoError(t, err)
+
+	snapshot, err := pool.Snapshot()
+	require.NoError(t, err)
+	restored, err := balancer.NewPoolFromSnapshot(snapshot)
+	require.NoError(t, err)
+	require.Equal(t, pool, restored)
+	require.Equal(t, pool.GetAllNormalizedPoolAssets(), restored.GetAllNormalizedPoolAssets())
+
+	// the restored pool is independent of the pool, and gets the same results.
+	for _, p := range []*balancer.Pool{&pool, &restored} {
+		p.PokePool(startTime.Add(41 * time.Minute))
+	}
+	require.Equal(t, pool, restored)
+	for _, tokenIn := range pool.GetTotalPoolLiquidity(ctx) {
+		for _, tokenOut := range pool.GetTotalPoolLiquidity(ctx) {
+			if tokenIn.Denom == tokenOut.Denom {
+				continue
+			}
+			tokensIn := sdk.NewCoins(sdk.NewCoin(tokenIn.Denom, tokenIn.Amount.QuoRaw(13)))
+			expectedTokenOut, err := pool.SwapOutAmtGivenIn(ctx, tokensIn, tokenOut.Denom, pool.GetSwapFee(ctx))
+			require.NoError(t, err)
+			tokenOutOfRestored, err := restored.SwapOutAmtGivenIn(ctx, tokensIn, tokenOut.Denom, restored.GetSwapFee(ctx))
+			require.NoError(t, err)
+			require.Equal(t, expectedTokenOut, tokenOutOfRestored)
+		}
+	}
+	expectedShares, _, err := pool.JoinPool(ctx, sdk.NewCoins(sdk.NewInt64Coin("usdc", 98_765)), pool.GetSwapFee(ctx))
+	require.NoError(t, err)
+	sharesOfRestored, _, err := restored.JoinPool(ctx, sdk.NewCoins(sdk.NewInt64Coin("usdc", 98_765)), restored.GetSwapFee(ctx))
+	require.NoError(t, err)
+	require.Equal(t, expectedShares.String(), sharesOfRestored.String())
+	require.Equal(t, pool, restored)
+}
+
+func TestNewPoolFromInvalidSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(pool *balancer.Pool)
+		expectedErr error
+	}{
+		{
+			name:        "swap fee of 1",
+			modify:      func(pool *balancer.Pool) { pool.PoolParams.SwapFee = sdk.OneDec() },
+			expectedErr: types.ErrTooMuchSwapFee,
+		},
+		{
+			name:        "shares of another pool",
+			modify:      func(pool *balancer.Pool) { pool.TotalShares.Denom = types.GetPoolShareDenom(pool.Id + 1) },
+			expectedErr: types.ErrInvalidPool,
+		},
+		{
+			name:        "zero total shares",
+			modify:      func(pool *balancer.Pool) { pool.TotalShares.Amount = sdk.ZeroInt() },
+			expectedErr: types.ErrPoolHasNoShares,
+		},
+		{
+			name:        "one asset",
+			modify:      func(pool *balancer.Pool) { pool.PoolAssets = pool.PoolAssets[:1] },
+			expectedErr: types.ErrTooFewPoolAssets,
+		},
+		{
+			name: "unsorted assets",
+			modify: func(pool *balancer.Pool) {
+				pool.PoolAssets[0], pool.PoolAssets[1] = pool.PoolAssets[1], pool.PoolAssets[0]
+			},
+			expectedErr: types.ErrInvalidPoolAssets,
+		},
+		{
+			name:        "zero weight",
+			modify:      func(pool *balancer.Pool) { pool.PoolAssets[0].Weight = sdk.ZeroInt() },
+			expectedErr: types.ErrNotPositiveWeight,
+		},
+		{
+			name:        "total weight of other weights",
+			modify:      func(pool *balancer.Pool) { pool.TotalWeight = pool.TotalWeight.AddRaw(1) },
+			expectedErr: types.ErrInvalidPoolAssets,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(),
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(100)},
+				balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(100)},
+			).(*balancer.Pool)
+			snapshot, err := pool.Snapshot()
+			require.NoError(t, err)
+			_, err = balancer.NewPoolFromSnapshot(snapshot)
+			require.NoError(t, err)
+
+			tc.modify(pool)
+			snapshot, err = pool.Snapshot()
+			require.NoError(t, err)
+			_, err = balancer.NewPoolFromSnapshot(snapshot)
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+
+	pool := createTestPool(t, sdk.ZeroDec(), sdk.ZeroDec(),
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("bar", 1_000_000), Weight: sdk.NewInt(100)},
+		balancer.PoolAsset{Token: sdk.NewInt64Coin("foo", 1_000_000), Weight: sdk.NewInt(100)},
+	).(*balancer.Pool)
+	snapshot, err := pool.Snapshot()
+	require.NoError(t, err)
+	// pools with an invalid address can't be snapshotted, but a snapshot can be edited to have none.
+	_, err = balancer.NewPoolFromSnapshot(bytes.Replace(snapshot, []byte(pool.Address), nil, 1))
+	require.ErrorIs(t, err, types.ErrInvalidPool)
+	_, err = balancer.NewPoolFromSnapshot(snapshot[:len(snapshot)-1])
+	require.Error(t, err)
+}
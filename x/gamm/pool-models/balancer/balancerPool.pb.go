@@ -12,12 +12,13 @@ import (
 	fmt "fmt"
 	_ "github.com/cosmos/cosmos-proto"
 	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
-	types1 "github.com/cosmos/cosmos-sdk/types"
+	types2 "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/cosmos-sdk/x/auth/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types1 "github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -136,6 +137,9 @@ type PoolParams struct {
 	SwapFee                  github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,1,opt,name=swap_fee,json=swapFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"swap_fee" yaml:"swap_fee"`
 	ExitFee                  github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=exit_fee,json=exitFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"exit_fee" yaml:"exit_fee"`
 	SmoothWeightChangeParams *SmoothWeightChangeParams              `protobuf:"bytes,3,opt,name=smooth_weight_change_params,json=smoothWeightChangeParams,proto3" json:"smooth_weight_change_params,omitempty" yaml:"smooth_weight_change_params"`
+	// The swap fees of the pool by trader volume. Traders whose volume is
+	// below every tier pay swap_fee.
+	SwapFeeTiers *types1.SwapFeeTiers `protobuf:"bytes,4,opt,name=swap_fee_tiers,json=swapFeeTiers,proto3" json:"swap_fee_tiers,omitempty" yaml:"swap_fee_tiers"`
 }
 
 func (m *PoolParams) Reset()         { *m = PoolParams{} }
@@ -178,6 +182,13 @@ func (m *PoolParams) GetSmoothWeightChangeParams() *SmoothWeightChangeParams {
 	return nil
 }
 
+func (m *PoolParams) GetSwapFeeTiers() *types1.SwapFeeTiers {
+	if m != nil {
+		return m.SwapFeeTiers
+	}
+	return nil
+}
+
 // Pool asset is an internal struct that combines the amount of the
 // token in the pool, and its balancer weight.
 // This is an awkward packaging of data,
@@ -185,7 +196,7 @@ func (m *PoolParams) GetSmoothWeightChangeParams() *SmoothWeightChangeParams {
 type PoolAsset struct {
 	// Coins we are talking about,
 	// the denomination must be unique amongst all PoolAssets for this pool.
-	Token types1.Coin `protobuf:"bytes,1,opt,name=token,proto3" json:"token" yaml:"token"`
+	Token types2.Coin `protobuf:"bytes,1,opt,name=token,proto3" json:"token" yaml:"token"`
 	// Weight that is not normalized. This weight must be less than 2^50
 	Weight github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,2,opt,name=weight,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"weight" yaml:"weight"`
 }
@@ -223,11 +234,11 @@ func (m *PoolAsset) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PoolAsset proto.InternalMessageInfo
 
-func (m *PoolAsset) GetToken() types1.Coin {
+func (m *PoolAsset) GetToken() types2.Coin {
 	if m != nil {
 		return m.Token
 	}
-	return types1.Coin{}
+	return types2.Coin{}
 }
 
 type Pool struct {
@@ -245,7 +256,7 @@ type Pool struct {
 	// TODO: Further improve these docs
 	FuturePoolGovernor string `protobuf:"bytes,4,opt,name=future_pool_governor,json=futurePoolGovernor,proto3" json:"future_pool_governor,omitempty" yaml:"future_pool_governor"`
 	// sum of all LP tokens sent out
-	TotalShares types1.Coin `protobuf:"bytes,5,opt,name=total_shares,json=totalShares,proto3" json:"total_shares" yaml:"total_shares"`
+	TotalShares types2.Coin `protobuf:"bytes,5,opt,name=total_shares,json=totalShares,proto3" json:"total_shares" yaml:"total_shares"`
 	// These are assumed to be sorted by denomiation.
 	// They contain the pool asset and the information about the weight
 	PoolAssets []PoolAsset `protobuf:"bytes,6,rep,name=pool_assets,json=poolAssets,proto3" json:"pool_assets" yaml:"pool_assets"`
@@ -297,59 +308,62 @@ func init() {
 }
 
 var fileDescriptor_7e991f749f68c2a4 = []byte{
-	// 831 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4f, 0x6f, 0xeb, 0x44,
-	0x10, 0x8f, 0x93, 0xb4, 0x79, 0xdd, 0x3c, 0x1e, 0xea, 0xbe, 0x1c, 0xdc, 0x54, 0xc4, 0xd5, 0x22,
-	0xa1, 0x27, 0xf4, 0x62, 0xab, 0x0f, 0x24, 0xa4, 0x1e, 0x40, 0x4d, 0x5b, 0x50, 0x6f, 0xc5, 0x45,
-	0x6a, 0x41, 0x95, 0xac, 0x4d, 0xb2, 0xb1, 0xad, 0xda, 0x5e, 0xcb, 0xbb, 0x49, 0xdb, 0x6f, 0xc0,
-	0xb1, 0xc7, 0x72, 0xeb, 0x9d, 0x2b, 0x7c, 0x87, 0x0a, 0x2e, 0x3d, 0x22, 0x0e, 0x06, 0xb5, 0x9c,
-	0x38, 0xe6, 0x13, 0xa0, 0xfd, 0xe3, 0x24, 0x2d, 0x89, 0x68, 0xc5, 0x29, 0x3b, 0xb3, 0x33, 0xbf,
-	0xf9, 0xcd, 0xcc, 0x6f, 0x1d, 0xf0, 0x29, 0x65, 0x31, 0x65, 0x21, 0x73, 0x7c, 0x1c, 0xc7, 0x4e,
-	0x4a, 0x69, 0xd4, 0x8e, 0x69, 0x9f, 0x44, 0xcc, 0xe9, 0xe2, 0x08, 0x27, 0x3d, 0x92, 0x4d, 0x0e,
-	0x07, 0x94, 0x46, 0x76, 0x9a, 0x51, 0x4e, 0x61, 0x43, 0x67, 0xd9, 0x22, 0xcb, 0x1e, 0x6d, 0x76,
-	0x09, 0xc7, 0x9b, 0xcd, 0xb5, 0x9e, 0x74, 0x7b, 0x32, 0xc6, 0x51, 0x86, 0x4a, 0x68, 0x36, 0x7c,
-	0xea, 0x53, 0xe5, 0x17, 0x27, 0xed, 0x6d, 0xf9, 0x94, 0xfa, 0x11, 0x71, 0xa4, 0xd5, 0x1d, 0x0e,
-	0x9c, 0xfe, 0x30, 0xc3, 0x3c, 0xa4, 0x89, 0xbe, 0xb7, 0x1e, 0xdf, 0xf3, 0x30, 0x26, 0x8c, 0xe3,
-	0x38, 0x2d, 0x00, 0x54, 0x11, 0x07, 0x0f, 0x79, 0xe0, 0x68, 0x1a, 0xd2, 0x78, 0x74, 0xdf, 0xc5,
-	0x8c, 0x4c, 0xee, 0x7b, 0x34, 0xd4, 0x05, 0xd0, 0xaf, 0x15, 0x60, 0x1e, 0xc6, 0x94, 0xf2, 0xe0,
-	0x88, 0x84, 0x7e, 0xc0, 0x77, 0x02, 0x9c, 0xf8, 0xe4, 0x00, 0x67, 0x38, 0x66, 0xf0, 0x18, 0x00,
-	0xc6, 0x71, 0xc6, 0x3d, 0x51, 0xd5, 0x34, 0x36, 0x8c, 0x37, 0xf5, 0x77, 0x4d, 0x5b, 0x51, 0xb2,
-	0x0b, 0x4a, 0xf6, 0x37, 0x05, 0xa5, 0xce, 0x07, 0x37, 0xb9, 0x55, 0x1a, 0xe7, 0xd6, 0xea, 0x05,
-	0x8e, 0xa3, 0x2d, 0x34, 0xcd, 0x45, 0x97, 0x7f, 0x58, 0x86, 0xbb, 0x22, 0x1d, 0x22, 0x1c, 0x06,
-	0xe0, 0x45, 0xd1, 0xa9, 0x59, 0x96, 0xb8, 0x6b, 0xff, 0xc2, 0xdd, 0xd5, 0x01, 0x9d, 0x4d, 0x01,
-	0xfb, 0x77, 0x6e, 0xc1, 0x22, 0xe5, 0x2d, 0x8d, 0x43, 0x4e, 0xe2, 0x94, 0x5f, 0x8c, 0x73, 0xeb,
-	0x7d, 0x55, 0xac, 0xb8, 0x43, 0x57, 0xa2, 0xd4, 0x04, 0x1d, 0x8e, 0x40, 0x23, 0x4c, 0x42, 0x1e,
-	0xe2, 0xc8, 0x13, 0xbb, 0xf5, 0xce, 0x64, 0x9b, 0xcc, 0xac, 0x6c, 0x54, 0xde, 0xd4, 0xdf, 0x59,
-	0xf6, 0xbc, 0x3d, 0xda, 0x62, 0xd1, 0xdb, 0x8c, 0x11, 0xde, 0xf9, 0x50, 0xb7, 0xb4, 0xae, 0xaa,
-	0xcc, 0x83, 0x42, 0x2e, 0xd4, 0x6e, 0x91, 0xa6, 0xc6, 0xc8, 0x20, 0x03, 0xaf, 0x39, 0xce, 0x7c,
-	0xc2, 0x1f, 0x96, 0xad, 0x3e, 0xad, 0x2c, 0xd2, 0x65, 0x9b, 0xaa, 0xec, 0x1c, 0x24, 0xe4, 0xae,
-	0x2a, 0xef, 0x4c, 0x51, 0xf4, 0x57, 0x19, 0x00, 0x61, 0xeb, 0xfd, 0x9d, 0x80, 0x17, 0xec, 0x0c,
-	0xa7, 0xde, 0x80, 0xa8, 0xed, 0xad, 0x74, 0xb6, 0x05, 0xee, 0xef, 0xb9, 0xf5, 0x91, 0x1f, 0xf2,
-	0x60, 0xd8, 0xb5, 0x7b, 0x34, 0xd6, 0x32, 0xd5, 0x3f, 0x6d, 0xd6, 0x3f, 0x75, 0xf8, 0x45, 0x4a,
-	0x98, 0xbd, 0x4b, 0x7a, 0xd3, 0xf1, 0x16, 0x38, 0xc8, 0xad, 0x89, 0xe3, 0x97, 0x84, 0x08, 0x74,
-	0x72, 0x1e, 0x72, 0x89, 0x5e, 0xfe, 0x7f, 0xe8, 0x05, 0x0e, 0x72, 0x6b, 0xe2, 0x28, 0xd0, 0x7f,
-	0x30, 0xc0, 0x3a, 0x93, 0xc2, 0xd4, 0x1d, 0x7b, 0x3d, 0x29, 0x4d, 0x2f, 0x95, 0xbd, 0x99, 0x15,
-	0xa9, 0x1a, 0x7b, 0xfe, 0x20, 0x17, 0x29, 0xba, 0xf3, 0xf1, 0x4d, 0x6e, 0x19, 0xe3, 0xdc, 0x42,
-	0xba, 0xab, 0xc5, 0x05, 0x90, 0x6b, 0xb2, 0x05, 0x28, 0xe8, 0x47, 0x03, 0xac, 0x4c, 0x76, 0x05,
-	0xf7, 0xc0, 0x12, 0xa7, 0xa7, 0x24, 0xd1, 0x0f, 0x64, 0xcd, 0xd6, 0xef, 0x5e, 0x3c, 0xb9, 0x09,
-	0xa3, 0x1d, 0x1a, 0x26, 0x9d, 0x86, 0xde, 0xea, 0x4b, 0xbd, 0x55, 0x91, 0x85, 0x5c, 0x95, 0x0d,
-	0x8f, 0xc0, 0xb2, 0xe2, 0xa1, 0x87, 0xf9, 0xc5, 0x33, 0x86, 0xb9, 0x9f, 0xf0, 0x71, 0x6e, 0xbd,
-	0xa7, 0x60, 0x15, 0x0a, 0x72, 0x35, 0x1c, 0xfa, 0xb9, 0x0a, 0xaa, 0x82, 0x2d, 0x7c, 0x0b, 0x6a,
-	0xb8, 0xdf, 0xcf, 0x08, 0x63, 0x5a, 0x0d, 0x70, 0x9c, 0x5b, 0xaf, 0x54, 0x92, 0xbe, 0x40, 0x6e,
-	0x11, 0x02, 0x5f, 0x81, 0x72, 0xd8, 0x97, 0x5c, 0xaa, 0x6e, 0x39, 0xec, 0xc3, 0x01, 0xa8, 0x4b,
-	0xfd, 0x3d, 0x98, 0xff, 0xc6, 0x62, 0x21, 0xeb, 0x89, 0x3f, 0x7a, 0x40, 0xc5, 0xa7, 0xd4, 0x9b,
-	0xc1, 0x42, 0x2e, 0x48, 0xa7, 0xa2, 0xfd, 0x1a, 0x34, 0x06, 0x43, 0x3e, 0xcc, 0x88, 0x0a, 0xf1,
-	0xe9, 0x88, 0x64, 0x09, 0xcd, 0xcc, 0xaa, 0xa4, 0x6c, 0x4d, 0xa1, 0xe6, 0x45, 0x21, 0x17, 0x2a,
-	0xb7, 0x60, 0xf0, 0x95, 0x76, 0xc2, 0x6f, 0xc1, 0x4b, 0x4e, 0x39, 0x8e, 0x3c, 0x16, 0xe0, 0x8c,
-	0x30, 0x73, 0xe9, 0xbf, 0x16, 0xb5, 0xae, 0x49, 0xbf, 0x2e, 0x16, 0x35, 0x4d, 0x46, 0x6e, 0x5d,
-	0x9a, 0x87, 0xd2, 0x82, 0x27, 0x7a, 0x2a, 0x58, 0x48, 0x81, 0x99, 0xcb, 0x4f, 0x7b, 0xde, 0x4d,
-	0x8d, 0x0f, 0x15, 0xfe, 0x0c, 0x82, 0x9e, 0x85, 0x0c, 0x63, 0x30, 0x28, 0x88, 0x6b, 0x65, 0xd4,
-	0xe4, 0x0c, 0xf6, 0x9e, 0xad, 0x8c, 0x07, 0x7d, 0x14, 0xfa, 0x50, 0x7d, 0x28, 0x79, 0x6f, 0xad,
-	0x7e, 0x7f, 0x6d, 0x95, 0xae, 0xae, 0xad, 0xd2, 0x2f, 0x3f, 0xb5, 0x97, 0x04, 0xd1, 0xfd, 0xce,
-	0xf1, 0xcd, 0x5d, 0xcb, 0xb8, 0xbd, 0x6b, 0x19, 0x7f, 0xde, 0xb5, 0x8c, 0xcb, 0xfb, 0x56, 0xe9,
-	0xf6, 0xbe, 0x55, 0xfa, 0xed, 0xbe, 0x55, 0xfa, 0xee, 0xf3, 0x99, 0xc2, 0xba, 0xd3, 0x76, 0x84,
-	0xbb, 0xac, 0x30, 0x9c, 0xd1, 0x67, 0xce, 0xf9, 0xe2, 0xff, 0xd3, 0xee, 0xb2, 0xfc, 0xc6, 0x7f,
-	0xf2, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x54, 0xdd, 0x49, 0x11, 0x7b, 0x07, 0x00, 0x00,
+	// 875 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4f, 0x8b, 0xdb, 0x46,
+	0x14, 0xb7, 0xd6, 0xde, 0x75, 0x3c, 0xde, 0x6e, 0xd9, 0x89, 0x0b, 0x5a, 0x2f, 0xb1, 0x96, 0x29,
+	0x94, 0xa5, 0xc4, 0x12, 0x9b, 0x16, 0x0a, 0x39, 0xb4, 0x44, 0x49, 0x5a, 0x72, 0x4b, 0x95, 0x40,
+	0xd2, 0x12, 0x10, 0x63, 0x7b, 0x2c, 0x89, 0x48, 0x1a, 0xa1, 0x19, 0x3b, 0xd9, 0x6f, 0xd0, 0x63,
+	0x8e, 0xe9, 0x2d, 0xf7, 0x5e, 0xdb, 0xef, 0xb0, 0xb4, 0x14, 0x72, 0x2c, 0x3d, 0xa8, 0x65, 0xf7,
+	0xd6, 0xa3, 0x3f, 0x41, 0x99, 0x7f, 0xfe, 0xb3, 0xb5, 0x69, 0x96, 0x9c, 0xac, 0x79, 0xf3, 0xde,
+	0xef, 0xfd, 0xde, 0x7b, 0xbf, 0x37, 0x06, 0x9f, 0x53, 0x96, 0x51, 0x96, 0x30, 0x2f, 0xc2, 0x59,
+	0xe6, 0x15, 0x94, 0xa6, 0xfd, 0x8c, 0x8e, 0x48, 0xca, 0xbc, 0x01, 0x4e, 0x71, 0x3e, 0x24, 0xe5,
+	0xfc, 0xe3, 0x21, 0xa5, 0xa9, 0x5b, 0x94, 0x94, 0x53, 0xd8, 0xd1, 0x51, 0xae, 0x88, 0x72, 0xa7,
+	0x27, 0x03, 0xc2, 0xf1, 0x49, 0xf7, 0x60, 0x28, 0xcd, 0xa1, 0xf4, 0xf1, 0xd4, 0x41, 0x05, 0x74,
+	0x3b, 0x11, 0x8d, 0xa8, 0xb2, 0x8b, 0x2f, 0x6d, 0xed, 0x45, 0x94, 0x46, 0x29, 0xf1, 0xe4, 0x69,
+	0x30, 0x19, 0x7b, 0xa3, 0x49, 0x89, 0x79, 0x42, 0x73, 0x7d, 0xef, 0x5c, 0xbe, 0xe7, 0x49, 0x46,
+	0x18, 0xc7, 0x59, 0x61, 0x00, 0x54, 0x12, 0x0f, 0x4f, 0x78, 0xec, 0x69, 0x1a, 0xf2, 0x70, 0xe9,
+	0x7e, 0x80, 0x19, 0x99, 0xdf, 0x0f, 0x69, 0x62, 0x12, 0x1c, 0xaf, 0x54, 0x6f, 0x1c, 0xd8, 0x0b,
+	0x5c, 0x84, 0x63, 0x42, 0x42, 0x9e, 0x90, 0x52, 0x79, 0xa2, 0xdf, 0xea, 0xc0, 0x7e, 0x94, 0x51,
+	0xca, 0xe3, 0x27, 0x24, 0x89, 0x62, 0x7e, 0x37, 0xc6, 0x79, 0x44, 0x1e, 0xe2, 0x12, 0x67, 0x0c,
+	0x3e, 0x05, 0x80, 0x71, 0x5c, 0xf2, 0x50, 0xf0, 0xb3, 0xad, 0x23, 0xeb, 0xb8, 0x7d, 0xab, 0xeb,
+	0x2a, 0xf2, 0xae, 0x21, 0xef, 0x3e, 0x36, 0xe4, 0xfd, 0x1b, 0x67, 0x95, 0x53, 0x9b, 0x55, 0xce,
+	0xfe, 0x29, 0xce, 0xd2, 0xdb, 0x68, 0x11, 0x8b, 0x5e, 0xfd, 0xe5, 0x58, 0x41, 0x4b, 0x1a, 0x84,
+	0x3b, 0x8c, 0xc1, 0x35, 0xd3, 0x13, 0x7b, 0x4b, 0xe2, 0x1e, 0xfc, 0x07, 0xf7, 0x9e, 0x76, 0xf0,
+	0x4f, 0x04, 0xec, 0x3f, 0x95, 0x03, 0x4d, 0xc8, 0x4d, 0x9a, 0x25, 0x9c, 0x64, 0x05, 0x3f, 0x9d,
+	0x55, 0xce, 0x87, 0x2a, 0x99, 0xb9, 0x43, 0xaf, 0x45, 0xaa, 0x39, 0x3a, 0x9c, 0x82, 0x4e, 0x92,
+	0x27, 0x3c, 0xc1, 0x69, 0x28, 0x54, 0x10, 0xbe, 0x90, 0x65, 0x32, 0xbb, 0x7e, 0x54, 0x3f, 0x6e,
+	0xdf, 0x72, 0xdc, 0x75, 0x13, 0x77, 0x85, 0x24, 0xee, 0x30, 0x46, 0xb8, 0xff, 0xb1, 0x2e, 0xe9,
+	0x50, 0x65, 0x59, 0x07, 0x85, 0x02, 0xa8, 0xcd, 0x22, 0x4c, 0xb5, 0x91, 0x41, 0x06, 0xae, 0x73,
+	0x5c, 0x46, 0x84, 0xaf, 0xa6, 0x6d, 0xbc, 0x5b, 0x5a, 0xa4, 0xd3, 0x76, 0x55, 0xda, 0x35, 0x48,
+	0x28, 0xd8, 0x57, 0xd6, 0xa5, 0xa4, 0xe8, 0xf7, 0x3a, 0x00, 0xe2, 0xac, 0xe7, 0xf7, 0x0c, 0x5c,
+	0x33, 0x33, 0x97, 0xd3, 0x6b, 0xf9, 0x77, 0x04, 0xee, 0x9f, 0x95, 0xf3, 0x49, 0x94, 0xf0, 0x78,
+	0x32, 0x70, 0x87, 0x34, 0xd3, 0x82, 0xd6, 0x3f, 0x7d, 0x36, 0x7a, 0xee, 0xf1, 0xd3, 0x82, 0x30,
+	0xf7, 0x1e, 0x19, 0x2e, 0xda, 0x6b, 0x70, 0x50, 0xd0, 0x14, 0x9f, 0x5f, 0x13, 0x22, 0xd0, 0xc9,
+	0xcb, 0x84, 0x4b, 0xf4, 0xad, 0xf7, 0x43, 0x37, 0x38, 0x28, 0x68, 0x8a, 0x4f, 0x81, 0xfe, 0xa3,
+	0x05, 0x0e, 0x99, 0x14, 0xa6, 0xae, 0x38, 0x1c, 0x4a, 0x69, 0x86, 0x85, 0xac, 0xcd, 0xae, 0x4b,
+	0xd5, 0xb8, 0xeb, 0x1b, 0xb9, 0x49, 0xd1, 0xfe, 0xa7, 0x67, 0x95, 0x63, 0xcd, 0x2a, 0x07, 0xe9,
+	0xaa, 0x36, 0x27, 0x40, 0x81, 0xcd, 0x36, 0xed, 0x45, 0x04, 0xf6, 0x56, 0x76, 0x49, 0x8c, 0x55,
+	0xb0, 0x41, 0x1b, 0xd8, 0xa8, 0x86, 0x3d, 0x16, 0x9e, 0xfe, 0x0d, 0xcd, 0xe0, 0xa3, 0xd5, 0xbe,
+	0x2a, 0x1c, 0x14, 0xec, 0xb2, 0x25, 0x67, 0xf4, 0x93, 0x05, 0x5a, 0x73, 0x51, 0xc0, 0xfb, 0x60,
+	0x9b, 0xd3, 0xe7, 0x24, 0xd7, 0x9b, 0x78, 0xe0, 0xea, 0xa7, 0x48, 0xbc, 0x02, 0xf3, 0x64, 0x77,
+	0x69, 0x92, 0xfb, 0x1d, 0x2d, 0x9f, 0x5d, 0x2d, 0x1f, 0x11, 0x85, 0x02, 0x15, 0x0d, 0x9f, 0x80,
+	0x1d, 0x55, 0xb0, 0x9e, 0xda, 0x57, 0x57, 0x98, 0xda, 0x83, 0x9c, 0xcf, 0x2a, 0xe7, 0x03, 0x05,
+	0xab, 0x50, 0x50, 0xa0, 0xe1, 0xd0, 0x2f, 0x0d, 0xd0, 0x10, 0x6c, 0xe1, 0x4d, 0xd0, 0xc4, 0xa3,
+	0x51, 0x49, 0x18, 0xd3, 0xb2, 0x83, 0xb3, 0xca, 0xd9, 0x53, 0x41, 0xfa, 0x02, 0x05, 0xc6, 0x05,
+	0xee, 0x81, 0xad, 0x64, 0x24, 0xb9, 0x34, 0x82, 0xad, 0x64, 0x04, 0xc7, 0xa0, 0x2d, 0x85, 0xbe,
+	0x32, 0xe8, 0xa3, 0xcd, 0x1b, 0xa3, 0x47, 0x7b, 0x69, 0x53, 0xcd, 0xeb, 0x1e, 0x2e, 0x61, 0xa1,
+	0x00, 0x14, 0x8b, 0xed, 0xf8, 0x16, 0x74, 0xc6, 0x13, 0x3e, 0x29, 0x89, 0x72, 0x89, 0xe8, 0x94,
+	0x94, 0x39, 0x2d, 0xe5, 0x2c, 0x5b, 0xbe, 0xb3, 0x80, 0x5a, 0xe7, 0x85, 0x02, 0xa8, 0xcc, 0x82,
+	0xc1, 0x37, 0xda, 0x08, 0xbf, 0x03, 0xbb, 0x9c, 0x72, 0x9c, 0x86, 0x2c, 0xc6, 0x25, 0x61, 0xf6,
+	0xf6, 0xff, 0x0d, 0xea, 0x50, 0x93, 0xbe, 0x6e, 0x06, 0xb5, 0x08, 0x46, 0x41, 0x5b, 0x1e, 0x1f,
+	0xc9, 0x13, 0x7c, 0xa6, 0xbb, 0x82, 0x85, 0x14, 0x98, 0xbd, 0xf3, 0x6e, 0xef, 0x48, 0x57, 0xe3,
+	0x43, 0x85, 0xbf, 0x84, 0xa0, 0x7b, 0x21, 0xdd, 0x18, 0x8c, 0x0d, 0x71, 0xad, 0x8c, 0xa6, 0xec,
+	0xc1, 0xfd, 0x2b, 0x2b, 0x63, 0xa5, 0x0e, 0xa3, 0x0f, 0x55, 0x87, 0xda, 0xa3, 0xdb, 0xfb, 0x3f,
+	0xbc, 0x71, 0x6a, 0xaf, 0xdf, 0x38, 0xb5, 0x5f, 0x7f, 0xee, 0x6f, 0x0b, 0xa2, 0x0f, 0xfc, 0xa7,
+	0x67, 0xe7, 0x3d, 0xeb, 0xed, 0x79, 0xcf, 0xfa, 0xfb, 0xbc, 0x67, 0xbd, 0xba, 0xe8, 0xd5, 0xde,
+	0x5e, 0xf4, 0x6a, 0x7f, 0x5c, 0xf4, 0x6a, 0xdf, 0x7f, 0xb9, 0x94, 0x58, 0x57, 0xda, 0x4f, 0xf1,
+	0x80, 0x99, 0x83, 0x37, 0xfd, 0xc2, 0x7b, 0xb9, 0xf9, 0x2f, 0x7e, 0xb0, 0x23, 0xff, 0x4c, 0x3e,
+	0xfb, 0x77, 0x00, 0xf8, 0x3a, 0xf1, 0x93, 0x0e, 0x08, 0x00, 0x00,
 }
 
 func (m *SmoothWeightChangeParams) Marshal() (dAtA []byte, err error) {
@@ -439,6 +453,18 @@ func (m *PoolParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SwapFeeTiers != nil {
+		{
+			size, err := m.SwapFeeTiers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBalancerPool(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.SmoothWeightChangeParams != nil {
 		{
 			size, err := m.SmoothWeightChangeParams.MarshalToSizedBuffer(dAtA[:i])
@@ -653,6 +679,10 @@ func (m *PoolParams) Size() (n int) {
 		l = m.SmoothWeightChangeParams.Size()
 		n += 1 + l + sovBalancerPool(uint64(l))
 	}
+	if m.SwapFeeTiers != nil {
+		l = m.SwapFeeTiers.Size()
+		n += 1 + l + sovBalancerPool(uint64(l))
+	}
 	return n
 }
 
@@ -1024,6 +1054,42 @@ func (m *PoolParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapFeeTiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBalancerPool
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBalancerPool
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBalancerPool
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SwapFeeTiers == nil {
+				m.SwapFeeTiers = &types1.SwapFeeTiers{}
+			}
+			if err := m.SwapFeeTiers.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBalancerPool(dAtA[iNdEx:])
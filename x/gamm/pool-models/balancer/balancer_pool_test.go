@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
 var (
@@ -260,6 +262,81 @@ func TestBalancerPoolAssetsWeightAndTokenBalance(t *testing.T) {
 	}
 }
 
+func TestValidatePoolAssets(t *testing.T) {
+	assets := []PoolAsset{
+		{Weight: sdk.NewInt(100), Token: sdk.NewCoin("test1", sdk.NewInt(50000))},
+		{Weight: sdk.NewInt(200), Token: sdk.NewCoin("test2", sdk.NewInt(50000))},
+	}
+	pool, err := NewBalancerPool(defaultPoolId, defaultBalancerPoolParams, assets, defaultFutureGovernor, defaultCurBlockTime)
+	require.NoError(t, err)
+	require.NoError(t, pool.ValidatePoolAssets())
+
+	// a pool whose asset has zero weight is rejected, even though its total weight is consistent.
+	zeroWeightPool := pool
+	zeroWeightPool.PoolAssets = []PoolAsset{pool.PoolAssets[0], {Weight: sdk.ZeroInt(), Token: pool.PoolAssets[1].Token}}
+	zeroWeightPool.TotalWeight = pool.PoolAssets[0].Weight
+	require.ErrorIs(t, zeroWeightPool.ValidatePoolAssets(), types.ErrNotPositiveWeight)
+
+	// a pool whose total weight is not the sum of its asset weights is rejected.
+	inconsistentPool := pool
+	inconsistentPool.TotalWeight = pool.TotalWeight.AddRaw(1)
+	require.ErrorIs(t, inconsistentPool.ValidatePoolAssets(), types.ErrInvalidPoolAssets)
+
+	// a zero weight is rejected at pool creation.
+	assets[1].Weight = sdk.ZeroInt()
+	_, err = NewBalancerPool(defaultPoolId, defaultBalancerPoolParams, assets, defaultFutureGovernor, defaultCurBlockTime)
+	require.Error(t, err)
+}
+
+// TestPoolAssetsCanonicalOrder tests that a pool's assets are stored and iterated sorted by denom,
+// whatever order they were given in, so that exits produce identical coins on every node.
+func TestPoolAssetsCanonicalOrder(t *testing.T) {
+	denoms := []string{"uosmo", "uatom", "foo", "bar", "baz", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2", "qux", "aaa"}
+	assets := make([]PoolAsset, len(denoms))
+	for i, denom := range denoms {
+		assets[i] = PoolAsset{Token: sdk.NewInt64Coin(denom, int64(1_000_000*(i+1))), Weight: sdk.NewInt(int64(10 * (i + 1)))}
+	}
+	reversedAssets := make([]PoolAsset, len(assets))
+	for i, asset := range assets {
+		reversedAssets[len(assets)-1-i] = asset
+	}
+
+	pool, err := NewBalancerPool(defaultPoolId, defaultBalancerPoolParams, assets, defaultFutureGovernor, defaultCurBlockTime)
+	require.NoError(t, err)
+	reversedPool, err := NewBalancerPool(defaultPoolId, defaultBalancerPoolParams, reversedAssets, defaultFutureGovernor, defaultCurBlockTime)
+	require.NoError(t, err)
+	require.Equal(t, pool.PoolAssets, reversedPool.PoolAssets)
+	for i := 1; i < len(pool.PoolAssets); i++ {
+		require.Less(t, pool.PoolAssets[i-1].Token.Denom, pool.PoolAssets[i].Token.Denom)
+	}
+
+	liquidity := pool.GetTotalPoolLiquidity(sdk.Context{})
+	require.NoError(t, liquidity.Validate())
+	require.Len(t, liquidity, len(denoms))
+
+	firstExitCoins, err := pool.CalcExitPoolShares(sdk.Context{}, types.OneShare.MulRaw(10), defaultExitFee)
+	require.NoError(t, err)
+	require.NoError(t, firstExitCoins.Validate())
+	for i := 0; i < 10; i++ {
+		exitCoins, err := pool.CalcExitPoolShares(sdk.Context{}, types.OneShare.MulRaw(10), defaultExitFee)
+		require.NoError(t, err)
+		require.Equal(t, firstExitCoins, exitCoins)
+		reversedExitCoins, err := reversedPool.CalcExitPoolShares(sdk.Context{}, types.OneShare.MulRaw(10), defaultExitFee)
+		require.NoError(t, err)
+		require.Equal(t, firstExitCoins, reversedExitCoins)
+	}
+
+	// a pool whose assets aren't sorted by denom is rejected.
+	unsortedPool := pool
+	unsortedPool.PoolAssets = SortPoolAssetsOutOfPlaceByDenom(pool.PoolAssets)
+	unsortedPool.PoolAssets[0], unsortedPool.PoolAssets[1] = unsortedPool.PoolAssets[1], unsortedPool.PoolAssets[0]
+	require.ErrorIs(t, unsortedPool.ValidatePoolAssets(), types.ErrInvalidPoolAssets)
+	duplicatePool := pool
+	duplicatePool.PoolAssets = SortPoolAssetsOutOfPlaceByDenom(pool.PoolAssets)
+	duplicatePool.PoolAssets[1].Token.Denom = duplicatePool.PoolAssets[0].Token.Denom
+	require.ErrorIs(t, duplicatePool.ValidatePoolAssets(), types.ErrInvalidPoolAssets)
+}
+
 // TODO: Figure out what parts of this test, if any, make sense.
 func TestGetBalancerPoolAssets(t *testing.T) {
 	// Adds []PoolAssets, one after another
@@ -5,6 +5,8 @@ import sdk "github.com/cosmos/cosmos-sdk/types"
 const (
 	ErrMsgFormatRepeatingPoolAssetsNotAllowed = errMsgFormatRepeatingPoolAssetsNotAllowed
 	ErrMsgFormatNoPoolAssetFound              = errMsgFormatNoPoolAssetFound
+
+	MaxPowIterations = maxPowIterations
 )
 
 var (
@@ -12,9 +14,15 @@ var (
 
 	CalcPoolSharesOutGivenSingleAssetIn   = calcPoolSharesOutGivenSingleAssetIn
 	CalcSingleAssetInGivenPoolSharesOut   = calcSingleAssetInGivenPoolSharesOut
+	CalcPoolSharesInGivenSingleAssetOut   = calcPoolSharesInGivenSingleAssetOut
 	UpdateIntermediaryPoolAssetsLiquidity = updateIntermediaryPoolAssetsLiquidity
 
 	GetPoolAssetsByDenom = getPoolAssetsByDenom
+
+	SolveConstantFunctionInvariant = solveConstantFunctionInvariant
+	SolveConstantProductInvariant  = solveConstantProductInvariant
+
+	PowAtLeastOne = powAtLeastOne
 )
 
 func (p *Pool) CalcSingleAssetJoin(tokenIn sdk.Coin, swapFee sdk.Dec, tokenInPoolAsset PoolAsset, totalShares sdk.Int) (numShares sdk.Int, err error) {
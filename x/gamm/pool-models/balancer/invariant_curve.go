@@ -0,0 +1,93 @@
+package balancer
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// InvariantCurve is the invariant a balancer pool swaps against.
+type InvariantCurve int
+
+const (
+	// ConstantMeanCurve is the weighted constant mean invariant, prod(balance_i ^ weight_i) = k.
+	ConstantMeanCurve InvariantCurve = iota
+	// ConstantProductCurve is the constant product invariant, prod(balance_i) = k.
+	// It is the constant mean invariant of a pool whose assets all have the same weight.
+	ConstantProductCurve
+)
+
+// String implements fmt.Stringer.
+func (c InvariantCurve) String() string {
+	switch c {
+	case ConstantProductCurve:
+		return "constant-product"
+	default:
+		return "constant-mean"
+	}
+}
+
+// InvariantCurve returns the invariant the pool swaps against, given its current weights.
+// Pools whose assets all have the same weight swap against the constant product invariant,
+// which solves swaps without approximating a fractional power.
+// The weights of a pool can change over time, and with them its curve.
+func (p Pool) InvariantCurve() InvariantCurve {
+	for _, asset := range p.PoolAssets {
+		if !asset.Weight.Equal(p.PoolAssets[0].Weight) {
+			return ConstantMeanCurve
+		}
+	}
+	return ConstantProductCurve
+}
+
+// solveSwapInvariant solves the pool's invariant for the balance change of the unknown asset of a swap,
+// with the same semantics as solveConstantFunctionInvariant.
+func (p Pool) solveSwapInvariant(
+	tokenBalanceFixedBefore,
+	tokenBalanceFixedAfter,
+	tokenWeightFixed,
+	tokenBalanceUnknownBefore,
+	tokenWeightUnknown sdk.Dec,
+) (sdk.Dec, error) {
+	if p.InvariantCurve() == ConstantProductCurve {
+		return solveConstantProductInvariant(tokenBalanceFixedBefore, tokenBalanceFixedAfter, tokenBalanceUnknownBefore)
+	}
+	return solveConstantFunctionInvariant(
+		tokenBalanceFixedBefore, tokenBalanceFixedAfter, tokenWeightFixed, tokenBalanceUnknownBefore, tokenWeightUnknown)
+}
+
+// solveConstantProductInvariant is solveConstantFunctionInvariant for equal weights:
+// balanceYDelta = balanceY * (1 - balanceXBefore/balanceXAfter)
+//
+// It returns exactly what solveConstantFunctionInvariant returns for a weight ratio of one,
+// including its errors for inputs outside of the domain of the power approximation,
+// so that a pool's swaps don't change with the curve it is solved on.
+func solveConstantProductInvariant(
+	tokenBalanceFixedBefore,
+	tokenBalanceFixedAfter,
+	tokenBalanceUnknownBefore sdk.Dec,
+) (amountY sdk.Dec, err error) {
+	if !tokenBalanceFixedBefore.IsPositive() || !tokenBalanceFixedAfter.IsPositive() || !tokenBalanceUnknownBefore.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "balances must be positive, got %s, %s and %s",
+			tokenBalanceFixedBefore, tokenBalanceFixedAfter, tokenBalanceUnknownBefore)
+	}
+	if tokenBalanceFixedBefore.Sub(tokenBalanceFixedAfter).GTE(tokenBalanceFixedAfter) {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "balance before %s must be less than twice the balance after %s",
+			tokenBalanceFixedBefore, tokenBalanceFixedAfter)
+	}
+
+	// sdk.Dec panics on overflow, which is possible for an extremely large balanceY.
+	defer func() {
+		if r := recover(); r != nil {
+			amountY, err = sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox, "overflow solving the constant product invariant: %v", r)
+		}
+	}()
+
+	// y = balanceXBefore/balanceXAfter
+	y := tokenBalanceFixedBefore.Quo(tokenBalanceFixedAfter)
+
+	// amountY = balanceY * (1 - y)
+	amountY = tokenBalanceUnknownBefore.Mul(sdk.OneDec().Sub(y))
+	return amountY, nil
+}
@@ -200,6 +200,44 @@ func TestMsgCreateBalancerPool(t *testing.T) {
 			}),
 			expectPass: true,
 		},
+		{
+			name: "swap fee tiers",
+			msg: createMsg(func(msg MsgCreateBalancerPool) MsgCreateBalancerPool {
+				msg.PoolParams.SwapFeeTiers = &types.SwapFeeTiers{VolumeDenom: "test", Tiers: []types.SwapFeeTier{
+					{MinVolume: sdk.NewInt(1000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+				}}
+				return msg
+			}),
+			expectPass: true,
+		},
+		{
+			name: "swap fee tier above the swap fee",
+			msg: createMsg(func(msg MsgCreateBalancerPool) MsgCreateBalancerPool {
+				msg.PoolParams.SwapFeeTiers = &types.SwapFeeTiers{VolumeDenom: "test", Tiers: []types.SwapFeeTier{
+					{MinVolume: sdk.NewInt(1000), SwapFee: sdk.NewDecWithPrec(2, 2)},
+				}}
+				return msg
+			}),
+			expectPass: false,
+		},
+		{
+			name: "swap fee tiers volume denom not a pool asset",
+			msg: createMsg(func(msg MsgCreateBalancerPool) MsgCreateBalancerPool {
+				msg.PoolParams.SwapFeeTiers = &types.SwapFeeTiers{VolumeDenom: "uatom", Tiers: []types.SwapFeeTier{
+					{MinVolume: sdk.NewInt(1000), SwapFee: sdk.NewDecWithPrec(5, 3)},
+				}}
+				return msg
+			}),
+			expectPass: false,
+		},
+		{
+			name: "swap fee tiers without a tier",
+			msg: createMsg(func(msg MsgCreateBalancerPool) MsgCreateBalancerPool {
+				msg.PoolParams.SwapFeeTiers = &types.SwapFeeTiers{VolumeDenom: "test"}
+				return msg
+			}),
+			expectPass: false,
+		},
 		{
 			name: "too large of a weight",
 			msg: createMsg(func(msg MsgCreateBalancerPool) MsgCreateBalancerPool {
@@ -83,9 +83,9 @@ func ValidateUserSpecifiedPoolAssets(assets []PoolAsset) error {
 		return types.ErrTooFewPoolAssets
 	}
 
-	// TODO: Add the limit of binding token to the pool params?
-	if len(assets) > 8 {
-		return sdkerrors.Wrapf(types.ErrTooManyPoolAssets, "%d", len(assets))
+	// CreatePool checks the MaxPoolAssets param, which is at most MaxPoolAssetsLimit.
+	if len(assets) > types.MaxPoolAssetsLimit {
+		return sdkerrors.Wrapf(types.ErrTooManyPoolAssets, "%d, the most a pool can have is %d", len(assets), types.MaxPoolAssetsLimit)
 	}
 
 	assetExistsMap := map[string]bool{}
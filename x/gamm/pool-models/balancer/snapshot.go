@@ -0,0 +1,50 @@
+package balancer
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// Snapshot returns the full state of the pool as the codec's JSON, e.g. to set up simulation or upgrade tests
+// with a pool's exact state without going through the keeper's store, see NewPoolFromSnapshot.
+// The weights are the stored weights of the pool's assets and smooth weight change params,
+// not the user specified ones, so that a pool restored from its snapshot is identical to it.
+func (pa Pool) Snapshot() ([]byte, error) {
+	return ModuleCdc.MarshalJSON(&pa)
+}
+
+// NewPoolFromSnapshot returns the pool whose full state is the JSON snapshot bz, see Pool.Snapshot.
+// Unlike NewBalancerPool, the weights are not scaled, and the initial pool weights of the smooth weight
+// change params are kept, so that the pool is identical to the snapshotted pool.
+// It errors if the snapshot isn't of a valid pool.
+func NewPoolFromSnapshot(bz []byte) (Pool, error) {
+	var pool Pool
+	if err := ModuleCdc.UnmarshalJSON(bz, &pool); err != nil {
+		return Pool{}, err
+	}
+
+	if _, err := sdk.AccAddressFromBech32(pool.Address); err != nil {
+		return Pool{}, sdkerrors.Wrapf(types.ErrInvalidPool, "invalid address %s of pool %d: %s", pool.Address, pool.Id, err)
+	}
+	if err := types.ValidateSwapFee(pool.PoolParams.SwapFee); err != nil {
+		return Pool{}, err
+	}
+	if err := types.ValidateExitFee(pool.PoolParams.ExitFee); err != nil {
+		return Pool{}, err
+	}
+	if pool.TotalShares.Denom != types.GetPoolShareDenom(pool.Id) {
+		return Pool{}, sdkerrors.Wrapf(types.ErrInvalidPool, "shares of pool %d have denom %s", pool.Id, pool.TotalShares.Denom)
+	}
+	if pool.TotalShares.Amount.IsNil() || !pool.TotalShares.Amount.IsPositive() {
+		return Pool{}, sdkerrors.Wrapf(types.ErrPoolHasNoShares, "total shares of pool %d are %s", pool.Id, pool.TotalShares.Amount)
+	}
+	if len(pool.PoolAssets) < types.MinPoolAssets {
+		return Pool{}, sdkerrors.Wrapf(types.ErrTooFewPoolAssets, "pool %d has %d assets", pool.Id, len(pool.PoolAssets))
+	}
+	if err := pool.ValidatePoolAssets(); err != nil {
+		return Pool{}, err
+	}
+	return pool, nil
+}
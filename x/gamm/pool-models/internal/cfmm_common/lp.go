@@ -2,6 +2,7 @@ package cfmm_common
 
 import (
 	"errors"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -15,6 +16,9 @@ const errMsgFormatSharesLargerThanMax = "%s resulted shares is larger than the m
 // CalcExitPool returns how many tokens should come out, when exiting k LP shares against a "standard" CFMM
 func CalcExitPool(ctx sdk.Context, pool types.PoolI, exitingShares sdk.Int, exitFee sdk.Dec) (sdk.Coins, error) {
 	totalShares := pool.GetTotalShares()
+	if !totalShares.IsPositive() {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrPoolHasNoShares, "pool %d has no shares to exit", pool.GetId())
+	}
 	if exitingShares.GTE(totalShares) {
 		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrLimitMaxAmount, errMsgFormatSharesLargerThanMax, exitingShares, totalShares)
 	}
@@ -30,6 +34,12 @@ func CalcExitPool(ctx sdk.Context, pool types.PoolI, exitingShares sdk.Int, exit
 		refundedShares = exitingShares.ToDec()
 	}
 
+	// a non-positive refundedShares would exit no tokens, or even take tokens from the exiter.
+	if !refundedShares.IsPositive() {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrExitTooSmall,
+			"exiting %s shares at an exit fee of %s refunds no shares", exitingShares, exitFee)
+	}
+
 	shareOutRatio := refundedShares.QuoInt(totalShares)
 	// exitedCoins = shareOutRatio * pool liquidity
 	exitedCoins := sdk.Coins{}
@@ -46,6 +56,12 @@ func CalcExitPool(ctx sdk.Context, pool types.PoolI, exitingShares sdk.Int, exit
 		}
 		exitedCoins = exitedCoins.Add(sdk.NewCoin(asset.Denom, exitAmt))
 	}
+	// the exit amounts truncated to zero for every asset,
+	// so the shares would be burnt without getting anything out.
+	if exitedCoins.Empty() {
+		return sdk.Coins{}, sdkerrors.Wrapf(types.ErrExitTooSmall,
+			"exiting %s shares at an exit fee of %s", exitingShares, exitFee)
+	}
 
 	return exitedCoins, nil
 }
@@ -110,10 +126,19 @@ func MaximalExactRatioJoin(p types.PoolI, ctx sdk.Context, tokensIn sdk.Coins) (
 	totalShares := p.GetTotalShares()
 
 	for i, coin := range tokensIn {
+		poolAmount := poolLiquidity.AmountOfNoDenomValidation(coin.Denom)
+		if !poolAmount.IsPositive() {
+			poolDenoms := make([]string, len(poolLiquidity))
+			for j, poolCoin := range poolLiquidity {
+				poolDenoms[j] = poolCoin.Denom
+			}
+			return numShares, remCoins, sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "input denom %s is not in the pool, the denoms of pool %d are: %s",
+				coin.Denom, p.GetId(), strings.Join(poolDenoms, ", "))
+		}
 		// Note: QuoInt implements floor division, unlike Quo
 		// This is because it calls the native golang routine big.Int.Quo
 		// https://pkg.go.dev/math/big#Int.Quo
-		shareRatio := coin.Amount.ToDec().QuoInt(poolLiquidity.AmountOfNoDenomValidation(coin.Denom))
+		shareRatio := coin.Amount.ToDec().QuoInt(poolAmount)
 		if shareRatio.LT(minShareRatio) {
 			minShareRatio = shareRatio
 		}
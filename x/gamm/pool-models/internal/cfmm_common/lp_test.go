@@ -43,7 +43,7 @@ func TestCalcExitPool(t *testing.T) {
 	// create these pools used for testing
 	twoAssetPool, err := stableswap.NewStableswapPool(
 		1,
-		stableswap.PoolParams{ExitFee: sdk.ZeroDec()},
+		stableswap.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()},
 		twoStablePoolAssets,
 		"",
 		time.Now(),
@@ -61,7 +61,7 @@ func TestCalcExitPool(t *testing.T) {
 
 	twoAssetPoolWithExitFee, err := stableswap.NewStableswapPool(
 		1,
-		stableswap.PoolParams{ExitFee: sdk.MustNewDecFromStr("0.0001")},
+		stableswap.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.MustNewDecFromStr("0.0001")},
 		twoStablePoolAssets,
 		"",
 		time.Now(),
@@ -77,6 +77,15 @@ func TestCalcExitPool(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	threeAssetPoolWithHighExitFee, err := balancer.NewBalancerPool(
+		1,
+		balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.MustNewDecFromStr("0.99")},
+		threeBalancerPoolAssets,
+		"",
+		time.Now(),
+	)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name          string
 		pool          gammtypes.PoolI
@@ -119,6 +128,24 @@ func TestCalcExitPool(t *testing.T) {
 			exitingShares: sdk.NewIntFromUint64(7000000000000),
 			expError:      false,
 		},
+		{
+			name:          "three-asset pool with 99% exit fee, one share exiting no tokens",
+			pool:          &threeAssetPoolWithHighExitFee,
+			exitingShares: sdk.OneInt(),
+			expError:      true,
+		},
+		{
+			name:          "three-asset pool with 99% exit fee, valid exiting shares",
+			pool:          &threeAssetPoolWithHighExitFee,
+			exitingShares: sdk.NewIntFromUint64(7000000000000),
+			expError:      false,
+		},
+		{
+			name:          "three-asset pool, zero exiting shares",
+			pool:          &threeAssetPool,
+			exitingShares: sdk.ZeroInt(),
+			expError:      true,
+		},
 	}
 
 	for _, test := range tests {
@@ -151,6 +178,7 @@ func TestMaximalExactRatioJoin(t *testing.T) {
 		tokensIn    sdk.Coins
 		expNumShare sdk.Int
 		expRemCoin  sdk.Coins
+		expectErr   bool
 	}{
 		{
 			name: "two asset pool, same tokenIn ratio",
@@ -186,22 +214,58 @@ func TestMaximalExactRatioJoin(t *testing.T) {
 			expNumShare: sdk.NewIntFromUint64(10000000000000000000),
 			expRemCoin:  sdk.NewCoins(sdk.NewCoin("bar", sdk.NewIntFromUint64(1))),
 		},
+		{
+			name: "three asset pool, remainder in two denoms",
+			pool: func() gammtypes.PoolI {
+				balancerPool, err := balancer.NewBalancerPool(
+					1,
+					balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()},
+					[]balancer.PoolAsset{
+						{Token: sdk.NewInt64Coin("foo", 100), Weight: sdk.NewIntFromUint64(5)},
+						{Token: sdk.NewInt64Coin("bar", 200), Weight: sdk.NewIntFromUint64(5)},
+						{Token: sdk.NewInt64Coin("baz", 400), Weight: sdk.NewIntFromUint64(5)},
+					},
+					"",
+					time.Now(),
+				)
+				require.NoError(t, err)
+				return &balancerPool
+			},
+			// min ratio is 10 / 100 = 0.1, so 20 bar and 40 baz are consumed.
+			tokensIn:    sdk.NewCoins(sdk.NewInt64Coin("foo", 10), sdk.NewInt64Coin("bar", 25), sdk.NewInt64Coin("baz", 50)),
+			expNumShare: sdk.NewIntFromUint64(10000000000000000000),
+			expRemCoin:  sdk.NewCoins(sdk.NewInt64Coin("bar", 5), sdk.NewInt64Coin("baz", 10)),
+		},
+		{
+			name: "two asset pool, tokenIn denom not in pool",
+			pool: func() gammtypes.PoolI {
+				balancerPool, err := balancer.NewBalancerPool(
+					1,
+					balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()},
+					balancerPoolAsset,
+					"",
+					time.Now(),
+				)
+				require.NoError(t, err)
+				return &balancerPool
+			},
+			tokensIn:  sdk.NewCoins(sdk.NewInt64Coin("foo", 10), sdk.NewInt64Coin("baz", 10)),
+			expectErr: true,
+		},
 	}
 
 	for _, test := range tests {
-		balancerPool, err := balancer.NewBalancerPool(
-			1,
-			balancer.PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec()},
-			balancerPoolAsset,
-			"",
-			time.Now(),
-		)
-		require.NoError(t, err)
-
-		numShare, remCoins, err := cfmm_common.MaximalExactRatioJoin(&balancerPool, emptyContext, test.tokensIn)
-
-		require.NoError(t, err)
-		require.Equal(t, test.expNumShare, numShare)
-		require.Equal(t, test.expRemCoin, remCoins)
+		t.Run(test.name, func(t *testing.T) {
+			pool := test.pool()
+			numShare, remCoins, err := cfmm_common.MaximalExactRatioJoin(pool, emptyContext, test.tokensIn)
+
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expNumShare, numShare)
+			require.Equal(t, test.expRemCoin, remCoins)
+		})
 	}
 }
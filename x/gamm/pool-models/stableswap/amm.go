@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/pool-models/internal/cfmm_common"
 	types "github.com/osmosis-labs/osmosis/v7/x/gamm/types"
@@ -315,6 +316,116 @@ func solveCFMMBinarySearchMulti(constantFunction func(sdk.Dec, sdk.Dec, sdk.Dec,
 	}
 }
 
+// CurveMaxNewtonIterations bounds the number of Newton iterations taken when solving the
+// curve stableswap invariant. From a starting point of the sum of the reserves, the iterations
+// converge in a handful of steps, so reaching the bound indicates degenerate reserves.
+var CurveMaxNewtonIterations = 256
+
+// MaxScaledReserve is the largest scaled reserve of a two asset pool on which Curve's CFMM is solved
+// without overflowing sdk.Dec, for any amplification parameter up to MaxAmplificationParameter.
+// Solving for a reserve multiplies up to three values of the magnitude of the invariant D,
+// which is at most the sum of the reserves.
+var MaxScaledReserve = sdk.NewDec(10).Power(24)
+
+// curveNewtonTolerance is the relative change between Newton iterations below which
+// they are considered converged.
+var curveNewtonTolerance = sdk.NewDecWithPrec(1, 15)
+
+func newtonConverged(prev, cur sdk.Dec) bool {
+	return prev.Sub(cur).Abs().LTE(cur.Mul(curveNewtonTolerance))
+}
+
+// curveInvariant returns the invariant D of Curve's stableswap CFMM with amplification parameter A,
+// which for n assets with reserves x_i is
+// A n^n sum(x_i) + D = A n^n D + D^(n+1) / (n^n prod(x_i))
+// A large A makes the CFMM closer to a constant sum, and a small A closer to a constant product.
+// D is the total amount of assets when all reserves are equal.
+// There is no closed form for D, so it is found with Newton's method, starting from sum(x_i):
+// D_{k+1} = (A n^n sum(x_i) + n D_P) D_k / ((A n^n - 1) D_k + (n + 1) D_P),
+// where D_P = D_k^(n+1) / (n^n prod(x_i)).
+func curveInvariant(reserves []sdk.Dec, amp sdk.Dec) (sdk.Dec, error) {
+	if amp.LT(sdk.OneDec()) {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidAmplificationParameter, "amplification parameter must be at least 1, was %s", amp)
+	}
+	n := int64(len(reserves))
+	sum := sdk.ZeroDec()
+	for _, reserve := range reserves {
+		if !reserve.IsPositive() {
+			return sdk.Dec{}, sdkerrors.Wrapf(types.ErrPoolAssetDepleted, "reserves must be positive, got %s", reserve)
+		}
+		sum = sum.Add(reserve)
+	}
+	ann := amp.Mul(sdk.NewDec(n).Power(uint64(n)))
+
+	d := sum
+	for i := 0; i < CurveMaxNewtonIterations; i++ {
+		dP := d
+		for _, reserve := range reserves {
+			dP = dP.Mul(d).Quo(reserve.MulInt64(n))
+		}
+		numerator := ann.Mul(sum).Add(dP.MulInt64(n)).Mul(d)
+		denominator := ann.Sub(sdk.OneDec()).Mul(d).Add(dP.MulInt64(n + 1))
+		prevD := d
+		d = numerator.Quo(denominator)
+		if newtonConverged(prevD, d) {
+			return d, nil
+		}
+	}
+	return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox,
+		"curve invariant of reserves %v did not converge within %d iterations", reserves, CurveMaxNewtonIterations)
+}
+
+// solveCurve returns how many units of reserves[outIndex] come out of the curve stableswap CFMM
+// with amplification parameter A, for an addition of yIn units to reserves[inIndex].
+// yIn may be negative, in which case the negated result is the amount that has to be added
+// to reserves[outIndex] to take -yIn out of reserves[inIndex].
+// The new reserve x of the out asset solves the invariant for the other reserves and
+// the unchanged D, which rearranges to x^2 + (b - D) x = c with
+// b = sum_{j != out}(x_j) + D / (A n^n) and c = D^(n+1) / (A n^(2n) prod_{j != out}(x_j)).
+// This is solved with Newton's method, starting from D: x_{k+1} = (x_k^2 + c) / (2 x_k + b - D).
+func solveCurve(reserves []sdk.Dec, amp sdk.Dec, inIndex, outIndex int, yIn sdk.Dec) (sdk.Dec, error) {
+	if inIndex == outIndex {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSameDenom, "asset %d in and out", inIndex)
+	}
+	d, err := curveInvariant(reserves, amp)
+	if err != nil {
+		return sdk.Dec{}, err
+	}
+	newInReserve := reserves[inIndex].Add(yIn)
+	if !newInReserve.IsPositive() {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrTooManyTokensOut,
+			"can't take %s out of a reserve of %s", yIn.Neg(), reserves[inIndex])
+	}
+
+	n := int64(len(reserves))
+	ann := amp.Mul(sdk.NewDec(n).Power(uint64(n)))
+	c := d
+	sumOthers := sdk.ZeroDec()
+	for j, reserve := range reserves {
+		if j == outIndex {
+			continue
+		}
+		if j == inIndex {
+			reserve = newInReserve
+		}
+		sumOthers = sumOthers.Add(reserve)
+		c = c.Mul(d).Quo(reserve.MulInt64(n))
+	}
+	c = c.Mul(d).Quo(ann.MulInt64(n))
+	b := sumOthers.Add(d.Quo(ann))
+
+	x := d
+	for i := 0; i < CurveMaxNewtonIterations; i++ {
+		prevX := x
+		x = x.Mul(x).Add(c).Quo(x.MulInt64(2).Add(b).Sub(d))
+		if newtonConverged(prevX, x) {
+			return reserves[outIndex].Sub(x), nil
+		}
+	}
+	return sdk.Dec{}, sdkerrors.Wrapf(types.ErrInvalidMathApprox,
+		"curve reserve of asset %d did not converge within %d iterations", outIndex, CurveMaxNewtonIterations)
+}
+
 //nolint:unused
 func spotPrice(baseReserve, quoteReserve sdk.Dec) sdk.Dec {
 	// y = baseAsset, x = quoteAsset
@@ -336,8 +447,50 @@ func spotPrice(baseReserve, quoteReserve sdk.Dec) sdk.Dec {
 	return solveCfmm(baseReserve, quoteReserve, a)
 }
 
+// curveReserves returns the scaled reserves of all of the pool's assets, in liquidity order,
+// and the indexes of denomIn and denomOut in them, for solving the pool's swaps on Curve's CFMM.
+func (pa Pool) curveReserves(denomIn, denomOut string) (reserves []sdk.Dec, inIndex, outIndex int, err error) {
+	denoms := make([]string, len(pa.PoolLiquidity))
+	for i, coin := range pa.PoolLiquidity {
+		denoms[i] = coin.Denom
+	}
+	reserves, err = pa.getScaledPoolAmts(denoms...)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	liquidityIndexes := pa.getLiquidityIndexMap()
+	inIndex, ok := liquidityIndexes[denomIn]
+	if !ok {
+		return nil, 0, 0, pa.errDenomNotFoundInPool(denomIn)
+	}
+	outIndex, ok = liquidityIndexes[denomOut]
+	if !ok {
+		return nil, 0, 0, pa.errDenomNotFoundInPool(denomOut)
+	}
+	return reserves, inIndex, outIndex, nil
+}
+
+// amplificationParameter returns the pool's Curve amplification parameter A as a decimal,
+// which is zero for pools solved on the Solidly CFMM.
+func (pa Pool) amplificationParameter() sdk.Dec {
+	return sdk.NewDecFromInt(sdk.NewIntFromUint64(pa.PoolParams.AmplificationParameter))
+}
+
 // returns outAmt as a decimal
 func (pa *Pool) calcOutAmtGivenIn(tokenIn sdk.Coin, tokenOutDenom string, swapFee sdk.Dec) (sdk.Dec, error) {
+	if pa.PoolParams.AmplificationParameter != 0 {
+		reserves, inIndex, outIndex, err := pa.curveReserves(tokenIn.Denom, tokenOutDenom)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		scaledTokenIn := tokenIn.Amount.ToDec().QuoInt64Mut(int64(pa.GetScalingFactorByLiquidityIndex(inIndex)))
+		curveOut, err := solveCurve(reserves, pa.amplificationParameter(), inIndex, outIndex, scaledTokenIn)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		return pa.getDescaledPoolAmt(tokenOutDenom, curveOut), nil
+	}
+
 	reserves, err := pa.getScaledPoolAmts(tokenIn.Denom, tokenOutDenom)
 	if err != nil {
 		return sdk.Dec{}, err
@@ -351,6 +504,21 @@ func (pa *Pool) calcOutAmtGivenIn(tokenIn sdk.Coin, tokenOutDenom string, swapFe
 
 // returns inAmt as a decimal
 func (pa *Pool) calcInAmtGivenOut(tokenOut sdk.Coin, tokenInDenom string, swapFee sdk.Dec) (sdk.Dec, error) {
+	if pa.PoolParams.AmplificationParameter != 0 {
+		reserves, inIndex, outIndex, err := pa.curveReserves(tokenInDenom, tokenOut.Denom)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		// taking tokenOut out of its reserve is solved as adding -tokenOut to it,
+		// for which the negated amount out of the reserve of tokenInDenom is the amount in.
+		scaledTokenOut := tokenOut.Amount.ToDec().QuoInt64Mut(int64(pa.GetScalingFactorByLiquidityIndex(outIndex)))
+		curveIn, err := solveCurve(reserves, pa.amplificationParameter(), outIndex, inIndex, scaledTokenOut.NegMut())
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		return pa.getDescaledPoolAmt(tokenInDenom, curveIn.NegMut()), nil
+	}
+
 	reserves, err := pa.getScaledPoolAmts(tokenInDenom, tokenOut.Denom)
 	if err != nil {
 		return sdk.Dec{}, err
@@ -6,6 +6,8 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
 // Replace with https://github.com/cosmos/cosmos-sdk/blob/master/types/decimal.go#L892-L895
@@ -100,3 +102,261 @@ func TestCFMMInvariantMultiAssets(t *testing.T) {
 		decApproxEq(t, k2, k3, kErrTolerance)
 	}
 }
+
+// curveInvariantSides returns both sides of the curve stableswap invariant
+// A n^n sum(x_i) + D = A n^n D + D^(n+1) / (n^n prod(x_i))
+func curveInvariantSides(reserves []sdk.Dec, amp sdk.Dec, d sdk.Dec) (lhs sdk.Dec, rhs sdk.Dec) {
+	n := int64(len(reserves))
+	ann := amp.Mul(sdk.NewDec(n).Power(uint64(n)))
+	sum := sdk.ZeroDec()
+	dP := d
+	for _, reserve := range reserves {
+		sum = sum.Add(reserve)
+		dP = dP.Mul(d).Quo(reserve.MulInt64(n))
+	}
+	return ann.Mul(sum).Add(d), ann.Mul(d).Add(dP)
+}
+
+func TestCurveInvariantConvergence(t *testing.T) {
+	tests := map[string]struct {
+		reserves []sdk.Dec
+		amp      sdk.Dec
+	}{
+		"balanced two assets, A = 100": {
+			reserves: []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)},
+			amp:      sdk.NewDec(100),
+		},
+		"unbalanced two assets, A = 100": {
+			reserves: []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(100)},
+			amp:      sdk.NewDec(100),
+		},
+		"unbalanced two assets, A = 1": {
+			reserves: []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(100)},
+			amp:      sdk.OneDec(),
+		},
+		"unbalanced two assets, A = 5000": {
+			reserves: []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(100)},
+			amp:      sdk.NewDec(5000),
+		},
+		"large unbalanced three assets, A = 200": {
+			reserves: []sdk.Dec{sdk.NewDec(3_000_000_000_000), sdk.NewDec(1_000_000_000_000), sdk.NewDec(2_000_000_000_000)},
+			amp:      sdk.NewDec(200),
+		},
+		"fractional reserves, A = 10": {
+			reserves: []sdk.Dec{sdk.MustNewDecFromStr("0.5"), sdk.MustNewDecFromStr("1.25")},
+			amp:      sdk.NewDec(10),
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			d, err := curveInvariant(tc.reserves, tc.amp)
+			require.NoError(t, err)
+
+			lhs, rhs := curveInvariantSides(tc.reserves, tc.amp, d)
+			require.True(t, approxDecEqual(lhs, rhs, sdk.NewDecWithPrec(1, 12)), "lhs %s, rhs %s", lhs, rhs)
+
+			// D is at most the sum of the reserves, with equality for balanced reserves
+			sum := sdk.ZeroDec()
+			for _, reserve := range tc.reserves {
+				sum = sum.Add(reserve)
+			}
+			require.True(t, d.LTE(sum), "D %s exceeds the sum of reserves %s", d, sum)
+		})
+	}
+}
+
+func TestCurveInvariantBalancedReserves(t *testing.T) {
+	reserves := []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000), sdk.NewDec(1000000)}
+	d, err := curveInvariant(reserves, sdk.NewDec(100))
+	require.NoError(t, err)
+	decApproxEq(t, sdk.NewDec(3000000), d, sdk.NewDecWithPrec(1, 9))
+}
+
+func TestCurveInvariantErrors(t *testing.T) {
+	_, err := curveInvariant([]sdk.Dec{sdk.NewDec(100), sdk.NewDec(100)}, sdk.ZeroDec())
+	require.ErrorIs(t, err, types.ErrInvalidAmplificationParameter)
+
+	_, err = curveInvariant([]sdk.Dec{sdk.NewDec(100), sdk.ZeroDec()}, sdk.NewDec(100))
+	require.ErrorIs(t, err, types.ErrPoolAssetDepleted)
+
+	defer func(iterations int) { CurveMaxNewtonIterations = iterations }(CurveMaxNewtonIterations)
+	CurveMaxNewtonIterations = 1
+	_, err = curveInvariant([]sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(100)}, sdk.NewDec(100))
+	require.ErrorIs(t, err, types.ErrInvalidMathApprox)
+}
+
+func TestSolveCurve(t *testing.T) {
+	tests := map[string]struct {
+		reserves []sdk.Dec
+		amp      sdk.Dec
+		inIndex  int
+		outIndex int
+		yIn      sdk.Dec
+		// bounds of the ratio of the amount out to the amount in
+		minOutRatio sdk.Dec
+		maxOutRatio sdk.Dec
+	}{
+		"small swap in balanced pool, A = 100": {
+			reserves:    []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)},
+			amp:         sdk.NewDec(100),
+			inIndex:     0,
+			outIndex:    1,
+			yIn:         sdk.NewDec(1000),
+			minOutRatio: sdk.MustNewDecFromStr("0.9999"),
+			maxOutRatio: sdk.OneDec(),
+		},
+		"large swap in balanced pool, A = 100": {
+			reserves:    []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)},
+			amp:         sdk.NewDec(100),
+			inIndex:     1,
+			outIndex:    0,
+			yIn:         sdk.NewDec(500000),
+			minOutRatio: sdk.MustNewDecFromStr("0.99"),
+			maxOutRatio: sdk.OneDec(),
+		},
+		"swap into the scarce asset of an unbalanced pool, A = 100": {
+			reserves:    []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(10000)},
+			amp:         sdk.NewDec(100),
+			inIndex:     0,
+			outIndex:    1,
+			yIn:         sdk.NewDec(100000),
+			minOutRatio: sdk.ZeroDec(),
+			maxOutRatio: sdk.OneDec(),
+		},
+		// the out asset is the more abundant one, so its price is below 1
+		"three assets, A = 200": {
+			reserves:    []sdk.Dec{sdk.NewDec(3_000_000_000_000), sdk.NewDec(1_000_000_000_000), sdk.NewDec(2_000_000_000_000)},
+			amp:         sdk.NewDec(200),
+			inIndex:     2,
+			outIndex:    0,
+			yIn:         sdk.NewDec(1_000_000),
+			minOutRatio: sdk.MustNewDecFromStr("0.99"),
+			maxOutRatio: sdk.MustNewDecFromStr("1.001"),
+		},
+		// a negative amount in solves for the amount that has to be put in, which is more than comes out
+		"exact amount out, A = 100": {
+			reserves:    []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)},
+			amp:         sdk.NewDec(100),
+			inIndex:     0,
+			outIndex:    1,
+			yIn:         sdk.NewDec(-1000),
+			minOutRatio: sdk.OneDec(),
+			maxOutRatio: sdk.MustNewDecFromStr("1.0001"),
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			d, err := curveInvariant(tc.reserves, tc.amp)
+			require.NoError(t, err)
+
+			out, err := solveCurve(tc.reserves, tc.amp, tc.inIndex, tc.outIndex, tc.yIn)
+			require.NoError(t, err)
+			require.True(t, out.Mul(tc.yIn).IsPositive(), "expected amount out %s to have the sign of amount in %s", out, tc.yIn)
+			require.True(t, out.Quo(tc.yIn).GTE(tc.minOutRatio), "amount out %s for %s in", out, tc.yIn)
+			require.True(t, out.Quo(tc.yIn).LTE(tc.maxOutRatio), "amount out %s for %s in", out, tc.yIn)
+
+			newReserves := make([]sdk.Dec, len(tc.reserves))
+			copy(newReserves, tc.reserves)
+			newReserves[tc.inIndex] = newReserves[tc.inIndex].Add(tc.yIn)
+			newReserves[tc.outIndex] = newReserves[tc.outIndex].Sub(out)
+			newD, err := curveInvariant(newReserves, tc.amp)
+			require.NoError(t, err)
+			require.True(t, approxDecEqual(d, newD, sdk.NewDecWithPrec(1, 12)), "invariant changed from %s to %s", d, newD)
+		})
+	}
+}
+
+// TestSolveCurveAmplification tests that a higher amplification parameter gives less slippage.
+func TestSolveCurveAmplification(t *testing.T) {
+	reserves := []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)}
+	yIn := sdk.NewDec(100000)
+
+	prevOut := sdk.ZeroDec()
+	for _, amp := range []int64{1, 10, 100, 1000} {
+		out, err := solveCurve(reserves, sdk.NewDec(amp), 0, 1, yIn)
+		require.NoError(t, err)
+		require.True(t, out.GT(prevOut), "A = %d gave %s out, lower A gave %s", amp, out, prevOut)
+		prevOut = out
+	}
+}
+
+func TestSolveCurveTooManyTokensOut(t *testing.T) {
+	reserves := []sdk.Dec{sdk.NewDec(1000000), sdk.NewDec(1000000)}
+	_, err := solveCurve(reserves, sdk.NewDec(100), 0, 1, sdk.NewDec(-1000000))
+	require.ErrorIs(t, err, types.ErrTooManyTokensOut)
+}
+
+// TestSolveCurveMaxAmplification tests that the curve is solved for the max amplification parameter,
+// also for unbalanced reserves.
+func TestSolveCurveMaxAmplification(t *testing.T) {
+	amp := sdk.NewDec(MaxAmplificationParameter)
+	for _, reserves := range [][]sdk.Dec{
+		{sdk.NewDec(1000000), sdk.NewDec(1000000)},
+		{sdk.NewDec(1000000), sdk.NewDec(10)},
+		{sdk.NewDec(10), sdk.NewDec(1000000)},
+	} {
+		out, err := solveCurve(reserves, amp, 0, 1, sdk.NewDec(5))
+		require.NoError(t, err)
+		require.True(t, out.IsPositive() && out.LT(reserves[1]), "%s out of reserves %v", out, reserves)
+	}
+}
+
+func newCurvePool(amp uint64, liquidity sdk.Coins, scalingFactors []uint64) Pool {
+	return Pool{
+		Address:       types.NewPoolAddress(1).String(),
+		Id:            1,
+		PoolParams:    PoolParams{SwapFee: sdk.ZeroDec(), ExitFee: sdk.ZeroDec(), AmplificationParameter: amp},
+		TotalShares:   sdk.NewCoin(types.GetPoolShareDenom(1), types.InitPoolSharesSupply),
+		PoolLiquidity: liquidity,
+		ScalingFactor: scalingFactors,
+	}
+}
+
+// TestCurvePoolSwaps tests that the swaps of pools with an amplification parameter are solved on Curve's CFMM,
+// on the reserves scaled by the pool's scaling factors.
+func TestCurvePoolSwaps(t *testing.T) {
+	liquidity := sdk.NewCoins(sdk.NewInt64Coin("bar", 1_000_000_000), sdk.NewInt64Coin("foo", 1_000_000))
+	// bar has 3 more decimals than foo, so the scaled reserves are balanced.
+	pool := newCurvePool(100, liquidity, []uint64{1000, 1})
+	tokenIn := sdk.NewInt64Coin("foo", 10_000)
+
+	tokenOut, err := pool.CalcOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", sdk.ZeroDec())
+	require.NoError(t, err)
+	expectedOut, err := solveCurve([]sdk.Dec{sdk.NewDec(1_000_000), sdk.NewDec(1_000_000)}, sdk.NewDec(100), 1, 0, sdk.NewDec(10_000))
+	require.NoError(t, err)
+	require.Equal(t, expectedOut.MulInt64(1000).TruncateInt(), tokenOut.Amount)
+	// with balanced reserves and A = 100, the swap is close to 1:1 in whole tokens.
+	require.True(t, tokenOut.Amount.GT(sdk.NewInt(9_990_000)) && tokenOut.Amount.LT(sdk.NewInt(10_000_000)), tokenOut.String())
+
+	// the amount in for that amount out is the amount swapped in, rounded up.
+	tokenInAmount, err := pool.CalcInAmtGivenOut(sdk.Context{}, sdk.Coins{tokenOut}, "foo", sdk.ZeroDec())
+	require.NoError(t, err)
+	require.True(t, tokenInAmount.Amount.Sub(tokenIn.Amount).Abs().LTE(sdk.OneInt()), tokenInAmount.String())
+
+	// the spot price of balanced reserves is about 1 in scaled units.
+	spotPrice, err := pool.SpotPrice(sdk.Context{}, "foo", "bar")
+	require.NoError(t, err)
+	decApproxEq(t, sdk.OneDec(), spotPrice, sdk.NewDecWithPrec(1, 3))
+
+	// the swap updates the reserves, and keeps the invariant.
+	d0, err := curveInvariant([]sdk.Dec{sdk.NewDec(1_000_000), sdk.NewDec(1_000_000)}, sdk.NewDec(100))
+	require.NoError(t, err)
+	_, err = pool.SwapOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", sdk.ZeroDec())
+	require.NoError(t, err)
+	require.Equal(t, liquidity.Add(tokenIn).Sub(sdk.Coins{tokenOut}), pool.GetTotalPoolLiquidity(sdk.Context{}))
+	reserves, _, _, err := pool.curveReserves("foo", "bar")
+	require.NoError(t, err)
+	d1, err := curveInvariant(reserves, sdk.NewDec(100))
+	require.NoError(t, err)
+	require.True(t, d1.GTE(d0), "invariant decreased from %s to %s", d0, d1)
+
+	// pools without an amplification parameter are solved on the Solidly CFMM.
+	solidlyPool := newCurvePool(0, sdk.NewCoins(sdk.NewInt64Coin("bar", 1_000_000), sdk.NewInt64Coin("foo", 1_000_000)), []uint64{1, 1})
+	solidlyOut, err := solidlyPool.CalcOutAmtGivenIn(sdk.Context{}, sdk.Coins{tokenIn}, "bar", sdk.ZeroDec())
+	require.NoError(t, err)
+	require.Equal(t, solveCfmm(sdk.NewDec(1_000_000), sdk.NewDec(1_000_000), sdk.NewDec(10_000)).TruncateInt(), solidlyOut.Amount)
+}
@@ -101,6 +101,22 @@ func TestMsgCreateStableswapPool(t *testing.T) {
 			}),
 			expectPass: false,
 		},
+		{
+			name: "max amplification parameter",
+			msg: createMsg(func(msg MsgCreateStableswapPool) MsgCreateStableswapPool {
+				msg.PoolParams.AmplificationParameter = MaxAmplificationParameter
+				return msg
+			}),
+			expectPass: true,
+		},
+		{
+			name: "amplification parameter too large",
+			msg: createMsg(func(msg MsgCreateStableswapPool) MsgCreateStableswapPool {
+				msg.PoolParams.AmplificationParameter = MaxAmplificationParameter + 1
+				return msg
+			}),
+			expectPass: false,
+		},
 		{
 			name: "negative swap fee with zero exit fee",
 			msg: createMsg(func(msg MsgCreateStableswapPool) MsgCreateStableswapPool {
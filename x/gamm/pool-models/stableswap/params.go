@@ -1,26 +1,28 @@
 package stableswap
 
 import (
-	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
 
-func (params PoolParams) Validate() error {
-	if params.ExitFee.IsNegative() {
-		return types.ErrNegativeExitFee
-	}
+// MaxAmplificationParameter is the largest amplification parameter of a pool.
+// Curve's CFMM approaches a constant sum as A grows, and Newton's method solves it
+// within CurveMaxNewtonIterations for any A up to it.
+const MaxAmplificationParameter = 1_000_000
 
-	if params.ExitFee.GTE(sdk.OneDec()) {
-		return types.ErrTooMuchExitFee
+func (params PoolParams) Validate() error {
+	if err := types.ValidateExitFee(params.ExitFee); err != nil {
+		return err
 	}
 
-	if params.SwapFee.IsNegative() {
-		return types.ErrNegativeSwapFee
+	if err := types.ValidateSwapFee(params.SwapFee); err != nil {
+		return err
 	}
 
-	if params.SwapFee.GTE(sdk.OneDec()) {
-		return types.ErrTooMuchSwapFee
+	if params.AmplificationParameter > MaxAmplificationParameter {
+		return sdkerrors.Wrapf(types.ErrInvalidAmplificationParameter,
+			"amplification parameter %d is larger than %d", params.AmplificationParameter, MaxAmplificationParameter)
 	}
 	return nil
 }
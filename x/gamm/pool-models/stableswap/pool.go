@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -76,13 +77,24 @@ func (pa Pool) getPoolAmts(denoms ...string) ([]sdk.Int, error) {
 	for i, d := range denoms {
 		amt := poolLiquidity.AmountOf(d)
 		if amt.IsZero() {
-			return []sdk.Int{}, fmt.Errorf("denom %s does not exist in pool", d)
+			return []sdk.Int{}, pa.errDenomNotFoundInPool(d)
 		}
 		result[i] = amt
 	}
 	return result, nil
 }
 
+// errDenomNotFoundInPool returns ErrDenomNotFoundInPool for denom,
+// listing the pool's denoms so that the caller can correct the denom provided.
+func (pa Pool) errDenomNotFoundInPool(denom string) error {
+	denoms := make([]string, len(pa.PoolLiquidity))
+	for i, coin := range pa.PoolLiquidity {
+		denoms[i] = coin.Denom
+	}
+	return sdkerrors.Wrapf(types.ErrDenomNotFoundInPool, "denom %s does not exist in pool, the denoms of pool %d are: %s",
+		denom, pa.Id, strings.Join(denoms, ", "))
+}
+
 // getScaledPoolAmts returns scaled amount of pool liquidity based on each asset's precisions
 func (pa Pool) getScaledPoolAmts(denoms ...string) ([]sdk.Dec, error) {
 	result := make([]sdk.Dec, len(denoms))
@@ -94,7 +106,7 @@ func (pa Pool) getScaledPoolAmts(denoms ...string) ([]sdk.Dec, error) {
 
 		amt := poolLiquidity.AmountOf(denom)
 		if amt.IsZero() {
-			return []sdk.Dec{}, fmt.Errorf("denom %s does not exist in pool", denom)
+			return []sdk.Dec{}, pa.errDenomNotFoundInPool(denom)
 		}
 		scalingFactor := pa.GetScalingFactorByLiquidityIndex(liquidityIndex)
 		result[i] = amt.ToDec().QuoInt64Mut(int64(scalingFactor))
@@ -152,6 +164,12 @@ func (pa Pool) CalcOutAmtGivenIn(ctx sdk.Context, tokenIn sdk.Coins, tokenOutDen
 	if tokenIn.Len() != 1 {
 		return sdk.Coin{}, errors.New("stableswap CalcOutAmtGivenIn: tokenIn is of wrong length")
 	}
+	if tokenIn[0].Denom == tokenOutDenom {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenOutDenom)
+	}
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Coin{}, err
+	}
 	outAmtDec, err := pa.calcOutAmtGivenIn(tokenIn[0], tokenOutDenom, swapFee)
 	if err != nil {
 		return sdk.Coin{}, err
@@ -180,6 +198,12 @@ func (pa Pool) CalcInAmtGivenOut(ctx sdk.Context, tokenOut sdk.Coins, tokenInDen
 	if tokenOut.Len() != 1 {
 		return sdk.Coin{}, errors.New("stableswap CalcInAmtGivenOut: tokenOut is of wrong length")
 	}
+	if tokenOut[0].Denom == tokenInDenom {
+		return sdk.Coin{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", tokenInDenom)
+	}
+	if err := types.ValidateSwapFee(swapFee); err != nil {
+		return sdk.Coin{}, err
+	}
 	// TODO: Refactor this later to handle scaling factors
 	amt, err := pa.calcInAmtGivenOut(tokenOut[0], tokenInDenom, swapFee)
 	if err != nil {
@@ -208,6 +232,22 @@ func (pa *Pool) SwapInAmtGivenOut(ctx sdk.Context, tokenOut sdk.Coins, tokenInDe
 }
 
 func (pa Pool) SpotPrice(ctx sdk.Context, baseAssetDenom string, quoteAssetDenom string) (sdk.Dec, error) {
+	if baseAssetDenom == quoteAssetDenom {
+		return sdk.Dec{}, sdkerrors.Wrapf(types.ErrSameDenom, "denom %s", baseAssetDenom)
+	}
+	if pa.PoolParams.AmplificationParameter != 0 {
+		// as on the Solidly CFMM, the spot price is approximated by the amount out of a swap of 1 unit in.
+		reserves, quoteIndex, baseIndex, err := pa.curveReserves(quoteAssetDenom, baseAssetDenom)
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		scaledSpotPrice, err := solveCurve(reserves, pa.amplificationParameter(), quoteIndex, baseIndex, sdk.OneDec())
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+		return pa.getDescaledPoolAmt(baseAssetDenom, scaledSpotPrice), nil
+	}
+
 	reserves, err := pa.getScaledPoolAmts(baseAssetDenom, quoteAssetDenom)
 	if err != nil {
 		return sdk.Dec{}, err
@@ -226,12 +266,28 @@ func (pa Pool) Copy() Pool {
 
 func (pa *Pool) CalcJoinPoolShares(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, newLiquidity sdk.Coins, err error) {
 	paCopy := pa.Copy()
-	return paCopy.joinPoolSharesInternal(ctx, tokensIn, swapFee)
+	numShares, newLiquidity, err = paCopy.joinPoolSharesInternal(ctx, tokensIn, swapFee)
+	if err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	if err := types.ValidateTotalSharesAfterJoin(pa.GetTotalShares(), numShares); err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	return numShares, newLiquidity, nil
 }
 
-func (pa *Pool) JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, err error) {
-	numShares, _, err = pa.joinPoolSharesInternal(ctx, tokensIn, swapFee)
-	return numShares, err
+// JoinPool joins tokensIn into the pool. Like CalcJoinPoolShares, it errors with ErrTooManyPoolShares
+// if the join would take the pool's total shares past types.MaxTotalShares.
+func (pa *Pool) JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, tokensJoined sdk.Coins, err error) {
+	totalShares := pa.GetTotalShares()
+	numShares, tokensJoined, err = pa.joinPoolSharesInternal(ctx, tokensIn, swapFee)
+	if err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	if err := types.ValidateTotalSharesAfterJoin(totalShares, numShares); err != nil {
+		return sdk.ZeroInt(), sdk.NewCoins(), err
+	}
+	return numShares, tokensJoined, nil
 }
 
 func (pa *Pool) ExitPool(ctx sdk.Context, exitingShares sdk.Int, exitFee sdk.Dec) (exitingCoins sdk.Coins, err error) {
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
 )
@@ -11,11 +12,22 @@ import (
 var _ types.PoolI = &Pool{}
 
 // NewStableswapPool returns a stableswap pool
+// The pool stores initialLiquidity sorted by denom, so its liquidity is iterated in the same order on every node.
+// initialLiquidity must otherwise be valid coins, i.e. positive and without duplicate denoms.
 // Invariants that are assumed to be satisfied and not checked:
 // * len(initialLiquidity) = 2
 // * FutureGovernor is valid
 // * poolID doesn't already exist
 func NewStableswapPool(poolId uint64, stableswapPoolParams PoolParams, initialLiquidity sdk.Coins, futureGovernor string, blockTime time.Time) (Pool, error) {
+	if err := stableswapPoolParams.Validate(); err != nil {
+		return Pool{}, err
+	}
+	// sort a copy, so that the caller's coins aren't reordered.
+	initialLiquidity = append(sdk.Coins{}, initialLiquidity...).Sort()
+	if err := initialLiquidity.Validate(); err != nil {
+		return Pool{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+
 	pool := Pool{
 		Address:            types.NewPoolAddress(poolId).String(),
 		Id:                 poolId,
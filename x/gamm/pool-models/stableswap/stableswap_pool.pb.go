@@ -35,6 +35,10 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type PoolParams struct {
 	SwapFee github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,1,opt,name=swap_fee,json=swapFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"swap_fee" yaml:"swap_fee"`
 	ExitFee github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=exit_fee,json=exitFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"exit_fee" yaml:"exit_fee"`
+	// amplification_parameter is the amplification parameter A of the Curve
+	// stableswap CFMM that the pool's swaps are solved on. Zero solves them on
+	// the Solidly CFMM xy(x^2 + y^2) = k instead.
+	AmplificationParameter uint64 `protobuf:"varint,3,opt,name=amplification_parameter,json=amplificationParameter,proto3" json:"amplification_parameter,omitempty" yaml:"amplification_parameter"`
 }
 
 func (m *PoolParams) Reset()         { *m = PoolParams{} }
@@ -70,6 +74,13 @@ func (m *PoolParams) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PoolParams proto.InternalMessageInfo
 
+func (m *PoolParams) GetAmplificationParameter() uint64 {
+	if m != nil {
+		return m.AmplificationParameter
+	}
+	return 0
+}
+
 // Pool is the stableswap Pool struct
 type Pool struct {
 	Address    string     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty" yaml:"address"`
@@ -136,47 +147,49 @@ func init() {
 }
 
 var fileDescriptor_ae0f054436f9999a = []byte{
-	// 632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x8d, 0xdb, 0xb4, 0x29, 0x5b, 0x1a, 0x84, 0x29, 0xc2, 0x6d, 0x85, 0x37, 0x5a, 0x09, 0x14,
-	0x21, 0x62, 0x53, 0x90, 0x40, 0xf4, 0x04, 0x01, 0x15, 0x21, 0x21, 0x51, 0xcc, 0x89, 0x0a, 0x29,
-	0x5a, 0xdb, 0x1b, 0x77, 0x85, 0x9d, 0x35, 0xde, 0x75, 0x69, 0x2f, 0x9c, 0x39, 0x72, 0xe4, 0xd8,
-	0x13, 0x07, 0xce, 0x7c, 0x44, 0xc5, 0xa9, 0x47, 0xc4, 0xc1, 0xa0, 0xf6, 0x0f, 0xf2, 0x05, 0x68,
-	0xd7, 0xeb, 0x26, 0x29, 0x55, 0x55, 0x89, 0x53, 0x76, 0x66, 0xde, 0xbc, 0x99, 0x79, 0x33, 0x31,
-	0x78, 0xc8, 0x78, 0xc2, 0x38, 0xe5, 0x6e, 0x84, 0x93, 0xc4, 0x4d, 0x19, 0x8b, 0x3b, 0x09, 0x0b,
-	0x49, 0xcc, 0x5d, 0x2e, 0xb0, 0x1f, 0x13, 0xfe, 0x01, 0xa7, 0x63, 0xcf, 0x9e, 0x44, 0x38, 0x69,
-	0xc6, 0x04, 0x33, 0x6f, 0xe9, 0x54, 0x47, 0xa6, 0x3a, 0x32, 0x50, 0x66, 0x3a, 0x23, 0xb8, 0xb3,
-	0xbd, 0xea, 0x13, 0x81, 0x57, 0x97, 0x97, 0x02, 0x05, 0xee, 0xa9, 0x4c, 0xb7, 0x34, 0x4a, 0x9a,
-	0xe5, 0xc5, 0x88, 0x45, 0xac, 0xf4, 0xcb, 0x97, 0xf6, 0xda, 0x11, 0x63, 0x51, 0x4c, 0x5c, 0x65,
-	0xf9, 0x79, 0xdf, 0x0d, 0xf3, 0x0c, 0x0b, 0xca, 0x06, 0x3a, 0x0e, 0x4f, 0xc6, 0x05, 0x4d, 0x08,
-	0x17, 0x38, 0x49, 0x2b, 0x82, 0xb2, 0x88, 0x8b, 0x73, 0xb1, 0xe5, 0xea, 0x36, 0x94, 0x71, 0x22,
-	0xee, 0x63, 0x4e, 0x8e, 0xe3, 0x01, 0xa3, 0xba, 0x00, 0xda, 0x37, 0x00, 0xd8, 0x60, 0x2c, 0xde,
-	0xc0, 0x19, 0x4e, 0xb8, 0xf9, 0x16, 0xcc, 0xa9, 0xf9, 0xfb, 0x84, 0x58, 0x46, 0xcb, 0x68, 0x5f,
-	0xe8, 0x3e, 0xde, 0x2f, 0x60, 0xed, 0x57, 0x01, 0x6f, 0x46, 0x54, 0x6c, 0xe5, 0xbe, 0x13, 0xb0,
-	0x44, 0x0f, 0xa6, 0x7f, 0x3a, 0x3c, 0x7c, 0xe7, 0x8a, 0xdd, 0x94, 0x70, 0xe7, 0x29, 0x09, 0x86,
-	0x05, 0xbc, 0xb4, 0x8b, 0x93, 0x78, 0x0d, 0x55, 0x3c, 0xc8, 0x6b, 0xc8, 0xe7, 0x3a, 0x21, 0x92,
-	0x9d, 0xec, 0x50, 0xa1, 0xd8, 0xa7, 0xfe, 0x8f, 0xbd, 0xe2, 0x41, 0x5e, 0x43, 0x3e, 0xd7, 0x09,
-	0x41, 0x5f, 0x67, 0x40, 0x5d, 0x8e, 0x62, 0xde, 0x06, 0x0d, 0x1c, 0x86, 0x19, 0xe1, 0x5c, 0xcf,
-	0x60, 0x0e, 0x0b, 0xd8, 0x2c, 0xf3, 0x74, 0x00, 0x79, 0x15, 0xc4, 0x6c, 0x82, 0x29, 0x1a, 0xaa,
-	0x76, 0xea, 0xde, 0x14, 0x0d, 0xcd, 0x8f, 0x60, 0x5e, 0x2e, 0xb9, 0x97, 0x2a, 0x45, 0xac, 0xe9,
-	0x96, 0xd1, 0x9e, 0xbf, 0x7b, 0xdf, 0x39, 0xff, 0x15, 0x38, 0x23, 0x3d, 0xbb, 0x37, 0xe4, 0x7c,
-	0xc3, 0x02, 0x5e, 0xd7, 0x9a, 0x4c, 0x5e, 0x98, 0xae, 0x81, 0x3c, 0x90, 0x8e, 0x56, 0xf0, 0x0a,
-	0x2c, 0xf6, 0x73, 0x91, 0x67, 0xa4, 0x84, 0x44, 0x6c, 0x9b, 0x64, 0x03, 0x96, 0x59, 0x75, 0x35,
-	0x0a, 0x1c, 0x16, 0x70, 0xa5, 0x24, 0x3b, 0x0d, 0x85, 0x3c, 0xb3, 0x74, 0xcb, 0x1e, 0x9e, 0x69,
-	0xa7, 0xf9, 0x06, 0x5c, 0x14, 0x4c, 0xe0, 0xb8, 0xc7, 0xb7, 0x70, 0x46, 0xb8, 0x35, 0xa3, 0x66,
-	0x5a, 0x72, 0xf4, 0x81, 0xca, 0xdb, 0x38, 0x6e, 0xfe, 0x09, 0xa3, 0x83, 0xee, 0x8a, 0x6e, 0xfb,
-	0x4a, 0x59, 0x69, 0x3c, 0x19, 0x79, 0xf3, 0xca, 0x7c, 0xad, 0x2c, 0x33, 0x03, 0x4d, 0xd5, 0x40,
-	0x4c, 0xdf, 0xe7, 0x34, 0xa4, 0x62, 0xd7, 0x9a, 0x6d, 0x4d, 0x9f, 0x4d, 0x7e, 0x47, 0x92, 0x7f,
-	0xfb, 0x0d, 0xdb, 0xe7, 0xd8, 0xb9, 0x4c, 0xe0, 0xde, 0x82, 0x2c, 0xf1, 0xa2, 0xaa, 0x60, 0xbe,
-	0x04, 0x4d, 0x1e, 0xe0, 0x98, 0x0e, 0xa2, 0x5e, 0x1f, 0x07, 0x82, 0x65, 0x56, 0xa3, 0x35, 0xdd,
-	0xae, 0x77, 0xdb, 0xba, 0xeb, 0xd6, 0x3f, 0x62, 0x4f, 0xc2, 0x91, 0xb7, 0xa0, 0x1d, 0xeb, 0xca,
-	0x36, 0x37, 0xc1, 0xb5, 0x49, 0xc4, 0x48, 0xf5, 0x39, 0xa5, 0x3a, 0x1a, 0x16, 0xd0, 0xd6, 0xac,
-	0xa7, 0x03, 0x91, 0x77, 0x75, 0x82, 0xb3, 0xd2, 0x7e, 0xed, 0xf2, 0xa7, 0x3d, 0x58, 0xfb, 0xb2,
-	0x07, 0x6b, 0x3f, 0xbe, 0x77, 0x66, 0xe4, 0x56, 0x9e, 0x77, 0x37, 0xf7, 0x0f, 0x6d, 0xe3, 0xe0,
-	0xd0, 0x36, 0xfe, 0x1c, 0xda, 0xc6, 0xe7, 0x23, 0xbb, 0x76, 0x70, 0x64, 0xd7, 0x7e, 0x1e, 0xd9,
-	0xb5, 0xcd, 0x47, 0x63, 0x92, 0xe8, 0x83, 0xeb, 0xc4, 0xd8, 0xe7, 0x95, 0xe1, 0x6e, 0x3f, 0x70,
-	0x77, 0xce, 0xfa, 0x86, 0xf9, 0xb3, 0xea, 0x6f, 0x7d, 0xef, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x44, 0x51, 0xea, 0xde, 0xf1, 0x04, 0x00, 0x00,
+	// 660 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x4c,
+	0x10, 0x4e, 0xd2, 0xb4, 0xe9, 0xbb, 0x7d, 0x1b, 0x84, 0x29, 0x34, 0x6d, 0x85, 0x1d, 0xad, 0x04,
+	0x8a, 0x10, 0xb1, 0x29, 0x48, 0x20, 0x7a, 0x82, 0x80, 0x8a, 0x90, 0x90, 0x28, 0xe6, 0x44, 0x41,
+	0x8a, 0xd6, 0xf6, 0xc6, 0x5d, 0x61, 0x67, 0x8d, 0x77, 0x5d, 0xda, 0x0b, 0x67, 0x8e, 0x1c, 0x39,
+	0xf6, 0x02, 0x07, 0xce, 0xfc, 0x88, 0x8a, 0x53, 0x8f, 0x88, 0x83, 0x41, 0xed, 0x3f, 0xc8, 0x2f,
+	0x40, 0xfb, 0xe1, 0x7c, 0x14, 0x5a, 0x55, 0xe2, 0xe4, 0xf9, 0x78, 0xe6, 0x99, 0x99, 0x67, 0x47,
+	0x06, 0x77, 0x29, 0x8b, 0x29, 0x23, 0xcc, 0x09, 0x51, 0x1c, 0x3b, 0x09, 0xa5, 0x51, 0x3b, 0xa6,
+	0x01, 0x8e, 0x98, 0xc3, 0x38, 0xf2, 0x22, 0xcc, 0xde, 0xa2, 0x64, 0xcc, 0xec, 0x0a, 0x84, 0x9d,
+	0xa4, 0x94, 0x53, 0xe3, 0x9a, 0x2e, 0xb5, 0x45, 0xa9, 0x2d, 0x12, 0xaa, 0xd2, 0x1e, 0xc1, 0xed,
+	0xed, 0x55, 0x0f, 0x73, 0xb4, 0xba, 0xbc, 0xe4, 0x4b, 0x70, 0x57, 0x56, 0x3a, 0xca, 0x51, 0x34,
+	0xcb, 0x0b, 0x21, 0x0d, 0xa9, 0x8a, 0x0b, 0x4b, 0x47, 0xcd, 0x90, 0xd2, 0x30, 0xc2, 0x8e, 0xf4,
+	0xbc, 0xac, 0xe7, 0x04, 0x59, 0x8a, 0x38, 0xa1, 0x7d, 0x9d, 0xb7, 0x8e, 0xe7, 0x39, 0x89, 0x31,
+	0xe3, 0x28, 0x4e, 0x0a, 0x02, 0xd5, 0xc4, 0x41, 0x19, 0xdf, 0x72, 0xf4, 0x18, 0xd2, 0x39, 0x96,
+	0xf7, 0x10, 0xc3, 0xc3, 0xbc, 0x4f, 0x89, 0x6e, 0x00, 0x3f, 0x55, 0x00, 0xd8, 0xa0, 0x34, 0xda,
+	0x40, 0x29, 0x8a, 0x99, 0xf1, 0x0a, 0xcc, 0xca, 0xfd, 0x7b, 0x18, 0x37, 0xca, 0xcd, 0x72, 0xeb,
+	0xbf, 0xce, 0xfd, 0xfd, 0xdc, 0x2a, 0xfd, 0xc8, 0xad, 0xab, 0x21, 0xe1, 0x5b, 0x99, 0x67, 0xfb,
+	0x34, 0xd6, 0x8b, 0xe9, 0x4f, 0x9b, 0x05, 0xaf, 0x1d, 0xbe, 0x9b, 0x60, 0x66, 0x3f, 0xc4, 0xfe,
+	0x20, 0xb7, 0xce, 0xed, 0xa2, 0x38, 0x5a, 0x83, 0x05, 0x0f, 0x74, 0x6b, 0xc2, 0x5c, 0xc7, 0x58,
+	0xb0, 0xe3, 0x1d, 0xc2, 0x25, 0x7b, 0xe5, 0xdf, 0xd8, 0x0b, 0x1e, 0xe8, 0xd6, 0x84, 0x29, 0xd8,
+	0x5f, 0x82, 0x45, 0x14, 0x27, 0x11, 0xe9, 0x11, 0x5f, 0x4a, 0xd8, 0x4d, 0xc4, 0x4e, 0x98, 0xe3,
+	0xb4, 0x31, 0xd5, 0x2c, 0xb7, 0xaa, 0x1d, 0x38, 0xc8, 0x2d, 0x53, 0x95, 0x9f, 0x00, 0x84, 0xee,
+	0xa5, 0x89, 0xcc, 0xc6, 0x30, 0xf1, 0x79, 0x1a, 0x54, 0x85, 0x4e, 0xc6, 0x75, 0x50, 0x43, 0x41,
+	0x90, 0x62, 0xc6, 0xb4, 0x40, 0xc6, 0x20, 0xb7, 0xea, 0x9a, 0x55, 0x25, 0xa0, 0x5b, 0x40, 0x8c,
+	0x3a, 0xa8, 0x90, 0x40, 0xee, 0x5a, 0x75, 0x2b, 0x24, 0x30, 0xde, 0x81, 0x39, 0x71, 0x41, 0xaa,
+	0x23, 0x93, 0x73, 0xcd, 0xdd, 0xbc, 0x6d, 0x9f, 0xfd, 0xc4, 0xec, 0xd1, 0x63, 0x75, 0xae, 0x08,
+	0xf1, 0x06, 0xb9, 0x75, 0x59, 0x0b, 0x3e, 0x79, 0xbe, 0xba, 0x07, 0x74, 0x41, 0x32, 0x7a, 0xdf,
+	0x67, 0x60, 0xa1, 0x97, 0xf1, 0x2c, 0xc5, 0x0a, 0x12, 0xd2, 0x6d, 0x9c, 0xf6, 0x69, 0xda, 0xa8,
+	0xca, 0x55, 0xac, 0x41, 0x6e, 0xad, 0x28, 0xb2, 0xbf, 0xa1, 0xa0, 0x6b, 0xa8, 0xb0, 0x98, 0xe1,
+	0x91, 0x0e, 0x1a, 0x2f, 0xc0, 0xff, 0x9c, 0x72, 0x14, 0x75, 0xd9, 0x16, 0x4a, 0x31, 0x6b, 0x4c,
+	0xcb, 0x9d, 0x96, 0x6c, 0x7d, 0xfd, 0xe2, 0xf0, 0x86, 0xc3, 0x3f, 0xa0, 0xa4, 0xdf, 0x59, 0xd1,
+	0x63, 0x5f, 0x50, 0x9d, 0xc6, 0x8b, 0xa1, 0x3b, 0x27, 0xdd, 0xe7, 0xd2, 0x33, 0x52, 0x50, 0x97,
+	0x03, 0x44, 0xe4, 0x4d, 0x46, 0x02, 0xc2, 0x77, 0x1b, 0x33, 0xcd, 0xa9, 0xd3, 0xc9, 0x6f, 0x08,
+	0xf2, 0x2f, 0x3f, 0xad, 0xd6, 0x19, 0x0e, 0x4a, 0x14, 0x30, 0x77, 0x5e, 0xb4, 0x78, 0x52, 0x74,
+	0x30, 0x9e, 0x82, 0x3a, 0xf3, 0x51, 0x44, 0xfa, 0x61, 0xb7, 0x87, 0x7c, 0x4e, 0xd3, 0x46, 0xad,
+	0x39, 0xd5, 0xaa, 0x76, 0x5a, 0x7a, 0xea, 0xe6, 0x1f, 0x62, 0x4f, 0xc2, 0xa1, 0x3b, 0xaf, 0x03,
+	0xeb, 0xd2, 0x37, 0x36, 0xc1, 0xe2, 0x24, 0x62, 0xa4, 0xfa, 0xac, 0x54, 0x7d, 0xec, 0x2c, 0x4f,
+	0x00, 0x42, 0xf7, 0xe2, 0x04, 0x67, 0xa1, 0xfd, 0xda, 0xf9, 0xf7, 0x7b, 0x56, 0xe9, 0xe3, 0x9e,
+	0x55, 0xfa, 0xf6, 0xb5, 0x3d, 0x2d, 0x5e, 0xe5, 0x71, 0x67, 0x73, 0xff, 0xd0, 0x2c, 0x1f, 0x1c,
+	0x9a, 0xe5, 0x5f, 0x87, 0x66, 0xf9, 0xc3, 0x91, 0x59, 0x3a, 0x38, 0x32, 0x4b, 0xdf, 0x8f, 0xcc,
+	0xd2, 0xe6, 0xbd, 0x31, 0x49, 0xf4, 0xc1, 0xb5, 0x23, 0xe4, 0xb1, 0xc2, 0x71, 0xb6, 0xef, 0x38,
+	0x3b, 0xa7, 0xfd, 0x20, 0xbd, 0x19, 0xf9, 0xcf, 0xb8, 0xf5, 0x7b, 0x00, 0x68, 0x41, 0x82, 0x48,
+	0x4e, 0x05, 0x00, 0x00,
 }
 
 func (m *PoolParams) Marshal() (dAtA []byte, err error) {
@@ -199,6 +212,11 @@ func (m *PoolParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AmplificationParameter != 0 {
+		i = encodeVarintStableswapPool(dAtA, i, uint64(m.AmplificationParameter))
+		i--
+		dAtA[i] = 0x18
+	}
 	{
 		size := m.ExitFee.Size()
 		i -= size
@@ -344,6 +362,9 @@ func (m *PoolParams) Size() (n int) {
 	n += 1 + l + sovStableswapPool(uint64(l))
 	l = m.ExitFee.Size()
 	n += 1 + l + sovStableswapPool(uint64(l))
+	if m.AmplificationParameter != 0 {
+		n += 1 + sovStableswapPool(uint64(m.AmplificationParameter))
+	}
 	return n
 }
 
@@ -491,6 +512,25 @@ func (m *PoolParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AmplificationParameter", wireType)
+			}
+			m.AmplificationParameter = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStableswapPool
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AmplificationParameter |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStableswapPool(dAtA[iNdEx:])
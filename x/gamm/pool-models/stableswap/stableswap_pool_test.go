@@ -0,0 +1,42 @@
+package stableswap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+// TestNewStableswapPoolSortsLiquidity tests that the pool stores its liquidity sorted by denom,
+// whatever order it was given in, so that exits produce identical coins on every node.
+func TestNewStableswapPoolSortsLiquidity(t *testing.T) {
+	params := PoolParams{SwapFee: sdk.MustNewDecFromStr("0.003"), ExitFee: sdk.ZeroDec()}
+	blockTime := time.Unix(1618700000, 0)
+	unsortedLiquidity := sdk.Coins{sdk.NewInt64Coin("uosmo", 2_000_000), sdk.NewInt64Coin("uatom", 1_000_000)}
+
+	pool, err := NewStableswapPool(1, params, unsortedLiquidity, "", blockTime)
+	require.NoError(t, err)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("uatom", 1_000_000), sdk.NewInt64Coin("uosmo", 2_000_000)}, pool.GetTotalPoolLiquidity(sdk.Context{}))
+	// the caller's coins aren't reordered.
+	require.Equal(t, "uosmo", unsortedLiquidity[0].Denom)
+
+	sortedPool, err := NewStableswapPool(1, params, unsortedLiquidity.Sort(), "", blockTime)
+	require.NoError(t, err)
+	require.Equal(t, sortedPool, pool)
+
+	firstExitCoins, err := pool.CalcExitPoolShares(sdk.Context{}, types.OneShare.MulRaw(10), sdk.ZeroDec())
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		exitCoins, err := pool.CalcExitPoolShares(sdk.Context{}, types.OneShare.MulRaw(10), sdk.ZeroDec())
+		require.NoError(t, err)
+		require.Equal(t, firstExitCoins, exitCoins)
+	}
+
+	// duplicate denoms are rejected.
+	_, err = NewStableswapPool(1, params, sdk.Coins{sdk.NewInt64Coin("uatom", 1), sdk.NewInt64Coin("uatom", 2)}, "", blockTime)
+	require.Error(t, err)
+}
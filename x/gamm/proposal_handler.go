@@ -0,0 +1,68 @@
+package gamm
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/keeper"
+	"github.com/osmosis-labs/osmosis/v7/x/gamm/types"
+)
+
+func NewGammProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *types.UpdatePoolSwapFeeProposal:
+			return handleUpdatePoolSwapFeeProposal(ctx, k, c)
+		case *types.SetExitFeeRecipientProposal:
+			return handleSetExitFeeRecipientProposal(ctx, k, c)
+		case *types.SetSwapFeeTiersProposal:
+			return handleSetSwapFeeTiersProposal(ctx, k, c)
+		case *types.SetMinPoolReserveProposal:
+			return handleSetMinPoolReserveProposal(ctx, k, c)
+		case *types.SetDirectionalSwapFeesProposal:
+			return handleSetDirectionalSwapFeesProposal(ctx, k, c)
+		case *types.SetCircuitBreakerEnabledProposal:
+			return handleSetCircuitBreakerEnabledProposal(ctx, k, c)
+		case *types.SetPoolPausedProposal:
+			return handleSetPoolPausedProposal(ctx, k, c)
+		case *types.MigrateBalancerPoolToStableswapProposal:
+			return handleMigrateBalancerPoolToStableswapProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized gamm proposal content type: %T", c)
+		}
+	}
+}
+
+func handleUpdatePoolSwapFeeProposal(ctx sdk.Context, k keeper.Keeper, p *types.UpdatePoolSwapFeeProposal) error {
+	return k.HandleUpdatePoolSwapFeeProposal(ctx, p)
+}
+
+func handleSetExitFeeRecipientProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetExitFeeRecipientProposal) error {
+	return k.HandleSetExitFeeRecipientProposal(ctx, p)
+}
+
+func handleSetSwapFeeTiersProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetSwapFeeTiersProposal) error {
+	return k.HandleSetSwapFeeTiersProposal(ctx, p)
+}
+
+func handleSetMinPoolReserveProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetMinPoolReserveProposal) error {
+	return k.HandleSetMinPoolReserveProposal(ctx, p)
+}
+
+func handleSetDirectionalSwapFeesProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetDirectionalSwapFeesProposal) error {
+	return k.HandleSetDirectionalSwapFeesProposal(ctx, p)
+}
+
+func handleSetCircuitBreakerEnabledProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetCircuitBreakerEnabledProposal) error {
+	return k.HandleSetCircuitBreakerEnabledProposal(ctx, p)
+}
+
+func handleSetPoolPausedProposal(ctx sdk.Context, k keeper.Keeper, p *types.SetPoolPausedProposal) error {
+	return k.HandleSetPoolPausedProposal(ctx, p)
+}
+
+func handleMigrateBalancerPoolToStableswapProposal(ctx sdk.Context, k keeper.Keeper, p *types.MigrateBalancerPoolToStableswapProposal) error {
+	return k.HandleMigrateBalancerPoolToStableswapProposal(ctx, p)
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Validate returns an error unless every factor is of a denom of poolLiquidity, with at most
+// sdk.Precision decimals, and no denom has more than one factor.
+func (factors AssetScalingFactors) Validate(poolLiquidity sdk.Coins) error {
+	seen := make(map[string]bool, len(factors.Factors))
+	for i, factor := range factors.Factors {
+		if poolLiquidity.AmountOf(factor.Denom).IsZero() {
+			return sdkerrors.Wrapf(ErrDenomNotFoundInPool, "scaling factor %d is of %s, which is not an asset of the pool %s", i, factor.Denom, poolLiquidity)
+		}
+		if factor.Decimals > sdk.Precision {
+			return sdkerrors.Wrapf(ErrPrecisionTooLarge, "scaling factor %d of %s has %d decimals, more than %d", i, factor.Denom, factor.Decimals, sdk.Precision)
+		}
+		if seen[factor.Denom] {
+			return sdkerrors.Wrapf(ErrDuplicateScalingFactor, "scaling factor %d of %s is a duplicate", i, factor.Denom)
+		}
+		seen[factor.Denom] = true
+	}
+	return nil
+}
+
+// ScalingFactor returns 10^decimals of denom, and one for a denom without a scaling factor.
+func (factors AssetScalingFactors) ScalingFactor(denom string) sdk.Dec {
+	for _, factor := range factors.Factors {
+		if factor.Denom == denom {
+			return sdk.NewDec(10).Power(factor.Decimals)
+		}
+	}
+	return sdk.OneDec()
+}
@@ -0,0 +1,604 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/gamm/v1beta1/asset_scaling_factor.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// AssetScalingFactor is the number of decimals of an asset of a pool, e.g. 6
+// for uosmo or 18 for wei. The amounts of the asset are scaled by
+// 10^decimals into whole tokens.
+type AssetScalingFactor struct {
+	Denom    string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty" yaml:"denom"`
+	Decimals uint64 `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty" yaml:"decimals"`
+}
+
+func (m *AssetScalingFactor) Reset()         { *m = AssetScalingFactor{} }
+func (m *AssetScalingFactor) String() string { return proto.CompactTextString(m) }
+func (*AssetScalingFactor) ProtoMessage()    {}
+func (*AssetScalingFactor) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9880e4b4f34699f4, []int{0}
+}
+func (m *AssetScalingFactor) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AssetScalingFactor) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AssetScalingFactor.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AssetScalingFactor) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssetScalingFactor.Merge(m, src)
+}
+func (m *AssetScalingFactor) XXX_Size() int {
+	return m.Size()
+}
+func (m *AssetScalingFactor) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssetScalingFactor.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssetScalingFactor proto.InternalMessageInfo
+
+func (m *AssetScalingFactor) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *AssetScalingFactor) GetDecimals() uint64 {
+	if m != nil {
+		return m.Decimals
+	}
+	return 0
+}
+
+// AssetScalingFactors are the decimals of the assets of a pool, for pricing
+// assets of different decimals by their whole tokens. Assets without a scaling
+// factor are priced by their base units.
+type AssetScalingFactors struct {
+	Factors []AssetScalingFactor `protobuf:"bytes,1,rep,name=factors,proto3" json:"factors" yaml:"factors"`
+}
+
+func (m *AssetScalingFactors) Reset()         { *m = AssetScalingFactors{} }
+func (m *AssetScalingFactors) String() string { return proto.CompactTextString(m) }
+func (*AssetScalingFactors) ProtoMessage()    {}
+func (*AssetScalingFactors) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9880e4b4f34699f4, []int{1}
+}
+func (m *AssetScalingFactors) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AssetScalingFactors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AssetScalingFactors.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AssetScalingFactors) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AssetScalingFactors.Merge(m, src)
+}
+func (m *AssetScalingFactors) XXX_Size() int {
+	return m.Size()
+}
+func (m *AssetScalingFactors) XXX_DiscardUnknown() {
+	xxx_messageInfo_AssetScalingFactors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AssetScalingFactors proto.InternalMessageInfo
+
+func (m *AssetScalingFactors) GetFactors() []AssetScalingFactor {
+	if m != nil {
+		return m.Factors
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*AssetScalingFactor)(nil), "osmosis.gamm.v1beta1.AssetScalingFactor")
+	proto.RegisterType((*AssetScalingFactors)(nil), "osmosis.gamm.v1beta1.AssetScalingFactors")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/asset_scaling_factor.proto", fileDescriptor_9880e4b4f34699f4)
+}
+
+var fileDescriptor_9880e4b4f34699f4 = []byte{
+	// 294 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xd2, 0xcf, 0x2f, 0xce, 0xcd,
+	0x2f, 0xce, 0x2c, 0xd6, 0x4f, 0x4f, 0xcc, 0xcd, 0xd5, 0x2f, 0x33, 0x4c, 0x4a, 0x2d, 0x49, 0x34,
+	0xd4, 0x4f, 0x2c, 0x2e, 0x4e, 0x2d, 0x89, 0x2f, 0x4e, 0x4e, 0xcc, 0xc9, 0xcc, 0x4b, 0x8f, 0x4f,
+	0x4b, 0x4c, 0x2e, 0xc9, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x81, 0x6a, 0xd0,
+	0x03, 0x69, 0xd0, 0x83, 0x6a, 0x90, 0x12, 0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x2b, 0xd0, 0x07, 0xb1,
+	0x20, 0x6a, 0x95, 0x8a, 0xb9, 0x84, 0x1c, 0x41, 0x26, 0x05, 0x43, 0x0c, 0x72, 0x03, 0x9b, 0x23,
+	0xa4, 0xc6, 0xc5, 0x9a, 0x92, 0x9a, 0x97, 0x9f, 0x2b, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0xe9, 0x24,
+	0xf0, 0xe9, 0x9e, 0x3c, 0x4f, 0x65, 0x62, 0x6e, 0x8e, 0x95, 0x12, 0x58, 0x58, 0x29, 0x08, 0x22,
+	0x2d, 0xa4, 0xcf, 0xc5, 0x91, 0x92, 0x9a, 0x9c, 0x99, 0x9b, 0x98, 0x53, 0x2c, 0xc1, 0xa4, 0xc0,
+	0xa8, 0xc1, 0xe2, 0x24, 0xfc, 0xe9, 0x9e, 0x3c, 0x3f, 0x4c, 0x29, 0x44, 0x46, 0x29, 0x08, 0xae,
+	0xc8, 0x8a, 0xe5, 0xc5, 0x02, 0x79, 0x46, 0xa5, 0x72, 0x2e, 0x61, 0x4c, 0x4b, 0x8b, 0x85, 0xa2,
+	0xb8, 0xd8, 0x21, 0xfe, 0x28, 0x96, 0x60, 0x54, 0x60, 0xd6, 0xe0, 0x36, 0xd2, 0xd0, 0xc3, 0xe6,
+	0x13, 0x3d, 0x4c, 0xbd, 0x4e, 0x62, 0x27, 0xee, 0xc9, 0x33, 0x7c, 0xba, 0x27, 0xcf, 0x07, 0xb1,
+	0x1a, 0x6a, 0x8c, 0x52, 0x10, 0xcc, 0x40, 0x88, 0xc5, 0x4e, 0x9e, 0x27, 0x1e, 0xc9, 0x31, 0x5e,
+	0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31,
+	0xdc, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x9f, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f,
+	0x0b, 0x0b, 0x6f, 0xdd, 0x9c, 0xc4, 0xa4, 0x62, 0x18, 0x47, 0xbf, 0xcc, 0x5c, 0xbf, 0x02, 0x12,
+	0x03, 0x25, 0x95, 0x05, 0xa9, 0xc5, 0x49, 0x6c, 0xe0, 0xf0, 0x33, 0x06, 0x0c, 0x00, 0xcc, 0x50,
+	0x85, 0xeb, 0x9e, 0x01, 0x00, 0x00,
+}
+
+func (this *AssetScalingFactor) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AssetScalingFactor)
+	if !ok {
+		that2, ok := that.(AssetScalingFactor)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Denom != that1.Denom {
+		return false
+	}
+	if this.Decimals != that1.Decimals {
+		return false
+	}
+	return true
+}
+func (this *AssetScalingFactors) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*AssetScalingFactors)
+	if !ok {
+		that2, ok := that.(AssetScalingFactors)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Factors) != len(that1.Factors) {
+		return false
+	}
+	for i := range this.Factors {
+		if !this.Factors[i].Equal(&that1.Factors[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (m *AssetScalingFactor) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AssetScalingFactor) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AssetScalingFactor) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Decimals != 0 {
+		i = encodeVarintAssetScalingFactor(dAtA, i, uint64(m.Decimals))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintAssetScalingFactor(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AssetScalingFactors) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AssetScalingFactors) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AssetScalingFactors) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Factors) > 0 {
+		for iNdEx := len(m.Factors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Factors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAssetScalingFactor(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintAssetScalingFactor(dAtA []byte, offset int, v uint64) int {
+	offset -= sovAssetScalingFactor(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *AssetScalingFactor) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovAssetScalingFactor(uint64(l))
+	}
+	if m.Decimals != 0 {
+		n += 1 + sovAssetScalingFactor(uint64(m.Decimals))
+	}
+	return n
+}
+
+func (m *AssetScalingFactors) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Factors) > 0 {
+		for _, e := range m.Factors {
+			l = e.Size()
+			n += 1 + l + sovAssetScalingFactor(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovAssetScalingFactor(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozAssetScalingFactor(x uint64) (n int) {
+	return sovAssetScalingFactor(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *AssetScalingFactor) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAssetScalingFactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AssetScalingFactor: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AssetScalingFactor: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAssetScalingFactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decimals", wireType)
+			}
+			m.Decimals = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAssetScalingFactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Decimals |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAssetScalingFactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AssetScalingFactors) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAssetScalingFactor
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AssetScalingFactors: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AssetScalingFactors: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Factors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAssetScalingFactor
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Factors = append(m.Factors, AssetScalingFactor{})
+			if err := m.Factors[len(m.Factors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAssetScalingFactor(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAssetScalingFactor
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipAssetScalingFactor(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowAssetScalingFactor
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAssetScalingFactor
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowAssetScalingFactor
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthAssetScalingFactor
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupAssetScalingFactor
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthAssetScalingFactor
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthAssetScalingFactor        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowAssetScalingFactor          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupAssetScalingFactor = fmt.Errorf("proto: unexpected end of group")
+)
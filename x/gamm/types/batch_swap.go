@@ -0,0 +1,14 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BatchSwapAmountIn is one of the independent exact amount in swaps of a batch swap,
+// with its own pool and minimum amount out.
+type BatchSwapAmountIn struct {
+	PoolId            uint64   `json:"pool_id"`
+	TokenIn           sdk.Coin `json:"token_in"`
+	TokenOutDenom     string   `json:"token_out_denom"`
+	TokenOutMinAmount sdk.Int  `json:"token_out_min_amount"`
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 )
 
 // RegisterLegacyAminoCodec registers the necessary x/gamm interfaces and concrete types
@@ -19,6 +20,14 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgJoinSwapShareAmountOut{}, "osmosis/gamm/join-swap-share-amount-out", nil)
 	cdc.RegisterConcrete(&MsgExitSwapExternAmountOut{}, "osmosis/gamm/exit-swap-extern-amount-out", nil)
 	cdc.RegisterConcrete(&MsgExitSwapShareAmountIn{}, "osmosis/gamm/exit-swap-share-amount-in", nil)
+	cdc.RegisterConcrete(&UpdatePoolSwapFeeProposal{}, "osmosis/UpdatePoolSwapFeeProposal", nil)
+	cdc.RegisterConcrete(&SetExitFeeRecipientProposal{}, "osmosis/SetExitFeeRecipientProposal", nil)
+	cdc.RegisterConcrete(&SetSwapFeeTiersProposal{}, "osmosis/SetSwapFeeTiersProposal", nil)
+	cdc.RegisterConcrete(&SetMinPoolReserveProposal{}, "osmosis/SetMinPoolReserveProposal", nil)
+	cdc.RegisterConcrete(&SetDirectionalSwapFeesProposal{}, "osmosis/SetDirectionalSwapFeesProposal", nil)
+	cdc.RegisterConcrete(&SetCircuitBreakerEnabledProposal{}, "osmosis/SetCircuitBreakerEnabledProposal", nil)
+	cdc.RegisterConcrete(&SetPoolPausedProposal{}, "osmosis/SetPoolPausedProposal", nil)
+	cdc.RegisterConcrete(&MigrateBalancerPoolToStableswapProposal{}, "osmosis/MigrateBalancerPoolToStableswapProposal", nil)
 }
 
 func RegisterInterfaces(registry types.InterfaceRegistry) {
@@ -38,6 +47,17 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgExitSwapExternAmountOut{},
 		&MsgExitSwapShareAmountIn{},
 	)
+	registry.RegisterImplementations(
+		(*govtypes.Content)(nil),
+		&UpdatePoolSwapFeeProposal{},
+		&SetExitFeeRecipientProposal{},
+		&SetSwapFeeTiersProposal{},
+		&SetMinPoolReserveProposal{},
+		&SetDirectionalSwapFeesProposal{},
+		&SetCircuitBreakerEnabledProposal{},
+		&SetPoolPausedProposal{},
+		&MigrateBalancerPoolToStableswapProposal{},
+	)
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
 
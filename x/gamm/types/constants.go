@@ -1,17 +1,29 @@
 package types
 
 import (
+	"math/big"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
 const (
 	MinPoolAssets = 2
-	MaxPoolAssets = 8
+	// DefaultMaxPoolAssets is the default of the MaxPoolAssets param, the maximum number of assets of a new pool.
+	DefaultMaxPoolAssets = 8
+	// MaxPoolAssetsLimit is the most assets any pool can have, whatever the MaxPoolAssets param.
+	// Pool operations iterate over all of a pool's assets, so message validation rejects more assets
+	// than this without reading the param.
+	MaxPoolAssetsLimit = 16
 
 	OneShareExponent = 18
 	// Raise 10 to the power of SigFigsExponent to determine number of significant figures.
 	// i.e. SigFigExponent = 8 is 10^8 which is 100000000. This gives 8 significant figures.
 	SigFigsExponent = 8
+
+	// V11UpgradeHeight is the height of the v11 upgrade. Changes of v11 to the results of existing
+	// pool operations only apply from this height on, so that earlier blocks replay identically.
+	V11UpgradeHeight = 5432450
 )
 
 var (
@@ -21,6 +33,32 @@ var (
 	// InitPoolSharesSupply is the amount of new shares to initialize a pool with.
 	InitPoolSharesSupply = OneShare.MulRaw(100)
 
+	// MaxTotalShares is the most shares a pool can have, 2^128, about 3.4 * 10^20 OneShare.
+	// The LP math multiplies the total shares by share ratios and liquidity as sdk.Dec,
+	// which overflows near 2^256 / 10^18, so joins are bounded well below that, see ValidateTotalSharesAfterJoin.
+	MaxTotalShares = sdk.NewIntFromBigInt(new(big.Int).Lsh(big.NewInt(1), 128))
+
+	// MinimumLiquidityShares is the amount of the initial shares of every pool that is locked forever,
+	// by minting it to MinimumLiquidityAddress rather than to the pool creator.
+	// As exiting all the shares of a pool is not allowed, this keeps the total shares of a pool above
+	// MinimumLiquidityShares, so that they can't be driven to a near zero denominator in the LP math.
+	MinimumLiquidityShares = sdk.NewInt(1_000_000)
+
+	// MinimumLiquidityAddress is the address holding the MinimumLiquidityShares of every pool.
+	// It is derived like a module account address, so that nobody has its private key.
+	MinimumLiquidityAddress = authtypes.NewModuleAddress(ModuleName + "/minimum-liquidity")
+
+	// DefaultMinPoolReserve is the minimum reserve of every denom in a pool, of denoms without a minimum set.
+	// Swaps can't take a reserve below its minimum, so that pools are never drained to dust,
+	// at which their spot prices and swap amounts lose all precision.
+	DefaultMinPoolReserve = sdk.NewInt(10)
+
 	// SigFigs is the amount of significant figures used to calculate SpotPrice
 	SigFigs = sdk.NewDec(10).Power(SigFigsExponent).TruncateInt()
+
+	// SpotPriceReciprocalTolerance bounds how far the product of the spot prices of a balancer pool
+	// in both directions may be from one. Each price is rounded to SigFigs significant figures,
+	// a relative error of at most 5*10^-SigFigsExponent, so their product is off by at most twice that.
+	// Prices below 10^-10 lose further precision to the 18 decimals of sdk.Dec.
+	SpotPriceReciprocalTolerance = sdk.NewDecWithPrec(1, SigFigsExponent-1)
 )
@@ -0,0 +1,62 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Validate returns an error unless the fees are valid, see ValidateBasic,
+// and every fee is between denoms of poolLiquidity.
+func (fees DirectionalSwapFees) Validate(poolLiquidity sdk.Coins) error {
+	for i, fee := range fees.Fees {
+		if poolLiquidity.AmountOf(fee.TokenInDenom).IsZero() || poolLiquidity.AmountOf(fee.TokenOutDenom).IsZero() {
+			return sdkerrors.Wrapf(ErrDenomNotFoundInPool, "fee %d is of %s for %s, which are not both assets of the pool %s", i, fee.TokenInDenom, fee.TokenOutDenom, poolLiquidity)
+		}
+	}
+	return fees.ValidateBasic()
+}
+
+// ValidateBasic returns an error unless every fee is a valid swap fee, between two different denoms,
+// and no direction has more than one fee.
+func (fees DirectionalSwapFees) ValidateBasic() error {
+	seen := make(map[[2]string]bool, len(fees.Fees))
+	for i, fee := range fees.Fees {
+		if err := sdk.ValidateDenom(fee.TokenInDenom); err != nil {
+			return sdkerrors.Wrapf(err, "fee %d", i)
+		}
+		if err := sdk.ValidateDenom(fee.TokenOutDenom); err != nil {
+			return sdkerrors.Wrapf(err, "fee %d", i)
+		}
+		if fee.TokenInDenom == fee.TokenOutDenom {
+			return sdkerrors.Wrapf(ErrSameDenom, "fee %d has the same token in and out denom %s", i, fee.TokenInDenom)
+		}
+		if fee.SwapFee.IsNil() {
+			return sdkerrors.Wrapf(ErrNegativeSwapFee, "fee %d has no swap fee", i)
+		}
+		if err := ValidateSwapFee(fee.SwapFee); err != nil {
+			return sdkerrors.Wrapf(err, "fee %d swap fee %s", i, fee.SwapFee)
+		}
+		direction := [2]string{fee.TokenInDenom, fee.TokenOutDenom}
+		if seen[direction] {
+			return sdkerrors.Wrapf(ErrDuplicateSwapDirection, "fee %d of %s for %s is a duplicate", i, fee.TokenInDenom, fee.TokenOutDenom)
+		}
+		seen[direction] = true
+	}
+	return nil
+}
+
+// SwapFeeForDirection returns the fee of swapping tokenInDenom for tokenOutDenom.
+// Without a fee of that direction, it is the fee of the opposite direction, and without either, swapFee.
+func (fees DirectionalSwapFees) SwapFeeForDirection(swapFee sdk.Dec, tokenInDenom, tokenOutDenom string) sdk.Dec {
+	for _, fee := range fees.Fees {
+		if fee.TokenInDenom == tokenInDenom && fee.TokenOutDenom == tokenOutDenom {
+			return fee.SwapFee
+		}
+	}
+	for _, fee := range fees.Fees {
+		if fee.TokenInDenom == tokenOutDenom && fee.TokenOutDenom == tokenInDenom {
+			return fee.SwapFee
+		}
+	}
+	return swapFee
+}
@@ -0,0 +1,675 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/gamm/v1beta1/directional_swap_fee.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// DirectionalSwapFee is the swap fee of a pool for swapping token_in_denom for
+// token_out_denom.
+type DirectionalSwapFee struct {
+	TokenInDenom  string                                 `protobuf:"bytes,1,opt,name=token_in_denom,json=tokenInDenom,proto3" json:"token_in_denom,omitempty" yaml:"token_in_denom"`
+	TokenOutDenom string                                 `protobuf:"bytes,2,opt,name=token_out_denom,json=tokenOutDenom,proto3" json:"token_out_denom,omitempty" yaml:"token_out_denom"`
+	SwapFee       github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,3,opt,name=swap_fee,json=swapFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"swap_fee" yaml:"swap_fee"`
+}
+
+func (m *DirectionalSwapFee) Reset()         { *m = DirectionalSwapFee{} }
+func (m *DirectionalSwapFee) String() string { return proto.CompactTextString(m) }
+func (*DirectionalSwapFee) ProtoMessage()    {}
+func (*DirectionalSwapFee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d784d0deb038a53d, []int{0}
+}
+func (m *DirectionalSwapFee) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DirectionalSwapFee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DirectionalSwapFee.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DirectionalSwapFee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectionalSwapFee.Merge(m, src)
+}
+func (m *DirectionalSwapFee) XXX_Size() int {
+	return m.Size()
+}
+func (m *DirectionalSwapFee) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectionalSwapFee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectionalSwapFee proto.InternalMessageInfo
+
+func (m *DirectionalSwapFee) GetTokenInDenom() string {
+	if m != nil {
+		return m.TokenInDenom
+	}
+	return ""
+}
+
+func (m *DirectionalSwapFee) GetTokenOutDenom() string {
+	if m != nil {
+		return m.TokenOutDenom
+	}
+	return ""
+}
+
+// DirectionalSwapFees are a pool's swap fees by swap direction, e.g. to charge
+// a higher fee for buying the scarcer asset of a pair. A pair with the fee of
+// only one direction set charges it in both directions, and pairs without any
+// fee set are charged the pool's swap fee.
+type DirectionalSwapFees struct {
+	Fees []DirectionalSwapFee `protobuf:"bytes,1,rep,name=fees,proto3" json:"fees" yaml:"fees"`
+}
+
+func (m *DirectionalSwapFees) Reset()         { *m = DirectionalSwapFees{} }
+func (m *DirectionalSwapFees) String() string { return proto.CompactTextString(m) }
+func (*DirectionalSwapFees) ProtoMessage()    {}
+func (*DirectionalSwapFees) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d784d0deb038a53d, []int{1}
+}
+func (m *DirectionalSwapFees) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DirectionalSwapFees) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DirectionalSwapFees.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DirectionalSwapFees) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectionalSwapFees.Merge(m, src)
+}
+func (m *DirectionalSwapFees) XXX_Size() int {
+	return m.Size()
+}
+func (m *DirectionalSwapFees) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectionalSwapFees.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirectionalSwapFees proto.InternalMessageInfo
+
+func (m *DirectionalSwapFees) GetFees() []DirectionalSwapFee {
+	if m != nil {
+		return m.Fees
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*DirectionalSwapFee)(nil), "osmosis.gamm.v1beta1.DirectionalSwapFee")
+	proto.RegisterType((*DirectionalSwapFees)(nil), "osmosis.gamm.v1beta1.DirectionalSwapFees")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/directional_swap_fee.proto", fileDescriptor_d784d0deb038a53d)
+}
+
+var fileDescriptor_d784d0deb038a53d = []byte{
+	// 365 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x4e, 0xea, 0x40,
+	0x18, 0xc5, 0x3b, 0x17, 0x72, 0xff, 0x94, 0xab, 0x24, 0x05, 0x0d, 0xb2, 0x68, 0x49, 0x17, 0x86,
+	0x0d, 0x33, 0x41, 0x17, 0x26, 0x6c, 0x8c, 0x0d, 0x31, 0x61, 0x65, 0xac, 0x3b, 0x63, 0xd2, 0x4c,
+	0xcb, 0x50, 0x1b, 0x68, 0xa7, 0x61, 0x06, 0x90, 0xb7, 0xf0, 0x11, 0x7c, 0x1c, 0x96, 0x2c, 0x8d,
+	0x8b, 0xc6, 0xc0, 0xc6, 0x75, 0xf7, 0x26, 0xa6, 0x33, 0x45, 0x31, 0xb8, 0x6a, 0xbf, 0xaf, 0xe7,
+	0xfc, 0x4e, 0x4f, 0x3b, 0x2a, 0xa2, 0x2c, 0xa4, 0x2c, 0x60, 0xc8, 0xc7, 0x61, 0x88, 0xa6, 0x6d,
+	0x97, 0x70, 0xdc, 0x46, 0xfd, 0x60, 0x4c, 0x3c, 0x1e, 0xd0, 0x08, 0x8f, 0x1c, 0x36, 0xc3, 0xb1,
+	0x33, 0x20, 0x04, 0xc6, 0x63, 0xca, 0xa9, 0x56, 0xcd, 0x0d, 0x30, 0x33, 0xc0, 0xdc, 0x50, 0xaf,
+	0xfa, 0xd4, 0xa7, 0x42, 0x80, 0xb2, 0x3b, 0xa9, 0x35, 0xdf, 0x81, 0xaa, 0x75, 0xbf, 0x50, 0x37,
+	0x33, 0x1c, 0x5f, 0x12, 0xa2, 0x9d, 0xab, 0xfb, 0x9c, 0x0e, 0x49, 0xe4, 0x04, 0x91, 0xd3, 0x27,
+	0x11, 0x0d, 0x6b, 0xa0, 0x01, 0x9a, 0xff, 0xac, 0xa3, 0x34, 0x31, 0x0e, 0xe6, 0x38, 0x1c, 0x75,
+	0xcc, 0xef, 0xcf, 0x4d, 0xfb, 0xbf, 0x58, 0xf4, 0xa2, 0x6e, 0x36, 0x6a, 0x96, 0x5a, 0x96, 0x02,
+	0x3a, 0xe1, 0x39, 0xe1, 0x97, 0x20, 0xd4, 0xd3, 0xc4, 0x38, 0xdc, 0x26, 0x7c, 0x0a, 0x4c, 0x7b,
+	0x4f, 0x6c, 0xae, 0x26, 0x5c, 0x32, 0xee, 0xd4, 0xbf, 0x9b, 0x66, 0xb5, 0x82, 0x30, 0x5f, 0x2c,
+	0x12, 0x43, 0x79, 0x49, 0x8c, 0x63, 0x3f, 0xe0, 0xf7, 0x13, 0x17, 0x7a, 0x34, 0x44, 0x9e, 0x68,
+	0x9b, 0x5f, 0x5a, 0xac, 0x3f, 0x44, 0x7c, 0x1e, 0x13, 0x06, 0xbb, 0xc4, 0x4b, 0x13, 0xa3, 0x2c,
+	0xa3, 0x36, 0x1c, 0xd3, 0xfe, 0xc3, 0x64, 0xc5, 0x4e, 0xf1, 0xed, 0xc9, 0x00, 0x66, 0xa4, 0x56,
+	0x76, 0xeb, 0x33, 0xed, 0x5a, 0x2d, 0x0e, 0x08, 0x61, 0x35, 0xd0, 0x28, 0x34, 0x4b, 0x27, 0x4d,
+	0xf8, 0xd3, 0x17, 0x85, 0xbb, 0x46, 0xab, 0x92, 0xbd, 0x60, 0x9a, 0x18, 0x25, 0x19, 0x9b, 0x31,
+	0x4c, 0x5b, 0xa0, 0x64, 0x9e, 0xd5, 0x5b, 0xac, 0x74, 0xb0, 0x5c, 0xe9, 0xe0, 0x75, 0xa5, 0x83,
+	0xc7, 0xb5, 0xae, 0x2c, 0xd7, 0xba, 0xf2, 0xbc, 0xd6, 0x95, 0x5b, 0xb4, 0xd5, 0x29, 0x8f, 0x6b,
+	0x8d, 0xb0, 0xcb, 0x36, 0x03, 0x9a, 0x9e, 0xa1, 0x07, 0x79, 0x06, 0x44, 0x41, 0xf7, 0xb7, 0xf8,
+	0x83, 0xa7, 0x1f, 0x03, 0x00, 0xe5, 0x0f, 0x3f, 0xbd, 0x20, 0x02, 0x00, 0x00,
+}
+
+func (this *DirectionalSwapFee) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DirectionalSwapFee)
+	if !ok {
+		that2, ok := that.(DirectionalSwapFee)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TokenInDenom != that1.TokenInDenom {
+		return false
+	}
+	if this.TokenOutDenom != that1.TokenOutDenom {
+		return false
+	}
+	if !this.SwapFee.Equal(that1.SwapFee) {
+		return false
+	}
+	return true
+}
+func (this *DirectionalSwapFees) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DirectionalSwapFees)
+	if !ok {
+		that2, ok := that.(DirectionalSwapFees)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Fees) != len(that1.Fees) {
+		return false
+	}
+	for i := range this.Fees {
+		if !this.Fees[i].Equal(&that1.Fees[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (m *DirectionalSwapFee) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DirectionalSwapFee) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DirectionalSwapFee) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.SwapFee.Size()
+		i -= size
+		if _, err := m.SwapFee.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintDirectionalSwapFee(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.TokenOutDenom) > 0 {
+		i -= len(m.TokenOutDenom)
+		copy(dAtA[i:], m.TokenOutDenom)
+		i = encodeVarintDirectionalSwapFee(dAtA, i, uint64(len(m.TokenOutDenom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TokenInDenom) > 0 {
+		i -= len(m.TokenInDenom)
+		copy(dAtA[i:], m.TokenInDenom)
+		i = encodeVarintDirectionalSwapFee(dAtA, i, uint64(len(m.TokenInDenom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DirectionalSwapFees) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DirectionalSwapFees) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DirectionalSwapFees) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Fees) > 0 {
+		for iNdEx := len(m.Fees) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fees[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDirectionalSwapFee(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDirectionalSwapFee(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDirectionalSwapFee(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DirectionalSwapFee) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenInDenom)
+	if l > 0 {
+		n += 1 + l + sovDirectionalSwapFee(uint64(l))
+	}
+	l = len(m.TokenOutDenom)
+	if l > 0 {
+		n += 1 + l + sovDirectionalSwapFee(uint64(l))
+	}
+	l = m.SwapFee.Size()
+	n += 1 + l + sovDirectionalSwapFee(uint64(l))
+	return n
+}
+
+func (m *DirectionalSwapFees) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Fees) > 0 {
+		for _, e := range m.Fees {
+			l = e.Size()
+			n += 1 + l + sovDirectionalSwapFee(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDirectionalSwapFee(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDirectionalSwapFee(x uint64) (n int) {
+	return sovDirectionalSwapFee(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DirectionalSwapFee) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDirectionalSwapFee
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DirectionalSwapFee: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DirectionalSwapFee: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenInDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenInDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenOutDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenOutDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapFee", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SwapFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDirectionalSwapFee(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DirectionalSwapFees) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDirectionalSwapFee
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DirectionalSwapFees: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DirectionalSwapFees: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fees", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fees = append(m.Fees, DirectionalSwapFee{})
+			if err := m.Fees[len(m.Fees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDirectionalSwapFee(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDirectionalSwapFee
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipDirectionalSwapFee(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowDirectionalSwapFee
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDirectionalSwapFee
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthDirectionalSwapFee
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupDirectionalSwapFee
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthDirectionalSwapFee
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthDirectionalSwapFee        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowDirectionalSwapFee          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupDirectionalSwapFee = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,35 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Validate returns an error unless every direction is between two different denoms of poolLiquidity,
+// and no direction is disabled more than once.
+func (disabled DisabledSwapDirections) Validate(poolLiquidity sdk.Coins) error {
+	seen := make(map[SwapDirection]bool, len(disabled.Directions))
+	for i, direction := range disabled.Directions {
+		if poolLiquidity.AmountOf(direction.TokenInDenom).IsZero() || poolLiquidity.AmountOf(direction.TokenOutDenom).IsZero() {
+			return sdkerrors.Wrapf(ErrDenomNotFoundInPool, "direction %d is of %s for %s, which are not both assets of the pool %s", i, direction.TokenInDenom, direction.TokenOutDenom, poolLiquidity)
+		}
+		if direction.TokenInDenom == direction.TokenOutDenom {
+			return sdkerrors.Wrapf(ErrSameDenom, "direction %d has the same token in and out denom %s", i, direction.TokenInDenom)
+		}
+		if seen[direction] {
+			return sdkerrors.Wrapf(ErrDuplicateSwapDirection, "direction %d of %s for %s is a duplicate", i, direction.TokenInDenom, direction.TokenOutDenom)
+		}
+		seen[direction] = true
+	}
+	return nil
+}
+
+// IsDisabled returns whether swapping tokenInDenom for tokenOutDenom is disabled.
+func (disabled DisabledSwapDirections) IsDisabled(tokenInDenom, tokenOutDenom string) bool {
+	for _, direction := range disabled.Directions {
+		if direction.TokenInDenom == tokenInDenom && direction.TokenOutDenom == tokenOutDenom {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,621 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/gamm/v1beta1/disabled_swap_direction.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SwapDirection is the direction of swapping token_in_denom for
+// token_out_denom.
+type SwapDirection struct {
+	TokenInDenom  string `protobuf:"bytes,1,opt,name=token_in_denom,json=tokenInDenom,proto3" json:"token_in_denom,omitempty" yaml:"token_in_denom"`
+	TokenOutDenom string `protobuf:"bytes,2,opt,name=token_out_denom,json=tokenOutDenom,proto3" json:"token_out_denom,omitempty" yaml:"token_out_denom"`
+}
+
+func (m *SwapDirection) Reset()         { *m = SwapDirection{} }
+func (m *SwapDirection) String() string { return proto.CompactTextString(m) }
+func (*SwapDirection) ProtoMessage()    {}
+func (*SwapDirection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b0d73476012dd220, []int{0}
+}
+func (m *SwapDirection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SwapDirection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SwapDirection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SwapDirection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapDirection.Merge(m, src)
+}
+func (m *SwapDirection) XXX_Size() int {
+	return m.Size()
+}
+func (m *SwapDirection) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapDirection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapDirection proto.InternalMessageInfo
+
+func (m *SwapDirection) GetTokenInDenom() string {
+	if m != nil {
+		return m.TokenInDenom
+	}
+	return ""
+}
+
+func (m *SwapDirection) GetTokenOutDenom() string {
+	if m != nil {
+		return m.TokenOutDenom
+	}
+	return ""
+}
+
+// DisabledSwapDirections are the swap directions a pool rejects swaps in, e.g.
+// to allow buying an asset but not selling it back during a one-way bonding
+// curve phase. Disabling a direction doesn't disable the opposite direction.
+type DisabledSwapDirections struct {
+	Directions []SwapDirection `protobuf:"bytes,1,rep,name=directions,proto3" json:"directions" yaml:"directions"`
+}
+
+func (m *DisabledSwapDirections) Reset()         { *m = DisabledSwapDirections{} }
+func (m *DisabledSwapDirections) String() string { return proto.CompactTextString(m) }
+func (*DisabledSwapDirections) ProtoMessage()    {}
+func (*DisabledSwapDirections) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b0d73476012dd220, []int{1}
+}
+func (m *DisabledSwapDirections) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DisabledSwapDirections) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DisabledSwapDirections.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DisabledSwapDirections) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisabledSwapDirections.Merge(m, src)
+}
+func (m *DisabledSwapDirections) XXX_Size() int {
+	return m.Size()
+}
+func (m *DisabledSwapDirections) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisabledSwapDirections.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisabledSwapDirections proto.InternalMessageInfo
+
+func (m *DisabledSwapDirections) GetDirections() []SwapDirection {
+	if m != nil {
+		return m.Directions
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SwapDirection)(nil), "osmosis.gamm.v1beta1.SwapDirection")
+	proto.RegisterType((*DisabledSwapDirections)(nil), "osmosis.gamm.v1beta1.DisabledSwapDirections")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/disabled_swap_direction.proto", fileDescriptor_b0d73476012dd220)
+}
+
+var fileDescriptor_b0d73476012dd220 = []byte{
+	// 323 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x31, 0x4f, 0x3a, 0x31,
+	0x18, 0xc6, 0xaf, 0xff, 0xbf, 0x31, 0xb1, 0x8a, 0xc6, 0x0b, 0x12, 0x60, 0xb8, 0x23, 0x75, 0x61,
+	0xb1, 0x0d, 0x38, 0x98, 0xb0, 0x98, 0x5c, 0x58, 0x98, 0x4c, 0x70, 0x73, 0xf0, 0xd2, 0xe3, 0x9a,
+	0xb3, 0x91, 0x5e, 0x2f, 0xb4, 0x80, 0x2c, 0x7e, 0x06, 0x67, 0x27, 0x3f, 0x0e, 0x23, 0xa3, 0xd3,
+	0xc5, 0xc0, 0xe2, 0xcc, 0x27, 0x30, 0xf4, 0x0e, 0xe4, 0x12, 0xb7, 0xbe, 0x6f, 0x9f, 0xe7, 0xf7,
+	0xbe, 0x4f, 0x0b, 0xdb, 0x52, 0x09, 0xa9, 0xb8, 0x22, 0x11, 0x15, 0x82, 0x4c, 0x5a, 0x01, 0xd3,
+	0xb4, 0x45, 0x42, 0xae, 0x68, 0x30, 0x64, 0xa1, 0xaf, 0xa6, 0x34, 0xf1, 0x43, 0x3e, 0x62, 0x03,
+	0xcd, 0x65, 0x8c, 0x93, 0x91, 0xd4, 0xd2, 0x2e, 0xe7, 0x1e, 0xbc, 0xf1, 0xe0, 0xdc, 0x53, 0x2f,
+	0x47, 0x32, 0x92, 0x46, 0x40, 0x36, 0xa7, 0x4c, 0x8b, 0xde, 0x01, 0x2c, 0xdd, 0x4f, 0x69, 0xd2,
+	0xdd, 0x32, 0xec, 0x5b, 0x78, 0xaa, 0xe5, 0x33, 0x8b, 0x7d, 0x1e, 0xfb, 0x21, 0x8b, 0xa5, 0xa8,
+	0x82, 0x06, 0x68, 0x1e, 0x79, 0xb5, 0x75, 0xea, 0x5e, 0xcc, 0xa8, 0x18, 0x76, 0x50, 0xf1, 0x1e,
+	0xf5, 0x4f, 0x4c, 0xa3, 0x17, 0x77, 0x37, 0xa5, 0xed, 0xc1, 0xb3, 0x4c, 0x20, 0xc7, 0x3a, 0x27,
+	0xfc, 0x33, 0x84, 0xfa, 0x3a, 0x75, 0x2b, 0xfb, 0x84, 0x9d, 0x00, 0xf5, 0x4b, 0xa6, 0x73, 0x37,
+	0xd6, 0x86, 0xd1, 0x39, 0xf8, 0xfe, 0x70, 0x01, 0x7a, 0x85, 0x95, 0x6e, 0x9e, 0xb4, 0xb0, 0xa3,
+	0xb2, 0x1f, 0x21, 0xdc, 0xa5, 0x56, 0x55, 0xd0, 0xf8, 0xdf, 0x3c, 0x6e, 0x5f, 0xe2, 0xbf, 0x72,
+	0xe3, 0x82, 0xd3, 0xab, 0xcd, 0x53, 0xd7, 0x5a, 0xa7, 0xee, 0x79, 0xb6, 0xc7, 0x2f, 0x04, 0xf5,
+	0xf7, 0x88, 0xd9, 0x7c, 0xaf, 0x37, 0x5f, 0x3a, 0x60, 0xb1, 0x74, 0xc0, 0xd7, 0xd2, 0x01, 0x6f,
+	0x2b, 0xc7, 0x5a, 0xac, 0x1c, 0xeb, 0x73, 0xe5, 0x58, 0x0f, 0x24, 0xe2, 0xfa, 0x69, 0x1c, 0xe0,
+	0x81, 0x14, 0x24, 0x9f, 0x7a, 0x35, 0xa4, 0x81, 0xda, 0x16, 0x64, 0x72, 0x43, 0x5e, 0xb2, 0x3f,
+	0xd3, 0xb3, 0x84, 0xa9, 0xe0, 0xd0, 0x3c, 0xf7, 0xf5, 0xcf, 0x00, 0x9b, 0x1e, 0x3d, 0xf4, 0xd0,
+	0x01, 0x00, 0x00,
+}
+
+func (this *SwapDirection) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SwapDirection)
+	if !ok {
+		that2, ok := that.(SwapDirection)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.TokenInDenom != that1.TokenInDenom {
+		return false
+	}
+	if this.TokenOutDenom != that1.TokenOutDenom {
+		return false
+	}
+	return true
+}
+func (this *DisabledSwapDirections) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*DisabledSwapDirections)
+	if !ok {
+		that2, ok := that.(DisabledSwapDirections)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Directions) != len(that1.Directions) {
+		return false
+	}
+	for i := range this.Directions {
+		if !this.Directions[i].Equal(&that1.Directions[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (m *SwapDirection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SwapDirection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SwapDirection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TokenOutDenom) > 0 {
+		i -= len(m.TokenOutDenom)
+		copy(dAtA[i:], m.TokenOutDenom)
+		i = encodeVarintDisabledSwapDirection(dAtA, i, uint64(len(m.TokenOutDenom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TokenInDenom) > 0 {
+		i -= len(m.TokenInDenom)
+		copy(dAtA[i:], m.TokenInDenom)
+		i = encodeVarintDisabledSwapDirection(dAtA, i, uint64(len(m.TokenInDenom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DisabledSwapDirections) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DisabledSwapDirections) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DisabledSwapDirections) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Directions) > 0 {
+		for iNdEx := len(m.Directions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Directions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDisabledSwapDirection(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintDisabledSwapDirection(dAtA []byte, offset int, v uint64) int {
+	offset -= sovDisabledSwapDirection(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SwapDirection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenInDenom)
+	if l > 0 {
+		n += 1 + l + sovDisabledSwapDirection(uint64(l))
+	}
+	l = len(m.TokenOutDenom)
+	if l > 0 {
+		n += 1 + l + sovDisabledSwapDirection(uint64(l))
+	}
+	return n
+}
+
+func (m *DisabledSwapDirections) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Directions) > 0 {
+		for _, e := range m.Directions {
+			l = e.Size()
+			n += 1 + l + sovDisabledSwapDirection(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovDisabledSwapDirection(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozDisabledSwapDirection(x uint64) (n int) {
+	return sovDisabledSwapDirection(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SwapDirection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDisabledSwapDirection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SwapDirection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SwapDirection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenInDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDisabledSwapDirection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenInDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenOutDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDisabledSwapDirection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenOutDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDisabledSwapDirection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DisabledSwapDirections) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDisabledSwapDirection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DisabledSwapDirections: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DisabledSwapDirections: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Directions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDisabledSwapDirection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Directions = append(m.Directions, SwapDirection{})
+			if err := m.Directions[len(m.Directions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDisabledSwapDirection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDisabledSwapDirection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipDisabledSwapDirection(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowDisabledSwapDirection
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDisabledSwapDirection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowDisabledSwapDirection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthDisabledSwapDirection
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupDisabledSwapDirection
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthDisabledSwapDirection
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthDisabledSwapDirection        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowDisabledSwapDirection          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupDisabledSwapDirection = fmt.Errorf("proto: unexpected end of group")
+)
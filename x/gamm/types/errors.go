@@ -8,7 +8,7 @@ var (
 	ErrPoolAlreadyExist    = sdkerrors.Register(ModuleName, 2, "pool already exist")
 	ErrPoolLocked          = sdkerrors.Register(ModuleName, 3, "pool is locked")
 	ErrTooFewPoolAssets    = sdkerrors.Register(ModuleName, 4, "pool should have at least 2 assets, as they must be swapping between at least two assets")
-	ErrTooManyPoolAssets   = sdkerrors.Register(ModuleName, 5, "pool has too many assets (currently capped at 8 assets per balancer pool and 2 per stableswap)")
+	ErrTooManyPoolAssets   = sdkerrors.Register(ModuleName, 5, "pool has too many assets (capped at the MaxPoolAssets param per balancer pool and 2 per stableswap)")
 	ErrLimitMaxAmount      = sdkerrors.Register(ModuleName, 6, "calculated amount is larger than max amount")
 	ErrLimitMinAmount      = sdkerrors.Register(ModuleName, 7, "calculated amount is lesser than min amount")
 	ErrInvalidMathApprox   = sdkerrors.Register(ModuleName, 8, "invalid calculated result")
@@ -27,13 +27,53 @@ var (
 	ErrNotPositiveCriteria      = sdkerrors.Register(ModuleName, 29, "min out amount or max in amount should be positive")
 	ErrNotPositiveRequireAmount = sdkerrors.Register(ModuleName, 30, "required amount should be positive")
 	ErrTooManyTokensOut         = sdkerrors.Register(ModuleName, 31, "tx is trying to get more tokens out of the pool than exist")
+	ErrPriceImpactTooHigh       = sdkerrors.Register(ModuleName, 32, "swap price impact is larger than the max price impact")
+	ErrSpotPriceInternal        = sdkerrors.Register(ModuleName, 33, "internal spot price error")
+	ErrTwapRecordNotFound       = sdkerrors.Register(ModuleName, 34, "no TWAP record found")
+	ErrSpotPriceUnreachable     = sdkerrors.Register(ModuleName, 35, "spot price can't be reached by swapping")
+	ErrInvariantDecreased       = sdkerrors.Register(ModuleName, 36, "swap decreased the pool invariant")
+	ErrExitTooSmall             = sdkerrors.Register(ModuleName, 37, "too few shares exited to get any tokens out")
+	ErrPoolReserveBelowMinimum  = sdkerrors.Register(ModuleName, 38, "swap takes the pool reserve below its minimum")
+	ErrSameDenom                = sdkerrors.Register(ModuleName, 39, "cannot trade same denomination in and out")
+	ErrUnauthorizedFeeFreeSwap  = sdkerrors.Register(ModuleName, 40, "sender is not authorized to swap without a swap fee")
+	ErrInvalidPowBase           = sdkerrors.Register(ModuleName, 41, "base of the power approximation must be positive")
+	ErrNotBalancerPool          = sdkerrors.Register(ModuleName, 42, "not balancer pool")
+	ErrInvalidTokenOutDenoms    = sdkerrors.Register(ModuleName, 43, "token out denoms must be a non-empty set of the pool's denoms")
+	ErrDeadlineExceeded         = sdkerrors.Register(ModuleName, 44, "swap deadline exceeded")
+	ErrTooManyPoolShares        = sdkerrors.Register(ModuleName, 45, "join takes the pool's total shares past the max total shares")
+	ErrInvalidFeeCheckpoint     = sdkerrors.Register(ModuleName, 46, "fee checkpoint is ahead of the pool's fees per share")
+	ErrSwapDirectionDisabled    = sdkerrors.Register(ModuleName, 47, "swap direction is disabled on the pool")
+	ErrArbWeightRatioMismatch   = sdkerrors.Register(ModuleName, 48, "pools' weight ratios of the pair differ")
+	ErrPoolAssetDepleted        = sdkerrors.Register(ModuleName, 49, "pool holds no balance of the denom")
 
 	ErrPoolParamsInvalidDenom     = sdkerrors.Register(ModuleName, 50, "pool params' LBP params has an invalid denomination")
 	ErrPoolParamsInvalidNumDenoms = sdkerrors.Register(ModuleName, 51, "pool params' LBP doesn't have same number of params as underlying pool")
 
+	ErrTokenInNotReceived     = sdkerrors.Register(ModuleName, 52, "pool did not receive the tokens swapped in")
+	ErrUnknownSwapMode        = sdkerrors.Register(ModuleName, 53, "unknown swap mode")
+	ErrNegativeMaxPriceImpact = sdkerrors.Register(ModuleName, 54, "max price impact must not be negative")
+	ErrInvalidSwapTokens      = sdkerrors.Register(ModuleName, 55, "invalid number of tokens in or out of a swap")
+	ErrInvalidSwapSplits      = sdkerrors.Register(ModuleName, 56, "invalid split of a trade across pools")
+	ErrInvalidAmountLimits    = sdkerrors.Register(ModuleName, 57, "max amount is lesser than the min amount")
+	ErrPoolHasNoShares        = sdkerrors.Register(ModuleName, 58, "pool has no shares")
+	ErrInvalidPoolAssets      = sdkerrors.Register(ModuleName, 59, "pool assets are inconsistent")
+
 	ErrNotImplemented = sdkerrors.Register(ModuleName, 60, "function not implemented")
 
 	ErrNotStableSwapPool               = sdkerrors.Register(ModuleName, 61, "not stableswap pool")
 	ErrInvalidStableswapScalingFactors = sdkerrors.Register(ModuleName, 62, "length between liquidity and scaling factors mismatch")
 	ErrNotScalingFactorGovernor        = sdkerrors.Register(ModuleName, 63, "not scaling factor governor")
+
+	ErrNoSwappablePools              = sdkerrors.Register(ModuleName, 64, "none of the pools can swap")
+	ErrPrecisionTooLarge             = sdkerrors.Register(ModuleName, 65, "precision is larger than sdk.Dec's")
+	ErrEmptySwaps                    = sdkerrors.Register(ModuleName, 66, "swaps not defined")
+	ErrNegativeMinPoolReserve        = sdkerrors.Register(ModuleName, 67, "minimum pool reserve must not be negative")
+	ErrInvalidSwapFeeTiers           = sdkerrors.Register(ModuleName, 68, "invalid swap fee tiers")
+	ErrDuplicateSwapDirection        = sdkerrors.Register(ModuleName, 69, "swap direction is set more than once")
+	ErrDuplicateScalingFactor        = sdkerrors.Register(ModuleName, 70, "denom has more than one scaling factor")
+	ErrUnsupportedPoolType           = sdkerrors.Register(ModuleName, 71, "pool type does not support the operation")
+	ErrInvalidAmplificationParameter = sdkerrors.Register(ModuleName, 72, "amplification parameter is out of range")
+	ErrPoolHasDirectionalSwapFees    = sdkerrors.Register(ModuleName, 73, "pool has directional swap fees")
+	ErrPoolHasSwapFeeTiers           = sdkerrors.Register(ModuleName, 74, "pool has swap fee tiers")
+	ErrNoModuleAccount               = sdkerrors.Register(ModuleName, 75, "module has no module account")
 )
@@ -2,6 +2,7 @@ package types
 
 import (
 	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -12,14 +13,39 @@ const (
 	TypeEvtPoolCreated  = "pool_created"
 	TypeEvtTokenSwapped = "token_swapped"
 
+	TypeEvtSingleAssetJoinSwap = "single_asset_join_swap"
+	TypeEvtPoolPaused          = "pool_paused"
+	TypeEvtPoolMigrated        = "pool_migrated"
+	TypeEvtPoolSwapFeeUpdated  = "pool_swap_fee_updated"
+
 	AttributeValueCategory = ModuleName
 	AttributeKeyPoolId     = "pool_id"
 	AttributeKeySwapFee    = "swap_fee"
 	AttributeKeyTokensIn   = "tokens_in"
 	AttributeKeyTokensOut  = "tokens_out"
+
+	AttributeKeySpotPriceBefore = "spot_price_before"
+	AttributeKeySpotPriceAfter  = "spot_price_after"
+	AttributeKeyEffectivePrice  = "effective_price"
+	AttributeKeyFeeFree         = "fee_free"
+	AttributeKeyImpliedSwap     = "implied_swap"
+	AttributeKeyThreshold       = "threshold"
+	AttributeKeyProtocolFee     = "protocol_fee"
+	AttributeKeyScalingFactors  = "scaling_factors"
+	AttributeKeySharesMinted    = "shares_minted"
+	AttributeKeySharesBurned    = "shares_burned"
+	AttributeKeyExitFee         = "exit_fee"
+	AttributeKeyOldSwapFee      = "old_swap_fee"
+	AttributeKeyNewSwapFee      = "new_swap_fee"
+
+	AttributeKeyAmplificationParameter = "amplification_parameter"
 )
 
-func CreateSwapEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins) sdk.Event {
+// CreateSwapEvent creates the event emitted for a swap of input for output.
+// All prices are in units of the input denom per output denom, the effective price
+// being the input amount divided by the output amount.
+func CreateSwapEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins, spotPriceBefore, spotPriceAfter sdk.Dec) sdk.Event {
+	effectivePrice := input[0].Amount.ToDec().Quo(output[0].Amount.ToDec())
 	return sdk.NewEvent(
 		TypeEvtTokenSwapped,
 		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
@@ -27,25 +53,87 @@ func CreateSwapEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, inpu
 		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
 		sdk.NewAttribute(AttributeKeyTokensIn, input.String()),
 		sdk.NewAttribute(AttributeKeyTokensOut, output.String()),
+		sdk.NewAttribute(AttributeKeySpotPriceBefore, spotPriceBefore.String()),
+		sdk.NewAttribute(AttributeKeySpotPriceAfter, spotPriceAfter.String()),
+		sdk.NewAttribute(AttributeKeyEffectivePrice, effectivePrice.String()),
+	)
+}
+
+// CreateSingleAssetJoinSwapEvent creates the event emitted for a single asset join of tokenIn,
+// with impliedSwap the part of tokenIn the join swaps against the pool's other assets.
+func CreateSingleAssetJoinSwapEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, tokenIn sdk.Coin, impliedSwap sdk.Coin) sdk.Event {
+	return sdk.NewEvent(
+		TypeEvtSingleAssetJoinSwap,
+		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
+		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(AttributeKeyTokensIn, tokenIn.String()),
+		sdk.NewAttribute(AttributeKeyImpliedSwap, impliedSwap.String()),
+	)
+}
+
+// CreatePoolPausedEvent creates the event emitted when a swap moving the spot price of poolId
+// from spotPriceBefore to spotPriceAfter, by more than threshold, trips the pool's circuit breaker.
+func CreatePoolPausedEvent(ctx sdk.Context, poolId uint64, spotPriceBefore, spotPriceAfter, threshold sdk.Dec) sdk.Event {
+	return sdk.NewEvent(
+		TypeEvtPoolPaused,
+		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(AttributeKeySpotPriceBefore, spotPriceBefore.String()),
+		sdk.NewAttribute(AttributeKeySpotPriceAfter, spotPriceAfter.String()),
+		sdk.NewAttribute(AttributeKeyThreshold, threshold.String()),
+	)
+}
+
+// CreatePoolMigratedEvent creates the event emitted when the balancer pool with poolId is migrated
+// to a stableswap pool with amplificationParameter and scalingFactors.
+func CreatePoolMigratedEvent(ctx sdk.Context, poolId uint64, amplificationParameter uint64, scalingFactors []uint64) sdk.Event {
+	factors := make([]string, len(scalingFactors))
+	for i, factor := range scalingFactors {
+		factors[i] = strconv.FormatUint(factor, 10)
+	}
+	return sdk.NewEvent(
+		TypeEvtPoolMigrated,
+		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(AttributeKeyAmplificationParameter, strconv.FormatUint(amplificationParameter, 10)),
+		sdk.NewAttribute(AttributeKeyScalingFactors, strings.Join(factors, ",")),
+	)
+}
+
+// CreatePoolSwapFeeUpdatedEvent creates the event emitted for updating the swap fee of poolId from oldSwapFee to newSwapFee.
+func CreatePoolSwapFeeUpdatedEvent(ctx sdk.Context, poolId uint64, oldSwapFee, newSwapFee sdk.Dec) sdk.Event {
+	return sdk.NewEvent(
+		TypeEvtPoolSwapFeeUpdated,
+		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
+		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
+		sdk.NewAttribute(AttributeKeyOldSwapFee, oldSwapFee.String()),
+		sdk.NewAttribute(AttributeKeyNewSwapFee, newSwapFee.String()),
 	)
 }
 
-func CreateAddLiquidityEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, liquidity sdk.Coins) sdk.Event {
+// CreateAddLiquidityEvent creates the event emitted for a join of liquidity into poolId, minting sharesMinted.
+func CreateAddLiquidityEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, liquidity sdk.Coins, sharesMinted sdk.Int) sdk.Event {
 	return sdk.NewEvent(
 		TypeEvtPoolJoined,
 		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
 		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
 		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
 		sdk.NewAttribute(AttributeKeyTokensIn, liquidity.String()),
+		sdk.NewAttribute(AttributeKeySharesMinted, sharesMinted.String()),
 	)
 }
 
-func CreateRemoveLiquidityEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, liquidity sdk.Coins) sdk.Event {
+// CreateRemoveLiquidityEvent creates the event emitted for an exit of liquidity from poolId, burning sharesBurned.
+// exitFee is the exit fee charged, as the amount of the burned shares that is withheld rather than exited.
+func CreateRemoveLiquidityEvent(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, liquidity sdk.Coins, sharesBurned sdk.Int, exitFee sdk.Coin) sdk.Event {
 	return sdk.NewEvent(
 		TypeEvtPoolExited,
 		sdk.NewAttribute(sdk.AttributeKeyModule, AttributeValueCategory),
 		sdk.NewAttribute(sdk.AttributeKeySender, sender.String()),
 		sdk.NewAttribute(AttributeKeyPoolId, strconv.FormatUint(poolId, 10)),
 		sdk.NewAttribute(AttributeKeyTokensOut, liquidity.String()),
+		sdk.NewAttribute(AttributeKeySharesBurned, sharesBurned.String()),
+		sdk.NewAttribute(AttributeKeyExitFee, exitFee.String()),
 	)
 }
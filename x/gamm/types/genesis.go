@@ -1,15 +1,29 @@
 package types
 
 import (
+	"fmt"
+
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // DefaultGenesis creates a default GenesisState object.
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
-		Pools:          []*codectypes.Any{},
-		NextPoolNumber: 1,
-		Params:         DefaultParams(),
+		Pools:                  []*codectypes.Any{},
+		NextPoolNumber:         1,
+		Params:                 DefaultParams(),
+		TwapRecords:            []TwapRecord{},
+		ExitFeeRecipients:      []PoolExitFeeRecipient{},
+		SwapVolumes:            []SwapVolumeBucket{},
+		PoolAccumulators:       []PoolAccumulatorsRecord{},
+		MinPoolReserves:        []MinPoolReserve{},
+		DirectionalSwapFees:    []PoolDirectionalSwapFees{},
+		FeeFreeSwapModules:     []string{},
+		CircuitBreakerPoolIds:  []uint64{},
+		PausedPoolIds:          []uint64{},
+		AssetScalingFactors:    []PoolAssetScalingFactors{},
+		DisabledSwapDirections: []PoolDisabledSwapDirections{},
 	}
 }
 
@@ -19,5 +33,42 @@ func (gs GenesisState) Validate() error {
 	if err := gs.Params.Validate(); err != nil {
 		return err
 	}
+	for _, record := range gs.TwapRecords {
+		if err := record.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, recipient := range gs.ExitFeeRecipients {
+		if _, err := sdk.AccAddressFromBech32(recipient.Recipient); err != nil {
+			return fmt.Errorf("invalid exit fee recipient of pool %d: %w", recipient.PoolId, err)
+		}
+	}
+	for _, bucket := range gs.SwapVolumes {
+		if _, err := sdk.AccAddressFromBech32(bucket.Trader); err != nil {
+			return fmt.Errorf("invalid trader of a swap volume bucket of pool %d: %w", bucket.PoolId, err)
+		}
+		if bucket.Volume.IsNil() || bucket.Volume.IsNegative() {
+			return fmt.Errorf("swap volume bucket of pool %d has a negative volume %s", bucket.PoolId, bucket.Volume)
+		}
+	}
+	for _, record := range gs.PoolAccumulators {
+		accumulators := record.Accumulators
+		if !accumulators.VolumeIn.IsValid() || !accumulators.VolumeOut.IsValid() ||
+			!accumulators.RoundingDust.IsValid() || !accumulators.FeesPerShare.IsValid() {
+			return fmt.Errorf("invalid accumulators of pool %d: %s", record.PoolId, accumulators.String())
+		}
+	}
+	for _, minReserve := range gs.MinPoolReserves {
+		if err := ValidateMinPoolReserve(minReserve.Denom, minReserve.MinReserve); err != nil {
+			return err
+		}
+	}
+	// whether the fee free swap modules have a module account is only known to the account keeper,
+	// and checked on InitGenesis.
+	for _, moduleName := range gs.FeeFreeSwapModules {
+		if moduleName == "" {
+			return fmt.Errorf("empty fee free swap module name")
+		}
+	}
 	return nil
 }
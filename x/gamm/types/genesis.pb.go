@@ -11,15 +11,19 @@ import (
 	types "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/gogo/protobuf/types"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -30,6 +34,15 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 // Params holds parameters for the incentives module
 type Params struct {
 	PoolCreationFee github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,1,rep,name=pool_creation_fee,json=poolCreationFee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"pool_creation_fee" yaml:"pool_creation_fee"`
+	// max_pool_assets is the maximum number of assets of pools created from
+	// then on, at most MaxPoolAssetsLimit.
+	MaxPoolAssets uint64 `protobuf:"varint,2,opt,name=max_pool_assets,json=maxPoolAssets,proto3" json:"max_pool_assets,omitempty" yaml:"max_pool_assets"`
+	// circuit_breaker_threshold is the relative spot price move of a single swap
+	// that pauses a pool with its circuit breaker enabled.
+	CircuitBreakerThreshold github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,3,opt,name=circuit_breaker_threshold,json=circuitBreakerThreshold,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"circuit_breaker_threshold" yaml:"circuit_breaker_threshold"`
+	// protocol_fee_share is the fraction of every swap fee sent to the protocol
+	// instead of staying in the pool.
+	ProtocolFeeShare github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,4,opt,name=protocol_fee_share,json=protocolFeeShare,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"protocol_fee_share" yaml:"protocol_fee_share"`
 }
 
 func (m *Params) Reset()         { *m = Params{} }
@@ -72,11 +85,36 @@ func (m *Params) GetPoolCreationFee() github_com_cosmos_cosmos_sdk_types.Coins {
 	return nil
 }
 
+func (m *Params) GetMaxPoolAssets() uint64 {
+	if m != nil {
+		return m.MaxPoolAssets
+	}
+	return 0
+}
+
 // GenesisState defines the gamm module's genesis state.
 type GenesisState struct {
 	Pools          []*types1.Any `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
 	NextPoolNumber uint64        `protobuf:"varint,2,opt,name=next_pool_number,json=nextPoolNumber,proto3" json:"next_pool_number,omitempty"`
 	Params         Params        `protobuf:"bytes,3,opt,name=params,proto3" json:"params"`
+	// twap_records are the TWAP records of the pools within the TWAP record
+	// history keep period.
+	TwapRecords         []TwapRecord              `protobuf:"bytes,4,rep,name=twap_records,json=twapRecords,proto3" json:"twap_records" yaml:"twap_records"`
+	ExitFeeRecipients   []PoolExitFeeRecipient    `protobuf:"bytes,5,rep,name=exit_fee_recipients,json=exitFeeRecipients,proto3" json:"exit_fee_recipients" yaml:"exit_fee_recipients"`
+	SwapVolumes         []SwapVolumeBucket        `protobuf:"bytes,6,rep,name=swap_volumes,json=swapVolumes,proto3" json:"swap_volumes" yaml:"swap_volumes"`
+	PoolAccumulators    []PoolAccumulatorsRecord  `protobuf:"bytes,7,rep,name=pool_accumulators,json=poolAccumulators,proto3" json:"pool_accumulators" yaml:"pool_accumulators"`
+	MinPoolReserves     []MinPoolReserve          `protobuf:"bytes,8,rep,name=min_pool_reserves,json=minPoolReserves,proto3" json:"min_pool_reserves" yaml:"min_pool_reserves"`
+	DirectionalSwapFees []PoolDirectionalSwapFees `protobuf:"bytes,9,rep,name=directional_swap_fees,json=directionalSwapFees,proto3" json:"directional_swap_fees" yaml:"directional_swap_fees"`
+	// fee_free_swap_modules are the names of the modules authorized to swap
+	// without a swap fee.
+	FeeFreeSwapModules []string `protobuf:"bytes,10,rep,name=fee_free_swap_modules,json=feeFreeSwapModules,proto3" json:"fee_free_swap_modules,omitempty" yaml:"fee_free_swap_modules"`
+	// circuit_breaker_pool_ids are the IDs of the pools with their circuit
+	// breaker enabled.
+	CircuitBreakerPoolIds []uint64 `protobuf:"varint,11,rep,packed,name=circuit_breaker_pool_ids,json=circuitBreakerPoolIds,proto3" json:"circuit_breaker_pool_ids,omitempty" yaml:"circuit_breaker_pool_ids"`
+	// paused_pool_ids are the IDs of the pools whose swaps are paused.
+	PausedPoolIds          []uint64                     `protobuf:"varint,12,rep,packed,name=paused_pool_ids,json=pausedPoolIds,proto3" json:"paused_pool_ids,omitempty" yaml:"paused_pool_ids"`
+	AssetScalingFactors    []PoolAssetScalingFactors    `protobuf:"bytes,13,rep,name=asset_scaling_factors,json=assetScalingFactors,proto3" json:"asset_scaling_factors" yaml:"asset_scaling_factors"`
+	DisabledSwapDirections []PoolDisabledSwapDirections `protobuf:"bytes,14,rep,name=disabled_swap_directions,json=disabledSwapDirections,proto3" json:"disabled_swap_directions" yaml:"disabled_swap_directions"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -133,186 +171,2598 @@ func (m *GenesisState) GetParams() Params {
 	return Params{}
 }
 
-func init() {
-	proto.RegisterType((*Params)(nil), "osmosis.gamm.v1beta1.Params")
-	proto.RegisterType((*GenesisState)(nil), "osmosis.gamm.v1beta1.GenesisState")
+func (m *GenesisState) GetTwapRecords() []TwapRecord {
+	if m != nil {
+		return m.TwapRecords
+	}
+	return nil
 }
 
-func init() {
-	proto.RegisterFile("osmosis/gamm/v1beta1/genesis.proto", fileDescriptor_5a324eb7f1dd793e)
+func (m *GenesisState) GetExitFeeRecipients() []PoolExitFeeRecipient {
+	if m != nil {
+		return m.ExitFeeRecipients
+	}
+	return nil
 }
 
-var fileDescriptor_5a324eb7f1dd793e = []byte{
-	// 400 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0xaa, 0xd3, 0x40,
-	0x18, 0xc5, 0x33, 0xde, 0x7b, 0x0b, 0xe6, 0x8a, 0x7f, 0x42, 0x17, 0xb9, 0x17, 0x49, 0x4b, 0x56,
-	0xd9, 0x74, 0x86, 0x5b, 0x11, 0xa1, 0x3b, 0x53, 0x50, 0x0a, 0x22, 0x25, 0xee, 0xdc, 0x84, 0x49,
-	0x9c, 0xc6, 0x60, 0x32, 0x5f, 0xc8, 0x4c, 0x4b, 0xf3, 0x16, 0x82, 0x7b, 0x1f, 0x40, 0xb7, 0x3e,
-	0x44, 0x71, 0xd5, 0xa5, 0xab, 0x2a, 0xed, 0x1b, 0xf8, 0x04, 0x32, 0x7f, 0x22, 0x82, 0x77, 0x95,
-	0x7c, 0xf3, 0xfd, 0xce, 0xe1, 0xcc, 0x49, 0xdc, 0x10, 0x44, 0x0d, 0xa2, 0x14, 0xa4, 0xa0, 0x75,
-	0x4d, 0x36, 0x37, 0x19, 0x93, 0xf4, 0x86, 0x14, 0x8c, 0x33, 0x51, 0x0a, 0xdc, 0xb4, 0x20, 0xc1,
-	0x1b, 0x5a, 0x06, 0x2b, 0x06, 0x5b, 0xe6, 0x7a, 0x58, 0x40, 0x01, 0x1a, 0x20, 0xea, 0xcd, 0xb0,
-	0xd7, 0x57, 0x05, 0x40, 0x51, 0x31, 0xa2, 0xa7, 0x6c, 0xbd, 0x22, 0x94, 0x77, 0xfd, 0x2a, 0xd7,
-	0x3e, 0xa9, 0xd1, 0x98, 0xc1, 0xae, 0x02, 0x33, 0x91, 0x8c, 0x0a, 0xf6, 0x37, 0x44, 0x0e, 0x25,
-	0x37, 0xfb, 0xf0, 0x33, 0x72, 0x07, 0x4b, 0xda, 0xd2, 0x5a, 0x78, 0x9f, 0x90, 0xfb, 0xa8, 0x01,
-	0xa8, 0xd2, 0xbc, 0x65, 0x54, 0x96, 0xc0, 0xd3, 0x15, 0x63, 0x3e, 0x1a, 0x9f, 0x45, 0x97, 0xd3,
-	0x2b, 0x6c, 0x5d, 0x95, 0x4f, 0x1f, 0x14, 0xcf, 0xa1, 0xe4, 0xf1, 0xab, 0xdd, 0x61, 0xe4, 0xfc,
-	0x3e, 0x8c, 0xfc, 0x8e, 0xd6, 0xd5, 0x2c, 0xfc, 0xcf, 0x21, 0xfc, 0xf2, 0x73, 0x14, 0x15, 0xa5,
-	0x7c, 0xbf, 0xce, 0x70, 0x0e, 0xb5, 0x8d, 0x67, 0x1f, 0x13, 0xf1, 0xee, 0x03, 0x91, 0x5d, 0xc3,
-	0x84, 0x36, 0x13, 0xc9, 0x03, 0xa5, 0x9f, 0x5b, 0xf9, 0x0b, 0xc6, 0xc2, 0xaf, 0xc8, 0xbd, 0xf7,
-	0xd2, 0x94, 0xf6, 0x46, 0x52, 0xc9, 0xbc, 0xa7, 0xee, 0x85, 0x62, 0x84, 0x4d, 0x36, 0xc4, 0xa6,
-	0x17, 0xdc, 0xf7, 0x82, 0x9f, 0xf3, 0x2e, 0xbe, 0xfb, 0xfd, 0xdb, 0xe4, 0x62, 0x09, 0x50, 0x2d,
-	0x12, 0x43, 0x7b, 0x91, 0xfb, 0x90, 0xb3, 0xad, 0x4c, 0x75, 0x3e, 0xbe, 0xae, 0x33, 0xd6, 0xfa,
-	0x77, 0xc6, 0x28, 0x3a, 0x4f, 0xee, 0xab, 0x73, 0xc5, 0xbe, 0xd6, 0xa7, 0xde, 0xcc, 0x1d, 0x34,
-	0xba, 0x11, 0xff, 0x6c, 0x8c, 0xa2, 0xcb, 0xe9, 0x63, 0x7c, 0xdb, 0x57, 0xc2, 0xa6, 0xb5, 0xf8,
-	0x5c, 0x5d, 0x3f, 0xb1, 0x8a, 0x78, 0xb1, 0x3b, 0x06, 0x68, 0x7f, 0x0c, 0xd0, 0xaf, 0x63, 0x80,
-	0x3e, 0x9e, 0x02, 0x67, 0x7f, 0x0a, 0x9c, 0x1f, 0xa7, 0xc0, 0x79, 0x4b, 0xfe, 0xa9, 0xc0, 0xfa,
-	0x4d, 0x2a, 0x9a, 0x89, 0x7e, 0x20, 0x9b, 0x67, 0x64, 0x6b, 0xfe, 0x15, 0xdd, 0x47, 0x36, 0xd0,
-	0x17, 0x7a, 0xf2, 0x27, 0x00, 0x00, 0xff, 0xff, 0xda, 0xf2, 0x18, 0x37, 0x48, 0x02, 0x00, 0x00,
+func (m *GenesisState) GetSwapVolumes() []SwapVolumeBucket {
+	if m != nil {
+		return m.SwapVolumes
+	}
+	return nil
 }
 
-func (m *Params) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *GenesisState) GetPoolAccumulators() []PoolAccumulatorsRecord {
+	if m != nil {
+		return m.PoolAccumulators
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Params) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *GenesisState) GetMinPoolReserves() []MinPoolReserve {
+	if m != nil {
+		return m.MinPoolReserves
+	}
+	return nil
 }
 
-func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.PoolCreationFee) > 0 {
-		for iNdEx := len(m.PoolCreationFee) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.PoolCreationFee[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintGenesis(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *GenesisState) GetDirectionalSwapFees() []PoolDirectionalSwapFees {
+	if m != nil {
+		return m.DirectionalSwapFees
+	}
+	return nil
+}
+
+func (m *GenesisState) GetFeeFreeSwapModules() []string {
+	if m != nil {
+		return m.FeeFreeSwapModules
+	}
+	return nil
+}
+
+func (m *GenesisState) GetCircuitBreakerPoolIds() []uint64 {
+	if m != nil {
+		return m.CircuitBreakerPoolIds
+	}
+	return nil
+}
+
+func (m *GenesisState) GetPausedPoolIds() []uint64 {
+	if m != nil {
+		return m.PausedPoolIds
+	}
+	return nil
+}
+
+func (m *GenesisState) GetAssetScalingFactors() []PoolAssetScalingFactors {
+	if m != nil {
+		return m.AssetScalingFactors
+	}
+	return nil
+}
+
+func (m *GenesisState) GetDisabledSwapDirections() []PoolDisabledSwapDirections {
+	if m != nil {
+		return m.DisabledSwapDirections
+	}
+	return nil
+}
+
+// PoolExitFeeRecipient is the address receiving the exit fees of a pool.
+type PoolExitFeeRecipient struct {
+	PoolId    uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Recipient string `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty" yaml:"recipient"`
+}
+
+func (m *PoolExitFeeRecipient) Reset()         { *m = PoolExitFeeRecipient{} }
+func (m *PoolExitFeeRecipient) String() string { return proto.CompactTextString(m) }
+func (*PoolExitFeeRecipient) ProtoMessage()    {}
+func (*PoolExitFeeRecipient) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{2}
+}
+func (m *PoolExitFeeRecipient) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolExitFeeRecipient) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolExitFeeRecipient.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PoolExitFeeRecipient) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolExitFeeRecipient.Merge(m, src)
+}
+func (m *PoolExitFeeRecipient) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolExitFeeRecipient) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolExitFeeRecipient.DiscardUnknown(m)
 }
 
-func (m *GenesisState) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PoolExitFeeRecipient proto.InternalMessageInfo
+
+func (m *PoolExitFeeRecipient) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *GenesisState) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *PoolExitFeeRecipient) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
 }
 
-func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+// SwapVolumeBucket is the swap volume of a trader through a pool with swap fee
+// tiers, in the bucket of swaps starting at bucket_start.
+type SwapVolumeBucket struct {
+	PoolId      uint64                                 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Trader      string                                 `protobuf:"bytes,2,opt,name=trader,proto3" json:"trader,omitempty" yaml:"trader"`
+	BucketStart time.Time                              `protobuf:"bytes,3,opt,name=bucket_start,json=bucketStart,proto3,stdtime" json:"bucket_start" yaml:"bucket_start"`
+	Volume      github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,4,opt,name=volume,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"volume" yaml:"volume"`
+}
+
+func (m *SwapVolumeBucket) Reset()         { *m = SwapVolumeBucket{} }
+func (m *SwapVolumeBucket) String() string { return proto.CompactTextString(m) }
+func (*SwapVolumeBucket) ProtoMessage()    {}
+func (*SwapVolumeBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{3}
+}
+func (m *SwapVolumeBucket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SwapVolumeBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SwapVolumeBucket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintGenesis(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0x1a
-	if m.NextPoolNumber != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.NextPoolNumber))
-		i--
-		dAtA[i] = 0x10
+}
+func (m *SwapVolumeBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapVolumeBucket.Merge(m, src)
+}
+func (m *SwapVolumeBucket) XXX_Size() int {
+	return m.Size()
+}
+func (m *SwapVolumeBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapVolumeBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapVolumeBucket proto.InternalMessageInfo
+
+func (m *SwapVolumeBucket) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
 	}
-	if len(m.Pools) > 0 {
-		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintGenesis(dAtA, i, uint64(size))
+	return 0
+}
+
+func (m *SwapVolumeBucket) GetTrader() string {
+	if m != nil {
+		return m.Trader
+	}
+	return ""
+}
+
+func (m *SwapVolumeBucket) GetBucketStart() time.Time {
+	if m != nil {
+		return m.BucketStart
+	}
+	return time.Time{}
+}
+
+// PoolAccumulatorsRecord are the cumulative amounts of the swaps through a
+// pool.
+type PoolAccumulatorsRecord struct {
+	PoolId       uint64           `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Accumulators PoolAccumulators `protobuf:"bytes,2,opt,name=accumulators,proto3" json:"accumulators" yaml:"accumulators"`
+}
+
+func (m *PoolAccumulatorsRecord) Reset()         { *m = PoolAccumulatorsRecord{} }
+func (m *PoolAccumulatorsRecord) String() string { return proto.CompactTextString(m) }
+func (*PoolAccumulatorsRecord) ProtoMessage()    {}
+func (*PoolAccumulatorsRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{4}
+}
+func (m *PoolAccumulatorsRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolAccumulatorsRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolAccumulatorsRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolAccumulatorsRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolAccumulatorsRecord.Merge(m, src)
+}
+func (m *PoolAccumulatorsRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolAccumulatorsRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolAccumulatorsRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolAccumulatorsRecord proto.InternalMessageInfo
+
+func (m *PoolAccumulatorsRecord) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *PoolAccumulatorsRecord) GetAccumulators() PoolAccumulators {
+	if m != nil {
+		return m.Accumulators
+	}
+	return PoolAccumulators{}
+}
+
+// MinPoolReserve is the minimum reserve of a denom in every pool, set by
+// governance.
+type MinPoolReserve struct {
+	Denom      string                                 `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty" yaml:"denom"`
+	MinReserve github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,2,opt,name=min_reserve,json=minReserve,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"min_reserve" yaml:"min_reserve"`
+}
+
+func (m *MinPoolReserve) Reset()         { *m = MinPoolReserve{} }
+func (m *MinPoolReserve) String() string { return proto.CompactTextString(m) }
+func (*MinPoolReserve) ProtoMessage()    {}
+func (*MinPoolReserve) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{5}
+}
+func (m *MinPoolReserve) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MinPoolReserve) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MinPoolReserve.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MinPoolReserve) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MinPoolReserve.Merge(m, src)
+}
+func (m *MinPoolReserve) XXX_Size() int {
+	return m.Size()
+}
+func (m *MinPoolReserve) XXX_DiscardUnknown() {
+	xxx_messageInfo_MinPoolReserve.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MinPoolReserve proto.InternalMessageInfo
+
+func (m *MinPoolReserve) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+// PoolDirectionalSwapFees are the swap fees of a pool by swap direction.
+type PoolDirectionalSwapFees struct {
+	PoolId uint64              `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Fees   DirectionalSwapFees `protobuf:"bytes,2,opt,name=fees,proto3" json:"fees" yaml:"fees"`
+}
+
+func (m *PoolDirectionalSwapFees) Reset()         { *m = PoolDirectionalSwapFees{} }
+func (m *PoolDirectionalSwapFees) String() string { return proto.CompactTextString(m) }
+func (*PoolDirectionalSwapFees) ProtoMessage()    {}
+func (*PoolDirectionalSwapFees) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{6}
+}
+func (m *PoolDirectionalSwapFees) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolDirectionalSwapFees) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolDirectionalSwapFees.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolDirectionalSwapFees) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolDirectionalSwapFees.Merge(m, src)
+}
+func (m *PoolDirectionalSwapFees) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolDirectionalSwapFees) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolDirectionalSwapFees.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolDirectionalSwapFees proto.InternalMessageInfo
+
+func (m *PoolDirectionalSwapFees) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *PoolDirectionalSwapFees) GetFees() DirectionalSwapFees {
+	if m != nil {
+		return m.Fees
+	}
+	return DirectionalSwapFees{}
+}
+
+// PoolAssetScalingFactors are the decimals of the assets of a pool.
+type PoolAssetScalingFactors struct {
+	PoolId  uint64              `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Factors AssetScalingFactors `protobuf:"bytes,2,opt,name=factors,proto3" json:"factors" yaml:"factors"`
+}
+
+func (m *PoolAssetScalingFactors) Reset()         { *m = PoolAssetScalingFactors{} }
+func (m *PoolAssetScalingFactors) String() string { return proto.CompactTextString(m) }
+func (*PoolAssetScalingFactors) ProtoMessage()    {}
+func (*PoolAssetScalingFactors) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{7}
+}
+func (m *PoolAssetScalingFactors) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolAssetScalingFactors) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolAssetScalingFactors.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolAssetScalingFactors) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolAssetScalingFactors.Merge(m, src)
+}
+func (m *PoolAssetScalingFactors) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolAssetScalingFactors) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolAssetScalingFactors.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolAssetScalingFactors proto.InternalMessageInfo
+
+func (m *PoolAssetScalingFactors) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *PoolAssetScalingFactors) GetFactors() AssetScalingFactors {
+	if m != nil {
+		return m.Factors
+	}
+	return AssetScalingFactors{}
+}
+
+// PoolDisabledSwapDirections are the swap directions disabled on a pool.
+type PoolDisabledSwapDirections struct {
+	PoolId     uint64                 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	Directions DisabledSwapDirections `protobuf:"bytes,2,opt,name=directions,proto3" json:"directions" yaml:"directions"`
+}
+
+func (m *PoolDisabledSwapDirections) Reset()         { *m = PoolDisabledSwapDirections{} }
+func (m *PoolDisabledSwapDirections) String() string { return proto.CompactTextString(m) }
+func (*PoolDisabledSwapDirections) ProtoMessage()    {}
+func (*PoolDisabledSwapDirections) Descriptor() ([]byte, []int) {
+	return fileDescriptor_5a324eb7f1dd793e, []int{8}
+}
+func (m *PoolDisabledSwapDirections) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolDisabledSwapDirections) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolDisabledSwapDirections.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolDisabledSwapDirections) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolDisabledSwapDirections.Merge(m, src)
+}
+func (m *PoolDisabledSwapDirections) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolDisabledSwapDirections) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolDisabledSwapDirections.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolDisabledSwapDirections proto.InternalMessageInfo
+
+func (m *PoolDisabledSwapDirections) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *PoolDisabledSwapDirections) GetDirections() DisabledSwapDirections {
+	if m != nil {
+		return m.Directions
+	}
+	return DisabledSwapDirections{}
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "osmosis.gamm.v1beta1.Params")
+	proto.RegisterType((*GenesisState)(nil), "osmosis.gamm.v1beta1.GenesisState")
+	proto.RegisterType((*PoolExitFeeRecipient)(nil), "osmosis.gamm.v1beta1.PoolExitFeeRecipient")
+	proto.RegisterType((*SwapVolumeBucket)(nil), "osmosis.gamm.v1beta1.SwapVolumeBucket")
+	proto.RegisterType((*PoolAccumulatorsRecord)(nil), "osmosis.gamm.v1beta1.PoolAccumulatorsRecord")
+	proto.RegisterType((*MinPoolReserve)(nil), "osmosis.gamm.v1beta1.MinPoolReserve")
+	proto.RegisterType((*PoolDirectionalSwapFees)(nil), "osmosis.gamm.v1beta1.PoolDirectionalSwapFees")
+	proto.RegisterType((*PoolAssetScalingFactors)(nil), "osmosis.gamm.v1beta1.PoolAssetScalingFactors")
+	proto.RegisterType((*PoolDisabledSwapDirections)(nil), "osmosis.gamm.v1beta1.PoolDisabledSwapDirections")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/genesis.proto", fileDescriptor_5a324eb7f1dd793e)
+}
+
+var fileDescriptor_5a324eb7f1dd793e = []byte{
+	// 1319 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xcd, 0x6f, 0x1b, 0xc5,
+	0x1b, 0xce, 0x36, 0x89, 0xfb, 0xf3, 0xd8, 0x49, 0x93, 0x49, 0x9a, 0x6e, 0xf2, 0xab, 0xbc, 0xd6,
+	0x50, 0x05, 0x17, 0x1a, 0x2f, 0x0d, 0x42, 0x48, 0xbd, 0xa0, 0x6e, 0x4b, 0x50, 0x05, 0x45, 0xd5,
+	0xa4, 0x02, 0x09, 0x10, 0x66, 0xbc, 0x3b, 0x71, 0x56, 0xdd, 0xdd, 0xb1, 0x76, 0xc6, 0xf9, 0x10,
+	0x12, 0xd7, 0xde, 0x50, 0x25, 0x6e, 0x5c, 0x38, 0xf4, 0xc6, 0x81, 0x03, 0xe2, 0x8f, 0xa8, 0x38,
+	0xf5, 0x88, 0x38, 0xb8, 0xa8, 0xbd, 0x73, 0xf0, 0x5f, 0x80, 0xe6, 0x63, 0xfd, 0xb1, 0x5e, 0x17,
+	0x7c, 0x4a, 0xf6, 0x9d, 0xe7, 0x7d, 0xde, 0x77, 0x9e, 0x79, 0xe7, 0x99, 0x04, 0x20, 0xc6, 0x63,
+	0xc6, 0x43, 0xee, 0x76, 0x48, 0x1c, 0xbb, 0x27, 0x37, 0xdb, 0x54, 0x90, 0x9b, 0x6e, 0x87, 0x26,
+	0x94, 0x87, 0xbc, 0xd9, 0x4d, 0x99, 0x60, 0x70, 0xd3, 0x60, 0x9a, 0x12, 0xd3, 0x34, 0x98, 0x9d,
+	0xcd, 0x0e, 0xeb, 0x30, 0x05, 0x70, 0xe5, 0x6f, 0x1a, 0xbb, 0xb3, 0xdd, 0x61, 0xac, 0x13, 0x51,
+	0x57, 0x7d, 0xb5, 0x7b, 0x47, 0x2e, 0x49, 0xce, 0xcd, 0x92, 0x93, 0x5f, 0x12, 0x61, 0x4c, 0xb9,
+	0x20, 0x71, 0x37, 0xcb, 0xf5, 0x55, 0xa1, 0x96, 0x26, 0xd5, 0x1f, 0x66, 0xa9, 0xa6, 0xbf, 0xdc,
+	0x36, 0xe1, 0x74, 0xd8, 0xa5, 0xcf, 0xc2, 0xc4, 0xac, 0xbb, 0x85, 0xdb, 0x20, 0x9c, 0x53, 0xd1,
+	0xe2, 0x3e, 0x89, 0xc2, 0xa4, 0xd3, 0x3a, 0x22, 0xbe, 0x60, 0xe9, 0x6b, 0x13, 0x82, 0x30, 0xa5,
+	0xbe, 0x08, 0x59, 0x42, 0xa2, 0x16, 0x3f, 0x25, 0xdd, 0xd6, 0x11, 0xa5, 0x26, 0x61, 0x7f, 0x46,
+	0x02, 0x27, 0xed, 0x88, 0x06, 0x1a, 0x3d, 0x4c, 0x37, 0x39, 0x37, 0x0a, 0x73, 0xba, 0x8c, 0x45,
+	0x2d, 0xe2, 0xfb, 0xbd, 0xb8, 0x17, 0x11, 0xc1, 0xd2, 0x6c, 0x8f, 0xbb, 0x85, 0x68, 0x21, 0x89,
+	0x53, 0xea, 0xb3, 0x34, 0xd0, 0x38, 0xf4, 0xf7, 0x22, 0x28, 0x3d, 0x20, 0x29, 0x89, 0x39, 0xfc,
+	0xc1, 0x02, 0xeb, 0x8a, 0xce, 0x4f, 0x29, 0x91, 0x85, 0x65, 0xc3, 0xb6, 0x55, 0x5f, 0x6c, 0x54,
+	0xf6, 0xb7, 0x9b, 0x46, 0x41, 0xa9, 0x59, 0x76, 0x6a, 0xcd, 0x3b, 0x2c, 0x4c, 0xbc, 0x4f, 0x9e,
+	0xf5, 0x9d, 0x85, 0x41, 0xdf, 0xb1, 0xcf, 0x49, 0x1c, 0xdd, 0x42, 0x53, 0x0c, 0xe8, 0xe7, 0x17,
+	0x4e, 0xa3, 0x13, 0x8a, 0xe3, 0x5e, 0xbb, 0xe9, 0xb3, 0xd8, 0x1c, 0x85, 0xf9, 0xb1, 0xc7, 0x83,
+	0x47, 0xae, 0x38, 0xef, 0x52, 0xae, 0xc8, 0x38, 0xbe, 0x24, 0xf3, 0xef, 0x98, 0xf4, 0x03, 0x4a,
+	0xa1, 0x07, 0x2e, 0xc5, 0xe4, 0xac, 0xa5, 0xf7, 0x29, 0x8f, 0x80, 0xdb, 0x17, 0xea, 0x56, 0x63,
+	0xc9, 0xdb, 0x19, 0xf4, 0x9d, 0x2d, 0x5d, 0x33, 0x07, 0x40, 0x78, 0x25, 0x26, 0x67, 0x0f, 0x18,
+	0x8b, 0x6e, 0xab, 0x6f, 0xf8, 0xbd, 0x05, 0xb6, 0xfd, 0x30, 0xf5, 0x7b, 0xa1, 0x68, 0xb5, 0x53,
+	0x4a, 0x1e, 0xd1, 0xb4, 0x25, 0x8e, 0x53, 0xca, 0x8f, 0x59, 0x14, 0xd8, 0x8b, 0x75, 0xab, 0x51,
+	0xf6, 0xb0, 0xdc, 0xc6, 0x9f, 0x7d, 0x67, 0xf7, 0x3f, 0xb4, 0x7a, 0x97, 0xfa, 0x83, 0xbe, 0x53,
+	0xd7, 0xc5, 0x67, 0x12, 0x23, 0x7c, 0xc5, 0xac, 0x79, 0x7a, 0xe9, 0x61, 0xb6, 0x02, 0xcf, 0x01,
+	0x54, 0xf2, 0xfb, 0x2c, 0x92, 0x12, 0xb5, 0xf8, 0x31, 0x49, 0xa9, 0xbd, 0xa4, 0x1a, 0xf9, 0x78,
+	0xee, 0x46, 0xb6, 0x8d, 0xf2, 0x53, 0x8c, 0x08, 0xaf, 0x65, 0xc1, 0x03, 0x4a, 0x0f, 0x55, 0xe8,
+	0x71, 0x05, 0x54, 0x3f, 0xd2, 0x37, 0xf2, 0x50, 0x10, 0x41, 0xe1, 0x7b, 0x60, 0x59, 0x6a, 0xc7,
+	0xcd, 0x49, 0x6f, 0x36, 0xf5, 0xcd, 0x6a, 0x66, 0x37, 0xab, 0x79, 0x3b, 0x39, 0xf7, 0xca, 0xbf,
+	0xff, 0xb6, 0xb7, 0x2c, 0x15, 0xbd, 0x87, 0x35, 0x1a, 0x36, 0xc0, 0x5a, 0x42, 0xcf, 0x84, 0xd6,
+	0x3d, 0xe9, 0xc5, 0x6d, 0x9a, 0xea, 0x83, 0xc1, 0xab, 0x32, 0x2e, 0xb1, 0x9f, 0xaa, 0x28, 0xbc,
+	0x05, 0x4a, 0x5d, 0x35, 0x61, 0x4a, 0xe9, 0xca, 0xfe, 0xd5, 0x66, 0x91, 0x05, 0x34, 0xf5, 0x14,
+	0x7a, 0x4b, 0x72, 0xfb, 0xd8, 0x64, 0xc0, 0x6f, 0x40, 0x75, 0x6c, 0x66, 0xb9, 0xbd, 0xa4, 0x7a,
+	0xac, 0x17, 0x33, 0x3c, 0x3c, 0x25, 0x5d, 0xac, 0x80, 0xde, 0xff, 0xcd, 0x50, 0x6e, 0x68, 0x69,
+	0xc6, 0x39, 0x10, 0xae, 0x88, 0x21, 0x90, 0xc3, 0xef, 0xc0, 0x06, 0x3d, 0x0b, 0x85, 0x12, 0x2d,
+	0xa5, 0x7e, 0xd8, 0x0d, 0x69, 0x22, 0xb8, 0xbd, 0xac, 0x0a, 0xbd, 0x35, 0xa3, 0x55, 0xc6, 0xa2,
+	0x0f, 0xcf, 0x42, 0x71, 0x40, 0x29, 0xce, 0x52, 0x3c, 0x64, 0x4a, 0xee, 0xe8, 0x92, 0x05, 0xa4,
+	0x08, 0xaf, 0xd3, 0x5c, 0x16, 0x87, 0x47, 0xa0, 0xaa, 0xae, 0xfb, 0x09, 0x8b, 0x7a, 0x31, 0xe5,
+	0x76, 0x49, 0x15, 0xde, 0x2d, 0x2e, 0x7c, 0x78, 0x4a, 0xba, 0x9f, 0x29, 0xa0, 0xd7, 0xf3, 0x1f,
+	0x51, 0x91, 0xdf, 0xe7, 0x38, 0x13, 0xc2, 0x15, 0x3e, 0x84, 0x73, 0xf8, 0x2d, 0x58, 0x9f, 0xf2,
+	0x0a, 0xfb, 0xa2, 0x2a, 0x76, 0x63, 0xf6, 0x2e, 0x6f, 0x8f, 0xa1, 0x8d, 0xb4, 0xf5, 0x82, 0xfb,
+	0x3e, 0x4e, 0x2a, 0x87, 0x2e, 0x97, 0x09, 0x53, 0xb0, 0x1e, 0x87, 0x89, 0x9e, 0x95, 0x94, 0x72,
+	0x9a, 0x9e, 0x50, 0x6e, 0xff, 0x4f, 0x15, 0xbf, 0x56, 0x5c, 0xfc, 0x7e, 0x98, 0xc8, 0xfa, 0x58,
+	0x83, 0xf3, 0x45, 0xa7, 0xc8, 0x10, 0xbe, 0x14, 0x4f, 0x64, 0x70, 0xf8, 0xd8, 0x02, 0x97, 0x8b,
+	0x2c, 0x98, 0xdb, 0x65, 0x55, 0x78, 0x6f, 0xf6, 0xae, 0xef, 0x8e, 0xd2, 0xa4, 0xe2, 0x07, 0x94,
+	0x72, 0xef, 0x9a, 0xe9, 0xe0, 0xaa, 0xee, 0xa0, 0x90, 0x19, 0xe1, 0x8d, 0x60, 0x3a, 0x15, 0x1e,
+	0x82, 0xcb, 0x72, 0x10, 0x8e, 0x52, 0x4a, 0x35, 0x36, 0x66, 0x41, 0x2f, 0xa2, 0xdc, 0x06, 0xf5,
+	0xc5, 0x46, 0xd9, 0xab, 0x8f, 0x58, 0x0b, 0x61, 0x08, 0xc3, 0x23, 0x4a, 0x0f, 0x52, 0x4a, 0x25,
+	0xe3, 0x7d, 0x1d, 0x84, 0x5f, 0x01, 0x3b, 0xef, 0x3c, 0x4a, 0x91, 0x30, 0xe0, 0x76, 0xa5, 0xbe,
+	0xd8, 0x58, 0xf2, 0xde, 0x18, 0xf4, 0x1d, 0xa7, 0xd8, 0xa3, 0x32, 0x24, 0xc2, 0x97, 0x27, 0x2d,
+	0x4a, 0x5d, 0xf1, 0x80, 0x4b, 0xd7, 0xed, 0x92, 0x1e, 0xa7, 0xc1, 0x88, 0xb4, 0x5a, 0x5f, 0x9c,
+	0x74, 0xdd, 0x1c, 0x00, 0xe1, 0x15, 0x1d, 0xc9, 0x38, 0xe4, 0x01, 0x14, 0x3d, 0x9a, 0xdc, 0x5e,
+	0xf9, 0xb7, 0x03, 0x50, 0xbe, 0x7d, 0xa8, 0xb3, 0x0e, 0x74, 0x52, 0xfe, 0x00, 0x0a, 0x99, 0x11,
+	0xde, 0x20, 0xd3, 0xa9, 0xf2, 0x65, 0xb3, 0x67, 0x3c, 0xae, 0xdc, 0x5e, 0x55, 0xcd, 0xbc, 0xf3,
+	0xba, 0x69, 0xd0, 0x99, 0x52, 0xfd, 0xe1, 0x64, 0x70, 0xef, 0x4d, 0xd3, 0x8f, 0x93, 0x0d, 0x44,
+	0x31, 0x3f, 0xc2, 0x5b, 0x41, 0x21, 0x01, 0x3a, 0x05, 0x9b, 0x45, 0x46, 0x02, 0xdf, 0x06, 0x17,
+	0x8d, 0xa6, 0xb6, 0xa5, 0x5e, 0x3a, 0x38, 0xe8, 0x3b, 0xab, 0x63, 0xb7, 0x2d, 0x0c, 0x10, 0x2e,
+	0x75, 0x95, 0xca, 0x70, 0x1f, 0x94, 0x87, 0x06, 0xa3, 0xfc, 0xb7, 0xec, 0x6d, 0x0e, 0xfa, 0xce,
+	0x9a, 0x86, 0x0f, 0x97, 0x10, 0x1e, 0xc1, 0xd0, 0xd3, 0x0b, 0x60, 0x2d, 0xef, 0x24, 0xf3, 0x55,
+	0xbd, 0x0e, 0x4a, 0x22, 0x25, 0x81, 0xb1, 0xfc, 0xb2, 0xb7, 0x3e, 0xe8, 0x3b, 0x2b, 0x1a, 0xab,
+	0xe3, 0x08, 0x1b, 0x00, 0xfc, 0x1a, 0x54, 0xdb, 0xaa, 0x42, 0x8b, 0x0b, 0x92, 0x0a, 0xf3, 0x06,
+	0xec, 0x4c, 0xbd, 0x32, 0x0f, 0xb3, 0xbf, 0xdf, 0x3c, 0x67, 0xd2, 0xd3, 0xc6, 0xb3, 0xd1, 0x93,
+	0x17, 0x8e, 0x85, 0x2b, 0x3a, 0x74, 0x28, 0x23, 0xf0, 0x73, 0x50, 0xd2, 0x86, 0x67, 0x9e, 0xcf,
+	0x0f, 0xe6, 0x78, 0x3e, 0xef, 0x25, 0x62, 0xd4, 0xb8, 0x66, 0x41, 0xd8, 0xd0, 0xa1, 0x5f, 0x2c,
+	0xb0, 0x55, 0x6c, 0x81, 0xf3, 0x69, 0xd5, 0x01, 0xd5, 0x09, 0xcf, 0xbd, 0x50, 0xb7, 0x66, 0x1b,
+	0x7c, 0xbe, 0x60, 0xde, 0xe0, 0x27, 0x8d, 0x76, 0x82, 0x18, 0xfd, 0x64, 0x81, 0xd5, 0x49, 0xdb,
+	0x84, 0xbb, 0x60, 0x39, 0xa0, 0x09, 0x8b, 0x55, 0x9b, 0x65, 0x6f, 0x6d, 0xd0, 0x77, 0xaa, 0x66,
+	0x5c, 0x65, 0x18, 0x61, 0xbd, 0x0c, 0x29, 0xa8, 0x48, 0x4b, 0x35, 0x6e, 0x6a, 0x0e, 0xf5, 0xee,
+	0xdc, 0x4a, 0xc2, 0x91, 0x3b, 0x1b, 0x2a, 0x84, 0x41, 0x1c, 0x26, 0xa6, 0x1d, 0xf4, 0xa3, 0x05,
+	0xae, 0xcc, 0xf0, 0xd7, 0xf9, 0x34, 0xc5, 0x60, 0x49, 0x39, 0xb9, 0xd6, 0xf2, 0x7a, 0xb1, 0x96,
+	0x45, 0x2e, 0xbe, 0x61, 0xe4, 0xac, 0x0c, 0xfd, 0x96, 0x23, 0xac, 0xb8, 0xd0, 0x53, 0xd3, 0x5c,
+	0x81, 0xf7, 0xcc, 0xd7, 0xdc, 0x97, 0xe0, 0x62, 0x66, 0x74, 0xaf, 0xed, 0xaf, 0xc8, 0xe4, 0xb6,
+	0x4c, 0x7f, 0x86, 0x7b, 0x68, 0x6b, 0x19, 0x23, 0xfa, 0xd5, 0x02, 0x3b, 0xb3, 0x4d, 0x69, 0xde,
+	0xc9, 0x04, 0x63, 0x3e, 0xa8, 0x7b, 0xbd, 0x31, 0x4b, 0xcb, 0x42, 0x0f, 0xdc, 0x36, 0xed, 0xae,
+	0xe7, 0x1e, 0x45, 0x8e, 0xf0, 0x18, 0xb5, 0x77, 0xef, 0xd9, 0xcb, 0x9a, 0xf5, 0xfc, 0x65, 0xcd,
+	0xfa, 0xeb, 0x65, 0xcd, 0x7a, 0xf2, 0xaa, 0xb6, 0xf0, 0xfc, 0x55, 0x6d, 0xe1, 0x8f, 0x57, 0xb5,
+	0x85, 0x2f, 0xdc, 0xb1, 0xd9, 0x32, 0x85, 0xf7, 0x22, 0xd2, 0xe6, 0xd9, 0x87, 0x7b, 0xf2, 0xbe,
+	0x7b, 0xa6, 0xff, 0x87, 0x51, 0x83, 0xd6, 0x2e, 0x29, 0xc3, 0x78, 0xf7, 0x9f, 0x01, 0x00, 0xd7,
+	0xb1, 0xc6, 0x9e, 0x6b, 0x0e, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.ProtocolFeeShare.Size()
+		i -= size
+		if _, err := m.ProtocolFeeShare.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size := m.CircuitBreakerThreshold.Size()
+		i -= size
+		if _, err := m.CircuitBreakerThreshold.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxPoolAssets != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.MaxPoolAssets))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.PoolCreationFee) > 0 {
+		for iNdEx := len(m.PoolCreationFee) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PoolCreationFee[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GenesisState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenesisState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DisabledSwapDirections) > 0 {
+		for iNdEx := len(m.DisabledSwapDirections) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DisabledSwapDirections[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if len(m.AssetScalingFactors) > 0 {
+		for iNdEx := len(m.AssetScalingFactors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AssetScalingFactors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if len(m.PausedPoolIds) > 0 {
+		dAtA2 := make([]byte, len(m.PausedPoolIds)*10)
+		var j1 int
+		for _, num := range m.PausedPoolIds {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintGenesis(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x62
+	}
+	if len(m.CircuitBreakerPoolIds) > 0 {
+		dAtA4 := make([]byte, len(m.CircuitBreakerPoolIds)*10)
+		var j3 int
+		for _, num := range m.CircuitBreakerPoolIds {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintGenesis(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.FeeFreeSwapModules) > 0 {
+		for iNdEx := len(m.FeeFreeSwapModules) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.FeeFreeSwapModules[iNdEx])
+			copy(dAtA[i:], m.FeeFreeSwapModules[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.FeeFreeSwapModules[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.DirectionalSwapFees) > 0 {
+		for iNdEx := len(m.DirectionalSwapFees) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DirectionalSwapFees[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.MinPoolReserves) > 0 {
+		for iNdEx := len(m.MinPoolReserves) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MinPoolReserves[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.PoolAccumulators) > 0 {
+		for iNdEx := len(m.PoolAccumulators) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PoolAccumulators[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.SwapVolumes) > 0 {
+		for iNdEx := len(m.SwapVolumes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SwapVolumes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.ExitFeeRecipients) > 0 {
+		for iNdEx := len(m.ExitFeeRecipients) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ExitFeeRecipients[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.TwapRecords) > 0 {
+		for iNdEx := len(m.TwapRecords) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TwapRecords[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.NextPoolNumber != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.NextPoolNumber))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Pools) > 0 {
+		for iNdEx := len(m.Pools) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pools[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolExitFeeRecipient) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolExitFeeRecipient) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolExitFeeRecipient) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Recipient) > 0 {
+		i -= len(m.Recipient)
+		copy(dAtA[i:], m.Recipient)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Recipient)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SwapVolumeBucket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SwapVolumeBucket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SwapVolumeBucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.Volume.Size()
+		i -= size
+		if _, err := m.Volume.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.BucketStart, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.BucketStart):])
+	if err6 != nil {
+		return 0, err6
+	}
+	i -= n6
+	i = encodeVarintGenesis(dAtA, i, uint64(n6))
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Trader) > 0 {
+		i -= len(m.Trader)
+		copy(dAtA[i:], m.Trader)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Trader)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolAccumulatorsRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolAccumulatorsRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolAccumulatorsRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Accumulators.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MinPoolReserve) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MinPoolReserve) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MinPoolReserve) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.MinReserve.Size()
+		i -= size
+		if _, err := m.MinReserve.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolDirectionalSwapFees) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolDirectionalSwapFees) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolDirectionalSwapFees) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Fees.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolAssetScalingFactors) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolAssetScalingFactors) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolAssetScalingFactors) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Factors.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PoolDisabledSwapDirections) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolDisabledSwapDirections) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolDisabledSwapDirections) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Directions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.PoolId != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
+	offset -= sovGenesis(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.PoolCreationFee) > 0 {
+		for _, e := range m.PoolCreationFee {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.MaxPoolAssets != 0 {
+		n += 1 + sovGenesis(uint64(m.MaxPoolAssets))
+	}
+	l = m.CircuitBreakerThreshold.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	l = m.ProtocolFeeShare.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *GenesisState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Pools) > 0 {
+		for _, e := range m.Pools {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.NextPoolNumber != 0 {
+		n += 1 + sovGenesis(uint64(m.NextPoolNumber))
+	}
+	l = m.Params.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	if len(m.TwapRecords) > 0 {
+		for _, e := range m.TwapRecords {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.ExitFeeRecipients) > 0 {
+		for _, e := range m.ExitFeeRecipients {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.SwapVolumes) > 0 {
+		for _, e := range m.SwapVolumes {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PoolAccumulators) > 0 {
+		for _, e := range m.PoolAccumulators {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.MinPoolReserves) > 0 {
+		for _, e := range m.MinPoolReserves {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.DirectionalSwapFees) > 0 {
+		for _, e := range m.DirectionalSwapFees {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.FeeFreeSwapModules) > 0 {
+		for _, s := range m.FeeFreeSwapModules {
+			l = len(s)
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.CircuitBreakerPoolIds) > 0 {
+		l = 0
+		for _, e := range m.CircuitBreakerPoolIds {
+			l += sovGenesis(uint64(e))
+		}
+		n += 1 + sovGenesis(uint64(l)) + l
+	}
+	if len(m.PausedPoolIds) > 0 {
+		l = 0
+		for _, e := range m.PausedPoolIds {
+			l += sovGenesis(uint64(e))
+		}
+		n += 1 + sovGenesis(uint64(l)) + l
+	}
+	if len(m.AssetScalingFactors) > 0 {
+		for _, e := range m.AssetScalingFactors {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.DisabledSwapDirections) > 0 {
+		for _, e := range m.DisabledSwapDirections {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PoolExitFeeRecipient) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = len(m.Recipient)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	return n
+}
+
+func (m *SwapVolumeBucket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = len(m.Trader)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.BucketStart)
+	n += 1 + l + sovGenesis(uint64(l))
+	l = m.Volume.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *PoolAccumulatorsRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = m.Accumulators.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *MinPoolReserve) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = m.MinReserve.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *PoolDirectionalSwapFees) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = m.Fees.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *PoolAssetScalingFactors) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = m.Factors.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *PoolDisabledSwapDirections) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovGenesis(uint64(m.PoolId))
+	}
+	l = m.Directions.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func sovGenesis(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozGenesis(x uint64) (n int) {
+	return sovGenesis(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolCreationFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolCreationFee = append(m.PoolCreationFee, types.Coin{})
+			if err := m.PoolCreationFee[len(m.PoolCreationFee)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxPoolAssets", wireType)
+			}
+			m.MaxPoolAssets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxPoolAssets |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CircuitBreakerThreshold", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CircuitBreakerThreshold.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolFeeShare", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ProtocolFeeShare.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenesisState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenesisState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenesisState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pools = append(m.Pools, &types1.Any{})
+			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPoolNumber", wireType)
+			}
+			m.NextPoolNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextPoolNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TwapRecords", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TwapRecords = append(m.TwapRecords, TwapRecord{})
+			if err := m.TwapRecords[len(m.TwapRecords)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExitFeeRecipients", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExitFeeRecipients = append(m.ExitFeeRecipients, PoolExitFeeRecipient{})
+			if err := m.ExitFeeRecipients[len(m.ExitFeeRecipients)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapVolumes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SwapVolumes = append(m.SwapVolumes, SwapVolumeBucket{})
+			if err := m.SwapVolumes[len(m.SwapVolumes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolAccumulators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolAccumulators = append(m.PoolAccumulators, PoolAccumulatorsRecord{})
+			if err := m.PoolAccumulators[len(m.PoolAccumulators)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinPoolReserves", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinPoolReserves = append(m.MinPoolReserves, MinPoolReserve{})
+			if err := m.MinPoolReserves[len(m.MinPoolReserves)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DirectionalSwapFees", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DirectionalSwapFees = append(m.DirectionalSwapFees, PoolDirectionalSwapFees{})
+			if err := m.DirectionalSwapFees[len(m.DirectionalSwapFees)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeFreeSwapModules", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeeFreeSwapModules = append(m.FeeFreeSwapModules, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.CircuitBreakerPoolIds = append(m.CircuitBreakerPoolIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.CircuitBreakerPoolIds) == 0 {
+					m.CircuitBreakerPoolIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.CircuitBreakerPoolIds = append(m.CircuitBreakerPoolIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field CircuitBreakerPoolIds", wireType)
+			}
+		case 12:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.PausedPoolIds = append(m.PausedPoolIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.PausedPoolIds) == 0 {
+					m.PausedPoolIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.PausedPoolIds = append(m.PausedPoolIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field PausedPoolIds", wireType)
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AssetScalingFactors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AssetScalingFactors = append(m.AssetScalingFactors, PoolAssetScalingFactors{})
+			if err := m.AssetScalingFactors[len(m.AssetScalingFactors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisabledSwapDirections", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DisabledSwapDirections = append(m.DisabledSwapDirections, PoolDisabledSwapDirections{})
+			if err := m.DisabledSwapDirections[len(m.DisabledSwapDirections)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolExitFeeRecipient) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolExitFeeRecipient: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolExitFeeRecipient: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Recipient", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Recipient = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SwapVolumeBucket) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SwapVolumeBucket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SwapVolumeBucket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Trader", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Trader = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BucketStart", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.BucketStart, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Volume", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Volume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolAccumulatorsRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolAccumulatorsRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolAccumulatorsRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accumulators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Accumulators.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			i--
-			dAtA[i] = 0xa
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return len(dAtA) - i, nil
-}
 
-func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
-	offset -= sovGenesis(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return nil
 }
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.PoolCreationFee) > 0 {
-		for _, e := range m.PoolCreationFee {
-			l = e.Size()
-			n += 1 + l + sovGenesis(uint64(l))
+func (m *MinPoolReserve) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
 		}
-	}
-	return n
-}
-
-func (m *GenesisState) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Pools) > 0 {
-		for _, e := range m.Pools {
-			l = e.Size()
-			n += 1 + l + sovGenesis(uint64(l))
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MinPoolReserve: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MinPoolReserve: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinReserve", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinReserve.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	if m.NextPoolNumber != 0 {
-		n += 1 + sovGenesis(uint64(m.NextPoolNumber))
-	}
-	l = m.Params.Size()
-	n += 1 + l + sovGenesis(uint64(l))
-	return n
-}
 
-func sovGenesis(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozGenesis(x uint64) (n int) {
-	return sovGenesis(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *PoolDirectionalSwapFees) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -335,15 +2785,34 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: PoolDirectionalSwapFees: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PoolDirectionalSwapFees: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PoolCreationFee", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fees", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -370,8 +2839,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PoolCreationFee = append(m.PoolCreationFee, types.Coin{})
-			if err := m.PoolCreationFee[len(m.PoolCreationFee)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Fees.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -396,7 +2864,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GenesisState) Unmarshal(dAtA []byte) error {
+func (m *PoolAssetScalingFactors) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -419,15 +2887,34 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GenesisState: wiretype end group for non-group")
+			return fmt.Errorf("proto: PoolAssetScalingFactors: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenesisState: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PoolAssetScalingFactors: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pools", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Factors", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -454,16 +2941,65 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pools = append(m.Pools, &types1.Any{})
-			if err := m.Pools[len(m.Pools)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Factors.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PoolDisabledSwapDirections) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolDisabledSwapDirections: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolDisabledSwapDirections: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextPoolNumber", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
 			}
-			m.NextPoolNumber = 0
+			m.PoolId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -473,14 +3009,14 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.NextPoolNumber |= uint64(b&0x7F) << shift
+				m.PoolId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Directions", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -507,7 +3043,7 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Directions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -0,0 +1,371 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	ProposalTypeUpdatePoolSwapFee               = "UpdatePoolSwapFee"
+	ProposalTypeSetExitFeeRecipient             = "SetExitFeeRecipient"
+	ProposalTypeSetSwapFeeTiers                 = "SetSwapFeeTiers"
+	ProposalTypeSetMinPoolReserve               = "SetMinPoolReserve"
+	ProposalTypeSetDirectionalSwapFees          = "SetDirectionalSwapFees"
+	ProposalTypeSetCircuitBreakerEnabled        = "SetCircuitBreakerEnabled"
+	ProposalTypeSetPoolPaused                   = "SetPoolPaused"
+	ProposalTypeMigrateBalancerPoolToStableswap = "MigrateBalancerPoolToStableswap"
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeUpdatePoolSwapFee)
+	govtypes.RegisterProposalTypeCodec(&UpdatePoolSwapFeeProposal{}, "osmosis/UpdatePoolSwapFeeProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetExitFeeRecipient)
+	govtypes.RegisterProposalTypeCodec(&SetExitFeeRecipientProposal{}, "osmosis/SetExitFeeRecipientProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetSwapFeeTiers)
+	govtypes.RegisterProposalTypeCodec(&SetSwapFeeTiersProposal{}, "osmosis/SetSwapFeeTiersProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetMinPoolReserve)
+	govtypes.RegisterProposalTypeCodec(&SetMinPoolReserveProposal{}, "osmosis/SetMinPoolReserveProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetDirectionalSwapFees)
+	govtypes.RegisterProposalTypeCodec(&SetDirectionalSwapFeesProposal{}, "osmosis/SetDirectionalSwapFeesProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetCircuitBreakerEnabled)
+	govtypes.RegisterProposalTypeCodec(&SetCircuitBreakerEnabledProposal{}, "osmosis/SetCircuitBreakerEnabledProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetPoolPaused)
+	govtypes.RegisterProposalTypeCodec(&SetPoolPausedProposal{}, "osmosis/SetPoolPausedProposal")
+	govtypes.RegisterProposalType(ProposalTypeMigrateBalancerPoolToStableswap)
+	govtypes.RegisterProposalTypeCodec(&MigrateBalancerPoolToStableswapProposal{}, "osmosis/MigrateBalancerPoolToStableswapProposal")
+}
+
+var (
+	_ govtypes.Content = &UpdatePoolSwapFeeProposal{}
+	_ govtypes.Content = &SetExitFeeRecipientProposal{}
+	_ govtypes.Content = &SetSwapFeeTiersProposal{}
+	_ govtypes.Content = &SetMinPoolReserveProposal{}
+	_ govtypes.Content = &SetDirectionalSwapFeesProposal{}
+	_ govtypes.Content = &SetCircuitBreakerEnabledProposal{}
+	_ govtypes.Content = &SetPoolPausedProposal{}
+	_ govtypes.Content = &MigrateBalancerPoolToStableswapProposal{}
+)
+
+func NewUpdatePoolSwapFeeProposal(title, description string, poolId uint64, swapFee sdk.Dec) govtypes.Content {
+	return &UpdatePoolSwapFeeProposal{
+		Title:       title,
+		Description: description,
+		PoolId:      poolId,
+		SwapFee:     swapFee,
+	}
+}
+
+func (p *UpdatePoolSwapFeeProposal) GetTitle() string { return p.Title }
+
+func (p *UpdatePoolSwapFeeProposal) GetDescription() string { return p.Description }
+
+func (p *UpdatePoolSwapFeeProposal) ProposalRoute() string { return RouterKey }
+
+func (p *UpdatePoolSwapFeeProposal) ProposalType() string { return ProposalTypeUpdatePoolSwapFee }
+
+func (p *UpdatePoolSwapFeeProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if p.SwapFee.IsNil() {
+		return ErrNegativeSwapFee
+	}
+	return ValidateSwapFee(p.SwapFee)
+}
+
+func (p UpdatePoolSwapFeeProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Update Pool Swap Fee Proposal:
+  Title:       %s
+  Description: %s
+  Pool Id:     %d
+  Swap Fee:    %s
+`, p.Title, p.Description, p.PoolId, p.SwapFee))
+	return b.String()
+}
+
+func NewSetExitFeeRecipientProposal(title, description string, poolId uint64, recipient string) govtypes.Content {
+	return &SetExitFeeRecipientProposal{
+		Title:       title,
+		Description: description,
+		PoolId:      poolId,
+		Recipient:   recipient,
+	}
+}
+
+func (p *SetExitFeeRecipientProposal) GetTitle() string { return p.Title }
+
+func (p *SetExitFeeRecipientProposal) GetDescription() string { return p.Description }
+
+func (p *SetExitFeeRecipientProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetExitFeeRecipientProposal) ProposalType() string { return ProposalTypeSetExitFeeRecipient }
+
+func (p *SetExitFeeRecipientProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if p.Recipient == "" {
+		return nil
+	}
+	_, err = sdk.AccAddressFromBech32(p.Recipient)
+	return err
+}
+
+func (p SetExitFeeRecipientProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Exit Fee Recipient Proposal:
+  Title:       %s
+  Description: %s
+  Pool Id:     %d
+  Recipient:   %s
+`, p.Title, p.Description, p.PoolId, p.Recipient))
+	return b.String()
+}
+
+func NewSetSwapFeeTiersProposal(title, description string, poolId uint64, tiers SwapFeeTiers) govtypes.Content {
+	return &SetSwapFeeTiersProposal{
+		Title:        title,
+		Description:  description,
+		PoolId:       poolId,
+		SwapFeeTiers: tiers,
+	}
+}
+
+func (p *SetSwapFeeTiersProposal) GetTitle() string { return p.Title }
+
+func (p *SetSwapFeeTiersProposal) GetDescription() string { return p.Description }
+
+func (p *SetSwapFeeTiersProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetSwapFeeTiersProposal) ProposalType() string { return ProposalTypeSetSwapFeeTiers }
+
+func (p *SetSwapFeeTiersProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if len(p.SwapFeeTiers.Tiers) == 0 {
+		return nil
+	}
+	// the tiers are checked against the pool's swap fee once the proposal is executed.
+	return p.SwapFeeTiers.Validate(sdk.OneDec())
+}
+
+func (p SetSwapFeeTiersProposal) String() string {
+	tiersStr := ""
+	for _, tier := range p.SwapFeeTiers.Tiers {
+		tiersStr = tiersStr + fmt.Sprintf("(MinVolume: %s, SwapFee: %s) ", tier.MinVolume, tier.SwapFee)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Swap Fee Tiers Proposal:
+  Title:        %s
+  Description:  %s
+  Pool Id:      %d
+  Volume Denom: %s
+  Tiers:        %s
+`, p.Title, p.Description, p.PoolId, p.SwapFeeTiers.VolumeDenom, tiersStr))
+	return b.String()
+}
+
+func NewSetMinPoolReserveProposal(title, description string, denom string, minReserve sdk.Int) govtypes.Content {
+	return &SetMinPoolReserveProposal{
+		Title:       title,
+		Description: description,
+		Denom:       denom,
+		MinReserve:  minReserve,
+	}
+}
+
+func (p *SetMinPoolReserveProposal) GetTitle() string { return p.Title }
+
+func (p *SetMinPoolReserveProposal) GetDescription() string { return p.Description }
+
+func (p *SetMinPoolReserveProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetMinPoolReserveProposal) ProposalType() string { return ProposalTypeSetMinPoolReserve }
+
+func (p *SetMinPoolReserveProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	return ValidateMinPoolReserve(p.Denom, p.MinReserve)
+}
+
+func (p SetMinPoolReserveProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Min Pool Reserve Proposal:
+  Title:       %s
+  Description: %s
+  Denom:       %s
+  Min Reserve: %s
+`, p.Title, p.Description, p.Denom, p.MinReserve))
+	return b.String()
+}
+
+func NewSetDirectionalSwapFeesProposal(title, description string, poolId uint64, fees DirectionalSwapFees) govtypes.Content {
+	return &SetDirectionalSwapFeesProposal{
+		Title:               title,
+		Description:         description,
+		PoolId:              poolId,
+		DirectionalSwapFees: fees,
+	}
+}
+
+func (p *SetDirectionalSwapFeesProposal) GetTitle() string { return p.Title }
+
+func (p *SetDirectionalSwapFeesProposal) GetDescription() string { return p.Description }
+
+func (p *SetDirectionalSwapFeesProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetDirectionalSwapFeesProposal) ProposalType() string {
+	return ProposalTypeSetDirectionalSwapFees
+}
+
+func (p *SetDirectionalSwapFeesProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	return p.DirectionalSwapFees.ValidateBasic()
+}
+
+func (p SetDirectionalSwapFeesProposal) String() string {
+	feesStr := ""
+	for _, fee := range p.DirectionalSwapFees.Fees {
+		feesStr = feesStr + fmt.Sprintf("(%s -> %s: %s) ", fee.TokenInDenom, fee.TokenOutDenom, fee.SwapFee)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Directional Swap Fees Proposal:
+  Title:       %s
+  Description: %s
+  Pool Id:     %d
+  Fees:        %s
+`, p.Title, p.Description, p.PoolId, feesStr))
+	return b.String()
+}
+
+func NewSetCircuitBreakerEnabledProposal(title, description string, poolId uint64, enabled bool) govtypes.Content {
+	return &SetCircuitBreakerEnabledProposal{
+		Title:       title,
+		Description: description,
+		PoolId:      poolId,
+		Enabled:     enabled,
+	}
+}
+
+func (p *SetCircuitBreakerEnabledProposal) GetTitle() string { return p.Title }
+
+func (p *SetCircuitBreakerEnabledProposal) GetDescription() string { return p.Description }
+
+func (p *SetCircuitBreakerEnabledProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetCircuitBreakerEnabledProposal) ProposalType() string {
+	return ProposalTypeSetCircuitBreakerEnabled
+}
+
+func (p *SetCircuitBreakerEnabledProposal) ValidateBasic() error {
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p SetCircuitBreakerEnabledProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Circuit Breaker Enabled Proposal:
+  Title:       %s
+  Description: %s
+  Pool Id:     %d
+  Enabled:     %t
+`, p.Title, p.Description, p.PoolId, p.Enabled))
+	return b.String()
+}
+
+func NewSetPoolPausedProposal(title, description string, poolId uint64, paused bool) govtypes.Content {
+	return &SetPoolPausedProposal{
+		Title:       title,
+		Description: description,
+		PoolId:      poolId,
+		Paused:      paused,
+	}
+}
+
+func (p *SetPoolPausedProposal) GetTitle() string { return p.Title }
+
+func (p *SetPoolPausedProposal) GetDescription() string { return p.Description }
+
+func (p *SetPoolPausedProposal) ProposalRoute() string { return RouterKey }
+
+func (p *SetPoolPausedProposal) ProposalType() string { return ProposalTypeSetPoolPaused }
+
+func (p *SetPoolPausedProposal) ValidateBasic() error {
+	return govtypes.ValidateAbstract(p)
+}
+
+func (p SetPoolPausedProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Set Pool Paused Proposal:
+  Title:       %s
+  Description: %s
+  Pool Id:     %d
+  Paused:      %t
+`, p.Title, p.Description, p.PoolId, p.Paused))
+	return b.String()
+}
+
+func NewMigrateBalancerPoolToStableswapProposal(title, description string, poolId uint64, amplificationParameter uint64, scalingFactors []uint64, scalingFactorGovernor string) govtypes.Content {
+	return &MigrateBalancerPoolToStableswapProposal{
+		Title:                  title,
+		Description:            description,
+		PoolId:                 poolId,
+		AmplificationParameter: amplificationParameter,
+		ScalingFactors:         scalingFactors,
+		ScalingFactorGovernor:  scalingFactorGovernor,
+	}
+}
+
+func (p *MigrateBalancerPoolToStableswapProposal) GetTitle() string { return p.Title }
+
+func (p *MigrateBalancerPoolToStableswapProposal) GetDescription() string { return p.Description }
+
+func (p *MigrateBalancerPoolToStableswapProposal) ProposalRoute() string { return RouterKey }
+
+func (p *MigrateBalancerPoolToStableswapProposal) ProposalType() string {
+	return ProposalTypeMigrateBalancerPoolToStableswap
+}
+
+func (p *MigrateBalancerPoolToStableswapProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	if p.AmplificationParameter == 0 {
+		return sdkerrors.Wrapf(ErrInvalidAmplificationParameter, "amplification parameter must be at least 1")
+	}
+	for _, scalingFactor := range p.ScalingFactors {
+		if scalingFactor == 0 {
+			return sdkerrors.Wrapf(ErrInvalidStableswapScalingFactors, "scaling factors must be positive")
+		}
+	}
+	return nil
+}
+
+func (p MigrateBalancerPoolToStableswapProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Migrate Balancer Pool To Stableswap Proposal:
+  Title:                   %s
+  Description:             %s
+  Pool Id:                 %d
+  Amplification Parameter: %d
+  Scaling Factors:         %v
+  Scaling Factor Governor: %s
+`, p.Title, p.Description, p.PoolId, p.AmplificationParameter, p.ScalingFactors, p.ScalingFactorGovernor))
+	return b.String()
+}
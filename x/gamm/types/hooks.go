@@ -5,12 +5,15 @@ import sdk "github.com/cosmos/cosmos-sdk/types"
 type GammHooks interface {
 	// AfterPoolCreated is called after CreatePool
 	AfterPoolCreated(ctx sdk.Context, sender sdk.AccAddress, poolId uint64)
-	// AfterJoinPool is called after JoinPool, JoinSwapExternAmountIn, and JoinSwapShareAmountOut
+	// AfterJoinPool is called after JoinPool, JoinSwapExternAmountIn, and JoinSwapShareAmountOut,
+	// once the join is fully applied. enterCoins are the coins consumed by the join.
 	AfterJoinPool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, enterCoins sdk.Coins, shareOutAmount sdk.Int)
-	// AfterExitPool is called after ExitPool, ExitSwapShareAmountIn, and ExitSwapExternAmountOut
+	// AfterExitPool is called after ExitPool, ExitSwapShareAmountIn, and ExitSwapExternAmountOut,
+	// once the exit is fully applied. exitCoins are the coins sent to the exiter.
 	AfterExitPool(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, shareInAmount sdk.Int, exitCoins sdk.Coins)
-	// AfterSwap is called after SwapExactAmountIn and SwapExactAmountOut
-	AfterSwap(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins)
+	// AfterSwap is called after SwapExactAmountIn and SwapExactAmountOut, and every hop of a multihop swap,
+	// once the swap is fully applied. If it returns an error, the swap is reverted.
+	AfterSwap(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins) error
 }
 
 var _ GammHooks = MultiGammHooks{}
@@ -41,8 +44,12 @@ func (h MultiGammHooks) AfterExitPool(ctx sdk.Context, sender sdk.AccAddress, po
 	}
 }
 
-func (h MultiGammHooks) AfterSwap(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins) {
+// AfterSwap runs the AfterSwap hooks in sequence, and returns the error of the first to fail.
+func (h MultiGammHooks) AfterSwap(ctx sdk.Context, sender sdk.AccAddress, poolId uint64, input sdk.Coins, output sdk.Coins) error {
 	for i := range h {
-		h[i].AfterSwap(ctx, sender, poolId, input, output)
+		if err := h[i].AfterSwap(ctx, sender, poolId, input, output); err != nil {
+			return err
+		}
 	}
+	return nil
 }
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 const (
@@ -16,6 +18,9 @@ const (
 	RouterKey = ModuleName
 
 	QuerierRoute = ModuleName
+
+	// ProtocolFeeModuleAcctName is the module account receiving the protocol's share of swap fees.
+	ProtocolFeeModuleAcctName = "gamm_protocol_fees"
 )
 
 var (
@@ -25,8 +30,40 @@ var (
 	KeyPrefixPools = []byte{0x02}
 	// KeyTotalLiquidity defines key to store total liquidity.
 	KeyTotalLiquidity = []byte{0x03}
+	// KeyPrefixTwapRecords defines prefix to store the TWAP records of pools.
+	KeyPrefixTwapRecords = []byte{0x04}
+	// KeyPrefixChangedPools defines prefix to store the pools whose reserves changed in the current block.
+	KeyPrefixChangedPools = []byte{0x05}
+	// KeyPrefixExitFeeRecipients defines prefix to store the addresses receiving the exit fees of pools.
+	KeyPrefixExitFeeRecipients = []byte{0x06}
+	// KeyPrefixTwapRecordHeights defines prefix to store the times of the TWAP records of pools by block height.
+	KeyPrefixTwapRecordHeights = []byte{0x07}
+	// KeyPrefixSwapVolumes defines prefix to store the swap volumes of traders through pools with swap fee tiers.
+	KeyPrefixSwapVolumes = []byte{0x09}
+	// KeyPrefixPoolAccumulators defines prefix to store the cumulative amounts of the swaps through pools.
+	KeyPrefixPoolAccumulators = []byte{0x0A}
+	// KeyPrefixMinPoolReserves defines prefix to store the minimum pool reserves of denoms.
+	KeyPrefixMinPoolReserves = []byte{0x0C}
+	// KeyPrefixDirectionalSwapFees defines prefix to store the swap fees of pools by swap direction.
+	KeyPrefixDirectionalSwapFees = []byte{0x0D}
+	// KeyPrefixFeeFreeSwapModules defines prefix to store the modules authorized to swap without a swap fee.
+	KeyPrefixFeeFreeSwapModules = []byte{0x0E}
+	// KeyPrefixPoolsByDenom defines prefix to index the pools by the denoms of their assets.
+	KeyPrefixPoolsByDenom = []byte{0x0F}
+	// KeyPrefixCircuitBreakerPools defines prefix to store the pools with their circuit breaker enabled.
+	KeyPrefixCircuitBreakerPools = []byte{0x11}
+	// KeyPrefixPausedPools defines prefix to store the pools paused by their circuit breaker.
+	KeyPrefixPausedPools = []byte{0x12}
+	// KeyPrefixAssetScalingFactors defines prefix to store the decimals of the assets of pools.
+	KeyPrefixAssetScalingFactors = []byte{0x13}
+	// KeyPrefixDisabledSwapDirections defines prefix to store the swap directions disabled on pools.
+	KeyPrefixDisabledSwapDirections = []byte{0x15}
 )
 
+// KeySeparator separates the denoms in TWAP record keys.
+// It is not a valid denom character, so keys of different denom pairs can't collide.
+const KeySeparator = "|"
+
 func MustGetPoolIdFromShareDenom(denom string) uint64 {
 	numberStr := strings.TrimLeft(denom, "gamm/pool/")
 	number, err := strconv.Atoi(numberStr)
@@ -56,3 +93,97 @@ func GetPoolShareDenom(poolId uint64) string {
 func GetKeyPrefixPools(poolId uint64) []byte {
 	return append(KeyPrefixPools, sdk.Uint64ToBigEndian(poolId)...)
 }
+
+// GetKeyPrefixTwapRecords returns the prefix of the TWAP records of poolId, with base and quote as denoms.
+func GetKeyPrefixTwapRecords(poolId uint64, baseDenom, quoteDenom string) []byte {
+	key := append(KeyPrefixTwapRecords, sdk.Uint64ToBigEndian(poolId)...)
+	return append(key, []byte(baseDenom+KeySeparator+quoteDenom+KeySeparator)...)
+}
+
+// GetKeyTwapRecord returns the key of the TWAP record of poolId, with base and quote as denoms, at time t.
+func GetKeyTwapRecord(poolId uint64, baseDenom, quoteDenom string, t time.Time) []byte {
+	return append(GetKeyPrefixTwapRecords(poolId, baseDenom, quoteDenom), sdk.FormatTimeBytes(t)...)
+}
+
+// GetKeyPrefixTwapRecordHeights returns the prefix of the heights of the TWAP records of poolId, with base and quote as denoms.
+func GetKeyPrefixTwapRecordHeights(poolId uint64, baseDenom, quoteDenom string) []byte {
+	key := append(KeyPrefixTwapRecordHeights, sdk.Uint64ToBigEndian(poolId)...)
+	return append(key, []byte(baseDenom+KeySeparator+quoteDenom+KeySeparator)...)
+}
+
+// GetKeyTwapRecordHeight returns the key of the time of the TWAP record of poolId, with base and quote as denoms,
+// written at height.
+func GetKeyTwapRecordHeight(poolId uint64, baseDenom, quoteDenom string, height int64) []byte {
+	return append(GetKeyPrefixTwapRecordHeights(poolId, baseDenom, quoteDenom), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetKeyChangedPool returns the key marking poolId as changed in the current block.
+func GetKeyChangedPool(poolId uint64) []byte {
+	return append(KeyPrefixChangedPools, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyExitFeeRecipient returns the key of the exit fee recipient of poolId.
+func GetKeyExitFeeRecipient(poolId uint64) []byte {
+	return append(KeyPrefixExitFeeRecipients, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyDirectionalSwapFees returns the key of the directional swap fees of poolId.
+func GetKeyDirectionalSwapFees(poolId uint64) []byte {
+	return append(KeyPrefixDirectionalSwapFees, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyPrefixSwapVolumes returns the prefix of the swap volume buckets of trader through poolId.
+func GetKeyPrefixSwapVolumes(poolId uint64, trader sdk.AccAddress) []byte {
+	key := append(KeyPrefixSwapVolumes, sdk.Uint64ToBigEndian(poolId)...)
+	return append(key, address.MustLengthPrefix(trader)...)
+}
+
+// GetKeySwapVolume returns the key of the swap volume of trader through poolId in the bucket starting at bucketStart.
+func GetKeySwapVolume(poolId uint64, trader sdk.AccAddress, bucketStart time.Time) []byte {
+	return append(GetKeyPrefixSwapVolumes(poolId, trader), sdk.FormatTimeBytes(bucketStart)...)
+}
+
+// GetKeyPoolAccumulators returns the key of the cumulative amounts of the swaps through poolId.
+func GetKeyPoolAccumulators(poolId uint64) []byte {
+	return append(KeyPrefixPoolAccumulators, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyMinPoolReserve returns the key of the minimum reserve of denom in every pool.
+func GetKeyMinPoolReserve(denom string) []byte {
+	return append(KeyPrefixMinPoolReserves, denom...)
+}
+
+// GetKeyFeeFreeSwapModule returns the key of the authorization of moduleName to swap without a swap fee.
+func GetKeyFeeFreeSwapModule(moduleName string) []byte {
+	return append(KeyPrefixFeeFreeSwapModules, moduleName...)
+}
+
+// GetKeyPrefixPoolsByDenom returns the prefix of the index of the pools with denom as an asset.
+func GetKeyPrefixPoolsByDenom(denom string) []byte {
+	return append(KeyPrefixPoolsByDenom, []byte(denom+KeySeparator)...)
+}
+
+// GetKeyPoolByDenom returns the key indexing poolId as a pool with denom as an asset.
+func GetKeyPoolByDenom(denom string, poolId uint64) []byte {
+	return append(GetKeyPrefixPoolsByDenom(denom), sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyCircuitBreakerPool returns the key marking poolId as having its circuit breaker enabled.
+func GetKeyCircuitBreakerPool(poolId uint64) []byte {
+	return append(KeyPrefixCircuitBreakerPools, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyPausedPool returns the key marking poolId as paused by its circuit breaker.
+func GetKeyPausedPool(poolId uint64) []byte {
+	return append(KeyPrefixPausedPools, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyAssetScalingFactors returns the key of the asset scaling factors of poolId.
+func GetKeyAssetScalingFactors(poolId uint64) []byte {
+	return append(KeyPrefixAssetScalingFactors, sdk.Uint64ToBigEndian(poolId)...)
+}
+
+// GetKeyDisabledSwapDirections returns the key of the swap directions disabled on poolId.
+func GetKeyDisabledSwapDirections(poolId uint64) []byte {
+	return append(KeyPrefixDisabledSwapDirections, sdk.Uint64ToBigEndian(poolId)...)
+}
@@ -11,24 +11,40 @@ import (
 
 // Parameter store keys.
 var (
-	KeyPoolCreationFee = []byte("PoolCreationFee")
+	KeyPoolCreationFee         = []byte("PoolCreationFee")
+	KeyMaxPoolAssets           = []byte("MaxPoolAssets")
+	KeyCircuitBreakerThreshold = []byte("CircuitBreakerThreshold")
+	KeyProtocolFeeShare        = []byte("ProtocolFeeShare")
 )
 
+// DefaultCircuitBreakerThreshold is the default of the CircuitBreakerThreshold param.
+var DefaultCircuitBreakerThreshold = sdk.NewDecWithPrec(5, 1)
+
+// DefaultProtocolFeeShare is the default of the ProtocolFeeShare param.
+// By default, all of the swap fee stays in the pool, for its liquidity providers.
+var DefaultProtocolFeeShare = sdk.ZeroDec()
+
 // ParamTable for gamm module.
 func ParamKeyTable() paramtypes.KeyTable {
 	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
 }
 
-func NewParams(poolCreationFee sdk.Coins) Params {
+func NewParams(poolCreationFee sdk.Coins, maxPoolAssets uint64, circuitBreakerThreshold, protocolFeeShare sdk.Dec) Params {
 	return Params{
-		PoolCreationFee: poolCreationFee,
+		PoolCreationFee:         poolCreationFee,
+		MaxPoolAssets:           maxPoolAssets,
+		CircuitBreakerThreshold: circuitBreakerThreshold,
+		ProtocolFeeShare:        protocolFeeShare,
 	}
 }
 
 // default gamm module parameters.
 func DefaultParams() Params {
 	return Params{
-		PoolCreationFee: sdk.Coins{sdk.NewInt64Coin(appparams.BaseCoinUnit, 1000_000_000)}, // 1000 OSMO
+		PoolCreationFee:         sdk.Coins{sdk.NewInt64Coin(appparams.BaseCoinUnit, 1000_000_000)}, // 1000 OSMO
+		MaxPoolAssets:           DefaultMaxPoolAssets,
+		CircuitBreakerThreshold: DefaultCircuitBreakerThreshold,
+		ProtocolFeeShare:        DefaultProtocolFeeShare,
 	}
 }
 
@@ -37,6 +53,15 @@ func (p Params) Validate() error {
 	if err := validatePoolCreationFee(p.PoolCreationFee); err != nil {
 		return err
 	}
+	if err := ValidateMaxPoolAssets(p.MaxPoolAssets); err != nil {
+		return err
+	}
+	if err := ValidateCircuitBreakerThreshold(p.CircuitBreakerThreshold); err != nil {
+		return err
+	}
+	if err := ValidateProtocolFeeShare(p.ProtocolFeeShare); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -45,6 +70,9 @@ func (p Params) Validate() error {
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
 		paramtypes.NewParamSetPair(KeyPoolCreationFee, &p.PoolCreationFee, validatePoolCreationFee),
+		paramtypes.NewParamSetPair(KeyMaxPoolAssets, &p.MaxPoolAssets, ValidateMaxPoolAssets),
+		paramtypes.NewParamSetPair(KeyCircuitBreakerThreshold, &p.CircuitBreakerThreshold, ValidateCircuitBreakerThreshold),
+		paramtypes.NewParamSetPair(KeyProtocolFeeShare, &p.ProtocolFeeShare, ValidateProtocolFeeShare),
 	}
 }
 
@@ -60,3 +88,46 @@ func validatePoolCreationFee(i interface{}) error {
 
 	return nil
 }
+
+// ValidateMaxPoolAssets validates the MaxPoolAssets param, which must allow pools of MinPoolAssets assets,
+// and be at most MaxPoolAssetsLimit.
+func ValidateMaxPoolAssets(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < MinPoolAssets || v > MaxPoolAssetsLimit {
+		return fmt.Errorf("max pool assets must be between %d and %d, is %d", MinPoolAssets, MaxPoolAssetsLimit, v)
+	}
+
+	return nil
+}
+
+// ValidateCircuitBreakerThreshold validates the CircuitBreakerThreshold param, which must be positive.
+func ValidateCircuitBreakerThreshold(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() {
+		return fmt.Errorf("circuit breaker threshold must be positive, is %s", v)
+	}
+
+	return nil
+}
+
+// ValidateProtocolFeeShare validates the ProtocolFeeShare param, which must be between 0 and 1.
+func ValidateProtocolFeeShare(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("protocol fee share must be between 0 and 1, is %s", v)
+	}
+
+	return nil
+}
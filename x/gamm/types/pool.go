@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/address"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 // PoolI defines an interface for pools that hold tokens.
@@ -57,7 +58,9 @@ type PoolI interface {
 	// This function is mutative and updates the pool's internal state if there is no error.
 	// It is up to pool implementation if they support LP'ing at arbitrary ratios, or a subset of ratios.
 	// Pools are expected to guarantee LP'ing at the exact ratio, and single sided LP'ing.
-	JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, err error)
+	// tokensJoined are the coins out of tokensIn that were actually added to the pool,
+	// anything in tokensIn beyond tokensJoined is left to the caller.
+	JoinPool(ctx sdk.Context, tokensIn sdk.Coins, swapFee sdk.Dec) (numShares sdk.Int, tokensJoined sdk.Coins, err error)
 
 	// CalcJoinPoolShares returns how many LP shares JoinPool would return on these arguments.
 	// This does not mutate the pool, or state.
@@ -78,7 +81,7 @@ type PoolI interface {
 
 // PoolAmountOutExtension is an extension of the PoolI
 // interface definiting an abstraction for pools that hold tokens.
-// In addition, it supports JoinSwapShareAmountOut and ExitSwapExactAmountOut methods
+// In addition, it supports JoinSwapShareAmountOut, ExitSwapShareAmountIn and ExitSwapExactAmountOut methods
 // that allow joining with the exact amount of shares to get out, and exiting with exact
 // amount of coins to get out.
 // See definitions below.
@@ -105,6 +108,14 @@ type PoolAmountOutExtension interface {
 		shareOutAmount sdk.Int,
 	) (tokenInAmount sdk.Int, err error)
 
+	// ExitSwapShareAmountIn removes liquidity from a specified pool with an exact amount of LP shares (shareInAmount)
+	// and swaps to a single asset (tokenOutDenom), returning the amount of tokenOutDenom to send to the exiter.
+	ExitSwapShareAmountIn(
+		ctx sdk.Context,
+		tokenOutDenom string,
+		shareInAmount sdk.Int,
+	) (tokenOutAmount sdk.Int, err error)
+
 	// ExitSwapExactAmountOut removes liquidity from a specified pool with a maximum amount of LP shares (shareInMaxAmount)
 	// and swaps to an exact amount of one of the token pairs (tokenOut).
 	ExitSwapExactAmountOut(
@@ -117,6 +128,52 @@ type PoolAmountOutExtension interface {
 	IncreaseLiquidity(sharesOut sdk.Int, coinsIn sdk.Coins)
 }
 
+// ValidateSwapFee returns an error if swapFee is not in [0, 1).
+// A swap fee of 1 or more would leave nothing (or less) of the input to swap,
+// and make the division by (1 - swapFee) in exact amount out swaps undefined.
+func ValidateSwapFee(swapFee sdk.Dec) error {
+	if swapFee.IsNegative() {
+		return ErrNegativeSwapFee
+	}
+
+	if swapFee.GTE(sdk.OneDec()) {
+		return ErrTooMuchSwapFee
+	}
+	return nil
+}
+
+// ValidateExitFee returns an error if exitFee is not in [0, 1).
+func ValidateExitFee(exitFee sdk.Dec) error {
+	if exitFee.IsNegative() {
+		return ErrNegativeExitFee
+	}
+
+	if exitFee.GTE(sdk.OneDec()) {
+		return ErrTooMuchExitFee
+	}
+	return nil
+}
+
+// ValidateMinPoolReserve returns an error if denom is invalid or minReserve is negative.
+func ValidateMinPoolReserve(denom string, minReserve sdk.Int) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return err
+	}
+	if minReserve.IsNil() || minReserve.IsNegative() {
+		return sdkerrors.Wrapf(ErrNegativeMinPoolReserve, "minimum pool reserve of %s is %s", denom, minReserve)
+	}
+	return nil
+}
+
+// ValidateTotalSharesAfterJoin returns ErrTooManyPoolShares if minting sharesOut to a pool of totalShares
+// would take its total shares past MaxTotalShares.
+func ValidateTotalSharesAfterJoin(totalShares, sharesOut sdk.Int) error {
+	if sharesOut.GT(MaxTotalShares.Sub(totalShares)) {
+		return sdkerrors.Wrapf(ErrTooManyPoolShares, "joining %s shares to the %s shares of the pool", sharesOut, totalShares)
+	}
+	return nil
+}
+
 func NewPoolAddress(poolId uint64) sdk.AccAddress {
 	key := append([]byte("pool"), sdk.Uint64ToBigEndian(poolId)...)
 	return address.Module(ModuleName, key)
@@ -0,0 +1,537 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/gamm/v1beta1/pool_accumulators.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// PoolAccumulators are the cumulative amounts of the swaps through a pool,
+// updated once per swap hop through the pool.
+type PoolAccumulators struct {
+	// The amounts of every denom swapped into the pool.
+	VolumeIn github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,1,rep,name=volume_in,json=volumeIn,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"volume_in" yaml:"volume_in"`
+	// The amounts of every denom swapped out of the pool.
+	VolumeOut github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,2,rep,name=volume_out,json=volumeOut,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"volume_out" yaml:"volume_out"`
+	// The fractions of tokens that swaps rounded the tokens out down by.
+	RoundingDust github_com_cosmos_cosmos_sdk_types.DecCoins `protobuf:"bytes,3,rep,name=rounding_dust,json=roundingDust,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.DecCoins" json:"rounding_dust" yaml:"rounding_dust"`
+	// The swap fees accrued to the pool's LPs per OneShare of the pool's shares.
+	FeesPerShare github_com_cosmos_cosmos_sdk_types.DecCoins `protobuf:"bytes,4,rep,name=fees_per_share,json=feesPerShare,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.DecCoins" json:"fees_per_share" yaml:"fees_per_share"`
+}
+
+func (m *PoolAccumulators) Reset()         { *m = PoolAccumulators{} }
+func (m *PoolAccumulators) String() string { return proto.CompactTextString(m) }
+func (*PoolAccumulators) ProtoMessage()    {}
+func (*PoolAccumulators) Descriptor() ([]byte, []int) {
+	return fileDescriptor_85a1989f56df85ec, []int{0}
+}
+func (m *PoolAccumulators) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PoolAccumulators) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PoolAccumulators.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PoolAccumulators) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolAccumulators.Merge(m, src)
+}
+func (m *PoolAccumulators) XXX_Size() int {
+	return m.Size()
+}
+func (m *PoolAccumulators) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolAccumulators.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolAccumulators proto.InternalMessageInfo
+
+func (m *PoolAccumulators) GetVolumeIn() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.VolumeIn
+	}
+	return nil
+}
+
+func (m *PoolAccumulators) GetVolumeOut() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.VolumeOut
+	}
+	return nil
+}
+
+func (m *PoolAccumulators) GetRoundingDust() github_com_cosmos_cosmos_sdk_types.DecCoins {
+	if m != nil {
+		return m.RoundingDust
+	}
+	return nil
+}
+
+func (m *PoolAccumulators) GetFeesPerShare() github_com_cosmos_cosmos_sdk_types.DecCoins {
+	if m != nil {
+		return m.FeesPerShare
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PoolAccumulators)(nil), "osmosis.gamm.v1beta1.PoolAccumulators")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/pool_accumulators.proto", fileDescriptor_85a1989f56df85ec)
+}
+
+var fileDescriptor_85a1989f56df85ec = []byte{
+	// 393 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcf, 0x4a, 0xeb, 0x40,
+	0x18, 0xc5, 0x93, 0xdb, 0x72, 0xb9, 0x37, 0xf7, 0x0f, 0x35, 0x54, 0x88, 0x45, 0x52, 0xe9, 0xaa,
+	0xa0, 0xcd, 0x50, 0x5d, 0x08, 0xee, 0xac, 0x75, 0x51, 0x14, 0x2c, 0x75, 0xe7, 0x26, 0x4c, 0xd2,
+	0x31, 0x0d, 0x26, 0xf9, 0x42, 0x66, 0xa6, 0x58, 0x10, 0x7c, 0x03, 0xe9, 0x73, 0xb8, 0xf7, 0x1d,
+	0xba, 0xec, 0xd2, 0x55, 0x95, 0xf6, 0x0d, 0x7c, 0x02, 0x49, 0x66, 0x5a, 0x5a, 0x10, 0x6c, 0x57,
+	0x6d, 0xc8, 0x39, 0xbf, 0xf3, 0x0b, 0x7c, 0xda, 0x01, 0xd0, 0x10, 0xa8, 0x4f, 0x91, 0x87, 0xc3,
+	0x10, 0xf5, 0xeb, 0x0e, 0x61, 0xb8, 0x8e, 0x62, 0x80, 0xc0, 0xc6, 0xae, 0xcb, 0x43, 0x1e, 0x60,
+	0x06, 0x09, 0xb5, 0xe2, 0x04, 0x18, 0xe8, 0x45, 0x99, 0xb6, 0xd2, 0xb4, 0x25, 0xd3, 0xa5, 0xa2,
+	0x07, 0x1e, 0x64, 0x01, 0x94, 0xfe, 0x13, 0xd9, 0x92, 0xe9, 0x66, 0x61, 0xe4, 0x60, 0x4a, 0x16,
+	0x60, 0x17, 0xfc, 0x48, 0xbc, 0xaf, 0xbc, 0xe4, 0xb5, 0x42, 0x1b, 0x20, 0x38, 0x5d, 0x9a, 0xd1,
+	0x1f, 0xb4, 0xdf, 0x7d, 0x08, 0x78, 0x48, 0x6c, 0x3f, 0x32, 0xd4, 0xbd, 0x5c, 0xf5, 0xcf, 0xe1,
+	0x8e, 0x25, 0x40, 0x56, 0x0a, 0x9a, 0x6f, 0x5a, 0x67, 0xe0, 0x47, 0x8d, 0xe6, 0x68, 0x52, 0x56,
+	0x3e, 0x26, 0xe5, 0xc2, 0x00, 0x87, 0xc1, 0x49, 0x65, 0xd1, 0xac, 0x3c, 0xbf, 0x95, 0xab, 0x9e,
+	0xcf, 0x7a, 0xdc, 0xb1, 0x5c, 0x08, 0x91, 0x34, 0x11, 0x3f, 0x35, 0xda, 0xbd, 0x43, 0x6c, 0x10,
+	0x13, 0x9a, 0x41, 0x68, 0xe7, 0x97, 0xe8, 0xb5, 0x22, 0xfd, 0x51, 0xd3, 0x24, 0x03, 0x38, 0x33,
+	0x7e, 0x7c, 0x37, 0x7f, 0x2e, 0xe7, 0xb7, 0x56, 0xe6, 0x81, 0xb3, 0xcd, 0xf6, 0xe5, 0x17, 0x5f,
+	0x71, 0xa6, 0x3f, 0xa9, 0xda, 0xbf, 0x04, 0x78, 0xd4, 0xf5, 0x23, 0xcf, 0xee, 0x72, 0xca, 0x8c,
+	0x5c, 0x26, 0xb1, 0xfb, 0xa5, 0x44, 0x93, 0xb8, 0x99, 0xc7, 0x85, 0xf4, 0x28, 0x0a, 0x8f, 0x15,
+	0x40, 0xaa, 0xb2, 0xbf, 0x86, 0x8a, 0x64, 0xd1, 0xce, 0xdf, 0x79, 0xbd, 0xc9, 0x29, 0xd3, 0x87,
+	0xaa, 0xf6, 0xff, 0x96, 0x10, 0x6a, 0xc7, 0x24, 0xb1, 0x69, 0x0f, 0x27, 0xc4, 0xc8, 0xaf, 0x61,
+	0x74, 0x29, 0x8d, 0xb6, 0x85, 0xd1, 0x2a, 0x61, 0x73, 0xa5, 0xb4, 0xdf, 0x26, 0xc9, 0x75, 0xda,
+	0x6e, 0xb4, 0x46, 0x53, 0x53, 0x1d, 0x4f, 0x4d, 0xf5, 0x7d, 0x6a, 0xaa, 0xc3, 0x99, 0xa9, 0x8c,
+	0x67, 0xa6, 0xf2, 0x3a, 0x33, 0x95, 0x1b, 0xb4, 0x04, 0x95, 0x87, 0x5a, 0x0b, 0xb0, 0x43, 0xe7,
+	0x0f, 0xa8, 0x7f, 0x8c, 0xee, 0xc5, 0xa1, 0x67, 0x0b, 0xce, 0xcf, 0xec, 0x12, 0x8f, 0x3e, 0x07,
+	0x00, 0xb1, 0x9d, 0x66, 0x5b, 0x05, 0x03, 0x00, 0x00,
+}
+
+func (m *PoolAccumulators) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PoolAccumulators) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PoolAccumulators) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.FeesPerShare) > 0 {
+		for iNdEx := len(m.FeesPerShare) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FeesPerShare[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPoolAccumulators(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.RoundingDust) > 0 {
+		for iNdEx := len(m.RoundingDust) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RoundingDust[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPoolAccumulators(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.VolumeOut) > 0 {
+		for iNdEx := len(m.VolumeOut) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.VolumeOut[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPoolAccumulators(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.VolumeIn) > 0 {
+		for iNdEx := len(m.VolumeIn) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.VolumeIn[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPoolAccumulators(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPoolAccumulators(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPoolAccumulators(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PoolAccumulators) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.VolumeIn) > 0 {
+		for _, e := range m.VolumeIn {
+			l = e.Size()
+			n += 1 + l + sovPoolAccumulators(uint64(l))
+		}
+	}
+	if len(m.VolumeOut) > 0 {
+		for _, e := range m.VolumeOut {
+			l = e.Size()
+			n += 1 + l + sovPoolAccumulators(uint64(l))
+		}
+	}
+	if len(m.RoundingDust) > 0 {
+		for _, e := range m.RoundingDust {
+			l = e.Size()
+			n += 1 + l + sovPoolAccumulators(uint64(l))
+		}
+	}
+	if len(m.FeesPerShare) > 0 {
+		for _, e := range m.FeesPerShare {
+			l = e.Size()
+			n += 1 + l + sovPoolAccumulators(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovPoolAccumulators(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPoolAccumulators(x uint64) (n int) {
+	return sovPoolAccumulators(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PoolAccumulators) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPoolAccumulators
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PoolAccumulators: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PoolAccumulators: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeIn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeIn = append(m.VolumeIn, types.Coin{})
+			if err := m.VolumeIn[len(m.VolumeIn)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeOut", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeOut = append(m.VolumeOut, types.Coin{})
+			if err := m.VolumeOut[len(m.VolumeOut)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoundingDust", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RoundingDust = append(m.RoundingDust, types.DecCoin{})
+			if err := m.RoundingDust[len(m.RoundingDust)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeesPerShare", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FeesPerShare = append(m.FeesPerShare, types.DecCoin{})
+			if err := m.FeesPerShare[len(m.FeesPerShare)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPoolAccumulators(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPoolAccumulators
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipPoolAccumulators(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowPoolAccumulators
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowPoolAccumulators
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthPoolAccumulators
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupPoolAccumulators
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthPoolAccumulators
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthPoolAccumulators        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowPoolAccumulators          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupPoolAccumulators = fmt.Errorf("proto: unexpected end of group")
+)
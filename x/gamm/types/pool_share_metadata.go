@@ -0,0 +1,60 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// maxPoolShareSymbolDenomLength bounds the length of the symbol of each pool asset in a pool share symbol,
+// so that e.g. the 64 character hashes of IBC denoms don't make the symbol unreadable.
+const maxPoolShareSymbolDenomLength = 8
+
+// NewPoolShareDenomMetadata returns the bank metadata of the share denom of poolId, whose assets are poolLiquidity.
+// The share is displayed as GAMM-{poolId}, with OneShareExponent decimals, and its name and symbol list
+// the pool's assets, e.g. "UATOM/UOSMO LP" for a pool of uatom and uosmo.
+func NewPoolShareDenomMetadata(poolId uint64, poolLiquidity sdk.Coins) banktypes.Metadata {
+	poolShareBaseDenom := GetPoolShareDenom(poolId)
+	poolShareDisplayDenom := fmt.Sprintf("GAMM-%d", poolId)
+
+	denoms := make([]string, len(poolLiquidity))
+	symbols := make([]string, len(poolLiquidity))
+	for i, coin := range poolLiquidity {
+		denoms[i] = coin.Denom
+		symbols[i] = poolShareSymbolOfDenom(coin.Denom)
+	}
+
+	return banktypes.Metadata{
+		Description: fmt.Sprintf("The share token of the gamm pool %d", poolId),
+		DenomUnits: []*banktypes.DenomUnit{
+			{
+				Denom:    poolShareBaseDenom,
+				Exponent: 0,
+				Aliases: []string{
+					"attopoolshare",
+				},
+			},
+			{
+				Denom:    poolShareDisplayDenom,
+				Exponent: OneShareExponent,
+				Aliases:  nil,
+			},
+		},
+		Base:    poolShareBaseDenom,
+		Display: poolShareDisplayDenom,
+		Name:    fmt.Sprintf("%s %s pool share", poolShareDisplayDenom, strings.Join(denoms, "/")),
+		Symbol:  strings.Join(symbols, "/") + " LP",
+	}
+}
+
+// poolShareSymbolOfDenom returns the symbol of denom in a pool share symbol: the last segment of its path,
+// upper cased and truncated to maxPoolShareSymbolDenomLength. E.g. uosmo is UOSMO, and gamm/pool/1 is 1.
+func poolShareSymbolOfDenom(denom string) string {
+	symbol := denom[strings.LastIndex(denom, "/")+1:]
+	if len(symbol) > maxPoolShareSymbolDenomLength {
+		symbol = symbol[:maxPoolShareSymbolDenomLength]
+	}
+	return strings.ToUpper(symbol)
+}
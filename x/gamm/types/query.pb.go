@@ -679,6 +679,103 @@ func (m *QuerySpotPriceResponse) GetSpotPrice() string {
 	return ""
 }
 
+//=============================== SwapableDenoms
+type QuerySwapableDenomsRequest struct {
+	PoolId       uint64 `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty" yaml:"pool_id"`
+	TokenInDenom string `protobuf:"bytes,2,opt,name=token_in_denom,json=tokenInDenom,proto3" json:"token_in_denom,omitempty" yaml:"token_in_denom"`
+}
+
+func (m *QuerySwapableDenomsRequest) Reset()         { *m = QuerySwapableDenomsRequest{} }
+func (m *QuerySwapableDenomsRequest) String() string { return proto.CompactTextString(m) }
+func (*QuerySwapableDenomsRequest) ProtoMessage()    {}
+func (*QuerySwapableDenomsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9a717df9ca609ef, []int{14}
+}
+func (m *QuerySwapableDenomsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuerySwapableDenomsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuerySwapableDenomsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuerySwapableDenomsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuerySwapableDenomsRequest.Merge(m, src)
+}
+func (m *QuerySwapableDenomsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuerySwapableDenomsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuerySwapableDenomsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuerySwapableDenomsRequest proto.InternalMessageInfo
+
+func (m *QuerySwapableDenomsRequest) GetPoolId() uint64 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *QuerySwapableDenomsRequest) GetTokenInDenom() string {
+	if m != nil {
+		return m.TokenInDenom
+	}
+	return ""
+}
+
+type QuerySwapableDenomsResponse struct {
+	Denoms []string `protobuf:"bytes,1,rep,name=denoms,proto3" json:"denoms,omitempty" yaml:"denoms"`
+}
+
+func (m *QuerySwapableDenomsResponse) Reset()         { *m = QuerySwapableDenomsResponse{} }
+func (m *QuerySwapableDenomsResponse) String() string { return proto.CompactTextString(m) }
+func (*QuerySwapableDenomsResponse) ProtoMessage()    {}
+func (*QuerySwapableDenomsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d9a717df9ca609ef, []int{15}
+}
+func (m *QuerySwapableDenomsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuerySwapableDenomsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuerySwapableDenomsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuerySwapableDenomsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuerySwapableDenomsResponse.Merge(m, src)
+}
+func (m *QuerySwapableDenomsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuerySwapableDenomsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuerySwapableDenomsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuerySwapableDenomsResponse proto.InternalMessageInfo
+
+func (m *QuerySwapableDenomsResponse) GetDenoms() []string {
+	if m != nil {
+		return m.Denoms
+	}
+	return nil
+}
+
 //=============================== EstimateSwapExactAmountIn
 type QuerySwapExactAmountInRequest struct {
 	Sender  string              `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty" yaml:"sender"`
@@ -691,7 +788,7 @@ func (m *QuerySwapExactAmountInRequest) Reset()         { *m = QuerySwapExactAmo
 func (m *QuerySwapExactAmountInRequest) String() string { return proto.CompactTextString(m) }
 func (*QuerySwapExactAmountInRequest) ProtoMessage()    {}
 func (*QuerySwapExactAmountInRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{14}
+	return fileDescriptor_d9a717df9ca609ef, []int{16}
 }
 func (m *QuerySwapExactAmountInRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -756,7 +853,7 @@ func (m *QuerySwapExactAmountInResponse) Reset()         { *m = QuerySwapExactAm
 func (m *QuerySwapExactAmountInResponse) String() string { return proto.CompactTextString(m) }
 func (*QuerySwapExactAmountInResponse) ProtoMessage()    {}
 func (*QuerySwapExactAmountInResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{15}
+	return fileDescriptor_d9a717df9ca609ef, []int{17}
 }
 func (m *QuerySwapExactAmountInResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -797,7 +894,7 @@ func (m *QuerySwapExactAmountOutRequest) Reset()         { *m = QuerySwapExactAm
 func (m *QuerySwapExactAmountOutRequest) String() string { return proto.CompactTextString(m) }
 func (*QuerySwapExactAmountOutRequest) ProtoMessage()    {}
 func (*QuerySwapExactAmountOutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{16}
+	return fileDescriptor_d9a717df9ca609ef, []int{18}
 }
 func (m *QuerySwapExactAmountOutRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -862,7 +959,7 @@ func (m *QuerySwapExactAmountOutResponse) Reset()         { *m = QuerySwapExactA
 func (m *QuerySwapExactAmountOutResponse) String() string { return proto.CompactTextString(m) }
 func (*QuerySwapExactAmountOutResponse) ProtoMessage()    {}
 func (*QuerySwapExactAmountOutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{17}
+	return fileDescriptor_d9a717df9ca609ef, []int{19}
 }
 func (m *QuerySwapExactAmountOutResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -898,7 +995,7 @@ func (m *QueryTotalLiquidityRequest) Reset()         { *m = QueryTotalLiquidityR
 func (m *QueryTotalLiquidityRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryTotalLiquidityRequest) ProtoMessage()    {}
 func (*QueryTotalLiquidityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{18}
+	return fileDescriptor_d9a717df9ca609ef, []int{20}
 }
 func (m *QueryTotalLiquidityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -935,7 +1032,7 @@ func (m *QueryTotalLiquidityResponse) Reset()         { *m = QueryTotalLiquidity
 func (m *QueryTotalLiquidityResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryTotalLiquidityResponse) ProtoMessage()    {}
 func (*QueryTotalLiquidityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d9a717df9ca609ef, []int{19}
+	return fileDescriptor_d9a717df9ca609ef, []int{21}
 }
 func (m *QueryTotalLiquidityResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -986,6 +1083,8 @@ func init() {
 	proto.RegisterType((*QueryTotalSharesResponse)(nil), "osmosis.gamm.v1beta1.QueryTotalSharesResponse")
 	proto.RegisterType((*QuerySpotPriceRequest)(nil), "osmosis.gamm.v1beta1.QuerySpotPriceRequest")
 	proto.RegisterType((*QuerySpotPriceResponse)(nil), "osmosis.gamm.v1beta1.QuerySpotPriceResponse")
+	proto.RegisterType((*QuerySwapableDenomsRequest)(nil), "osmosis.gamm.v1beta1.QuerySwapableDenomsRequest")
+	proto.RegisterType((*QuerySwapableDenomsResponse)(nil), "osmosis.gamm.v1beta1.QuerySwapableDenomsResponse")
 	proto.RegisterType((*QuerySwapExactAmountInRequest)(nil), "osmosis.gamm.v1beta1.QuerySwapExactAmountInRequest")
 	proto.RegisterType((*QuerySwapExactAmountInResponse)(nil), "osmosis.gamm.v1beta1.QuerySwapExactAmountInResponse")
 	proto.RegisterType((*QuerySwapExactAmountOutRequest)(nil), "osmosis.gamm.v1beta1.QuerySwapExactAmountOutRequest")
@@ -997,90 +1096,95 @@ func init() {
 func init() { proto.RegisterFile("osmosis/gamm/v1beta1/query.proto", fileDescriptor_d9a717df9ca609ef) }
 
 var fileDescriptor_d9a717df9ca609ef = []byte{
-	// 1321 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x97, 0xcd, 0x6f, 0x1b, 0x45,
-	0x14, 0xc0, 0xb3, 0xa9, 0x93, 0xc6, 0x13, 0x9a, 0x26, 0xd3, 0xb4, 0x75, 0x37, 0xad, 0xb7, 0x0c,
-	0xa2, 0x09, 0x6d, 0xb2, 0x4b, 0xda, 0x54, 0x48, 0x15, 0x50, 0x6a, 0x9a, 0xb4, 0xae, 0xa0, 0x0d,
-	0x5b, 0x04, 0x02, 0x0e, 0xd6, 0x26, 0x59, 0xdc, 0x55, 0xed, 0x9d, 0x8d, 0x67, 0xb6, 0x69, 0x84,
-	0x2a, 0x24, 0x84, 0x38, 0x71, 0x40, 0x2a, 0xdc, 0x2a, 0x95, 0x03, 0x07, 0xc4, 0x99, 0x7f, 0x01,
-	0xa9, 0x42, 0x42, 0x2a, 0xe2, 0x82, 0x38, 0x18, 0xd4, 0x72, 0xe0, 0xec, 0x7f, 0x00, 0x34, 0x33,
-	0x6f, 0x3f, 0x1c, 0x6f, 0xfc, 0x11, 0x84, 0xc4, 0x29, 0xf6, 0xfb, 0xfc, 0xbd, 0xf7, 0x66, 0x3c,
-	0x2f, 0xe8, 0x24, 0x65, 0x75, 0xca, 0x3c, 0x66, 0x55, 0x9d, 0x7a, 0xdd, 0xba, 0xb3, 0xb8, 0xe6,
-	0x72, 0x67, 0xd1, 0xda, 0x0c, 0xdd, 0xc6, 0xb6, 0x19, 0x34, 0x28, 0xa7, 0x78, 0x1a, 0x2c, 0x4c,
-	0x61, 0x61, 0x82, 0x85, 0x3e, 0x5d, 0xa5, 0x55, 0x2a, 0x0d, 0x2c, 0xf1, 0x49, 0xd9, 0xea, 0x27,
-	0x32, 0xa3, 0xf1, 0xbb, 0xa0, 0x2e, 0xae, 0x4b, 0xbd, 0xb5, 0xe6, 0x30, 0x37, 0xd6, 0xae, 0x53,
-	0xcf, 0x07, 0xfd, 0xe9, 0xb4, 0x5e, 0x32, 0xc4, 0x56, 0x81, 0x53, 0xf5, 0x7c, 0x87, 0x7b, 0x34,
-	0xb2, 0x3d, 0x5e, 0xa5, 0xb4, 0x5a, 0x73, 0x2d, 0x27, 0xf0, 0x2c, 0xc7, 0xf7, 0x29, 0x97, 0x4a,
-	0x06, 0xda, 0x63, 0xa0, 0x95, 0xdf, 0xd6, 0xc2, 0x0f, 0x2d, 0xc7, 0xdf, 0x8e, 0x54, 0x2a, 0x49,
-	0x45, 0xc1, 0xab, 0x2f, 0x4a, 0x45, 0x2e, 0xa2, 0xc9, 0xb7, 0x44, 0xd6, 0x55, 0x4a, 0x6b, 0xb6,
-	0xbb, 0x19, 0xba, 0x8c, 0xe3, 0x33, 0x68, 0x7f, 0x40, 0x69, 0xad, 0xe2, 0x6d, 0x14, 0xb4, 0x93,
-	0xda, 0x5c, 0xae, 0x84, 0x5b, 0x4d, 0x63, 0x62, 0xdb, 0xa9, 0xd7, 0x2e, 0x10, 0x50, 0x10, 0x7b,
-	0x54, 0x7c, 0x2a, 0x6f, 0x90, 0xab, 0x68, 0x2a, 0x15, 0x80, 0x05, 0xd4, 0x67, 0x2e, 0x3e, 0x87,
-	0x72, 0x42, 0x2d, 0xdd, 0xc7, 0xcf, 0x4e, 0x9b, 0x0a, 0xcd, 0x8c, 0xd0, 0xcc, 0x4b, 0xfe, 0x76,
-	0x29, 0xff, 0xe3, 0xf7, 0x0b, 0x23, 0xc2, 0xab, 0x6c, 0x4b, 0x63, 0xf2, 0x41, 0x2a, 0x12, 0x8b,
-	0x58, 0x56, 0x10, 0x4a, 0xfa, 0x50, 0x18, 0x96, 0xf1, 0x4e, 0x99, 0x50, 0x82, 0x68, 0x9a, 0xa9,
-	0x06, 0x07, 0x4d, 0x33, 0x57, 0x9d, 0xaa, 0x0b, 0xbe, 0x76, 0xca, 0x93, 0x7c, 0xa9, 0x21, 0x9c,
-	0x8e, 0x0e, 0xa0, 0xe7, 0xd1, 0x88, 0xc8, 0xcd, 0x0a, 0xda, 0xc9, 0x7d, 0xfd, 0x90, 0x2a, 0x6b,
-	0x7c, 0x25, 0x83, 0x6a, 0xb6, 0x27, 0x95, 0xca, 0xd9, 0x86, 0x75, 0x04, 0x4d, 0x4b, 0xaa, 0xeb,
-	0x61, 0x3d, 0x5d, 0x36, 0xb9, 0x86, 0x0e, 0xef, 0x90, 0x03, 0xf0, 0x22, 0xca, 0xfb, 0x61, 0xbd,
-	0x12, 0x41, 0x8b, 0xe9, 0x4c, 0xb7, 0x9a, 0xc6, 0xa4, 0x9a, 0x4e, 0xac, 0x22, 0xf6, 0x98, 0x0f,
-	0xae, 0x64, 0x19, 0x1d, 0x89, 0x2b, 0x5f, 0x75, 0x1a, 0x4e, 0x9d, 0xed, 0x69, 0xd0, 0x57, 0xd0,
-	0xd1, 0x8e, 0x30, 0x00, 0x35, 0x8f, 0x46, 0x03, 0x29, 0xe9, 0x36, 0x70, 0x1b, 0x6c, 0xc8, 0x9b,
-	0xa8, 0x28, 0x03, 0xbd, 0x4d, 0xb9, 0x53, 0x13, 0xd1, 0xde, 0xf0, 0x36, 0x43, 0x6f, 0xc3, 0xe3,
-	0xdb, 0x7b, 0xe2, 0xfa, 0x5a, 0x43, 0xc6, 0xae, 0xf1, 0x00, 0xf0, 0x1e, 0xca, 0xd7, 0x22, 0x21,
-	0x8c, 0xfa, 0x58, 0xdb, 0xb8, 0xa2, 0x41, 0xbd, 0x4e, 0x3d, 0xbf, 0x74, 0xf9, 0x51, 0xd3, 0x18,
-	0x4a, 0x9a, 0x1a, 0x7b, 0x92, 0xef, 0x7e, 0x37, 0xe6, 0xaa, 0x1e, 0xbf, 0x15, 0xae, 0x99, 0xeb,
-	0xb4, 0x0e, 0x17, 0x09, 0xfe, 0x2c, 0xb0, 0x8d, 0xdb, 0x16, 0xdf, 0x0e, 0x5c, 0x26, 0x83, 0x30,
-	0x3b, 0xc9, 0x48, 0x56, 0xa0, 0x75, 0x92, 0xf0, 0xe6, 0x2d, 0xa7, 0xe1, 0xee, 0x6d, 0x04, 0x21,
-	0x2a, 0x74, 0xc6, 0x81, 0x12, 0xdf, 0x43, 0xcf, 0x70, 0x21, 0xae, 0x30, 0x29, 0x87, 0x49, 0x74,
-	0xa9, 0x72, 0x06, 0xaa, 0x3c, 0xa4, 0x92, 0xa5, 0x9d, 0x89, 0x3d, 0xce, 0x93, 0x14, 0xe4, 0x2f,
-	0x0d, 0x4e, 0xe3, 0xcd, 0x80, 0xf2, 0xd5, 0x86, 0xb7, 0xee, 0xee, 0x85, 0x1e, 0x2f, 0xa3, 0x49,
-	0x41, 0x51, 0x71, 0x18, 0x73, 0x79, 0x65, 0xc3, 0xf5, 0x69, 0x5d, 0x5e, 0x9d, 0x7c, 0x69, 0xa6,
-	0xd5, 0x34, 0x8e, 0x2a, 0xaf, 0x9d, 0x16, 0xc4, 0x9e, 0x10, 0xa2, 0x4b, 0x42, 0x72, 0x59, 0x08,
-	0xf0, 0x55, 0x34, 0xb5, 0x19, 0x52, 0xde, 0x1e, 0x67, 0x9f, 0x8c, 0x73, 0xbc, 0xd5, 0x34, 0x0a,
-	0x2a, 0x4e, 0x87, 0x09, 0xb1, 0x0f, 0x4a, 0x59, 0x12, 0xe9, 0x5a, 0x6e, 0x2c, 0x37, 0x39, 0x62,
-	0x8f, 0x6f, 0x79, 0xfc, 0xd6, 0xcd, 0x2d, 0x27, 0x58, 0x71, 0x5d, 0x72, 0x1d, 0xee, 0x4a, 0xaa,
-	0x52, 0xe8, 0xef, 0x12, 0x42, 0x2c, 0xa0, 0xbc, 0x12, 0x08, 0xa9, 0xac, 0x36, 0x5f, 0x3a, 0xdc,
-	0x6a, 0x1a, 0x53, 0x2a, 0x5f, 0xa2, 0x23, 0x76, 0x9e, 0x45, 0xde, 0xe4, 0x6f, 0x0d, 0x9d, 0x50,
-	0x01, 0xb7, 0x9c, 0x60, 0xf9, 0xae, 0xb3, 0xce, 0x2f, 0xd5, 0x69, 0xe8, 0xf3, 0xb2, 0x1f, 0xb5,
-	0xf0, 0x05, 0x34, 0xca, 0x5c, 0x7f, 0xc3, 0x6d, 0x40, 0xcc, 0xa9, 0x56, 0xd3, 0x38, 0x00, 0x31,
-	0xa5, 0x9c, 0xd8, 0x60, 0x90, 0xee, 0xf6, 0x70, 0xcf, 0x6e, 0x9b, 0x68, 0x8c, 0xd3, 0xdb, 0xae,
-	0x5f, 0xf1, 0x7c, 0xe8, 0xce, 0xa1, 0x56, 0xd3, 0x38, 0x18, 0x0d, 0x5b, 0x69, 0x88, 0xbd, 0x5f,
-	0x7e, 0x2c, 0xfb, 0xf8, 0x1d, 0x34, 0xda, 0xa0, 0x21, 0x77, 0x59, 0x21, 0x27, 0xef, 0xc7, 0xac,
-	0x99, 0xf5, 0x08, 0x9a, 0xa2, 0x8e, 0xb8, 0x04, 0x61, 0x5f, 0x3a, 0x0c, 0xe7, 0x08, 0xa0, 0x55,
-	0x10, 0x62, 0x43, 0x34, 0xf2, 0x95, 0x06, 0xd7, 0x3d, 0xa3, 0x03, 0xd0, 0x5a, 0x86, 0x26, 0x15,
-	0x10, 0x0d, 0x79, 0xc5, 0x91, 0x5a, 0x68, 0x46, 0x59, 0xc4, 0xfe, 0xad, 0x69, 0x9c, 0xea, 0xe3,
-	0xd6, 0x95, 0x7d, 0x9e, 0x1c, 0xa3, 0x9d, 0xf1, 0x88, 0x3d, 0x21, 0x45, 0x37, 0x42, 0x48, 0x4f,
-	0x3e, 0x1d, 0xce, 0xe6, 0xba, 0x11, 0xf2, 0xff, 0x7a, 0x34, 0xef, 0xc6, 0xad, 0xde, 0x27, 0x5b,
-	0x3d, 0xd7, 0xab, 0xd5, 0x82, 0xa9, 0x8f, 0x5e, 0x8b, 0xc7, 0x21, 0x2e, 0xbc, 0x90, 0x93, 0xcc,
-	0xa9, 0xc7, 0x21, 0x56, 0x11, 0x7b, 0x2c, 0x6a, 0x06, 0xb9, 0x1f, 0xfd, 0x7a, 0x66, 0xb5, 0x01,
-	0xe6, 0x13, 0xa0, 0x83, 0xd1, 0x81, 0x69, 0x1f, 0xcf, 0xd5, 0x81, 0xc7, 0x73, 0xa4, 0xfd, 0xfc,
-	0xc5, 0xd3, 0x39, 0x00, 0xc7, 0x10, 0x86, 0x73, 0x1c, 0xe9, 0xc9, 0x0f, 0xdd, 0xce, 0xe7, 0x81,
-	0x3c, 0xd0, 0xd0, 0x4c, 0xa6, 0xfa, 0x7f, 0xf1, 0x6b, 0x7f, 0xf6, 0xe1, 0x01, 0x34, 0x22, 0xf1,
-	0xf0, 0xc7, 0x48, 0xae, 0x0d, 0x0c, 0xef, 0x72, 0x99, 0x3a, 0xd6, 0x1d, 0x7d, 0xae, 0xb7, 0xa1,
-	0x2a, 0x92, 0x3c, 0xf7, 0xc9, 0x2f, 0x7f, 0xde, 0x1f, 0x3e, 0x81, 0x67, 0xac, 0xcc, 0x05, 0x54,
-	0xed, 0x29, 0x9f, 0x6b, 0x68, 0x2c, 0x5a, 0x21, 0xf0, 0xe9, 0x2e, 0xb1, 0x77, 0xec, 0x1f, 0xfa,
-	0x99, 0xbe, 0x6c, 0x01, 0x65, 0x56, 0xa2, 0x3c, 0x8b, 0x8d, 0x6c, 0x94, 0x78, 0x29, 0xc1, 0xdf,
-	0x68, 0x68, 0xa2, 0x7d, 0x66, 0xf8, 0xc5, 0x2e, 0x89, 0x32, 0xa7, 0xaf, 0x2f, 0x0e, 0xe0, 0x01,
-	0x80, 0x0b, 0x12, 0x70, 0x16, 0x3f, 0x9f, 0x0d, 0xa8, 0x9e, 0xbe, 0x78, 0x80, 0xf8, 0x33, 0x0d,
-	0xe5, 0x44, 0x85, 0xf8, 0x54, 0x8f, 0x69, 0x44, 0x48, 0xb3, 0x3d, 0xed, 0xfa, 0x03, 0x91, 0x5d,
-	0xb2, 0x3e, 0x82, 0x1f, 0x8c, 0x7b, 0xf8, 0xa1, 0x86, 0x50, 0xb2, 0x6e, 0xe1, 0xf9, 0x1e, 0x69,
-	0xda, 0x96, 0x3b, 0x7d, 0xa1, 0x4f, 0x6b, 0x40, 0x5b, 0x92, 0x68, 0x26, 0x9e, 0xef, 0x0b, 0xcd,
-	0x52, 0xbb, 0x1c, 0xfe, 0x41, 0x43, 0xb8, 0x73, 0xef, 0xc2, 0x4b, 0xbd, 0x66, 0x94, 0xb5, 0xf6,
-	0xe9, 0xe7, 0x07, 0xf4, 0x02, 0xf2, 0x92, 0x24, 0x7f, 0x19, 0x5f, 0xe8, 0x8f, 0x5c, 0x4d, 0x5b,
-	0x7e, 0x4d, 0x46, 0xfe, 0xad, 0x86, 0xc6, 0x53, 0x5b, 0x15, 0x5e, 0xe8, 0x85, 0xd2, 0xb6, 0xc5,
-	0xe9, 0x66, 0xbf, 0xe6, 0x80, 0x7c, 0x41, 0x22, 0x2f, 0xe1, 0xb3, 0x83, 0x20, 0xab, 0xdd, 0x0c,
-	0x3f, 0xd0, 0x50, 0x3e, 0x5e, 0x4f, 0x70, 0xb7, 0x8b, 0xba, 0x73, 0x5d, 0xd3, 0xe7, 0xfb, 0x33,
-	0xde, 0xe3, 0x89, 0x10, 0xce, 0x0c, 0xff, 0xa4, 0xa1, 0x63, 0xcb, 0x8c, 0x7b, 0x75, 0x87, 0xbb,
-	0x1d, 0x4f, 0x3e, 0x3e, 0xd7, 0x8d, 0x60, 0x97, 0x15, 0x49, 0x5f, 0x1a, 0xcc, 0x09, 0xf0, 0x97,
-	0x25, 0xfe, 0x45, 0xfc, 0x4a, 0x36, 0x7e, 0x02, 0xee, 0x02, 0xad, 0xc5, 0xb6, 0x9c, 0xa0, 0xe2,
-	0x8a, 0x60, 0xf0, 0x2e, 0x55, 0x3c, 0x1f, 0xff, 0xac, 0x21, 0x7d, 0x97, 0x7a, 0x6e, 0x84, 0x1c,
-	0x0f, 0xc0, 0x96, 0x6c, 0x16, 0x5d, 0x4f, 0xfa, 0xee, 0x0f, 0x31, 0x59, 0x91, 0x25, 0xbd, 0x86,
-	0x5f, 0xfd, 0x17, 0x25, 0xd1, 0x90, 0x97, 0xca, 0x8f, 0x9e, 0x14, 0xb5, 0xc7, 0x4f, 0x8a, 0xda,
-	0x1f, 0x4f, 0x8a, 0xda, 0x17, 0x4f, 0x8b, 0x43, 0x8f, 0x9f, 0x16, 0x87, 0x7e, 0x7d, 0x5a, 0x1c,
-	0x7a, 0xdf, 0x4a, 0x3d, 0x78, 0x90, 0x63, 0xa1, 0xe6, 0xac, 0xb1, 0x38, 0xe1, 0x9d, 0x97, 0xac,
-	0xbb, 0x2a, 0xab, 0x7c, 0xfd, 0xd6, 0x46, 0xe5, 0xbf, 0x78, 0xe7, 0xfe, 0x09, 0x00, 0x00, 0xff,
-	0xff, 0x5d, 0x47, 0xc7, 0xb1, 0x55, 0x11, 0x00, 0x00,
+	// 1404 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0x5d, 0x6f, 0x14, 0x55,
+	0x18, 0xee, 0x94, 0x6d, 0xe9, 0x9e, 0x42, 0x69, 0x0f, 0x6d, 0xd9, 0x4e, 0x61, 0x07, 0x8f, 0x91,
+	0x16, 0x68, 0x67, 0x2c, 0xb4, 0x21, 0x21, 0x22, 0xb2, 0xd2, 0xd2, 0x25, 0x0a, 0x75, 0x30, 0x1a,
+	0xf5, 0x62, 0x33, 0xdb, 0x8e, 0xcb, 0x84, 0xdd, 0x39, 0xd3, 0x3d, 0x67, 0x80, 0xc6, 0x10, 0x13,
+	0x63, 0xbc, 0x30, 0x5e, 0x98, 0xa0, 0x77, 0x24, 0x7a, 0xe1, 0x85, 0xf1, 0x9a, 0xbf, 0x60, 0x42,
+	0x4c, 0x4c, 0x30, 0x26, 0xc6, 0x78, 0xb1, 0x1a, 0xf0, 0xc2, 0xeb, 0xfd, 0x03, 0x9a, 0x39, 0xe7,
+	0x9d, 0x8f, 0xdd, 0x0e, 0xdd, 0x0f, 0x63, 0xe2, 0x55, 0x77, 0xde, 0xcf, 0xe7, 0x79, 0x9f, 0x39,
+	0x73, 0xde, 0xa2, 0xe3, 0x94, 0xd5, 0x28, 0x73, 0x98, 0x51, 0xb1, 0x6a, 0x35, 0xe3, 0xf6, 0x52,
+	0xd9, 0xe6, 0xd6, 0x92, 0xb1, 0xed, 0xdb, 0xf5, 0x1d, 0xdd, 0xab, 0x53, 0x4e, 0xf1, 0x24, 0x44,
+	0xe8, 0x41, 0x84, 0x0e, 0x11, 0xea, 0x64, 0x85, 0x56, 0xa8, 0x08, 0x30, 0x82, 0x5f, 0x32, 0x56,
+	0x3d, 0x96, 0x5a, 0x8d, 0xdf, 0x05, 0x77, 0x7e, 0x53, 0xf8, 0x8d, 0xb2, 0xc5, 0xec, 0xc8, 0xbb,
+	0x49, 0x1d, 0x17, 0xfc, 0xa7, 0x92, 0x7e, 0x81, 0x21, 0x8a, 0xf2, 0xac, 0x8a, 0xe3, 0x5a, 0xdc,
+	0xa1, 0x61, 0xec, 0xd1, 0x0a, 0xa5, 0x95, 0xaa, 0x6d, 0x58, 0x9e, 0x63, 0x58, 0xae, 0x4b, 0xb9,
+	0x70, 0x32, 0xf0, 0xce, 0x80, 0x57, 0x3c, 0x95, 0xfd, 0xf7, 0x0d, 0xcb, 0xdd, 0x09, 0x5d, 0xb2,
+	0x49, 0x49, 0x82, 0x97, 0x0f, 0xd2, 0x45, 0x2e, 0xa2, 0xf1, 0x37, 0x82, 0xae, 0x1b, 0x94, 0x56,
+	0x4d, 0x7b, 0xdb, 0xb7, 0x19, 0xc7, 0xa7, 0xd1, 0x7e, 0x8f, 0xd2, 0x6a, 0xc9, 0xd9, 0xca, 0x29,
+	0xc7, 0x95, 0xf9, 0x4c, 0x01, 0x37, 0x1b, 0xda, 0xd8, 0x8e, 0x55, 0xab, 0x9e, 0x27, 0xe0, 0x20,
+	0xe6, 0x70, 0xf0, 0xab, 0xb8, 0x45, 0xd6, 0xd1, 0x44, 0xa2, 0x00, 0xf3, 0xa8, 0xcb, 0x6c, 0x7c,
+	0x16, 0x65, 0x02, 0xb7, 0x48, 0x1f, 0x3d, 0x33, 0xa9, 0x4b, 0x68, 0x7a, 0x08, 0x4d, 0xbf, 0xe4,
+	0xee, 0x14, 0xb2, 0x3f, 0x3c, 0x5c, 0x1c, 0x0a, 0xb2, 0x8a, 0xa6, 0x08, 0x26, 0xef, 0x25, 0x2a,
+	0xb1, 0x10, 0xcb, 0x1a, 0x42, 0xf1, 0x1c, 0x72, 0x83, 0xa2, 0xde, 0x09, 0x1d, 0x28, 0x04, 0x43,
+	0xd3, 0xa5, 0x70, 0x30, 0x34, 0x7d, 0xc3, 0xaa, 0xd8, 0x90, 0x6b, 0x26, 0x32, 0xc9, 0x17, 0x0a,
+	0xc2, 0xc9, 0xea, 0x00, 0x74, 0x05, 0x0d, 0x05, 0xbd, 0x59, 0x4e, 0x39, 0xbe, 0xaf, 0x1b, 0xa4,
+	0x32, 0x1a, 0x5f, 0x49, 0x41, 0x35, 0xd7, 0x11, 0x95, 0xec, 0xd9, 0x02, 0x6b, 0x1a, 0x4d, 0x0a,
+	0x54, 0xd7, 0xfc, 0x5a, 0x92, 0x36, 0xb9, 0x8a, 0xa6, 0xda, 0xec, 0x00, 0x78, 0x09, 0x65, 0x5d,
+	0xbf, 0x56, 0x0a, 0x41, 0x07, 0xea, 0x4c, 0x36, 0x1b, 0xda, 0xb8, 0x54, 0x27, 0x72, 0x11, 0x73,
+	0xc4, 0x85, 0x54, 0xb2, 0x8a, 0xa6, 0x23, 0xe6, 0x1b, 0x56, 0xdd, 0xaa, 0xb1, 0xbe, 0x84, 0xbe,
+	0x82, 0x8e, 0xec, 0x2a, 0x03, 0xa0, 0x16, 0xd0, 0xb0, 0x27, 0x2c, 0x7b, 0x09, 0x6e, 0x42, 0x0c,
+	0x79, 0x1d, 0xe5, 0x45, 0xa1, 0x37, 0x29, 0xb7, 0xaa, 0x41, 0xb5, 0xd7, 0x9c, 0x6d, 0xdf, 0xd9,
+	0x72, 0xf8, 0x4e, 0x5f, 0xb8, 0xbe, 0x56, 0x90, 0xf6, 0xcc, 0x7a, 0x00, 0xf0, 0x1e, 0xca, 0x56,
+	0x43, 0x23, 0x48, 0x3d, 0xd3, 0x22, 0x57, 0x28, 0xd4, 0xab, 0xd4, 0x71, 0x0b, 0x97, 0x1f, 0x35,
+	0xb4, 0x81, 0x78, 0xa8, 0x51, 0x26, 0xf9, 0xee, 0x77, 0x6d, 0xbe, 0xe2, 0xf0, 0x9b, 0x7e, 0x59,
+	0xdf, 0xa4, 0x35, 0x38, 0x48, 0xf0, 0x67, 0x91, 0x6d, 0xdd, 0x32, 0xf8, 0x8e, 0x67, 0x33, 0x51,
+	0x84, 0x99, 0x71, 0x47, 0xb2, 0x86, 0x8e, 0xc4, 0x08, 0x6f, 0xdc, 0xb4, 0xea, 0x76, 0x7f, 0x12,
+	0xf8, 0x28, 0xb7, 0xbb, 0x0e, 0x50, 0x7c, 0x07, 0x1d, 0xe0, 0x81, 0xb9, 0xc4, 0x84, 0x1d, 0x94,
+	0xd8, 0x83, 0xe5, 0x2c, 0xb0, 0x3c, 0x2c, 0x9b, 0x25, 0x93, 0x89, 0x39, 0xca, 0xe3, 0x16, 0xe4,
+	0x2f, 0x05, 0xde, 0xc6, 0x1b, 0x1e, 0xe5, 0x1b, 0x75, 0x67, 0xd3, 0xee, 0x07, 0x3d, 0x5e, 0x45,
+	0xe3, 0x01, 0x8a, 0x92, 0xc5, 0x98, 0xcd, 0x4b, 0x5b, 0xb6, 0x4b, 0x6b, 0xe2, 0xe8, 0x64, 0x0b,
+	0xb3, 0xcd, 0x86, 0x76, 0x44, 0x66, 0xb5, 0x47, 0x10, 0x73, 0x2c, 0x30, 0x5d, 0x0a, 0x2c, 0x97,
+	0x03, 0x03, 0x5e, 0x47, 0x13, 0xdb, 0x3e, 0xe5, 0xad, 0x75, 0xf6, 0x89, 0x3a, 0x47, 0x9b, 0x0d,
+	0x2d, 0x27, 0xeb, 0xec, 0x0a, 0x21, 0xe6, 0x21, 0x61, 0x8b, 0x2b, 0x5d, 0xcd, 0x8c, 0x64, 0xc6,
+	0x87, 0xcc, 0xd1, 0x3b, 0x0e, 0xbf, 0x79, 0xe3, 0x8e, 0xe5, 0xad, 0xd9, 0x36, 0xb9, 0x86, 0xa6,
+	0xdb, 0x99, 0xc2, 0x7c, 0x97, 0x11, 0x62, 0x1e, 0xe5, 0x25, 0x2f, 0xb0, 0x0a, 0xb6, 0xd9, 0xc2,
+	0x54, 0xb3, 0xa1, 0x4d, 0xc8, 0x7e, 0xb1, 0x8f, 0x98, 0x59, 0x16, 0x66, 0x93, 0x4f, 0x15, 0xa4,
+	0xca, 0x82, 0x77, 0x2c, 0xcf, 0x2a, 0x57, 0x6d, 0xd1, 0xb9, 0x2f, 0xf5, 0xf1, 0x45, 0x34, 0xc6,
+	0xe9, 0x2d, 0xdb, 0x2d, 0x39, 0x6e, 0xcb, 0xf4, 0x66, 0x9a, 0x0d, 0x6d, 0x2a, 0x14, 0x31, 0xe9,
+	0x27, 0xe6, 0x01, 0x61, 0x28, 0xba, 0xa2, 0x2b, 0x59, 0x47, 0xb3, 0xa9, 0x58, 0x80, 0xe1, 0x49,
+	0x34, 0x2c, 0xd2, 0xe4, 0xc7, 0x30, 0x5b, 0x98, 0x68, 0x36, 0xb4, 0x83, 0xb2, 0xae, 0xb4, 0x13,
+	0x13, 0x02, 0xc8, 0xdf, 0x0a, 0x3a, 0x16, 0x95, 0x5a, 0xbd, 0x6b, 0x6d, 0xf2, 0x4b, 0x35, 0xea,
+	0xbb, 0xbc, 0xe8, 0x86, 0xcc, 0x4e, 0xa2, 0x61, 0x66, 0xbb, 0x5b, 0x76, 0x1d, 0x46, 0x95, 0x28,
+	0x26, 0xed, 0xc4, 0x84, 0x80, 0xe4, 0x10, 0x06, 0x3b, 0x0e, 0x41, 0x47, 0x23, 0x21, 0x49, 0x10,
+	0xfd, 0x70, 0xb3, 0xa1, 0x1d, 0x6a, 0xa5, 0x4f, 0xcc, 0xfd, 0x40, 0x1c, 0xbf, 0x85, 0x86, 0xeb,
+	0xd4, 0xe7, 0x36, 0xcb, 0x65, 0xc4, 0xb1, 0x9f, 0xd3, 0xd3, 0xee, 0x76, 0x3d, 0xe0, 0x11, 0x51,
+	0x08, 0xe2, 0x0b, 0x53, 0x70, 0x3c, 0x00, 0xb4, 0x2c, 0x42, 0x4c, 0xa8, 0x46, 0xbe, 0x54, 0xe0,
+	0x2b, 0x96, 0x32, 0x01, 0x98, 0x27, 0x43, 0xe3, 0x12, 0x10, 0xf5, 0x79, 0xc9, 0x12, 0x5e, 0x18,
+	0x46, 0x31, 0xa8, 0xfd, 0x5b, 0x43, 0x3b, 0xd1, 0xc5, 0xc7, 0xa4, 0xe8, 0xf2, 0xf8, 0x74, 0xb4,
+	0xd7, 0x23, 0xa6, 0x7c, 0x25, 0xae, 0xfb, 0xd0, 0x9e, 0x7c, 0x3c, 0x98, 0x8e, 0xeb, 0xba, 0xcf,
+	0xff, 0x6b, 0x69, 0xde, 0x8e, 0x46, 0xbd, 0x4f, 0x8c, 0x7a, 0xbe, 0xd3, 0xa8, 0x03, 0x4c, 0x5d,
+	0xcc, 0x3a, 0xb8, 0xf3, 0x22, 0xe2, 0xb9, 0x8c, 0xc0, 0x9c, 0xb8, 0xf3, 0x22, 0x17, 0x31, 0x47,
+	0xc2, 0x61, 0x90, 0xfb, 0xe1, 0xa5, 0x90, 0x36, 0x06, 0xd0, 0xc7, 0x43, 0x87, 0xa2, 0xf3, 0xd2,
+	0x22, 0xcf, 0x7a, 0xcf, 0xf2, 0x4c, 0xb7, 0x1d, 0xbf, 0x50, 0x9d, 0x83, 0xf0, 0x1a, 0x82, 0x38,
+	0x47, 0x91, 0x1a, 0x7f, 0xbf, 0xdb, 0x6f, 0x3d, 0xf2, 0x40, 0x41, 0xb3, 0xa9, 0xee, 0xff, 0xc5,
+	0x25, 0x76, 0xe6, 0x97, 0x31, 0x34, 0x24, 0xe0, 0xe1, 0x0f, 0x91, 0xd8, 0x86, 0x18, 0x7e, 0xc6,
+	0x61, 0xda, 0xb5, 0xc5, 0xa9, 0xf3, 0x9d, 0x03, 0x25, 0x49, 0xf2, 0xfc, 0x47, 0x3f, 0xff, 0x79,
+	0x7f, 0xf0, 0x18, 0x9e, 0x35, 0x52, 0xf7, 0x6a, 0xb9, 0x7e, 0x7d, 0xa6, 0xa0, 0x91, 0x70, 0x33,
+	0xc2, 0xa7, 0xf6, 0xa8, 0xdd, 0xb6, 0x56, 0xa9, 0xa7, 0xbb, 0x8a, 0x05, 0x28, 0x73, 0x02, 0xca,
+	0x73, 0x58, 0x4b, 0x87, 0x12, 0xed, 0x5a, 0xf8, 0x1b, 0x05, 0x8d, 0xb5, 0x6a, 0x86, 0x5f, 0xdc,
+	0xa3, 0x51, 0xaa, 0xfa, 0xea, 0x52, 0x0f, 0x19, 0x00, 0x70, 0x51, 0x00, 0x9c, 0xc3, 0x2f, 0xa4,
+	0x03, 0x94, 0x37, 0x7a, 0x24, 0x20, 0xfe, 0x44, 0x41, 0x99, 0x80, 0x21, 0x3e, 0xd1, 0x41, 0x8d,
+	0x10, 0xd2, 0x5c, 0xc7, 0xb8, 0xee, 0x80, 0x88, 0x29, 0x19, 0x1f, 0xc0, 0x07, 0xe3, 0x1e, 0xfe,
+	0x4a, 0x41, 0x28, 0xde, 0x22, 0xf1, 0x42, 0x87, 0x36, 0x2d, 0x3b, 0xab, 0xba, 0xd8, 0x65, 0x34,
+	0x40, 0x5b, 0x16, 0xd0, 0x74, 0xbc, 0xd0, 0x15, 0x34, 0x43, 0xae, 0xa8, 0xf8, 0x7b, 0x05, 0xe1,
+	0xdd, 0xeb, 0x24, 0x5e, 0xee, 0xa4, 0x51, 0xda, 0x36, 0xab, 0xae, 0xf4, 0x98, 0x05, 0xc8, 0x0b,
+	0x02, 0xf9, 0x4b, 0xf8, 0x7c, 0x77, 0xc8, 0xa5, 0xda, 0xe2, 0x31, 0x96, 0xfc, 0x5b, 0x05, 0x8d,
+	0x26, 0x96, 0x45, 0xbc, 0xd8, 0x09, 0x4a, 0xcb, 0x72, 0xaa, 0xea, 0xdd, 0x86, 0x03, 0xe4, 0xf3,
+	0x02, 0xf2, 0x32, 0x3e, 0xd3, 0x0b, 0x64, 0xb9, 0x72, 0xe2, 0x07, 0x0a, 0xca, 0x46, 0x5b, 0x17,
+	0xde, 0xeb, 0xa0, 0xb6, 0x6f, 0xa1, 0xea, 0x42, 0x77, 0xc1, 0x7d, 0xbe, 0x11, 0x41, 0x32, 0xc3,
+	0x0f, 0x15, 0x34, 0xd6, 0xba, 0x37, 0xed, 0x79, 0xc6, 0x53, 0xd7, 0x3d, 0x75, 0xa9, 0x87, 0x0c,
+	0x40, 0x7b, 0x41, 0xa0, 0x3d, 0x87, 0x57, 0xba, 0x43, 0xcb, 0xa0, 0x8a, 0x5c, 0x00, 0x19, 0xfe,
+	0x51, 0x41, 0x33, 0xab, 0x8c, 0x3b, 0x35, 0x8b, 0xdb, 0xbb, 0x36, 0x15, 0x7c, 0xb6, 0x03, 0x9e,
+	0xb4, 0xcd, 0x4e, 0x5d, 0xee, 0x2d, 0x09, 0x78, 0xac, 0x0a, 0x1e, 0x17, 0xf1, 0x85, 0x74, 0x1e,
+	0x31, 0x03, 0x1b, 0xd0, 0x0a, 0x2a, 0x25, 0x3b, 0x28, 0x06, 0xd7, 0x69, 0xc9, 0x71, 0xf1, 0x4f,
+	0x0a, 0x52, 0x9f, 0xc1, 0xe7, 0xba, 0xcf, 0x71, 0x0f, 0xd8, 0xe2, 0x85, 0x48, 0x5d, 0xe9, 0x31,
+	0x0b, 0x28, 0xad, 0x09, 0x4a, 0xaf, 0xe0, 0x97, 0xff, 0x05, 0x25, 0xea, 0xf3, 0x42, 0xf1, 0xd1,
+	0x93, 0xbc, 0xf2, 0xf8, 0x49, 0x5e, 0xf9, 0xe3, 0x49, 0x5e, 0xf9, 0xfc, 0x69, 0x7e, 0xe0, 0xf1,
+	0xd3, 0xfc, 0xc0, 0xaf, 0x4f, 0xf3, 0x03, 0xef, 0x1a, 0x89, 0x7b, 0x1a, 0x7a, 0x2c, 0x56, 0xad,
+	0x32, 0x8b, 0x1a, 0xde, 0x3e, 0x67, 0xdc, 0x95, 0x5d, 0xc5, 0xa5, 0x5d, 0x1e, 0x16, 0xff, 0x70,
+	0x9f, 0xfd, 0x67, 0x00, 0x29, 0x40, 0x44, 0xd4, 0xe3, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1106,6 +1210,9 @@ type QueryClient interface {
 	// SpotPrice defines a gRPC query handler that returns the spot price given
 	// a base denomination and a quote denomination.
 	SpotPrice(ctx context.Context, in *QuerySpotPriceRequest, opts ...grpc.CallOption) (*QuerySpotPriceResponse, error)
+	// SwapableDenoms returns the denoms that a denom of a pool can be swapped
+	// for in the pool.
+	SwapableDenoms(ctx context.Context, in *QuerySwapableDenomsRequest, opts ...grpc.CallOption) (*QuerySwapableDenomsResponse, error)
 	// Estimate the swap.
 	EstimateSwapExactAmountIn(ctx context.Context, in *QuerySwapExactAmountInRequest, opts ...grpc.CallOption) (*QuerySwapExactAmountInResponse, error)
 	EstimateSwapExactAmountOut(ctx context.Context, in *QuerySwapExactAmountOutRequest, opts ...grpc.CallOption) (*QuerySwapExactAmountOutResponse, error)
@@ -1191,6 +1298,15 @@ func (c *queryClient) SpotPrice(ctx context.Context, in *QuerySpotPriceRequest,
 	return out, nil
 }
 
+func (c *queryClient) SwapableDenoms(ctx context.Context, in *QuerySwapableDenomsRequest, opts ...grpc.CallOption) (*QuerySwapableDenomsResponse, error) {
+	out := new(QuerySwapableDenomsResponse)
+	err := c.cc.Invoke(ctx, "/osmosis.gamm.v1beta1.Query/SwapableDenoms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryClient) EstimateSwapExactAmountIn(ctx context.Context, in *QuerySwapExactAmountInRequest, opts ...grpc.CallOption) (*QuerySwapExactAmountInResponse, error) {
 	out := new(QuerySwapExactAmountInResponse)
 	err := c.cc.Invoke(ctx, "/osmosis.gamm.v1beta1.Query/EstimateSwapExactAmountIn", in, out, opts...)
@@ -1222,6 +1338,9 @@ type QueryServer interface {
 	// SpotPrice defines a gRPC query handler that returns the spot price given
 	// a base denomination and a quote denomination.
 	SpotPrice(context.Context, *QuerySpotPriceRequest) (*QuerySpotPriceResponse, error)
+	// SwapableDenoms returns the denoms that a denom of a pool can be swapped
+	// for in the pool.
+	SwapableDenoms(context.Context, *QuerySwapableDenomsRequest) (*QuerySwapableDenomsResponse, error)
 	// Estimate the swap.
 	EstimateSwapExactAmountIn(context.Context, *QuerySwapExactAmountInRequest) (*QuerySwapExactAmountInResponse, error)
 	EstimateSwapExactAmountOut(context.Context, *QuerySwapExactAmountOutRequest) (*QuerySwapExactAmountOutResponse, error)
@@ -1255,6 +1374,9 @@ func (*UnimplementedQueryServer) TotalShares(ctx context.Context, req *QueryTota
 func (*UnimplementedQueryServer) SpotPrice(ctx context.Context, req *QuerySpotPriceRequest) (*QuerySpotPriceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SpotPrice not implemented")
 }
+func (*UnimplementedQueryServer) SwapableDenoms(ctx context.Context, req *QuerySwapableDenomsRequest) (*QuerySwapableDenomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapableDenoms not implemented")
+}
 func (*UnimplementedQueryServer) EstimateSwapExactAmountIn(ctx context.Context, req *QuerySwapExactAmountInRequest) (*QuerySwapExactAmountInResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EstimateSwapExactAmountIn not implemented")
 }
@@ -1410,6 +1532,24 @@ func _Query_SpotPrice_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_SwapableDenoms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySwapableDenomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).SwapableDenoms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/osmosis.gamm.v1beta1.Query/SwapableDenoms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SwapableDenoms(ctx, req.(*QuerySwapableDenomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Query_EstimateSwapExactAmountIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QuerySwapExactAmountInRequest)
 	if err := dec(in); err != nil {
@@ -1482,6 +1622,10 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SpotPrice",
 			Handler:    _Query_SpotPrice_Handler,
 		},
+		{
+			MethodName: "SwapableDenoms",
+			Handler:    _Query_SwapableDenoms_Handler,
+		},
 		{
 			MethodName: "EstimateSwapExactAmountIn",
 			Handler:    _Query_EstimateSwapExactAmountIn_Handler,
@@ -1954,6 +2098,73 @@ func (m *QuerySpotPriceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *QuerySwapableDenomsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuerySwapableDenomsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuerySwapableDenomsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TokenInDenom) > 0 {
+		i -= len(m.TokenInDenom)
+		copy(dAtA[i:], m.TokenInDenom)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.TokenInDenom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PoolId != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.PoolId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuerySwapableDenomsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuerySwapableDenomsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuerySwapableDenomsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Denoms) > 0 {
+		for iNdEx := len(m.Denoms) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Denoms[iNdEx])
+			copy(dAtA[i:], m.Denoms[iNdEx])
+			i = encodeVarintQuery(dAtA, i, uint64(len(m.Denoms[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *QuerySwapExactAmountInRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2389,6 +2600,37 @@ func (m *QuerySpotPriceResponse) Size() (n int) {
 	return n
 }
 
+func (m *QuerySwapableDenomsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PoolId != 0 {
+		n += 1 + sovQuery(uint64(m.PoolId))
+	}
+	l = len(m.TokenInDenom)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QuerySwapableDenomsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Denoms) > 0 {
+		for _, s := range m.Denoms {
+			l = len(s)
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *QuerySwapExactAmountInRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -3648,6 +3890,189 @@ func (m *QuerySpotPriceResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QuerySwapableDenomsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySwapableDenomsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySwapableDenomsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolId", wireType)
+			}
+			m.PoolId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PoolId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenInDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenInDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuerySwapableDenomsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySwapableDenomsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySwapableDenomsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denoms", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denoms = append(m.Denoms, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *QuerySwapExactAmountInRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SwapDeadline is the last block height and block time a swap may execute at,
+// so that a swap stuck in the mempool isn't executed at a stale price.
+// A zero Height or Time doesn't bound the swap.
+type SwapDeadline struct {
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+}
+
+// Check returns ErrDeadlineExceeded if the block of ctx is past the deadline.
+func (deadline SwapDeadline) Check(ctx sdk.Context) error {
+	if deadline.Height != 0 && ctx.BlockHeight() > deadline.Height {
+		return sdkerrors.Wrapf(ErrDeadlineExceeded, "block height %d is past the deadline height %d", ctx.BlockHeight(), deadline.Height)
+	}
+	if !deadline.Time.IsZero() && ctx.BlockTime().After(deadline.Time) {
+		return sdkerrors.Wrapf(ErrDeadlineExceeded, "block time %s is past the deadline time %s", ctx.BlockTime(), deadline.Time)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// SwapVolumeWindow is the rolling window over which a trader's swap volume
+	// through a pool is summed, to find the trader's swap fee tier.
+	SwapVolumeWindow = 24 * time.Hour
+	// SwapVolumeBucketDuration is the granularity at which swap volume is recorded.
+	// The volume of a trader is the volume of the buckets overlapping the last SwapVolumeWindow,
+	// so volume leaves the window up to one bucket later than SwapVolumeWindow after it was swapped.
+	SwapVolumeBucketDuration = time.Hour
+)
+
+// Validate returns an error unless the tiers have strictly increasing positive min volumes,
+// and non-increasing swap fees no larger than the pool's swapFee.
+func (tiers SwapFeeTiers) Validate(swapFee sdk.Dec) error {
+	if err := sdk.ValidateDenom(tiers.VolumeDenom); err != nil {
+		return err
+	}
+	if len(tiers.Tiers) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSwapFeeTiers, "a swap fee schedule needs at least one tier")
+	}
+
+	lastMinVolume, lastSwapFee := sdk.ZeroInt(), swapFee
+	for i, tier := range tiers.Tiers {
+		if tier.MinVolume.IsNil() || !tier.MinVolume.GT(lastMinVolume) {
+			return sdkerrors.Wrapf(ErrInvalidSwapFeeTiers, "tier %d min volume %s must be positive and greater than the previous tier's", i, tier.MinVolume)
+		}
+		if tier.SwapFee.IsNil() || tier.SwapFee.IsNegative() {
+			return sdkerrors.Wrapf(ErrNegativeSwapFee, "tier %d swap fee %s", i, tier.SwapFee)
+		}
+		if tier.SwapFee.GT(lastSwapFee) {
+			return sdkerrors.Wrapf(ErrInvalidSwapFeeTiers, "tier %d swap fee %s must not be greater than the previous tier's swap fee %s", i, tier.SwapFee, lastSwapFee)
+		}
+		lastMinVolume, lastSwapFee = tier.MinVolume, tier.SwapFee
+	}
+	return nil
+}
+
+// SwapFeeForVolume returns the swap fee of the highest tier whose min volume is at most volume,
+// or swapFee if volume is below every tier.
+func (tiers SwapFeeTiers) SwapFeeForVolume(swapFee sdk.Dec, volume sdk.Int) sdk.Dec {
+	for _, tier := range tiers.Tiers {
+		if volume.LT(tier.MinVolume) {
+			break
+		}
+		swapFee = tier.SwapFee
+	}
+	return swapFee
+}
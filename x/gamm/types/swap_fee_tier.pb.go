@@ -0,0 +1,671 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: osmosis/gamm/v1beta1/swap_fee_tier.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// SwapFeeTier is the swap fee of a pool for traders whose swap volume through
+// the pool, over the last SwapVolumeWindow, is at least min_volume.
+type SwapFeeTier struct {
+	MinVolume github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,1,opt,name=min_volume,json=minVolume,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"min_volume" yaml:"min_volume"`
+	SwapFee   github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=swap_fee,json=swapFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"swap_fee" yaml:"swap_fee"`
+}
+
+func (m *SwapFeeTier) Reset()         { *m = SwapFeeTier{} }
+func (m *SwapFeeTier) String() string { return proto.CompactTextString(m) }
+func (*SwapFeeTier) ProtoMessage()    {}
+func (*SwapFeeTier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ba5304eba98963bf, []int{0}
+}
+func (m *SwapFeeTier) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SwapFeeTier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SwapFeeTier.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SwapFeeTier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapFeeTier.Merge(m, src)
+}
+func (m *SwapFeeTier) XXX_Size() int {
+	return m.Size()
+}
+func (m *SwapFeeTier) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapFeeTier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapFeeTier proto.InternalMessageInfo
+
+// SwapFeeTiers is a pool's schedule of swap fees by trader volume.
+// Volume is measured in volume_denom, which must be one of the pool's assets.
+// Traders whose volume is below the first tier's min_volume pay the pool's
+// swap fee.
+type SwapFeeTiers struct {
+	VolumeDenom string        `protobuf:"bytes,1,opt,name=volume_denom,json=volumeDenom,proto3" json:"volume_denom,omitempty" yaml:"volume_denom"`
+	Tiers       []SwapFeeTier `protobuf:"bytes,2,rep,name=tiers,proto3" json:"tiers" yaml:"tiers"`
+}
+
+func (m *SwapFeeTiers) Reset()         { *m = SwapFeeTiers{} }
+func (m *SwapFeeTiers) String() string { return proto.CompactTextString(m) }
+func (*SwapFeeTiers) ProtoMessage()    {}
+func (*SwapFeeTiers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ba5304eba98963bf, []int{1}
+}
+func (m *SwapFeeTiers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SwapFeeTiers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SwapFeeTiers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SwapFeeTiers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SwapFeeTiers.Merge(m, src)
+}
+func (m *SwapFeeTiers) XXX_Size() int {
+	return m.Size()
+}
+func (m *SwapFeeTiers) XXX_DiscardUnknown() {
+	xxx_messageInfo_SwapFeeTiers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SwapFeeTiers proto.InternalMessageInfo
+
+func (m *SwapFeeTiers) GetVolumeDenom() string {
+	if m != nil {
+		return m.VolumeDenom
+	}
+	return ""
+}
+
+func (m *SwapFeeTiers) GetTiers() []SwapFeeTier {
+	if m != nil {
+		return m.Tiers
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SwapFeeTier)(nil), "osmosis.gamm.v1beta1.SwapFeeTier")
+	proto.RegisterType((*SwapFeeTiers)(nil), "osmosis.gamm.v1beta1.SwapFeeTiers")
+}
+
+func init() {
+	proto.RegisterFile("osmosis/gamm/v1beta1/swap_fee_tier.proto", fileDescriptor_ba5304eba98963bf)
+}
+
+var fileDescriptor_ba5304eba98963bf = []byte{
+	// 358 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xbf, 0x4a, 0xfb, 0x40,
+	0x1c, 0xcf, 0xf5, 0xf7, 0xb7, 0xd7, 0x82, 0x18, 0x0b, 0x16, 0x87, 0x5c, 0xbd, 0x41, 0xba, 0xf4,
+	0x8e, 0xea, 0x20, 0x74, 0xb3, 0x16, 0xa1, 0x83, 0x4b, 0x14, 0x07, 0x11, 0x42, 0xd2, 0x9e, 0x31,
+	0xd8, 0xcb, 0x85, 0x5e, 0xda, 0xda, 0xb7, 0xf0, 0x0d, 0xf4, 0x71, 0x3a, 0x76, 0x11, 0xc4, 0x21,
+	0x48, 0xbb, 0x38, 0xe7, 0x09, 0x24, 0x77, 0x29, 0x66, 0x70, 0x71, 0xca, 0xf7, 0x4b, 0x3e, 0xf7,
+	0xf9, 0x77, 0x07, 0x9b, 0x42, 0x72, 0x21, 0x03, 0x49, 0x7d, 0x97, 0x73, 0x3a, 0x6d, 0x7b, 0x2c,
+	0x76, 0xdb, 0x54, 0xce, 0xdc, 0xc8, 0xb9, 0x65, 0xcc, 0x89, 0x03, 0x36, 0x26, 0xd1, 0x58, 0xc4,
+	0xc2, 0xac, 0xe5, 0x48, 0x92, 0x21, 0x49, 0x8e, 0xdc, 0xab, 0xf9, 0xc2, 0x17, 0x0a, 0x40, 0xb3,
+	0x49, 0x63, 0xf1, 0x0b, 0x80, 0x95, 0x8b, 0x99, 0x1b, 0x9d, 0x31, 0x76, 0x19, 0xb0, 0xb1, 0xe9,
+	0x41, 0xc8, 0x83, 0xd0, 0x99, 0x8a, 0xd1, 0x84, 0xb3, 0x3a, 0x68, 0x80, 0x66, 0xb9, 0x7b, 0xba,
+	0x48, 0x90, 0xf1, 0x96, 0xa0, 0x03, 0x3f, 0x88, 0xef, 0x26, 0x1e, 0x19, 0x08, 0x4e, 0x07, 0x4a,
+	0x23, 0xff, 0xb4, 0xe4, 0xf0, 0x9e, 0xc6, 0xf3, 0x88, 0x49, 0xd2, 0x0f, 0xe3, 0x34, 0x41, 0xdb,
+	0x73, 0x97, 0x8f, 0x3a, 0xf8, 0x8b, 0x09, 0xdb, 0x65, 0x1e, 0x84, 0x57, 0x6a, 0x36, 0x6f, 0xe0,
+	0xff, 0x8d, 0xed, 0x7a, 0x49, 0x29, 0x9c, 0xfc, 0x40, 0xa1, 0xc7, 0x06, 0x69, 0x82, 0xb6, 0xb4,
+	0xc2, 0x86, 0x07, 0xdb, 0xff, 0xa4, 0x4e, 0xd1, 0xf9, 0xfd, 0xf1, 0x8c, 0x00, 0x7e, 0x02, 0xb0,
+	0x5a, 0xc8, 0x25, 0xcd, 0x0e, 0xac, 0x6a, 0x2b, 0xce, 0x90, 0x85, 0x82, 0xe7, 0xd1, 0x76, 0xd3,
+	0x04, 0xed, 0x68, 0xaa, 0xe2, 0x5f, 0x6c, 0x57, 0xf4, 0xda, 0xcb, 0x36, 0xf3, 0x1c, 0xfe, 0xc9,
+	0xea, 0x95, 0xf5, 0x52, 0xe3, 0x57, 0xb3, 0x72, 0xb8, 0x4f, 0xbe, 0x2b, 0x98, 0x14, 0xe4, 0xba,
+	0xb5, 0x2c, 0x50, 0x9a, 0xa0, 0xaa, 0xe6, 0x56, 0xa7, 0xb1, 0xad, 0x59, 0xb4, 0xc3, 0x6e, 0x7f,
+	0xb1, 0xb2, 0xc0, 0x72, 0x65, 0x81, 0xf7, 0x95, 0x05, 0x1e, 0xd7, 0x96, 0xb1, 0x5c, 0x5b, 0xc6,
+	0xeb, 0xda, 0x32, 0xae, 0x69, 0xa1, 0x85, 0x5c, 0xa9, 0x35, 0x72, 0x3d, 0xb9, 0x59, 0xe8, 0xf4,
+	0x98, 0x3e, 0xe8, 0x67, 0xa0, 0x2a, 0xf1, 0xfe, 0xaa, 0xbb, 0x3c, 0xfa, 0x1c, 0x00, 0xa0, 0x16,
+	0x7a, 0x6c, 0x23, 0x02, 0x00, 0x00,
+}
+
+func (this *SwapFeeTier) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SwapFeeTier)
+	if !ok {
+		that2, ok := that.(SwapFeeTier)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.MinVolume.Equal(that1.MinVolume) {
+		return false
+	}
+	if !this.SwapFee.Equal(that1.SwapFee) {
+		return false
+	}
+	return true
+}
+func (this *SwapFeeTiers) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SwapFeeTiers)
+	if !ok {
+		that2, ok := that.(SwapFeeTiers)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.VolumeDenom != that1.VolumeDenom {
+		return false
+	}
+	if len(this.Tiers) != len(that1.Tiers) {
+		return false
+	}
+	for i := range this.Tiers {
+		if !this.Tiers[i].Equal(&that1.Tiers[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (m *SwapFeeTier) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SwapFeeTier) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SwapFeeTier) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.SwapFee.Size()
+		i -= size
+		if _, err := m.SwapFee.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSwapFeeTier(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size := m.MinVolume.Size()
+		i -= size
+		if _, err := m.MinVolume.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSwapFeeTier(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SwapFeeTiers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SwapFeeTiers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SwapFeeTiers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tiers) > 0 {
+		for iNdEx := len(m.Tiers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Tiers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSwapFeeTier(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.VolumeDenom) > 0 {
+		i -= len(m.VolumeDenom)
+		copy(dAtA[i:], m.VolumeDenom)
+		i = encodeVarintSwapFeeTier(dAtA, i, uint64(len(m.VolumeDenom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSwapFeeTier(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSwapFeeTier(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SwapFeeTier) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MinVolume.Size()
+	n += 1 + l + sovSwapFeeTier(uint64(l))
+	l = m.SwapFee.Size()
+	n += 1 + l + sovSwapFeeTier(uint64(l))
+	return n
+}
+
+func (m *SwapFeeTiers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.VolumeDenom)
+	if l > 0 {
+		n += 1 + l + sovSwapFeeTier(uint64(l))
+	}
+	if len(m.Tiers) > 0 {
+		for _, e := range m.Tiers {
+			l = e.Size()
+			n += 1 + l + sovSwapFeeTier(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovSwapFeeTier(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSwapFeeTier(x uint64) (n int) {
+	return sovSwapFeeTier(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SwapFeeTier) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSwapFeeTier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SwapFeeTier: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SwapFeeTier: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVolume", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinVolume.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SwapFee", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SwapFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSwapFeeTier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SwapFeeTiers) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSwapFeeTier
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SwapFeeTiers: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SwapFeeTiers: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeDenom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VolumeDenom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tiers = append(m.Tiers, SwapFeeTier{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSwapFeeTier(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSwapFeeTier
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSwapFeeTier(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSwapFeeTier
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSwapFeeTier
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSwapFeeTier
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSwapFeeTier
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSwapFeeTier
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSwapFeeTier        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSwapFeeTier          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSwapFeeTier = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,40 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SwapAmountInSplit is the fraction of a trade's tokenIn that is swapped through a pool,
+// when the trade is split across multiple pools.
+type SwapAmountInSplit struct {
+	PoolId   uint64  `json:"pool_id"`
+	Fraction sdk.Dec `json:"fraction"`
+}
+
+// ValidateSwapAmountInSplits returns an error unless the splits are through distinct pools,
+// all with a positive fraction, and the fractions sum to exactly one.
+func ValidateSwapAmountInSplits(splits []SwapAmountInSplit) error {
+	if len(splits) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSwapSplits, "a split trade needs at least one split")
+	}
+
+	seenPoolIds := make(map[uint64]bool, len(splits))
+	sum := sdk.ZeroDec()
+	for _, split := range splits {
+		if seenPoolIds[split.PoolId] {
+			return sdkerrors.Wrapf(ErrInvalidSwapSplits, "pool %d appears in more than one split", split.PoolId)
+		}
+		seenPoolIds[split.PoolId] = true
+
+		if split.Fraction.IsNil() || !split.Fraction.IsPositive() {
+			return sdkerrors.Wrapf(ErrInvalidSwapSplits, "split through pool %d has a non-positive fraction %s", split.PoolId, split.Fraction)
+		}
+		sum = sum.Add(split.Fraction)
+	}
+
+	if !sum.Equal(sdk.OneDec()) {
+		return sdkerrors.Wrapf(ErrInvalidSwapSplits, "split fractions must sum to 1, got %s", sum)
+	}
+	return nil
+}
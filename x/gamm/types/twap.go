@@ -0,0 +1,40 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccumulatorAt returns the value of the record's accumulator at time t,
+// assuming that the spot price doesn't change between the record's time and t.
+// t is expected not to be before the record's time.
+func (record TwapRecord) AccumulatorAt(t time.Time) sdk.Dec {
+	elapsedMs := t.Sub(record.Time).Milliseconds()
+	return record.Accumulator.Add(record.SpotPrice.MulInt64(elapsedMs))
+}
+
+// Validate checks that the record is of a pool and denom pair, with a positive spot price
+// and a non-negative accumulator.
+func (record TwapRecord) Validate() error {
+	if record.PoolId == 0 {
+		return fmt.Errorf("TWAP record has no pool ID")
+	}
+	if err := sdk.ValidateDenom(record.BaseDenom); err != nil {
+		return err
+	}
+	if err := sdk.ValidateDenom(record.QuoteDenom); err != nil {
+		return err
+	}
+	if record.BaseDenom == record.QuoteDenom {
+		return fmt.Errorf("TWAP record of pool %d has base and quote denom %s", record.PoolId, record.BaseDenom)
+	}
+	if record.SpotPrice.IsNil() || !record.SpotPrice.IsPositive() {
+		return fmt.Errorf("TWAP record of pool %d has a non-positive spot price %s", record.PoolId, record.SpotPrice)
+	}
+	if record.Accumulator.IsNil() || record.Accumulator.IsNegative() {
+		return fmt.Errorf("TWAP record of pool %d has a negative accumulator %s", record.PoolId, record.Accumulator)
+	}
+	return nil
+}